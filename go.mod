@@ -132,3 +132,7 @@ require (
 replace github.com/docker/docker => github.com/docker/docker v1.4.2-0.20191213113251-3452f136aa68
 
 replace github.com/Azure/go-autorest => github.com/Azure/go-autorest v13.3.2+incompatible
+
+// s2 is patched to stream the bodies of UploadPart requests, see
+// third_party/github.com/pachyderm/s2/README.md
+replace github.com/pachyderm/s2 => ./third_party/github.com/pachyderm/s2
@@ -133,6 +133,7 @@ type ErrNotAuthorized struct {
 
 	Repo     string // Repo that the user is attempting to access
 	Required Scope  // Caller needs 'Required'-level access to 'Repo'
+	Actual   *Scope // Caller has 'Actual'-level access to 'Repo', if known
 
 	// Group 2:
 	// AdminOp indicates an operation that the caller couldn't perform because
@@ -155,6 +156,9 @@ func (e *ErrNotAuthorized) Error() string {
 	}
 	if e.Required != Scope_NONE {
 		msg += ", must have at least " + e.Required.String() + " access"
+		if e.Actual != nil {
+			msg += " but has " + e.Actual.String() + " access"
+		}
 	}
 	if e.AdminOp != "" {
 		msg += "; must be an admin to call " + e.AdminOp
@@ -65,6 +65,24 @@ func TestIsErrNotAuthorized(t *testing.T) {
 	})))
 }
 
+func TestErrNotAuthorizedActualScope(t *testing.T) {
+	actual := Scope_READER
+	err := &ErrNotAuthorized{
+		Subject:  "alice",
+		Repo:     "data",
+		Required: Scope_WRITER,
+		Actual:   &actual,
+	}
+	require.Equal(t, "alice is not authorized to perform this operation on the repo data, "+
+		"must have at least WRITER access but has READER access", err.Error())
+	require.True(t, IsErrNotAuthorized(err))
+	require.True(t, IsErrNotAuthorized(grpcify(err)))
+
+	// No access is still reported
+	actual = Scope_NONE
+	require.Matches(t, "but has NONE access", err.Error())
+}
+
 func TestIsErrInvalidPrincipal(t *testing.T) {
 	require.False(t, IsErrInvalidPrincipal(nil))
 	require.True(t, IsErrInvalidPrincipal(&ErrInvalidPrincipal{
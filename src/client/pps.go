@@ -62,6 +62,10 @@ const (
 	// OutputCommitIDEnv is an env var that is added to the environment of user
 	// pipelined code and indicates the id of the output commit.
 	OutputCommitIDEnv = "PACH_OUTPUT_COMMIT_ID"
+	// S3EndpointEnv is an env var that is added to the environment of user
+	// pipeline code with S3 inputs or outputs, and indicates the endpoint of the
+	// S3 gateway that serves them.
+	S3EndpointEnv = "S3_ENDPOINT"
 	// PeerPortEnv is the env var that sets a custom peer port
 	PeerPortEnv = "PEER_PORT"
 )
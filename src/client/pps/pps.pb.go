@@ -188,6 +188,45 @@ func (PipelineState) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_dbf57f97f56369c0, []int{3}
 }
 
+// DatumFailurePolicy specifies what a worker does when processing one of its
+// datums errors.
+type DatumFailurePolicy int32
+
+const (
+	// FAIL_FAST records a datum that the user code fails on, and keeps
+	// processing the other datums. Any other error cancels the other datums,
+	// failing the whole subtask so that it's retried.
+	DatumFailurePolicy_FAIL_FAST DatumFailurePolicy = 0
+	// COLLECT_ALL is like FAIL_FAST, but also records any other error as a
+	// failed datum rather than canceling the other datums, so that the job fails
+	// once all of them have been processed.
+	DatumFailurePolicy_COLLECT_ALL DatumFailurePolicy = 1
+	// CANCEL_ON_FAILURE is like FAIL_FAST, but also cancels the other datums as
+	// soon as the user code fails on one of them, so that the job fails without
+	// processing them.
+	DatumFailurePolicy_CANCEL_ON_FAILURE DatumFailurePolicy = 2
+)
+
+var DatumFailurePolicy_name = map[int32]string{
+	0: "FAIL_FAST",
+	1: "COLLECT_ALL",
+	2: "CANCEL_ON_FAILURE",
+}
+
+var DatumFailurePolicy_value = map[string]int32{
+	"FAIL_FAST":         0,
+	"COLLECT_ALL":       1,
+	"CANCEL_ON_FAILURE": 2,
+}
+
+func (x DatumFailurePolicy) String() string {
+	return proto.EnumName(DatumFailurePolicy_name, int32(x))
+}
+
+func (DatumFailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dbf57f97f56369c0, []int{4}
+}
+
 type SecretMount struct {
 	// Name must be the name of the secret in kubernetes.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -262,22 +301,26 @@ func (m *SecretMount) GetEnvVar() string {
 }
 
 type Transform struct {
-	Image                string            `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Cmd                  []string          `protobuf:"bytes,2,rep,name=cmd,proto3" json:"cmd,omitempty"`
-	ErrCmd               []string          `protobuf:"bytes,13,rep,name=err_cmd,json=errCmd,proto3" json:"err_cmd,omitempty"`
-	Env                  map[string]string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Secrets              []*SecretMount    `protobuf:"bytes,4,rep,name=secrets,proto3" json:"secrets,omitempty"`
-	ImagePullSecrets     []string          `protobuf:"bytes,9,rep,name=image_pull_secrets,json=imagePullSecrets,proto3" json:"image_pull_secrets,omitempty"`
-	Stdin                []string          `protobuf:"bytes,5,rep,name=stdin,proto3" json:"stdin,omitempty"`
-	ErrStdin             []string          `protobuf:"bytes,14,rep,name=err_stdin,json=errStdin,proto3" json:"err_stdin,omitempty"`
-	AcceptReturnCode     []int64           `protobuf:"varint,6,rep,packed,name=accept_return_code,json=acceptReturnCode,proto3" json:"accept_return_code,omitempty"`
-	Debug                bool              `protobuf:"varint,7,opt,name=debug,proto3" json:"debug,omitempty"`
-	User                 string            `protobuf:"bytes,10,opt,name=user,proto3" json:"user,omitempty"`
-	WorkingDir           string            `protobuf:"bytes,11,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	Dockerfile           string            `protobuf:"bytes,12,opt,name=dockerfile,proto3" json:"dockerfile,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Image            string            `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Cmd              []string          `protobuf:"bytes,2,rep,name=cmd,proto3" json:"cmd,omitempty"`
+	ErrCmd           []string          `protobuf:"bytes,13,rep,name=err_cmd,json=errCmd,proto3" json:"err_cmd,omitempty"`
+	Env              map[string]string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Secrets          []*SecretMount    `protobuf:"bytes,4,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	ImagePullSecrets []string          `protobuf:"bytes,9,rep,name=image_pull_secrets,json=imagePullSecrets,proto3" json:"image_pull_secrets,omitempty"`
+	Stdin            []string          `protobuf:"bytes,5,rep,name=stdin,proto3" json:"stdin,omitempty"`
+	ErrStdin         []string          `protobuf:"bytes,14,rep,name=err_stdin,json=errStdin,proto3" json:"err_stdin,omitempty"`
+	AcceptReturnCode []int64           `protobuf:"varint,6,rep,packed,name=accept_return_code,json=acceptReturnCode,proto3" json:"accept_return_code,omitempty"`
+	Debug            bool              `protobuf:"varint,7,opt,name=debug,proto3" json:"debug,omitempty"`
+	User             string            `protobuf:"bytes,10,opt,name=user,proto3" json:"user,omitempty"`
+	WorkingDir       string            `protobuf:"bytes,11,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	Dockerfile       string            `protobuf:"bytes,12,opt,name=dockerfile,proto3" json:"dockerfile,omitempty"`
+	// termination_grace_period is how long user code is given to exit after
+	// being sent SIGTERM (e.g. when its datum times out or is cancelled) before
+	// it is sent SIGKILL.
+	TerminationGracePeriod *types.Duration `protobuf:"bytes,15,opt,name=termination_grace_period,json=terminationGracePeriod,proto3" json:"termination_grace_period,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}        `json:"-"`
+	XXX_unrecognized       []byte          `json:"-"`
+	XXX_sizecache          int32           `json:"-"`
 }
 
 func (m *Transform) Reset()         { *m = Transform{} }
@@ -404,6 +447,13 @@ func (m *Transform) GetDockerfile() string {
 	return ""
 }
 
+func (m *Transform) GetTerminationGracePeriod() *types.Duration {
+	if m != nil {
+		return m.TerminationGracePeriod
+	}
+	return nil
+}
+
 type TFJob struct {
 	// tf_job  is a serialized Kubeflow TFJob spec. Pachyderm sends this directly
 	// to a kubernetes cluster on which kubeflow has been installed, instead of
@@ -1724,12 +1774,15 @@ type WorkerStatus struct {
 	JobID    string       `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	Data     []*InputFile `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
 	// Started is the time processing on the current datum began.
-	Started              *types.Timestamp `protobuf:"bytes,4,opt,name=started,proto3" json:"started,omitempty"`
-	Stats                *ProcessStats    `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
-	QueueSize            int64            `protobuf:"varint,6,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Started   *types.Timestamp `protobuf:"bytes,4,opt,name=started,proto3" json:"started,omitempty"`
+	Stats     *ProcessStats    `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	QueueSize int64            `protobuf:"varint,6,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`
+	// waiting_for_s3_gateway is set while the worker is waiting for its job's S3
+	// gateway to be reachable, before it processes any datums.
+	WaitingForS3Gateway  bool     `protobuf:"varint,7,opt,name=waiting_for_s3_gateway,json=waitingForS3Gateway,proto3" json:"waiting_for_s3_gateway,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *WorkerStatus) Reset()         { *m = WorkerStatus{} }
@@ -1807,6 +1860,13 @@ func (m *WorkerStatus) GetQueueSize() int64 {
 	return 0
 }
 
+func (m *WorkerStatus) GetWaitingForS3Gateway() bool {
+	if m != nil {
+		return m.WaitingForS3Gateway
+	}
+	return false
+}
+
 // ResourceSpec describes the amount of resources that pipeline pods should
 // request from kubernetes, for scheduling.
 type ResourceSpec struct {
@@ -1959,6 +2019,7 @@ type EtcdJobInfo struct {
 	DataTotal     int64 `protobuf:"varint,7,opt,name=data_total,json=dataTotal,proto3" json:"data_total,omitempty"`
 	DataFailed    int64 `protobuf:"varint,8,opt,name=data_failed,json=dataFailed,proto3" json:"data_failed,omitempty"`
 	DataRecovered int64 `protobuf:"varint,15,opt,name=data_recovered,json=dataRecovered,proto3" json:"data_recovered,omitempty"`
+	DataExcluded  int64 `protobuf:"varint,16,opt,name=data_excluded,json=dataExcluded,proto3" json:"data_excluded,omitempty"`
 	// Download/process/upload time and download/upload bytes
 	Stats                *ProcessStats    `protobuf:"bytes,9,opt,name=stats,proto3" json:"stats,omitempty"`
 	StatsCommit          *pfs.Commit      `protobuf:"bytes,10,opt,name=stats_commit,json=statsCommit,proto3" json:"stats_commit,omitempty"`
@@ -2067,6 +2128,13 @@ func (m *EtcdJobInfo) GetDataRecovered() int64 {
 	return 0
 }
 
+func (m *EtcdJobInfo) GetDataExcluded() int64 {
+	if m != nil {
+		return m.DataExcluded
+	}
+	return 0
+}
+
 func (m *EtcdJobInfo) GetStats() *ProcessStats {
 	if m != nil {
 		return m.Stats
@@ -2132,6 +2200,7 @@ type JobInfo struct {
 	DataSkipped           int64            `protobuf:"varint,30,opt,name=data_skipped,json=dataSkipped,proto3" json:"data_skipped,omitempty"`
 	DataFailed            int64            `protobuf:"varint,40,opt,name=data_failed,json=dataFailed,proto3" json:"data_failed,omitempty"`
 	DataRecovered         int64            `protobuf:"varint,46,opt,name=data_recovered,json=dataRecovered,proto3" json:"data_recovered,omitempty"`
+	DataExcluded          int64            `protobuf:"varint,49,opt,name=data_excluded,json=dataExcluded,proto3" json:"data_excluded,omitempty"`
 	DataTotal             int64            `protobuf:"varint,23,opt,name=data_total,json=dataTotal,proto3" json:"data_total,omitempty"`
 	Stats                 *ProcessStats    `protobuf:"bytes,31,opt,name=stats,proto3" json:"stats,omitempty"`
 	WorkerStatus          []*WorkerStatus  `protobuf:"bytes,24,rep,name=worker_status,json=workerStatus,proto3" json:"worker_status,omitempty"`
@@ -2342,6 +2411,13 @@ func (m *JobInfo) GetDataRecovered() int64 {
 	return 0
 }
 
+func (m *JobInfo) GetDataExcluded() int64 {
+	if m != nil {
+		return m.DataExcluded
+	}
+	return 0
+}
+
 func (m *JobInfo) GetDataTotal() int64 {
 	if m != nil {
 		return m.DataTotal
@@ -2760,25 +2836,31 @@ type PipelineInfo struct {
 	EnableStats           bool            `protobuf:"varint,24,opt,name=enable_stats,json=enableStats,proto3" json:"enable_stats,omitempty"`
 	Salt                  string          `protobuf:"bytes,25,opt,name=salt,proto3" json:"salt,omitempty"`
 	// reason includes any error messages associated with a failed pipeline
-	Reason               string          `protobuf:"bytes,28,opt,name=reason,proto3" json:"reason,omitempty"`
-	MaxQueueSize         int64           `protobuf:"varint,29,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
-	Service              *Service        `protobuf:"bytes,30,opt,name=service,proto3" json:"service,omitempty"`
-	Spout                *Spout          `protobuf:"bytes,45,opt,name=spout,proto3" json:"spout,omitempty"`
-	ChunkSpec            *ChunkSpec      `protobuf:"bytes,32,opt,name=chunk_spec,json=chunkSpec,proto3" json:"chunk_spec,omitempty"`
-	DatumTimeout         *types.Duration `protobuf:"bytes,33,opt,name=datum_timeout,json=datumTimeout,proto3" json:"datum_timeout,omitempty"`
-	JobTimeout           *types.Duration `protobuf:"bytes,34,opt,name=job_timeout,json=jobTimeout,proto3" json:"job_timeout,omitempty"`
-	GithookURL           string          `protobuf:"bytes,35,opt,name=githook_url,json=githookUrl,proto3" json:"githook_url,omitempty"`
-	SpecCommit           *pfs.Commit     `protobuf:"bytes,36,opt,name=spec_commit,json=specCommit,proto3" json:"spec_commit,omitempty"`
-	Standby              bool            `protobuf:"varint,37,opt,name=standby,proto3" json:"standby,omitempty"`
-	DatumTries           int64           `protobuf:"varint,39,opt,name=datum_tries,json=datumTries,proto3" json:"datum_tries,omitempty"`
-	SchedulingSpec       *SchedulingSpec `protobuf:"bytes,40,opt,name=scheduling_spec,json=schedulingSpec,proto3" json:"scheduling_spec,omitempty"`
-	PodSpec              string          `protobuf:"bytes,41,opt,name=pod_spec,json=podSpec,proto3" json:"pod_spec,omitempty"`
-	PodPatch             string          `protobuf:"bytes,44,opt,name=pod_patch,json=podPatch,proto3" json:"pod_patch,omitempty"`
-	S3Out                bool            `protobuf:"varint,47,opt,name=s3_out,json=s3Out,proto3" json:"s3_out,omitempty"`
-	Metadata             *Metadata       `protobuf:"bytes,48,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Reason               string             `protobuf:"bytes,28,opt,name=reason,proto3" json:"reason,omitempty"`
+	MaxQueueSize         int64              `protobuf:"varint,29,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
+	Service              *Service           `protobuf:"bytes,30,opt,name=service,proto3" json:"service,omitempty"`
+	Spout                *Spout             `protobuf:"bytes,45,opt,name=spout,proto3" json:"spout,omitempty"`
+	ChunkSpec            *ChunkSpec         `protobuf:"bytes,32,opt,name=chunk_spec,json=chunkSpec,proto3" json:"chunk_spec,omitempty"`
+	DatumTimeout         *types.Duration    `protobuf:"bytes,33,opt,name=datum_timeout,json=datumTimeout,proto3" json:"datum_timeout,omitempty"`
+	JobTimeout           *types.Duration    `protobuf:"bytes,34,opt,name=job_timeout,json=jobTimeout,proto3" json:"job_timeout,omitempty"`
+	GithookURL           string             `protobuf:"bytes,35,opt,name=githook_url,json=githookUrl,proto3" json:"githook_url,omitempty"`
+	SpecCommit           *pfs.Commit        `protobuf:"bytes,36,opt,name=spec_commit,json=specCommit,proto3" json:"spec_commit,omitempty"`
+	Standby              bool               `protobuf:"varint,37,opt,name=standby,proto3" json:"standby,omitempty"`
+	DatumTries           int64              `protobuf:"varint,39,opt,name=datum_tries,json=datumTries,proto3" json:"datum_tries,omitempty"`
+	SchedulingSpec       *SchedulingSpec    `protobuf:"bytes,40,opt,name=scheduling_spec,json=schedulingSpec,proto3" json:"scheduling_spec,omitempty"`
+	PodSpec              string             `protobuf:"bytes,41,opt,name=pod_spec,json=podSpec,proto3" json:"pod_spec,omitempty"`
+	PodPatch             string             `protobuf:"bytes,44,opt,name=pod_patch,json=podPatch,proto3" json:"pod_patch,omitempty"`
+	S3Out                bool               `protobuf:"varint,47,opt,name=s3_out,json=s3Out,proto3" json:"s3_out,omitempty"`
+	Metadata             *Metadata          `protobuf:"bytes,48,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	LogSampling          *LogSampling       `protobuf:"bytes,52,opt,name=log_sampling,json=logSampling,proto3" json:"log_sampling,omitempty"`
+	DownloadTimeout      *types.Duration    `protobuf:"bytes,53,opt,name=download_timeout,json=downloadTimeout,proto3" json:"download_timeout,omitempty"`
+	UploadTimeout        *types.Duration    `protobuf:"bytes,54,opt,name=upload_timeout,json=uploadTimeout,proto3" json:"upload_timeout,omitempty"`
+	DatumFailurePolicy   DatumFailurePolicy `protobuf:"varint,55,opt,name=datum_failure_policy,json=datumFailurePolicy,proto3,enum=pps.DatumFailurePolicy" json:"datum_failure_policy,omitempty"`
+	DatumExclude         string             `protobuf:"bytes,56,opt,name=datum_exclude,json=datumExclude,proto3" json:"datum_exclude,omitempty"`
+	S3Endpoint           *S3Endpoint        `protobuf:"bytes,57,opt,name=s3_endpoint,json=s3Endpoint,proto3" json:"s3_endpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *PipelineInfo) Reset()         { *m = PipelineInfo{} }
@@ -3101,6 +3183,48 @@ func (m *PipelineInfo) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *PipelineInfo) GetLogSampling() *LogSampling {
+	if m != nil {
+		return m.LogSampling
+	}
+	return nil
+}
+
+func (m *PipelineInfo) GetDownloadTimeout() *types.Duration {
+	if m != nil {
+		return m.DownloadTimeout
+	}
+	return nil
+}
+
+func (m *PipelineInfo) GetUploadTimeout() *types.Duration {
+	if m != nil {
+		return m.UploadTimeout
+	}
+	return nil
+}
+
+func (m *PipelineInfo) GetDatumFailurePolicy() DatumFailurePolicy {
+	if m != nil {
+		return m.DatumFailurePolicy
+	}
+	return DatumFailurePolicy_FAIL_FAST
+}
+
+func (m *PipelineInfo) GetDatumExclude() string {
+	if m != nil {
+		return m.DatumExclude
+	}
+	return ""
+}
+
+func (m *PipelineInfo) GetS3Endpoint() *S3Endpoint {
+	if m != nil {
+		return m.S3Endpoint
+	}
+	return nil
+}
+
 type PipelineInfos struct {
 	PipelineInfo         []*PipelineInfo `protobuf:"bytes,1,rep,name=pipeline_info,json=pipelineInfo,proto3" json:"pipeline_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
@@ -3159,6 +3283,7 @@ type CreateJobRequest struct {
 	DataTotal     int64 `protobuf:"varint,29,opt,name=data_total,json=dataTotal,proto3" json:"data_total,omitempty"`
 	DataFailed    int64 `protobuf:"varint,30,opt,name=data_failed,json=dataFailed,proto3" json:"data_failed,omitempty"`
 	DataRecovered int64 `protobuf:"varint,31,opt,name=data_recovered,json=dataRecovered,proto3" json:"data_recovered,omitempty"`
+	DataExcluded  int64 `protobuf:"varint,38,opt,name=data_excluded,json=dataExcluded,proto3" json:"data_excluded,omitempty"`
 	// Download/process/upload time and download/upload bytes
 	Stats                *ProcessStats    `protobuf:"bytes,32,opt,name=stats,proto3" json:"stats,omitempty"`
 	StatsCommit          *pfs.Commit      `protobuf:"bytes,33,opt,name=stats_commit,json=statsCommit,proto3" json:"stats_commit,omitempty"`
@@ -3260,6 +3385,13 @@ func (m *CreateJobRequest) GetDataRecovered() int64 {
 	return 0
 }
 
+func (m *CreateJobRequest) GetDataExcluded() int64 {
+	if m != nil {
+		return m.DataExcluded
+	}
+	return 0
+}
+
 func (m *CreateJobRequest) GetStats() *ProcessStats {
 	if m != nil {
 		return m.Stats
@@ -3623,6 +3755,7 @@ type UpdateJobStateRequest struct {
 	DataSkipped          int64         `protobuf:"varint,6,opt,name=data_skipped,json=dataSkipped,proto3" json:"data_skipped,omitempty"`
 	DataFailed           int64         `protobuf:"varint,7,opt,name=data_failed,json=dataFailed,proto3" json:"data_failed,omitempty"`
 	DataRecovered        int64         `protobuf:"varint,8,opt,name=data_recovered,json=dataRecovered,proto3" json:"data_recovered,omitempty"`
+	DataExcluded         int64         `protobuf:"varint,11,opt,name=data_excluded,json=dataExcluded,proto3" json:"data_excluded,omitempty"`
 	DataTotal            int64         `protobuf:"varint,9,opt,name=data_total,json=dataTotal,proto3" json:"data_total,omitempty"`
 	Stats                *ProcessStats `protobuf:"bytes,10,opt,name=stats,proto3" json:"stats,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -3719,6 +3852,13 @@ func (m *UpdateJobStateRequest) GetDataRecovered() int64 {
 	return 0
 }
 
+func (m *UpdateJobStateRequest) GetDataExcluded() int64 {
+	if m != nil {
+		return m.DataExcluded
+	}
+	return 0
+}
+
 func (m *UpdateJobStateRequest) GetDataTotal() int64 {
 	if m != nil {
 		return m.DataTotal
@@ -3856,11 +3996,13 @@ type LogMessage struct {
 	// User is true if log message comes from the users code.
 	User bool `protobuf:"varint,8,opt,name=user,proto3" json:"user,omitempty"`
 	// The message logged, and the time at which it was logged
-	Ts                   *types.Timestamp `protobuf:"bytes,5,opt,name=ts,proto3" json:"ts,omitempty"`
-	Message              string           `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Ts      *types.Timestamp `protobuf:"bytes,5,opt,name=ts,proto3" json:"ts,omitempty"`
+	Message string           `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Structured key/value pairs attached to the message by the logger
+	Fields               *types.Struct `protobuf:"bytes,11,opt,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *LogMessage) Reset()         { *m = LogMessage{} }
@@ -3959,6 +4101,13 @@ func (m *LogMessage) GetMessage() string {
 	return ""
 }
 
+func (m *LogMessage) GetFields() *types.Struct {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
 type RestartDatumRequest struct {
 	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	DataFilters          []string `protobuf:"bytes,2,rep,name=data_filters,json=dataFilters,proto3" json:"data_filters,omitempty"`
@@ -4256,6 +4405,137 @@ func (m *ListDatumStreamResponse) GetPage() int64 {
 	return 0
 }
 
+// LogSampling specifies how a pipeline thins the logs of its datums, for
+// pipelines that process many datums. Error messages are always logged.
+type LogSampling struct {
+	// every, if nonzero, specifies that only one in every `every` messages is
+	// logged.
+	Every int64 `protobuf:"varint,1,opt,name=every,proto3" json:"every,omitempty"`
+	// per_second, if nonzero, specifies the maximum number of messages logged
+	// per second by each worker.
+	PerSecond            int64    `protobuf:"varint,2,opt,name=per_second,json=perSecond,proto3" json:"per_second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogSampling) Reset()         { *m = LogSampling{} }
+func (m *LogSampling) String() string { return proto.CompactTextString(m) }
+func (*LogSampling) ProtoMessage()    {}
+func (*LogSampling) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf57f97f56369c0, []int{46}
+}
+func (m *LogSampling) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogSampling) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogSampling.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LogSampling) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSampling.Merge(m, src)
+}
+func (m *LogSampling) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogSampling) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSampling.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSampling proto.InternalMessageInfo
+
+func (m *LogSampling) GetEvery() int64 {
+	if m != nil {
+		return m.Every
+	}
+	return 0
+}
+
+func (m *LogSampling) GetPerSecond() int64 {
+	if m != nil {
+		return m.PerSecond
+	}
+	return 0
+}
+
+// S3Endpoint overrides the S3_ENDPOINT that's passed to the user code of a
+// pipeline with S3 inputs or outputs, for S3 gateways that are fronted by TLS
+// or reached through a different hostname. Unset fields keep their defaults.
+type S3Endpoint struct {
+	// scheme is either "http" (the default) or "https".
+	Scheme string `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// host, if set, replaces the job's S3 gateway sidecar service.
+	Host string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	// port, if nonzero, replaces the S3 gateway port that pachd is configured
+	// with.
+	Port                 int32    `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *S3Endpoint) Reset()         { *m = S3Endpoint{} }
+func (m *S3Endpoint) String() string { return proto.CompactTextString(m) }
+func (*S3Endpoint) ProtoMessage()    {}
+func (*S3Endpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dbf57f97f56369c0, []int{47}
+}
+func (m *S3Endpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *S3Endpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_S3Endpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *S3Endpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S3Endpoint.Merge(m, src)
+}
+func (m *S3Endpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *S3Endpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_S3Endpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S3Endpoint proto.InternalMessageInfo
+
+func (m *S3Endpoint) GetScheme() string {
+	if m != nil {
+		return m.Scheme
+	}
+	return ""
+}
+
+func (m *S3Endpoint) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *S3Endpoint) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
 // ChunkSpec specifies how a pipeline should chunk its datums.
 type ChunkSpec struct {
 	// number, if nonzero, specifies that each chunk should contain `number`
@@ -4275,7 +4555,7 @@ func (m *ChunkSpec) Reset()         { *m = ChunkSpec{} }
 func (m *ChunkSpec) String() string { return proto.CompactTextString(m) }
 func (*ChunkSpec) ProtoMessage()    {}
 func (*ChunkSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{46}
+	return fileDescriptor_dbf57f97f56369c0, []int{48}
 }
 func (m *ChunkSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4330,7 +4610,7 @@ func (m *SchedulingSpec) Reset()         { *m = SchedulingSpec{} }
 func (m *SchedulingSpec) String() string { return proto.CompactTextString(m) }
 func (*SchedulingSpec) ProtoMessage()    {}
 func (*SchedulingSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{47}
+	return fileDescriptor_dbf57f97f56369c0, []int{49}
 }
 func (m *SchedulingSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4400,31 +4680,42 @@ type CreatePipelineRequest struct {
 	EnableStats           bool          `protobuf:"varint,17,opt,name=enable_stats,json=enableStats,proto3" json:"enable_stats,omitempty"`
 	// Reprocess forces the pipeline to reprocess all datums.
 	// It only has meaning if Update is true
-	Reprocess            bool            `protobuf:"varint,18,opt,name=reprocess,proto3" json:"reprocess,omitempty"`
-	MaxQueueSize         int64           `protobuf:"varint,20,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
-	Service              *Service        `protobuf:"bytes,21,opt,name=service,proto3" json:"service,omitempty"`
-	Spout                *Spout          `protobuf:"bytes,33,opt,name=spout,proto3" json:"spout,omitempty"`
-	ChunkSpec            *ChunkSpec      `protobuf:"bytes,23,opt,name=chunk_spec,json=chunkSpec,proto3" json:"chunk_spec,omitempty"`
-	DatumTimeout         *types.Duration `protobuf:"bytes,24,opt,name=datum_timeout,json=datumTimeout,proto3" json:"datum_timeout,omitempty"`
-	JobTimeout           *types.Duration `protobuf:"bytes,25,opt,name=job_timeout,json=jobTimeout,proto3" json:"job_timeout,omitempty"`
-	Salt                 string          `protobuf:"bytes,26,opt,name=salt,proto3" json:"salt,omitempty"`
-	Standby              bool            `protobuf:"varint,27,opt,name=standby,proto3" json:"standby,omitempty"`
-	DatumTries           int64           `protobuf:"varint,28,opt,name=datum_tries,json=datumTries,proto3" json:"datum_tries,omitempty"`
-	SchedulingSpec       *SchedulingSpec `protobuf:"bytes,29,opt,name=scheduling_spec,json=schedulingSpec,proto3" json:"scheduling_spec,omitempty"`
-	PodSpec              string          `protobuf:"bytes,30,opt,name=pod_spec,json=podSpec,proto3" json:"pod_spec,omitempty"`
-	PodPatch             string          `protobuf:"bytes,32,opt,name=pod_patch,json=podPatch,proto3" json:"pod_patch,omitempty"`
-	SpecCommit           *pfs.Commit     `protobuf:"bytes,34,opt,name=spec_commit,json=specCommit,proto3" json:"spec_commit,omitempty"`
-	Metadata             *Metadata       `protobuf:"bytes,46,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Reprocess      bool            `protobuf:"varint,18,opt,name=reprocess,proto3" json:"reprocess,omitempty"`
+	MaxQueueSize   int64           `protobuf:"varint,20,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
+	Service        *Service        `protobuf:"bytes,21,opt,name=service,proto3" json:"service,omitempty"`
+	Spout          *Spout          `protobuf:"bytes,33,opt,name=spout,proto3" json:"spout,omitempty"`
+	ChunkSpec      *ChunkSpec      `protobuf:"bytes,23,opt,name=chunk_spec,json=chunkSpec,proto3" json:"chunk_spec,omitempty"`
+	DatumTimeout   *types.Duration `protobuf:"bytes,24,opt,name=datum_timeout,json=datumTimeout,proto3" json:"datum_timeout,omitempty"`
+	JobTimeout     *types.Duration `protobuf:"bytes,25,opt,name=job_timeout,json=jobTimeout,proto3" json:"job_timeout,omitempty"`
+	Salt           string          `protobuf:"bytes,26,opt,name=salt,proto3" json:"salt,omitempty"`
+	Standby        bool            `protobuf:"varint,27,opt,name=standby,proto3" json:"standby,omitempty"`
+	DatumTries     int64           `protobuf:"varint,28,opt,name=datum_tries,json=datumTries,proto3" json:"datum_tries,omitempty"`
+	SchedulingSpec *SchedulingSpec `protobuf:"bytes,29,opt,name=scheduling_spec,json=schedulingSpec,proto3" json:"scheduling_spec,omitempty"`
+	PodSpec        string          `protobuf:"bytes,30,opt,name=pod_spec,json=podSpec,proto3" json:"pod_spec,omitempty"`
+	PodPatch       string          `protobuf:"bytes,32,opt,name=pod_patch,json=podPatch,proto3" json:"pod_patch,omitempty"`
+	SpecCommit     *pfs.Commit     `protobuf:"bytes,34,opt,name=spec_commit,json=specCommit,proto3" json:"spec_commit,omitempty"`
+	Metadata       *Metadata       `protobuf:"bytes,46,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	LogSampling    *LogSampling    `protobuf:"bytes,48,opt,name=log_sampling,json=logSampling,proto3" json:"log_sampling,omitempty"`
+	// download_timeout and upload_timeout bound the time spent downloading a
+	// datum's inputs and uploading its outputs, independently of datum_timeout
+	// (which only bounds the user code).
+	DownloadTimeout    *types.Duration    `protobuf:"bytes,49,opt,name=download_timeout,json=downloadTimeout,proto3" json:"download_timeout,omitempty"`
+	UploadTimeout      *types.Duration    `protobuf:"bytes,50,opt,name=upload_timeout,json=uploadTimeout,proto3" json:"upload_timeout,omitempty"`
+	DatumFailurePolicy DatumFailurePolicy `protobuf:"varint,51,opt,name=datum_failure_policy,json=datumFailurePolicy,proto3,enum=pps.DatumFailurePolicy" json:"datum_failure_policy,omitempty"`
+	// datum_exclude is a glob pattern; datums with an input file matching it are
+	// excluded from processing, and counted in the job's data_excluded.
+	DatumExclude         string      `protobuf:"bytes,52,opt,name=datum_exclude,json=datumExclude,proto3" json:"datum_exclude,omitempty"`
+	S3Endpoint           *S3Endpoint `protobuf:"bytes,53,opt,name=s3_endpoint,json=s3Endpoint,proto3" json:"s3_endpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *CreatePipelineRequest) Reset()         { *m = CreatePipelineRequest{} }
 func (m *CreatePipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*CreatePipelineRequest) ProtoMessage()    {}
 func (*CreatePipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{48}
+	return fileDescriptor_dbf57f97f56369c0, []int{50}
 }
 func (m *CreatePipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4670,6 +4961,48 @@ func (m *CreatePipelineRequest) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *CreatePipelineRequest) GetLogSampling() *LogSampling {
+	if m != nil {
+		return m.LogSampling
+	}
+	return nil
+}
+
+func (m *CreatePipelineRequest) GetDownloadTimeout() *types.Duration {
+	if m != nil {
+		return m.DownloadTimeout
+	}
+	return nil
+}
+
+func (m *CreatePipelineRequest) GetUploadTimeout() *types.Duration {
+	if m != nil {
+		return m.UploadTimeout
+	}
+	return nil
+}
+
+func (m *CreatePipelineRequest) GetDatumFailurePolicy() DatumFailurePolicy {
+	if m != nil {
+		return m.DatumFailurePolicy
+	}
+	return DatumFailurePolicy_FAIL_FAST
+}
+
+func (m *CreatePipelineRequest) GetDatumExclude() string {
+	if m != nil {
+		return m.DatumExclude
+	}
+	return ""
+}
+
+func (m *CreatePipelineRequest) GetS3Endpoint() *S3Endpoint {
+	if m != nil {
+		return m.S3Endpoint
+	}
+	return nil
+}
+
 type InspectPipelineRequest struct {
 	Pipeline             *Pipeline `protobuf:"bytes,1,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -4681,7 +5014,7 @@ func (m *InspectPipelineRequest) Reset()         { *m = InspectPipelineRequest{}
 func (m *InspectPipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectPipelineRequest) ProtoMessage()    {}
 func (*InspectPipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{49}
+	return fileDescriptor_dbf57f97f56369c0, []int{51}
 }
 func (m *InspectPipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4737,7 +5070,7 @@ func (m *ListPipelineRequest) Reset()         { *m = ListPipelineRequest{} }
 func (m *ListPipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*ListPipelineRequest) ProtoMessage()    {}
 func (*ListPipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{50}
+	return fileDescriptor_dbf57f97f56369c0, []int{52}
 }
 func (m *ListPipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4794,7 +5127,7 @@ func (m *DeletePipelineRequest) Reset()         { *m = DeletePipelineRequest{} }
 func (m *DeletePipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*DeletePipelineRequest) ProtoMessage()    {}
 func (*DeletePipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{51}
+	return fileDescriptor_dbf57f97f56369c0, []int{53}
 }
 func (m *DeletePipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4862,7 +5195,7 @@ func (m *StartPipelineRequest) Reset()         { *m = StartPipelineRequest{} }
 func (m *StartPipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*StartPipelineRequest) ProtoMessage()    {}
 func (*StartPipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{52}
+	return fileDescriptor_dbf57f97f56369c0, []int{54}
 }
 func (m *StartPipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4909,7 +5242,7 @@ func (m *StopPipelineRequest) Reset()         { *m = StopPipelineRequest{} }
 func (m *StopPipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*StopPipelineRequest) ProtoMessage()    {}
 func (*StopPipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{53}
+	return fileDescriptor_dbf57f97f56369c0, []int{55}
 }
 func (m *StopPipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4958,7 +5291,7 @@ func (m *RunPipelineRequest) Reset()         { *m = RunPipelineRequest{} }
 func (m *RunPipelineRequest) String() string { return proto.CompactTextString(m) }
 func (*RunPipelineRequest) ProtoMessage()    {}
 func (*RunPipelineRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{54}
+	return fileDescriptor_dbf57f97f56369c0, []int{56}
 }
 func (m *RunPipelineRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5019,7 +5352,7 @@ func (m *RunCronRequest) Reset()         { *m = RunCronRequest{} }
 func (m *RunCronRequest) String() string { return proto.CompactTextString(m) }
 func (*RunCronRequest) ProtoMessage()    {}
 func (*RunCronRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{55}
+	return fileDescriptor_dbf57f97f56369c0, []int{57}
 }
 func (m *RunCronRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5066,7 +5399,7 @@ func (m *CreateSecretRequest) Reset()         { *m = CreateSecretRequest{} }
 func (m *CreateSecretRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateSecretRequest) ProtoMessage()    {}
 func (*CreateSecretRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{56}
+	return fileDescriptor_dbf57f97f56369c0, []int{58}
 }
 func (m *CreateSecretRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5113,7 +5446,7 @@ func (m *DeleteSecretRequest) Reset()         { *m = DeleteSecretRequest{} }
 func (m *DeleteSecretRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteSecretRequest) ProtoMessage()    {}
 func (*DeleteSecretRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{57}
+	return fileDescriptor_dbf57f97f56369c0, []int{59}
 }
 func (m *DeleteSecretRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5160,7 +5493,7 @@ func (m *InspectSecretRequest) Reset()         { *m = InspectSecretRequest{} }
 func (m *InspectSecretRequest) String() string { return proto.CompactTextString(m) }
 func (*InspectSecretRequest) ProtoMessage()    {}
 func (*InspectSecretRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{58}
+	return fileDescriptor_dbf57f97f56369c0, []int{60}
 }
 func (m *InspectSecretRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5207,7 +5540,7 @@ func (m *Secret) Reset()         { *m = Secret{} }
 func (m *Secret) String() string { return proto.CompactTextString(m) }
 func (*Secret) ProtoMessage()    {}
 func (*Secret) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{59}
+	return fileDescriptor_dbf57f97f56369c0, []int{61}
 }
 func (m *Secret) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5256,7 +5589,7 @@ func (m *SecretInfo) Reset()         { *m = SecretInfo{} }
 func (m *SecretInfo) String() string { return proto.CompactTextString(m) }
 func (*SecretInfo) ProtoMessage()    {}
 func (*SecretInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{60}
+	return fileDescriptor_dbf57f97f56369c0, []int{62}
 }
 func (m *SecretInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5317,7 +5650,7 @@ func (m *SecretInfos) Reset()         { *m = SecretInfos{} }
 func (m *SecretInfos) String() string { return proto.CompactTextString(m) }
 func (*SecretInfos) ProtoMessage()    {}
 func (*SecretInfos) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{61}
+	return fileDescriptor_dbf57f97f56369c0, []int{63}
 }
 func (m *SecretInfos) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5367,7 +5700,7 @@ func (m *GarbageCollectRequest) Reset()         { *m = GarbageCollectRequest{} }
 func (m *GarbageCollectRequest) String() string { return proto.CompactTextString(m) }
 func (*GarbageCollectRequest) ProtoMessage()    {}
 func (*GarbageCollectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{62}
+	return fileDescriptor_dbf57f97f56369c0, []int{64}
 }
 func (m *GarbageCollectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5413,7 +5746,7 @@ func (m *GarbageCollectResponse) Reset()         { *m = GarbageCollectResponse{}
 func (m *GarbageCollectResponse) String() string { return proto.CompactTextString(m) }
 func (*GarbageCollectResponse) ProtoMessage()    {}
 func (*GarbageCollectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{63}
+	return fileDescriptor_dbf57f97f56369c0, []int{65}
 }
 func (m *GarbageCollectResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5452,7 +5785,7 @@ func (m *ActivateAuthRequest) Reset()         { *m = ActivateAuthRequest{} }
 func (m *ActivateAuthRequest) String() string { return proto.CompactTextString(m) }
 func (*ActivateAuthRequest) ProtoMessage()    {}
 func (*ActivateAuthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{64}
+	return fileDescriptor_dbf57f97f56369c0, []int{66}
 }
 func (m *ActivateAuthRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5491,7 +5824,7 @@ func (m *ActivateAuthResponse) Reset()         { *m = ActivateAuthResponse{} }
 func (m *ActivateAuthResponse) String() string { return proto.CompactTextString(m) }
 func (*ActivateAuthResponse) ProtoMessage()    {}
 func (*ActivateAuthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dbf57f97f56369c0, []int{65}
+	return fileDescriptor_dbf57f97f56369c0, []int{67}
 }
 func (m *ActivateAuthResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5525,6 +5858,7 @@ func init() {
 	proto.RegisterEnum("pps.DatumState", DatumState_name, DatumState_value)
 	proto.RegisterEnum("pps.WorkerState", WorkerState_name, WorkerState_value)
 	proto.RegisterEnum("pps.PipelineState", PipelineState_name, PipelineState_value)
+	proto.RegisterEnum("pps.DatumFailurePolicy", DatumFailurePolicy_name, DatumFailurePolicy_value)
 	proto.RegisterType((*SecretMount)(nil), "pps.SecretMount")
 	proto.RegisterType((*Transform)(nil), "pps.Transform")
 	proto.RegisterMapType((map[string]string)(nil), "pps.Transform.EnvEntry")
@@ -5576,6 +5910,8 @@ func init() {
 	proto.RegisterType((*ListDatumRequest)(nil), "pps.ListDatumRequest")
 	proto.RegisterType((*ListDatumResponse)(nil), "pps.ListDatumResponse")
 	proto.RegisterType((*ListDatumStreamResponse)(nil), "pps.ListDatumStreamResponse")
+	proto.RegisterType((*LogSampling)(nil), "pps.LogSampling")
+	proto.RegisterType((*S3Endpoint)(nil), "pps.S3Endpoint")
 	proto.RegisterType((*ChunkSpec)(nil), "pps.ChunkSpec")
 	proto.RegisterType((*SchedulingSpec)(nil), "pps.SchedulingSpec")
 	proto.RegisterMapType((map[string]string)(nil), "pps.SchedulingSpec.NodeSelectorEntry")
@@ -5602,320 +5938,344 @@ func init() {
 func init() { proto.RegisterFile("client/pps/pps.proto", fileDescriptor_dbf57f97f56369c0) }
 
 var fileDescriptor_dbf57f97f56369c0 = []byte{
-	// 5000 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5c, 0xdd, 0x6f, 0x1b, 0x49,
-	0x72, 0x37, 0xc9, 0x21, 0x39, 0x2c, 0x7e, 0x68, 0xd4, 0xfa, 0x30, 0x45, 0xdb, 0x92, 0x3c, 0xfe,
-	0x58, 0xdb, 0xe7, 0x95, 0xbc, 0xd2, 0xdd, 0xe6, 0xce, 0xbb, 0xd9, 0x3d, 0x7d, 0xd9, 0x27, 0xae,
-	0xd6, 0x56, 0x86, 0xd2, 0x06, 0xb9, 0x17, 0x62, 0x44, 0x36, 0xa9, 0xb1, 0x86, 0x33, 0x73, 0x33,
-	0x43, 0x79, 0xb5, 0x40, 0x90, 0x87, 0xfc, 0x03, 0x41, 0x02, 0xe4, 0x21, 0x0f, 0xf9, 0x0f, 0x82,
-	0xe4, 0x0f, 0xb8, 0xc7, 0x3c, 0x1c, 0x10, 0x04, 0x48, 0x82, 0xdc, 0xab, 0x11, 0x18, 0x87, 0xfc,
-	0x09, 0x79, 0x48, 0x10, 0x20, 0xe8, 0xea, 0x9e, 0xe1, 0xcc, 0x90, 0x22, 0x29, 0xe9, 0x90, 0x07,
-	0x01, 0xdd, 0xd5, 0xd5, 0x5f, 0xd5, 0x5d, 0x55, 0xbf, 0xaa, 0x1e, 0x0a, 0xe6, 0x5b, 0xa6, 0x41,
-	0x2d, 0x7f, 0xdd, 0x71, 0x3c, 0xf6, 0xb7, 0xe6, 0xb8, 0xb6, 0x6f, 0x93, 0x8c, 0xe3, 0x78, 0xb5,
-	0x3b, 0x5d, 0xdb, 0xee, 0x9a, 0x74, 0x1d, 0x49, 0x27, 0xfd, 0xce, 0x3a, 0xed, 0x39, 0xfe, 0x05,
-	0xe7, 0xa8, 0xad, 0x24, 0x1b, 0x7d, 0xa3, 0x47, 0x3d, 0x5f, 0xef, 0x39, 0x82, 0x61, 0x39, 0xc9,
-	0xd0, 0xee, 0xbb, 0xba, 0x6f, 0xd8, 0x96, 0x68, 0x9f, 0xef, 0xda, 0x5d, 0x1b, 0x8b, 0xeb, 0xac,
-	0x14, 0x50, 0x83, 0xe5, 0x74, 0x3c, 0xf6, 0xc7, 0xa9, 0xea, 0x19, 0x14, 0x1b, 0xb4, 0xe5, 0x52,
-	0xff, 0x5b, 0xbb, 0x6f, 0xf9, 0x84, 0x80, 0x64, 0xe9, 0x3d, 0x5a, 0x4d, 0xad, 0xa6, 0x9e, 0x14,
-	0x34, 0x2c, 0x13, 0x05, 0x32, 0x67, 0xf4, 0xa2, 0x2a, 0x21, 0x89, 0x15, 0xc9, 0x3d, 0x80, 0x1e,
-	0x63, 0x6f, 0x3a, 0xba, 0x7f, 0x5a, 0x4d, 0x63, 0x43, 0x01, 0x29, 0x87, 0xba, 0x7f, 0x4a, 0x6e,
-	0x43, 0x9e, 0x5a, 0xe7, 0xcd, 0x73, 0xdd, 0xad, 0x66, 0xb0, 0x2d, 0x47, 0xad, 0xf3, 0xef, 0x74,
-	0x57, 0xfd, 0x6d, 0x06, 0x0a, 0x47, 0xae, 0x6e, 0x79, 0x1d, 0xdb, 0xed, 0x91, 0x79, 0xc8, 0x1a,
-	0x3d, 0xbd, 0x1b, 0x4c, 0xc6, 0x2b, 0x6c, 0xb6, 0x56, 0xaf, 0x5d, 0x4d, 0xaf, 0x66, 0xd8, 0x6c,
-	0xad, 0x5e, 0x1b, 0x87, 0x73, 0xdd, 0x26, 0xa3, 0x96, 0x91, 0x9a, 0xa3, 0xae, 0xbb, 0xd3, 0x6b,
-	0x93, 0xa7, 0x90, 0xa1, 0xd6, 0x79, 0x35, 0xb3, 0x9a, 0x79, 0x52, 0xdc, 0xb8, 0xbd, 0xc6, 0x64,
-	0x1c, 0x8e, 0xbe, 0xb6, 0x67, 0x9d, 0xef, 0x59, 0xbe, 0x7b, 0xa1, 0x31, 0x1e, 0xf2, 0x0c, 0xf2,
-	0x1e, 0x6e, 0xd3, 0xab, 0x4a, 0xc8, 0xae, 0x20, 0x7b, 0x64, 0xeb, 0x5a, 0xc0, 0x40, 0x9e, 0x03,
-	0xc1, 0xa5, 0x34, 0x9d, 0xbe, 0x69, 0x36, 0x83, 0x6e, 0x05, 0x9c, 0x5a, 0xc1, 0x96, 0xc3, 0xbe,
-	0x69, 0x36, 0x04, 0xf7, 0x3c, 0x64, 0x3d, 0xbf, 0x6d, 0x58, 0xd5, 0x2c, 0x32, 0xf0, 0x0a, 0xb9,
-	0x03, 0x05, 0xb6, 0x66, 0xde, 0x52, 0xc1, 0x16, 0x99, 0xba, 0x6e, 0x03, 0x1b, 0x9f, 0x03, 0xd1,
-	0x5b, 0x2d, 0xea, 0xf8, 0x4d, 0x97, 0xfa, 0x7d, 0xd7, 0x6a, 0xb6, 0xec, 0x36, 0xad, 0xe6, 0x56,
-	0x33, 0x4f, 0x32, 0x9a, 0xc2, 0x5b, 0x34, 0x6c, 0xd8, 0xb1, 0xdb, 0x94, 0x4d, 0xd0, 0xa6, 0x27,
-	0xfd, 0x6e, 0x35, 0xbf, 0x9a, 0x7a, 0x22, 0x6b, 0xbc, 0xc2, 0x0e, 0xaa, 0xef, 0x51, 0xb7, 0x0a,
-	0xfc, 0xa0, 0x58, 0x99, 0xac, 0x40, 0xf1, 0xbd, 0xed, 0x9e, 0x19, 0x56, 0xb7, 0xd9, 0x36, 0xdc,
-	0x6a, 0x11, 0x9b, 0x40, 0x90, 0x76, 0x0d, 0x97, 0x2c, 0x03, 0xb4, 0xed, 0xd6, 0x19, 0x75, 0x3b,
-	0x86, 0x49, 0xab, 0x25, 0xde, 0x3e, 0xa0, 0xd4, 0x3e, 0x07, 0x39, 0x10, 0x5b, 0x70, 0xea, 0xa9,
-	0xc1, 0xa9, 0xcf, 0x43, 0xf6, 0x5c, 0x37, 0xfb, 0x54, 0x1c, 0x38, 0xaf, 0xbc, 0x4c, 0xff, 0x34,
-	0xa5, 0x3e, 0x85, 0xec, 0xd1, 0xab, 0xba, 0x7d, 0x42, 0x56, 0x21, 0xe7, 0x77, 0x9a, 0xef, 0xec,
-	0x13, 0xde, 0x6f, 0xbb, 0xf0, 0xf1, 0xc3, 0x0a, 0x6f, 0xd2, 0xb2, 0x7e, 0xa7, 0x6e, 0x9f, 0xa8,
-	0x35, 0xc8, 0xed, 0x75, 0x5d, 0xea, 0x79, 0x6c, 0x82, 0x63, 0xed, 0x20, 0x98, 0xe0, 0x58, 0x3b,
-	0x50, 0xef, 0x41, 0x86, 0x0d, 0xb2, 0x08, 0x69, 0xa3, 0x2d, 0x06, 0xc8, 0x7d, 0xfc, 0xb0, 0x92,
-	0xde, 0xdf, 0xd5, 0xd2, 0x46, 0x5b, 0xfd, 0xef, 0x14, 0xc8, 0xdf, 0x52, 0x5f, 0x6f, 0xeb, 0xbe,
-	0x4e, 0x7e, 0x0e, 0x45, 0xdd, 0xb2, 0x6c, 0x1f, 0xef, 0xbd, 0x57, 0x4d, 0xe1, 0xa1, 0x2e, 0xe3,
-	0xa1, 0x06, 0x3c, 0x6b, 0x5b, 0x03, 0x06, 0x7e, 0x15, 0xa2, 0x5d, 0xc8, 0x67, 0x90, 0x33, 0xf5,
-	0x13, 0x6a, 0x7a, 0x78, 0xd7, 0x8a, 0x1b, 0x4b, 0xf1, 0xce, 0x07, 0xd8, 0xc6, 0xfb, 0x09, 0xc6,
-	0xda, 0x57, 0xa0, 0x24, 0xc7, 0xbc, 0x8a, 0x9c, 0x6a, 0x3f, 0x83, 0x62, 0x64, 0xd8, 0x2b, 0x89,
-	0xf8, 0xcf, 0x20, 0xdf, 0xa0, 0xee, 0xb9, 0xd1, 0xa2, 0xe4, 0x01, 0x94, 0x0d, 0xcb, 0xa7, 0xae,
-	0xa5, 0x9b, 0x4d, 0xc7, 0x76, 0x7d, 0x1c, 0x20, 0xab, 0x95, 0x02, 0xe2, 0xa1, 0xed, 0xfa, 0x8c,
-	0x89, 0x7e, 0x1f, 0x65, 0x4a, 0x73, 0xa6, 0x80, 0x88, 0x4c, 0x4c, 0xd2, 0x0e, 0xd7, 0x51, 0x21,
-	0xe9, 0x43, 0x2d, 0x6d, 0x38, 0xec, 0x72, 0xf9, 0x17, 0x0e, 0x15, 0x2a, 0x8f, 0x65, 0x95, 0x42,
-	0xb6, 0xe1, 0xd8, 0x7d, 0x9f, 0xdc, 0x85, 0x82, 0x7d, 0x4e, 0xdd, 0xf7, 0xae, 0xe1, 0x73, 0xd5,
-	0x95, 0xb5, 0x01, 0x81, 0x3c, 0x66, 0x8a, 0x86, 0xeb, 0xc4, 0x19, 0x8b, 0x1b, 0x25, 0xa1, 0x68,
-	0x48, 0xd3, 0x82, 0x46, 0xb2, 0x08, 0xb9, 0x9e, 0xee, 0x9e, 0xd1, 0xd0, 0x44, 0xf0, 0x9a, 0xfa,
-	0xef, 0x29, 0x90, 0x0f, 0x5f, 0x35, 0xf6, 0x2d, 0xa7, 0x3f, 0xda, 0x1a, 0x11, 0x90, 0x5c, 0xea,
-	0xd8, 0x42, 0x42, 0x58, 0x66, 0x83, 0x9d, 0xb8, 0xba, 0xd5, 0x3a, 0x0d, 0x06, 0xe3, 0x35, 0x46,
-	0x6f, 0xd9, 0xbd, 0x9e, 0xe1, 0x8b, 0x9d, 0x88, 0x1a, 0x1b, 0xa3, 0x6b, 0xda, 0x27, 0xd5, 0x2c,
-	0x1f, 0x83, 0x95, 0x99, 0x95, 0x79, 0x67, 0x1b, 0x56, 0xd3, 0xb6, 0xaa, 0x32, 0x67, 0x66, 0xd5,
-	0xb7, 0x16, 0x63, 0x36, 0xf5, 0x1f, 0x2e, 0xaa, 0x39, 0xdc, 0x2a, 0x96, 0x99, 0xa6, 0xa1, 0xc5,
-	0x6e, 0x32, 0xb5, 0xf1, 0x84, 0x66, 0x02, 0x92, 0x5e, 0x31, 0x0a, 0xa9, 0x40, 0xda, 0xdb, 0xac,
-	0x16, 0x90, 0x9e, 0xf6, 0x36, 0xd5, 0xbf, 0x4f, 0x41, 0x61, 0xc7, 0xb5, 0xad, 0x2b, 0xef, 0x4b,
-	0xac, 0x3f, 0x93, 0x5c, 0xbf, 0xe7, 0xd0, 0x56, 0x70, 0x3e, 0xac, 0x1c, 0x3f, 0x96, 0x5c, 0xf2,
-	0x58, 0x5e, 0x30, 0x2b, 0xa5, 0xbb, 0x3e, 0x6e, 0xb9, 0xb8, 0x51, 0x5b, 0xe3, 0x2e, 0x64, 0x2d,
-	0x70, 0x21, 0x6b, 0x47, 0x81, 0x8f, 0xd1, 0x38, 0xa3, 0x6a, 0x80, 0xfc, 0xda, 0xf0, 0x2f, 0x5f,
-	0xef, 0x12, 0x64, 0xfa, 0xae, 0xc9, 0x97, 0xbb, 0x9d, 0xff, 0xf8, 0x61, 0x85, 0xa9, 0xb0, 0xc6,
-	0x68, 0x57, 0x3d, 0x0e, 0xf5, 0x5f, 0x53, 0x90, 0xe5, 0x13, 0xad, 0x40, 0xc6, 0xe9, 0x78, 0xb8,
-	0xfc, 0xe2, 0x46, 0x19, 0x6f, 0x4e, 0x70, 0x19, 0x34, 0xd6, 0x42, 0x96, 0x41, 0x62, 0xc7, 0x52,
-	0xcd, 0xa3, 0xca, 0x02, 0x72, 0xf0, 0x66, 0xa4, 0x93, 0x55, 0xc8, 0xb6, 0x5c, 0xdb, 0x0b, 0x74,
-	0x3a, 0xca, 0xc0, 0x1b, 0x18, 0x47, 0xdf, 0x32, 0x6c, 0x4b, 0xb8, 0x8d, 0x18, 0x07, 0x36, 0x10,
-	0x15, 0xa4, 0x96, 0x6b, 0x5b, 0xb8, 0xc8, 0xe2, 0x46, 0x05, 0x19, 0xc2, 0xb3, 0xd3, 0xb0, 0x8d,
-	0x2d, 0xb4, 0x6b, 0x04, 0xd2, 0xe4, 0x0b, 0x0d, 0xa4, 0xa5, 0xb1, 0x16, 0xf5, 0x0c, 0xe4, 0xba,
-	0x7d, 0x12, 0x17, 0x9f, 0x14, 0x11, 0xdf, 0x83, 0x50, 0x16, 0x29, 0x1c, 0xa3, 0xb8, 0xc6, 0x7c,
-	0xf2, 0x0e, 0x92, 0x86, 0xee, 0x69, 0x3a, 0x72, 0x4f, 0x83, 0xeb, 0x98, 0x19, 0x5c, 0x47, 0xf5,
-	0x18, 0x66, 0x0e, 0x75, 0x57, 0x37, 0x4d, 0x6a, 0x1a, 0x5e, 0xaf, 0xc1, 0xae, 0x43, 0x0d, 0xe4,
-	0x96, 0x6d, 0x79, 0xbe, 0x6e, 0x71, 0xd5, 0x97, 0xb4, 0xb0, 0x4e, 0x56, 0xa1, 0xd8, 0xb2, 0x69,
-	0xa7, 0x63, 0xb4, 0x18, 0x20, 0xc0, 0x91, 0x52, 0x5a, 0x94, 0x54, 0x97, 0xe4, 0x94, 0x92, 0x56,
-	0x9f, 0x41, 0xe9, 0x17, 0xba, 0x77, 0xea, 0xbb, 0x94, 0x0e, 0x8d, 0x99, 0x8a, 0x8f, 0xa9, 0x6e,
-	0x42, 0x01, 0x37, 0xcb, 0xae, 0x3f, 0x5b, 0x23, 0x22, 0x03, 0xb1, 0x61, 0x56, 0x66, 0xb4, 0x53,
-	0xdd, 0x3b, 0x45, 0x91, 0x95, 0x34, 0x2c, 0xab, 0x5f, 0x40, 0x76, 0x57, 0xf7, 0xfb, 0xbd, 0xcb,
-	0x4c, 0x3e, 0xa9, 0x41, 0xe6, 0x9d, 0xd8, 0x7f, 0x71, 0x43, 0x46, 0x31, 0x33, 0x5f, 0xc2, 0x88,
-	0xea, 0x6f, 0x52, 0x50, 0xc0, 0xde, 0xfb, 0x56, 0xc7, 0x66, 0xc7, 0xda, 0x66, 0x15, 0x21, 0x4e,
-	0x7e, 0xac, 0xd8, 0xac, 0xf1, 0x06, 0xf2, 0x08, 0x55, 0xc0, 0xe7, 0x76, 0xa9, 0xb2, 0x31, 0x33,
-	0xe0, 0x68, 0x30, 0xb2, 0xc6, 0x5b, 0xc9, 0x27, 0x9c, 0xcd, 0x43, 0xb1, 0x14, 0x37, 0x66, 0xf9,
-	0x25, 0x74, 0xed, 0x16, 0xf5, 0x3c, 0xc6, 0xe8, 0x71, 0x46, 0x8f, 0x3c, 0x86, 0x82, 0xd3, 0xf1,
-	0x9a, 0x7c, 0x4c, 0x7e, 0x57, 0x0a, 0x78, 0x88, 0x4c, 0x04, 0x9a, 0xec, 0x74, 0x90, 0x9d, 0x92,
-	0xfb, 0x20, 0x31, 0x87, 0x82, 0xf8, 0x00, 0xef, 0x8a, 0x60, 0x61, 0xcb, 0xd6, 0xb0, 0x49, 0xfd,
-	0x87, 0x14, 0x14, 0xb6, 0xba, 0x5d, 0x97, 0x76, 0x59, 0x87, 0x79, 0xc8, 0xb6, 0x18, 0x22, 0xc1,
-	0xad, 0x64, 0x34, 0x5e, 0x61, 0xf2, 0xeb, 0x51, 0xdd, 0xc2, 0xd5, 0xa7, 0x34, 0x2c, 0x33, 0x85,
-	0xf2, 0xfc, 0x76, 0x9b, 0x9e, 0x8b, 0x33, 0x14, 0x35, 0xf2, 0x14, 0x94, 0x8e, 0xd1, 0xf1, 0x4f,
-	0x9b, 0x0e, 0x75, 0x5b, 0xd4, 0xf2, 0x99, 0xb7, 0x97, 0x90, 0x63, 0x06, 0xe9, 0x87, 0x21, 0x99,
-	0x7c, 0x0e, 0xb7, 0x2d, 0xc3, 0xa2, 0x68, 0xca, 0x12, 0x3d, 0xb2, 0xd8, 0x63, 0x81, 0x37, 0xbf,
-	0x8a, 0xf7, 0x53, 0xff, 0x32, 0x0d, 0xa5, 0xa8, 0x54, 0xc8, 0x57, 0x50, 0x6e, 0xdb, 0xef, 0x2d,
-	0xd3, 0xd6, 0xdb, 0x4d, 0x06, 0x58, 0xc5, 0x41, 0x2c, 0x0d, 0x59, 0x9a, 0x5d, 0x01, 0x56, 0xb5,
-	0x52, 0xc0, 0xcf, 0x6c, 0x0f, 0xf9, 0x12, 0x4a, 0x0e, 0x1f, 0x8f, 0x77, 0x4f, 0x4f, 0xea, 0x5e,
-	0x14, 0xec, 0xd8, 0xfb, 0x25, 0x14, 0xfb, 0xce, 0x60, 0xee, 0xcc, 0xa4, 0xce, 0xc0, 0xb9, 0xb1,
-	0xef, 0x23, 0xa8, 0x84, 0x2b, 0x3f, 0xb9, 0xf0, 0xa9, 0x87, 0xb2, 0x92, 0xb4, 0x70, 0x3f, 0xdb,
-	0x8c, 0x48, 0xee, 0x43, 0x49, 0x4c, 0xc1, 0x99, 0xb2, 0xc8, 0x24, 0xa6, 0x45, 0x16, 0xf5, 0x6f,
-	0xd2, 0xb0, 0x10, 0x9e, 0x63, 0x4c, 0x3a, 0x9b, 0xa3, 0xa5, 0xc3, 0x8d, 0x4b, 0xd8, 0x25, 0x21,
-	0x92, 0xcf, 0x46, 0x8a, 0x24, 0xd9, 0x27, 0x26, 0x87, 0xf5, 0x51, 0x72, 0x48, 0xf6, 0x88, 0x6e,
-	0xfe, 0x27, 0x23, 0x37, 0x3f, 0xdc, 0x27, 0x21, 0x8c, 0xcf, 0x46, 0x08, 0x63, 0xc4, 0xd2, 0xa2,
-	0xc2, 0xf9, 0xdf, 0x14, 0x94, 0xfe, 0xd8, 0x66, 0x4e, 0x9e, 0x89, 0xa4, 0xef, 0x91, 0xa7, 0x50,
-	0x78, 0x8f, 0xf5, 0x66, 0xa8, 0xfb, 0xa5, 0x8f, 0x1f, 0x56, 0x64, 0xce, 0xb4, 0xbf, 0xab, 0xc9,
-	0xbc, 0x79, 0xbf, 0xcd, 0x70, 0xe5, 0x3b, 0xfb, 0x84, 0xf1, 0xa5, 0x07, 0xb8, 0x92, 0xd9, 0xd7,
-	0x5d, 0x2d, 0xfb, 0xce, 0x3e, 0xd9, 0x6f, 0x33, 0xa3, 0x8d, 0x5a, 0xc6, 0xad, 0x7a, 0x65, 0x60,
-	0xd5, 0x51, 0x1b, 0xb1, 0x8d, 0xfc, 0x18, 0xf2, 0xe8, 0xdb, 0x68, 0x5b, 0x6c, 0x72, 0x9c, 0x1b,
-	0x0c, 0x58, 0x07, 0x06, 0x21, 0x3b, 0xc1, 0x20, 0xdc, 0x03, 0xf8, 0x55, 0x9f, 0xf6, 0x69, 0xd3,
-	0x33, 0x7e, 0xe0, 0x2e, 0x38, 0xa3, 0x15, 0x90, 0xd2, 0x30, 0x7e, 0xa0, 0xaa, 0x0b, 0x25, 0x8d,
-	0x7a, 0x76, 0xdf, 0x6d, 0x71, 0x6b, 0xca, 0x02, 0x1d, 0xa7, 0x8f, 0x1b, 0x4f, 0x6b, 0xac, 0x88,
-	0x98, 0x88, 0xf6, 0x6c, 0xf7, 0x42, 0x18, 0x7c, 0x51, 0x23, 0xcb, 0x90, 0xe9, 0x3a, 0x7d, 0x31,
-	0x3f, 0xc7, 0x53, 0xaf, 0x0f, 0x8f, 0xd9, 0x20, 0x1a, 0x6b, 0x60, 0xa6, 0xa1, 0x6d, 0x78, 0x67,
-	0x81, 0xb9, 0x65, 0xe5, 0xba, 0x24, 0x67, 0x14, 0x49, 0xfd, 0x09, 0xe4, 0x05, 0x67, 0x88, 0xe9,
-	0x52, 0x03, 0x4c, 0xc7, 0x26, 0xb4, 0xfa, 0xbd, 0x13, 0xea, 0xe2, 0x84, 0x19, 0x4d, 0xd4, 0xd4,
-	0xdf, 0x4a, 0x50, 0xdc, 0xf3, 0x5b, 0x6d, 0xf4, 0x60, 0x1d, 0x3b, 0x30, 0xc3, 0xa9, 0x11, 0x66,
-	0x98, 0x3c, 0x05, 0xd9, 0x31, 0x1c, 0x6a, 0x1a, 0x56, 0x70, 0x41, 0x85, 0xdf, 0x16, 0x44, 0x2d,
-	0x6c, 0x26, 0x2f, 0xa0, 0x6c, 0xf7, 0x7d, 0xa7, 0xef, 0x37, 0x23, 0xa8, 0x26, 0xe1, 0xfa, 0x4a,
-	0x9c, 0x83, 0xd7, 0x48, 0x15, 0xf2, 0x2e, 0xe5, 0xc0, 0x85, 0xeb, 0x64, 0x50, 0x45, 0xa5, 0xd5,
-	0x7d, 0xbd, 0x29, 0x2e, 0x3f, 0x6d, 0xa3, 0x78, 0x32, 0x5a, 0x99, 0x51, 0x0f, 0x03, 0x22, 0x53,
-	0x5a, 0x64, 0xf3, 0xce, 0x0c, 0xc7, 0xa1, 0x6d, 0x71, 0x2a, 0x45, 0x46, 0x6b, 0x70, 0x12, 0x3b,
-	0x36, 0x64, 0xf1, 0x6d, 0x5f, 0x37, 0x11, 0xca, 0x65, 0xb4, 0x02, 0xa3, 0x1c, 0x31, 0x02, 0x83,
-	0x7a, 0xd8, 0xdc, 0xd1, 0x0d, 0x93, 0xb6, 0x11, 0x1b, 0x66, 0x34, 0xec, 0xf1, 0x0a, 0x29, 0xe1,
-	0x4a, 0x5c, 0xda, 0x62, 0x78, 0x8b, 0xb6, 0xab, 0x33, 0x83, 0x95, 0x68, 0x01, 0x71, 0x70, 0x8d,
-	0x0a, 0x13, 0xae, 0xd1, 0x1a, 0x94, 0xb0, 0x10, 0x08, 0x09, 0x86, 0x85, 0x54, 0x44, 0x06, 0x21,
-	0xa3, 0x07, 0x81, 0x5f, 0x2b, 0xa2, 0x5f, 0x2b, 0x07, 0xc7, 0x13, 0xf3, 0x6a, 0x8b, 0x90, 0x73,
-	0xa9, 0xee, 0xd9, 0x96, 0x88, 0xfa, 0x44, 0x2d, 0xaa, 0x12, 0xe5, 0xe9, 0x55, 0xe2, 0x73, 0x90,
-	0x3b, 0x86, 0x65, 0x78, 0xa7, 0xb4, 0x5d, 0xad, 0x4c, 0xec, 0x16, 0xf2, 0xaa, 0xbf, 0x2b, 0x43,
-	0x7e, 0x9a, 0x3b, 0xf5, 0x1c, 0x0a, 0x7e, 0x10, 0xc8, 0xc7, 0xac, 0x5e, 0x18, 0xde, 0x6b, 0x03,
-	0x86, 0xd8, 0x0d, 0xcc, 0x8c, 0xbf, 0x81, 0x4f, 0x41, 0x09, 0xca, 0xcd, 0x73, 0xea, 0x7a, 0x0c,
-	0x07, 0x96, 0xf1, 0x62, 0xcd, 0x04, 0xf4, 0xef, 0x38, 0x99, 0x3c, 0x87, 0x22, 0xc3, 0xd5, 0xc1,
-	0x29, 0xac, 0x0f, 0x9f, 0x02, 0xb0, 0x76, 0x71, 0x08, 0x5f, 0x83, 0xe2, 0x0c, 0x10, 0x58, 0x13,
-	0xd1, 0x79, 0x09, 0xbb, 0xcc, 0xf3, 0xb5, 0xc4, 0xe1, 0x99, 0x36, 0xe3, 0x24, 0xf0, 0xda, 0x03,
-	0xc8, 0x51, 0x8c, 0x8b, 0xf1, 0xf6, 0xe0, 0x4c, 0x8e, 0xb7, 0xc6, 0x43, 0x65, 0x4d, 0x34, 0x91,
-	0x4f, 0x00, 0x1c, 0xdd, 0xa5, 0x96, 0x8f, 0x21, 0x76, 0x2e, 0x21, 0xba, 0x02, 0x6f, 0x63, 0x21,
-	0x74, 0xe4, 0x58, 0xf3, 0xd7, 0x3b, 0x56, 0x79, 0xfa, 0x63, 0x1d, 0xd6, 0xeb, 0xc2, 0x24, 0xbd,
-	0x0e, 0xef, 0x2c, 0x4c, 0x75, 0x67, 0x1f, 0xc4, 0xee, 0x6c, 0x24, 0xc4, 0xac, 0x8c, 0x0b, 0x31,
-	0x57, 0x21, 0xeb, 0xb1, 0x88, 0xb5, 0xfa, 0x69, 0x04, 0x12, 0x62, 0x0c, 0xab, 0xf1, 0x06, 0xf2,
-	0x0c, 0x8a, 0x62, 0xe1, 0x18, 0x7a, 0x91, 0x08, 0x88, 0xd3, 0xa8, 0x63, 0x6b, 0xc0, 0x5b, 0x59,
-	0x99, 0x05, 0xd4, 0x82, 0x57, 0xc4, 0x36, 0xb3, 0xb8, 0x28, 0xb1, 0xaf, 0x6d, 0x1e, 0xe1, 0x44,
-	0xec, 0xd5, 0xfc, 0x24, 0x7b, 0xb5, 0x38, 0x8d, 0xbd, 0x5a, 0x1e, 0xb6, 0x57, 0x09, 0x83, 0xf4,
-	0x64, 0x0a, 0x83, 0xb4, 0x36, 0xca, 0x20, 0xc5, 0xed, 0xde, 0xed, 0xa4, 0xdd, 0x0b, 0xed, 0xd5,
-	0xca, 0x04, 0x7b, 0xf5, 0x39, 0x94, 0x85, 0x1b, 0xf7, 0xd0, 0xaf, 0x57, 0xab, 0xe8, 0x82, 0x79,
-	0x87, 0xa8, 0xc3, 0xd7, 0x4a, 0xef, 0xa3, 0xee, 0xff, 0x2b, 0x98, 0x75, 0x85, 0x3f, 0x6c, 0xba,
-	0xf4, 0x57, 0x7d, 0xea, 0xf9, 0x5e, 0x75, 0x29, 0x32, 0x59, 0xd4, 0x5b, 0x6a, 0x4a, 0xc0, 0xab,
-	0x09, 0x56, 0xf2, 0x12, 0x66, 0xc2, 0xfe, 0xa6, 0xd1, 0x33, 0x7c, 0xaf, 0xfa, 0xf0, 0xb2, 0xde,
-	0x95, 0x80, 0xf3, 0x00, 0x19, 0xc9, 0x3e, 0xdc, 0xf6, 0x8c, 0x36, 0x6d, 0xe9, 0x6e, 0x33, 0x39,
-	0xc6, 0x8b, 0xcb, 0xc6, 0x58, 0x10, 0x3d, 0xb4, 0xf8, 0x50, 0xab, 0x90, 0x35, 0x18, 0xce, 0xa8,
-	0xd6, 0x22, 0xb7, 0x4c, 0xc4, 0x93, 0xd8, 0x40, 0xd6, 0x00, 0x2c, 0xfa, 0x3e, 0xb8, 0x36, 0x77,
-	0x90, 0x6d, 0x06, 0x2f, 0x19, 0xbf, 0x35, 0x18, 0x08, 0x14, 0x2c, 0xfa, 0x5e, 0x5c, 0xa2, 0xa4,
-	0x03, 0xb8, 0x37, 0xc1, 0x01, 0xdc, 0x87, 0x12, 0xb5, 0xf4, 0x13, 0x93, 0x36, 0xf9, 0x81, 0xad,
-	0x62, 0x64, 0x58, 0xe4, 0x34, 0x0e, 0x3f, 0x09, 0x48, 0x9e, 0x6e, 0xfa, 0xd5, 0xfb, 0x22, 0x61,
-	0xa0, 0x9b, 0x3e, 0xf9, 0x14, 0xa0, 0x75, 0xda, 0xb7, 0xce, 0xb8, 0xb1, 0x7a, 0x14, 0x0d, 0x76,
-	0x19, 0x19, 0xf7, 0x5c, 0x68, 0x05, 0x45, 0xc4, 0xf7, 0x2c, 0x58, 0x42, 0x60, 0xc9, 0xb4, 0xea,
-	0xf1, 0x64, 0x7c, 0xcf, 0xf8, 0x8f, 0x38, 0x3b, 0x43, 0xe8, 0x0c, 0xc2, 0x05, 0xbd, 0x3f, 0x99,
-	0x88, 0xd0, 0xdf, 0xd9, 0x27, 0x41, 0x5f, 0x7e, 0xe5, 0xd9, 0xdc, 0xae, 0x41, 0xbd, 0xea, 0xd3,
-	0xf0, 0xca, 0xf7, 0x7b, 0x47, 0x8c, 0x42, 0xbe, 0x84, 0x19, 0xaf, 0x75, 0x4a, 0xdb, 0x7d, 0xd3,
-	0xb0, 0xba, 0x7c, 0x43, 0xcf, 0x70, 0x82, 0x39, 0xae, 0xf4, 0x61, 0x1b, 0xbf, 0x0d, 0x5e, 0xac,
-	0x4e, 0x96, 0x40, 0x76, 0xec, 0x36, 0xef, 0xf6, 0x23, 0x94, 0x50, 0xde, 0xb1, 0xdb, 0xd8, 0x74,
-	0x07, 0x0a, 0xac, 0xc9, 0xd1, 0xfd, 0xd6, 0x69, 0xf5, 0x39, 0xb6, 0x31, 0xde, 0x43, 0x56, 0xaf,
-	0x4b, 0xb2, 0xa4, 0x64, 0xeb, 0x92, 0x9c, 0x55, 0x72, 0x75, 0x49, 0xbe, 0xab, 0xdc, 0xab, 0x4b,
-	0xb2, 0xaa, 0x3c, 0x50, 0x77, 0x21, 0xc7, 0xef, 0xfd, 0xc8, 0xc4, 0xc9, 0xe3, 0x78, 0x1c, 0xaa,
-	0x24, 0xf4, 0x24, 0x30, 0x7f, 0xea, 0xa6, 0xc8, 0x20, 0x74, 0x6c, 0x66, 0xf8, 0x65, 0xc4, 0xbf,
-	0x56, 0xc7, 0x16, 0xa9, 0xce, 0x52, 0x60, 0x32, 0xf1, 0xf6, 0xe4, 0xdf, 0xf1, 0x82, 0xba, 0x0c,
-	0x72, 0xe0, 0xf6, 0x46, 0x4d, 0xae, 0xfe, 0x4f, 0x1a, 0x14, 0x86, 0xec, 0x02, 0x26, 0x74, 0xc5,
-	0x4f, 0x82, 0x15, 0xa5, 0x70, 0x45, 0x24, 0xe6, 0x3d, 0x2f, 0x31, 0xc9, 0x52, 0xcc, 0x24, 0x27,
-	0x9c, 0x65, 0x7a, 0xbc, 0xb3, 0xdc, 0x01, 0x76, 0xb8, 0x4d, 0x8c, 0x6b, 0x3d, 0x81, 0xd8, 0x1f,
-	0x72, 0x7f, 0x97, 0x58, 0x1a, 0xdb, 0xe0, 0x0e, 0xb2, 0xf1, 0x44, 0x6c, 0xe1, 0x5d, 0x50, 0x67,
-	0xe6, 0x4b, 0xef, 0xfb, 0xa7, 0x4d, 0xdf, 0x3e, 0xa3, 0x96, 0xc8, 0xe4, 0x15, 0x18, 0xe5, 0x88,
-	0x11, 0xc8, 0x26, 0x54, 0x4c, 0xdd, 0x43, 0x47, 0x29, 0x42, 0xf4, 0xdc, 0x28, 0x57, 0x53, 0x62,
-	0x4c, 0x41, 0x8d, 0xac, 0x42, 0x31, 0xe2, 0x97, 0xd1, 0x75, 0x4a, 0x5a, 0x94, 0x54, 0xfb, 0x12,
-	0x2a, 0xf1, 0x25, 0x45, 0x93, 0xb8, 0xd9, 0x11, 0x49, 0xdc, 0x6c, 0x34, 0x89, 0xfb, 0x8f, 0x15,
-	0x28, 0xc5, 0x24, 0xcf, 0xf3, 0x1e, 0xb3, 0x43, 0x79, 0x8f, 0x28, 0xa4, 0x49, 0x8d, 0x87, 0x34,
-	0x55, 0xc8, 0x07, 0x48, 0xa6, 0xc8, 0x5d, 0xce, 0x79, 0x88, 0x60, 0xae, 0x82, 0xa2, 0x9e, 0x87,
-	0xa9, 0xfb, 0xb5, 0x88, 0x21, 0xc3, 0xdc, 0xfd, 0x70, 0x1a, 0x7f, 0x24, 0xde, 0x81, 0xab, 0xe0,
-	0x9d, 0xcf, 0xa1, 0x7c, 0x2a, 0x72, 0x4b, 0x51, 0x7d, 0xe5, 0x76, 0x37, 0x9a, 0x75, 0xd2, 0x4a,
-	0xa7, 0xd1, 0x1c, 0xd4, 0x54, 0x38, 0xe9, 0x67, 0x00, 0x2d, 0x97, 0xea, 0x3e, 0x6d, 0x37, 0x75,
-	0x5f, 0xe0, 0xa4, 0x71, 0x50, 0xa6, 0x20, 0xb8, 0xb7, 0xfc, 0x81, 0x2e, 0xe4, 0x27, 0xe9, 0x42,
-	0x95, 0x61, 0x2c, 0x1b, 0xbd, 0xf4, 0x63, 0xb4, 0xb8, 0x41, 0x95, 0x19, 0x64, 0x97, 0xb6, 0x18,
-	0x4c, 0xa3, 0xae, 0x6b, 0xbb, 0x22, 0x9f, 0x5c, 0xe4, 0xb4, 0x3d, 0x46, 0x22, 0x3f, 0x82, 0x59,
-	0xee, 0x0c, 0xbd, 0xc0, 0xf7, 0xd1, 0x76, 0xf5, 0x33, 0xb4, 0x6b, 0x8a, 0x68, 0xd0, 0x02, 0x7a,
-	0x94, 0x59, 0x3f, 0xd7, 0x0d, 0x93, 0xd9, 0xf5, 0xea, 0x46, 0x8c, 0x79, 0x2b, 0xa0, 0x93, 0xaf,
-	0x63, 0xca, 0x55, 0x40, 0xe5, 0x5a, 0x8d, 0xed, 0x62, 0x82, 0x62, 0x0d, 0x6b, 0xce, 0x8f, 0x26,
-	0x6b, 0xce, 0x10, 0x3a, 0x52, 0x46, 0xa0, 0xa3, 0x91, 0x1e, 0x7f, 0xee, 0x46, 0x1e, 0x7f, 0xe5,
-	0xf7, 0xe0, 0xf1, 0x37, 0xaf, 0xeb, 0xf1, 0xe7, 0x2f, 0xf3, 0xf8, 0xab, 0x50, 0x6c, 0x53, 0xaf,
-	0xe5, 0x1a, 0x0e, 0x73, 0x65, 0xd5, 0x05, 0x7e, 0xfe, 0x11, 0x12, 0xb3, 0x5e, 0x2d, 0xbd, 0x75,
-	0x2a, 0x72, 0x05, 0xb7, 0xb9, 0xf5, 0x42, 0x4a, 0xc3, 0xf8, 0x81, 0x0e, 0xb9, 0xf4, 0xea, 0xe5,
-	0x2e, 0x7d, 0x29, 0xe2, 0xd2, 0x07, 0xe6, 0xf9, 0x6e, 0xcc, 0x3c, 0x3f, 0x84, 0x4a, 0x4f, 0xff,
-	0xbe, 0x19, 0xc9, 0x4e, 0xdc, 0xc3, 0xdb, 0x53, 0xea, 0xe9, 0xdf, 0xff, 0x51, 0x90, 0xa0, 0x88,
-	0xe2, 0xea, 0xe5, 0x9b, 0xe1, 0xea, 0x38, 0xb4, 0x58, 0xbd, 0x32, 0xb4, 0xb8, 0x7f, 0x23, 0x68,
-	0xa1, 0x5e, 0x05, 0x5a, 0xac, 0x43, 0xb1, 0x6b, 0xf8, 0xa7, 0xb6, 0x7d, 0xd6, 0xec, 0xbb, 0x26,
-	0x8f, 0x34, 0xb6, 0x2b, 0x1f, 0x3f, 0xac, 0xc0, 0x6b, 0x4e, 0x3e, 0xd6, 0x0e, 0x34, 0x10, 0x2c,
-	0xc7, 0xae, 0x99, 0x74, 0x75, 0x0f, 0xc7, 0xbb, 0x3a, 0x34, 0x12, 0xba, 0xd5, 0x3e, 0xb9, 0x40,
-	0x84, 0x85, 0x46, 0x02, 0xab, 0x49, 0x4c, 0xf3, 0xc9, 0x34, 0x98, 0xe6, 0xc9, 0xf5, 0x30, 0xcd,
-	0xd3, 0xe9, 0x31, 0x0d, 0x59, 0x80, 0x9c, 0xb7, 0xd9, 0x64, 0x62, 0x5c, 0xe7, 0xcf, 0xcd, 0xde,
-	0xe6, 0xdb, 0xbe, 0xcf, 0x1c, 0x52, 0x4f, 0xbc, 0x8c, 0x0a, 0x84, 0x5c, 0x8e, 0x3d, 0x97, 0x6a,
-	0x61, 0xf3, 0xcd, 0x5c, 0x24, 0xcf, 0x5b, 0x85, 0xc8, 0x6a, 0x51, 0xb9, 0x5d, 0x97, 0xe4, 0x9a,
-	0x72, 0xa7, 0x2e, 0xc9, 0x77, 0x94, 0xbb, 0x75, 0x49, 0x26, 0xca, 0x9c, 0xfa, 0x1a, 0xca, 0x51,
-	0x5b, 0x86, 0x21, 0x48, 0x18, 0xd6, 0x47, 0x30, 0xd2, 0xec, 0x90, 0xd9, 0xd3, 0x4a, 0x4e, 0xa4,
-	0xa6, 0xfe, 0x3a, 0x0b, 0xca, 0x0e, 0x9a, 0x7e, 0xe6, 0xda, 0xb8, 0x99, 0xb9, 0x51, 0x42, 0x6b,
-	0xe9, 0x0a, 0x09, 0xad, 0xda, 0xa4, 0x00, 0xf1, 0xce, 0x34, 0x01, 0xe2, 0xdd, 0x49, 0x09, 0xad,
-	0x7b, 0x13, 0x12, 0x5a, 0xcb, 0x53, 0xc4, 0x8f, 0x2b, 0x63, 0x13, 0x5a, 0xab, 0x57, 0x4c, 0x68,
-	0xdd, 0x9f, 0x36, 0xa1, 0xa5, 0x5e, 0x23, 0x39, 0x10, 0xc9, 0x7c, 0x3c, 0xbc, 0x5e, 0xe6, 0xe3,
-	0xd1, 0xf4, 0x99, 0x8f, 0xc4, 0x6d, 0x4d, 0x29, 0xe9, 0xba, 0x24, 0x83, 0x52, 0xac, 0x4b, 0x72,
-	0x5e, 0x91, 0xeb, 0x92, 0x5c, 0x50, 0xa0, 0x2e, 0xc9, 0xb2, 0x52, 0xa8, 0x4b, 0x72, 0x49, 0x29,
-	0xd7, 0x25, 0xb9, 0xa8, 0x94, 0xea, 0x92, 0x5c, 0x56, 0x2a, 0x75, 0x49, 0xae, 0x28, 0x33, 0x75,
-	0x49, 0x5e, 0x50, 0x16, 0xeb, 0x92, 0x3c, 0xa3, 0x28, 0x75, 0x49, 0x56, 0x94, 0xd9, 0xba, 0x24,
-	0xcf, 0x2a, 0x84, 0xdf, 0xf4, 0xba, 0x24, 0xcf, 0x29, 0xf3, 0x75, 0x49, 0x9e, 0x57, 0x16, 0x42,
-	0x6d, 0xb8, 0xad, 0x54, 0xeb, 0x92, 0x5c, 0x55, 0x96, 0xd4, 0xbf, 0x4e, 0xc1, 0xec, 0xbe, 0xc5,
-	0x54, 0xdc, 0x8f, 0xdc, 0xdf, 0x71, 0x89, 0xb5, 0xab, 0x67, 0x60, 0x57, 0xa0, 0x78, 0x62, 0xda,
-	0xad, 0xb3, 0xe6, 0x20, 0x66, 0x91, 0x35, 0x40, 0x12, 0xf7, 0xfc, 0x04, 0xa4, 0x4e, 0xdf, 0x34,
-	0x31, 0x20, 0x90, 0x35, 0x2c, 0xab, 0xff, 0x94, 0x82, 0xca, 0x81, 0xe1, 0xf9, 0x97, 0x68, 0xd5,
-	0x04, 0x44, 0xbb, 0x06, 0x25, 0x74, 0xa3, 0x83, 0x68, 0x22, 0x33, 0x74, 0x5f, 0x90, 0x41, 0x2c,
-	0xf1, 0x5a, 0x69, 0xe5, 0x53, 0xc3, 0xf3, 0x6d, 0x97, 0x7f, 0xd5, 0x94, 0xd1, 0x82, 0x6a, 0xb8,
-	0x9b, 0x6c, 0x64, 0x37, 0xef, 0x60, 0xe6, 0x95, 0xd9, 0xf7, 0x4e, 0x23, 0xbb, 0x79, 0x04, 0x79,
-	0x3e, 0x57, 0xf0, 0xe5, 0x49, 0x6c, 0xb2, 0xa0, 0x8d, 0xbc, 0x80, 0x92, 0x6f, 0x37, 0x83, 0x8d,
-	0x05, 0x8f, 0xd2, 0x89, 0x8d, 0x17, 0x7d, 0x3b, 0x28, 0x7b, 0xea, 0x1a, 0x28, 0xbb, 0xd4, 0xa4,
-	0x31, 0x83, 0x34, 0xe6, 0x40, 0xd5, 0xe7, 0x50, 0x69, 0xf8, 0xb6, 0x33, 0x25, 0xf7, 0xef, 0xd2,
-	0xb0, 0x70, 0xec, 0xb4, 0xb9, 0xbd, 0xe3, 0xea, 0x34, 0xc5, 0xa5, 0x79, 0x10, 0x0f, 0x58, 0x27,
-	0xe9, 0x63, 0x26, 0xa6, 0x8f, 0xff, 0x1f, 0x19, 0xfc, 0x84, 0x45, 0xcb, 0x4f, 0x61, 0xd1, 0xe4,
-	0xc9, 0x19, 0xb1, 0xc2, 0xa5, 0x19, 0x31, 0x18, 0x6f, 0xf0, 0xd4, 0xff, 0x4c, 0x41, 0xe5, 0x35,
-	0xf5, 0x0f, 0xec, 0xae, 0x77, 0x0d, 0xa7, 0x32, 0xee, 0x28, 0x02, 0x61, 0x74, 0x0c, 0xd3, 0xa7,
-	0x2e, 0x8f, 0x9d, 0x0b, 0x5c, 0x18, 0xaf, 0x38, 0x69, 0xf0, 0x10, 0x9e, 0xbb, 0xec, 0x21, 0x1c,
-	0x3f, 0xbd, 0xf1, 0x7c, 0xea, 0x8a, 0x5b, 0x2e, 0x6a, 0x8c, 0xde, 0xb1, 0x4d, 0xd3, 0x7e, 0x2f,
-	0xbe, 0x67, 0x11, 0x35, 0x7c, 0x39, 0xd2, 0x0d, 0x53, 0xc8, 0x0c, 0xcb, 0xdc, 0xe4, 0xa9, 0xbf,
-	0x4e, 0x03, 0x1c, 0xd8, 0xdd, 0x6f, 0xa9, 0xe7, 0xe9, 0x5d, 0x0c, 0x02, 0x42, 0x37, 0x1c, 0xc9,
-	0x3c, 0x84, 0x3e, 0xf7, 0x8d, 0xde, 0xa3, 0x91, 0xa7, 0xbc, 0xcc, 0x25, 0x4f, 0x79, 0xb1, 0x77,
-	0xc1, 0xfc, 0xd8, 0x77, 0xc1, 0xc7, 0x20, 0x73, 0x10, 0x65, 0xb4, 0xf1, 0xbc, 0x0a, 0xdb, 0xc5,
-	0x8f, 0x1f, 0x56, 0xf2, 0xfc, 0xb3, 0x80, 0x5d, 0x2d, 0x8f, 0x8d, 0xfb, 0xed, 0xc8, 0x96, 0x21,
-	0xb6, 0xe5, 0xe0, 0xd5, 0x50, 0x1a, 0xf3, 0x6a, 0x18, 0x7c, 0x69, 0x27, 0x73, 0x93, 0x80, 0x5f,
-	0xda, 0x3d, 0x83, 0x74, 0xf8, 0x20, 0x38, 0xce, 0x53, 0xa4, 0x7d, 0x8f, 0x69, 0x40, 0x8f, 0x0b,
-	0x08, 0x8f, 0xa4, 0xa0, 0x05, 0x55, 0xf5, 0x08, 0xe6, 0x34, 0xae, 0x0c, 0xfc, 0x7c, 0xa6, 0xd0,
-	0xc5, 0xe4, 0x05, 0x48, 0x0f, 0x5d, 0x00, 0xf5, 0x0f, 0x60, 0x4e, 0x38, 0x85, 0xd8, 0xa8, 0x13,
-	0x3f, 0x90, 0x50, 0x9b, 0xa0, 0x30, 0xa3, 0x3d, 0xf5, 0x5a, 0x18, 0x8e, 0xd4, 0xbb, 0x22, 0xa0,
-	0xe0, 0x0f, 0x88, 0x32, 0x23, 0x60, 0x30, 0x81, 0x9f, 0x80, 0x74, 0xf9, 0x83, 0x4c, 0x46, 0xc3,
-	0xb2, 0x7a, 0x01, 0xb3, 0x91, 0x09, 0x3c, 0xc7, 0xb6, 0x3c, 0x7c, 0xb1, 0x16, 0x47, 0xc8, 0xa0,
-	0x9c, 0x30, 0xa7, 0x95, 0xc1, 0xea, 0x10, 0xb6, 0x71, 0x5c, 0xcc, 0xc1, 0xde, 0x0a, 0x14, 0x51,
-	0x41, 0x9b, 0x6c, 0x4c, 0x4f, 0x4c, 0x0c, 0x48, 0x3a, 0x64, 0x94, 0x91, 0x53, 0xff, 0x29, 0xdc,
-	0x0e, 0xa7, 0x6e, 0xf8, 0x2e, 0xd5, 0x07, 0x0b, 0xf8, 0x14, 0x60, 0xb0, 0x80, 0xd8, 0xbb, 0xfc,
-	0x60, 0xfe, 0x42, 0x38, 0xff, 0xf5, 0xa6, 0xdf, 0x86, 0x42, 0x18, 0xf9, 0x44, 0x5e, 0x5d, 0x53,
-	0xd1, 0x57, 0x57, 0x66, 0x7e, 0x98, 0x28, 0xc5, 0x8b, 0x3a, 0x1f, 0xb8, 0xc0, 0x28, 0xfc, 0xfd,
-	0xfc, 0x9f, 0x53, 0x50, 0x89, 0x83, 0x7e, 0x52, 0x87, 0xb2, 0x65, 0xb7, 0x69, 0xd3, 0xa3, 0x26,
-	0x6d, 0xf9, 0xb6, 0x2b, 0xa4, 0xf7, 0x68, 0x44, 0x80, 0xb0, 0xf6, 0xc6, 0x6e, 0xd3, 0x86, 0xe0,
-	0xe3, 0x31, 0x7f, 0xc9, 0x8a, 0x90, 0xc8, 0x1a, 0xcc, 0x39, 0xae, 0x61, 0xbb, 0x86, 0x7f, 0xd1,
-	0x6c, 0x99, 0xba, 0xe7, 0x71, 0x15, 0xe6, 0x2f, 0xd1, 0xb3, 0x41, 0xd3, 0x0e, 0x6b, 0x61, 0x7a,
-	0x5c, 0xfb, 0x1a, 0x66, 0x87, 0x86, 0xbc, 0xd2, 0x17, 0x8d, 0xff, 0x06, 0xb0, 0xc0, 0xc1, 0x77,
-	0x68, 0x04, 0xaf, 0x8e, 0x15, 0x06, 0x59, 0xab, 0x07, 0x53, 0x64, 0xad, 0xae, 0x96, 0x11, 0x1b,
-	0x95, 0xe3, 0xca, 0xdf, 0x28, 0xc7, 0xb5, 0x72, 0xd5, 0x1c, 0x57, 0xe1, 0xf2, 0x1c, 0xd7, 0x22,
-	0xe4, 0xfa, 0xe8, 0xca, 0x03, 0x2b, 0xce, 0x6b, 0xc3, 0x99, 0x18, 0x18, 0x91, 0x89, 0x19, 0x44,
-	0x79, 0x0f, 0xa3, 0x51, 0xde, 0xc8, 0x04, 0x4d, 0xe9, 0x46, 0x09, 0x9a, 0xc5, 0xdf, 0x43, 0x82,
-	0x66, 0xfd, 0xba, 0x09, 0x9a, 0xf2, 0x94, 0x09, 0x9a, 0xca, 0xa4, 0x04, 0x8d, 0x32, 0x29, 0x41,
-	0x33, 0x3b, 0x9c, 0xa0, 0xb9, 0x0b, 0x05, 0x97, 0x0a, 0x70, 0x83, 0x4f, 0x8b, 0xb2, 0x36, 0x20,
-	0x8c, 0x48, 0xc9, 0xcc, 0x8f, 0x4f, 0xc9, 0x2c, 0x4c, 0x95, 0x92, 0xb9, 0x3f, 0x5d, 0x4a, 0xe6,
-	0xf6, 0x95, 0x53, 0x32, 0xd5, 0x1b, 0xa5, 0x64, 0x96, 0xae, 0x92, 0x92, 0x09, 0x32, 0x5b, 0xb5,
-	0x48, 0x66, 0x2b, 0x92, 0x47, 0xb9, 0x33, 0x36, 0x8f, 0x72, 0x77, 0x9a, 0x3c, 0xca, 0xbd, 0xeb,
-	0xe5, 0x51, 0x96, 0xc7, 0xe4, 0x51, 0x56, 0x13, 0x79, 0x94, 0x44, 0x9a, 0x48, 0x1d, 0x9f, 0x26,
-	0x8a, 0xa6, 0x57, 0xd6, 0xc6, 0xa6, 0x57, 0x12, 0x21, 0x27, 0x0f, 0x27, 0x79, 0xf0, 0x38, 0xa7,
-	0xcc, 0xab, 0x3b, 0xb0, 0x28, 0x9c, 0xff, 0xf5, 0x8d, 0xaa, 0xfa, 0x4b, 0x98, 0x63, 0xce, 0xf2,
-	0x06, 0x66, 0x39, 0x12, 0x60, 0xa5, 0x63, 0x01, 0x96, 0xfa, 0x57, 0x29, 0x58, 0xe0, 0x11, 0xce,
-	0x0d, 0x86, 0x57, 0x20, 0xa3, 0x87, 0x21, 0x27, 0x2b, 0x32, 0x37, 0xd3, 0xb1, 0xdd, 0x56, 0x60,
-	0x0c, 0x79, 0x85, 0x9d, 0xd0, 0x19, 0xa5, 0x0e, 0x7f, 0xdd, 0xe7, 0xdf, 0x44, 0xcb, 0x8c, 0xa0,
-	0x51, 0xc7, 0xae, 0x4b, 0x72, 0x5a, 0xc9, 0x88, 0xef, 0xa4, 0xb6, 0x60, 0xbe, 0xc1, 0x70, 0xd8,
-	0x0d, 0x84, 0xf6, 0x73, 0x98, 0x63, 0x91, 0xd8, 0x0d, 0x46, 0xf8, 0xdb, 0x14, 0x10, 0xad, 0x6f,
-	0xdd, 0x40, 0x2e, 0x3f, 0x01, 0x70, 0x5c, 0xfb, 0x9c, 0x5a, 0xba, 0x85, 0xdf, 0xdf, 0x33, 0x30,
-	0xb0, 0x10, 0xb9, 0x73, 0x87, 0x61, 0xa3, 0x16, 0x61, 0x8c, 0x40, 0x72, 0x69, 0x34, 0x24, 0x17,
-	0x52, 0xfa, 0x02, 0x2a, 0x5a, 0xdf, 0xda, 0x71, 0x6d, 0xeb, 0x1a, 0xbb, 0x7b, 0x0a, 0x73, 0xdc,
-	0xdb, 0xf3, 0x1f, 0xce, 0x04, 0x23, 0xb0, 0x80, 0xdb, 0x30, 0x79, 0xef, 0x92, 0x86, 0x65, 0xf5,
-	0x25, 0xcc, 0xf1, 0x2b, 0x12, 0x67, 0x7d, 0x00, 0x39, 0xfe, 0x63, 0x9c, 0xc1, 0x27, 0xd3, 0xe1,
-	0x4f, 0x78, 0x34, 0xd1, 0xa4, 0x7e, 0x01, 0xf3, 0x42, 0x01, 0xae, 0xd1, 0xf9, 0x2e, 0xe4, 0x38,
-	0x65, 0xe4, 0xdb, 0xe9, 0x5f, 0xa4, 0x00, 0x78, 0x33, 0x02, 0xc1, 0x69, 0x46, 0x0c, 0xbf, 0xba,
-	0x4b, 0x47, 0xbe, 0xba, 0xdb, 0x07, 0x82, 0xef, 0x4d, 0x86, 0x6d, 0x35, 0xc3, 0x9f, 0x76, 0x89,
-	0xa4, 0xc5, 0xb8, 0x60, 0x62, 0x36, 0xe8, 0x15, 0x92, 0xd4, 0xaf, 0x83, 0x5f, 0x6f, 0x71, 0x68,
-	0xfc, 0x02, 0x8a, 0x7c, 0xde, 0x68, 0x16, 0x74, 0x26, 0xb2, 0x2e, 0x0e, 0xa6, 0xbd, 0xb0, 0xac,
-	0xbe, 0x84, 0x85, 0xd7, 0xba, 0x7b, 0xa2, 0x77, 0xe9, 0x8e, 0x6d, 0x32, 0x24, 0x17, 0xc8, 0xeb,
-	0x3e, 0x94, 0xf8, 0xd7, 0x87, 0x02, 0x8e, 0x72, 0xa8, 0x5a, 0xe4, 0x34, 0x0e, 0x48, 0xab, 0xb0,
-	0x98, 0xec, 0xcb, 0x21, 0xb5, 0xba, 0x00, 0x73, 0x5b, 0x2d, 0xdf, 0x38, 0xd7, 0x7d, 0xba, 0xd5,
-	0xf7, 0x4f, 0xc5, 0x98, 0xea, 0x22, 0xcc, 0xc7, 0xc9, 0x9c, 0xfd, 0xd9, 0x9f, 0xa7, 0xf0, 0xa9,
-	0x9b, 0xe7, 0x93, 0x14, 0x28, 0xd5, 0xdf, 0x6e, 0x37, 0x1b, 0x47, 0x5b, 0xda, 0xd1, 0xfe, 0x9b,
-	0xd7, 0xca, 0x2d, 0x32, 0x03, 0x45, 0x46, 0xd1, 0x8e, 0xdf, 0xbc, 0x61, 0x84, 0x54, 0x40, 0x78,
-	0xb5, 0xb5, 0x7f, 0x70, 0xac, 0xed, 0x29, 0xe9, 0x80, 0xd0, 0x38, 0xde, 0xd9, 0xd9, 0x6b, 0x34,
-	0x94, 0x0c, 0xa9, 0x00, 0x30, 0xc2, 0x37, 0xfb, 0x07, 0x07, 0x7b, 0xbb, 0x8a, 0x14, 0x30, 0x7c,
-	0xbb, 0xa7, 0xbd, 0x66, 0x43, 0x64, 0xc9, 0x2c, 0x94, 0x19, 0x61, 0xef, 0xb5, 0xb6, 0xd7, 0x68,
-	0x30, 0x52, 0xee, 0xd9, 0x5b, 0x80, 0xc1, 0xd7, 0xe0, 0x04, 0x20, 0xc7, 0xc6, 0xdf, 0xdb, 0x55,
-	0x6e, 0x91, 0x22, 0xe4, 0x83, 0xa1, 0x53, 0x58, 0xf9, 0x66, 0xff, 0xf0, 0x70, 0x6f, 0x57, 0x49,
-	0x93, 0x12, 0xc8, 0xe1, 0x42, 0x33, 0xa4, 0x0c, 0x05, 0x6d, 0x6f, 0xe7, 0xed, 0x77, 0x7b, 0x1a,
-	0x9b, 0xf4, 0xd9, 0xd7, 0x50, 0x8c, 0x3c, 0xeb, 0xb3, 0x35, 0x1c, 0xbe, 0xdd, 0x0d, 0xb7, 0x71,
-	0x2b, 0x20, 0x0c, 0x86, 0xae, 0x00, 0x30, 0x82, 0x98, 0x37, 0xfd, 0xec, 0xef, 0x52, 0x83, 0x44,
-	0x37, 0x1f, 0x63, 0x01, 0x66, 0x0f, 0xf7, 0x0f, 0xf7, 0x0e, 0xf6, 0xdf, 0xec, 0x45, 0x25, 0x34,
-	0x0f, 0x4a, 0x48, 0x1e, 0x88, 0xe9, 0x36, 0xcc, 0x0d, 0xa8, 0x7b, 0x21, 0x7b, 0x3a, 0xc6, 0x1e,
-	0x08, 0x31, 0x43, 0xe6, 0x60, 0x26, 0xa4, 0x1e, 0x6e, 0x1d, 0x37, 0x50, 0x70, 0x51, 0xd6, 0xc6,
-	0xd1, 0xd6, 0x9b, 0xdd, 0xed, 0x3f, 0x51, 0xb2, 0xb1, 0x65, 0xec, 0x68, 0x5b, 0x8d, 0x5f, 0xa0,
-	0x04, 0x37, 0xfe, 0xab, 0x0c, 0x99, 0xad, 0xc3, 0x7d, 0xb2, 0x06, 0x85, 0x30, 0xab, 0x4e, 0x16,
-	0xc4, 0xef, 0x27, 0xe2, 0x59, 0xf6, 0x5a, 0x18, 0x4b, 0xaa, 0xb7, 0xc8, 0x8f, 0x01, 0x06, 0x69,
-	0x4c, 0xb2, 0x28, 0xe0, 0x5a, 0x22, 0xaf, 0x59, 0x8b, 0x7d, 0xf1, 0xa0, 0xde, 0x22, 0xeb, 0x90,
-	0x17, 0x39, 0x46, 0xc2, 0x3d, 0x79, 0x3c, 0xe3, 0x58, 0x2b, 0x47, 0xf9, 0x3d, 0xf5, 0x16, 0x83,
-	0xe3, 0x82, 0x85, 0x47, 0x80, 0xa3, 0xbb, 0x25, 0xa6, 0x79, 0x91, 0x22, 0x1b, 0x20, 0x07, 0xf9,
-	0x3f, 0xc2, 0x91, 0x7f, 0x22, 0x1d, 0x38, 0xa2, 0xcf, 0x97, 0x50, 0x08, 0xf3, 0x78, 0x42, 0x04,
-	0xc9, 0xbc, 0x5e, 0x6d, 0x71, 0x48, 0xd7, 0xf7, 0x7a, 0x8e, 0x7f, 0xa1, 0xde, 0x22, 0x3f, 0x85,
-	0xbc, 0xc8, 0xea, 0x89, 0x35, 0xc6, 0x73, 0x7c, 0x63, 0x7a, 0xbe, 0x84, 0x52, 0x34, 0xf8, 0x27,
-	0xd5, 0xa8, 0x30, 0xa3, 0x91, 0x7d, 0x2d, 0x11, 0xe2, 0xaa, 0xb7, 0xd8, 0x9a, 0xc3, 0x18, 0x59,
-	0xac, 0x39, 0x99, 0x0f, 0xa8, 0x2d, 0x26, 0xc9, 0x42, 0xe3, 0x6f, 0x91, 0x3a, 0xcc, 0x24, 0x22,
-	0xec, 0xcb, 0xc6, 0xb8, 0x1b, 0x27, 0xc7, 0xc3, 0x71, 0x94, 0xde, 0x36, 0x7e, 0x2a, 0x1d, 0x26,
-	0x46, 0xc4, 0x2e, 0x46, 0xe4, 0x4a, 0xc6, 0x48, 0xe2, 0x15, 0x54, 0xe2, 0xd1, 0x25, 0xa9, 0x45,
-	0x6e, 0x62, 0xc2, 0xc9, 0x8e, 0x19, 0x67, 0x07, 0x66, 0x12, 0x88, 0x8a, 0xdc, 0x89, 0x0a, 0x35,
-	0x39, 0xd2, 0xf0, 0xa3, 0x93, 0x7a, 0x8b, 0x7c, 0x05, 0xa5, 0x28, 0xa2, 0x12, 0x1b, 0x1a, 0x01,
-	0xb2, 0x6a, 0x64, 0xa8, 0xbb, 0xc7, 0x37, 0x13, 0x07, 0x4d, 0x62, 0x33, 0x23, 0x91, 0xd4, 0x98,
-	0xcd, 0xec, 0x42, 0x39, 0x86, 0x73, 0xc8, 0x92, 0xb8, 0x5e, 0xc3, 0xd8, 0x67, 0xcc, 0x28, 0xdb,
-	0x50, 0x8a, 0x42, 0x1d, 0xb1, 0x9b, 0x11, 0xe8, 0x67, 0xcc, 0x18, 0x3f, 0x87, 0x62, 0x04, 0xeb,
-	0x10, 0xfe, 0xeb, 0xdd, 0x61, 0xf4, 0x33, 0x5e, 0x49, 0x04, 0x1a, 0x11, 0x4a, 0x12, 0xc7, 0x26,
-	0xe3, 0xd7, 0x1f, 0x85, 0x22, 0x62, 0xfd, 0x23, 0xd0, 0xc9, 0xf8, 0x31, 0xa2, 0x18, 0x45, 0x8c,
-	0x31, 0x02, 0xb6, 0x8c, 0xdd, 0x01, 0xb0, 0x2b, 0x20, 0x46, 0xb8, 0x84, 0xaf, 0xa6, 0x24, 0xfc,
-	0x37, 0xbb, 0x0f, 0x7f, 0x08, 0xe5, 0x18, 0xca, 0x11, 0xe7, 0x38, 0x0a, 0xf9, 0xd4, 0x92, 0xfe,
-	0x1f, 0xbb, 0x0b, 0xeb, 0xb4, 0x65, 0x9a, 0x97, 0xce, 0x7b, 0xf9, 0xba, 0x37, 0x21, 0x2f, 0xd2,
-	0xdb, 0x42, 0xf2, 0xf1, 0x64, 0xb7, 0x98, 0x71, 0x90, 0x18, 0x46, 0x9d, 0xfe, 0x06, 0x2a, 0x71,
-	0xb4, 0x20, 0xae, 0xf0, 0x48, 0xf8, 0x51, 0xbb, 0x33, 0xb2, 0x2d, 0x34, 0x36, 0x7b, 0x50, 0x8a,
-	0x22, 0x09, 0x21, 0xfd, 0x11, 0x98, 0xa3, 0xb6, 0x34, 0xa2, 0x25, 0x1c, 0xe6, 0x15, 0x54, 0xe2,
-	0xcf, 0x21, 0x62, 0x4d, 0x23, 0xdf, 0x48, 0x2e, 0x17, 0xc8, 0xf6, 0x17, 0xbf, 0xf9, 0xb8, 0x9c,
-	0xfa, 0x97, 0x8f, 0xcb, 0xa9, 0xff, 0xf8, 0xb8, 0x9c, 0xfa, 0xe5, 0xa7, 0x5d, 0xc3, 0x3f, 0xed,
-	0x9f, 0xac, 0xb5, 0xec, 0xde, 0xba, 0xa3, 0xb7, 0x4e, 0x2f, 0xda, 0xd4, 0x8d, 0x96, 0x3c, 0xb7,
-	0xb5, 0x3e, 0xf8, 0xd7, 0x00, 0x27, 0x39, 0x1c, 0x6e, 0xf3, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0x7f, 0x0a, 0x8b, 0x32, 0x2f, 0x40, 0x00, 0x00,
+	// 5380 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7c, 0xcb, 0x6f, 0x1b, 0xc9,
+	0x76, 0xb7, 0xf9, 0x6e, 0x1e, 0x3e, 0xd4, 0x2a, 0xbd, 0xda, 0xb4, 0x2d, 0xc9, 0xed, 0xb1, 0xc7,
+	0xf6, 0xf5, 0x48, 0x1e, 0x69, 0x66, 0xee, 0xbd, 0x9e, 0xf9, 0x66, 0x46, 0x0f, 0xca, 0x57, 0x1c,
+	0x8d, 0xad, 0xaf, 0x29, 0xdf, 0x20, 0x77, 0x43, 0xb4, 0xd8, 0x45, 0xa9, 0xad, 0x66, 0x77, 0xdf,
+	0xee, 0xa6, 0x3c, 0xba, 0x40, 0x90, 0x45, 0x56, 0x77, 0x17, 0x24, 0x40, 0x16, 0x59, 0x64, 0x93,
+	0x5d, 0x80, 0x20, 0x8f, 0xbf, 0xe2, 0x02, 0x41, 0x80, 0x04, 0xc8, 0xda, 0x48, 0xbc, 0xc8, 0x2e,
+	0xdb, 0x2c, 0x6e, 0x36, 0xc1, 0xa9, 0xaa, 0x6e, 0x76, 0x93, 0x14, 0x49, 0x49, 0x83, 0x2c, 0x04,
+	0x54, 0x9d, 0x73, 0xaa, 0xba, 0xea, 0x54, 0xd5, 0x79, 0xfc, 0xaa, 0x28, 0x98, 0x6f, 0x5b, 0x26,
+	0xb5, 0x83, 0x75, 0xd7, 0xf5, 0xf1, 0x6f, 0xcd, 0xf5, 0x9c, 0xc0, 0x21, 0x19, 0xd7, 0xf5, 0x6b,
+	0x77, 0x4e, 0x1c, 0xe7, 0xc4, 0xa2, 0xeb, 0x8c, 0x74, 0xdc, 0xeb, 0xac, 0xd3, 0xae, 0x1b, 0x5c,
+	0x70, 0x89, 0xda, 0xca, 0x20, 0x33, 0x30, 0xbb, 0xd4, 0x0f, 0xf4, 0xae, 0x2b, 0x04, 0x96, 0x07,
+	0x05, 0x8c, 0x9e, 0xa7, 0x07, 0xa6, 0x63, 0x0b, 0xfe, 0xdd, 0x41, 0xbe, 0x1f, 0x78, 0xbd, 0x76,
+	0x20, 0xb8, 0xf3, 0x27, 0xce, 0x89, 0xc3, 0x8a, 0xeb, 0x58, 0x0a, 0xa9, 0xe1, 0x60, 0x3b, 0x3e,
+	0xfe, 0x71, 0xaa, 0x7a, 0x06, 0xa5, 0x26, 0x6d, 0x7b, 0x34, 0xf8, 0xde, 0xe9, 0xd9, 0x01, 0x21,
+	0x90, 0xb5, 0xf5, 0x2e, 0x55, 0x52, 0xab, 0xa9, 0xc7, 0x45, 0x8d, 0x95, 0x89, 0x0c, 0x99, 0x33,
+	0x7a, 0xa1, 0x64, 0x19, 0x09, 0x8b, 0xe4, 0x1e, 0x40, 0x17, 0xc5, 0x5b, 0xae, 0x1e, 0x9c, 0x2a,
+	0x69, 0xc6, 0x28, 0x32, 0xca, 0xa1, 0x1e, 0x9c, 0x92, 0x25, 0x28, 0x50, 0xfb, 0xbc, 0x75, 0xae,
+	0x7b, 0x4a, 0x86, 0xf1, 0xf2, 0xd4, 0x3e, 0xff, 0xa5, 0xee, 0xa9, 0x7f, 0x93, 0x85, 0xe2, 0x91,
+	0xa7, 0xdb, 0x7e, 0xc7, 0xf1, 0xba, 0x64, 0x1e, 0x72, 0x66, 0x57, 0x3f, 0x09, 0x3f, 0xc6, 0x2b,
+	0xf8, 0xb5, 0x76, 0xd7, 0x50, 0xd2, 0xab, 0x19, 0xfc, 0x5a, 0xbb, 0x6b, 0xb0, 0xee, 0x3c, 0xaf,
+	0x85, 0xd4, 0x0a, 0xa3, 0xe6, 0xa9, 0xe7, 0xed, 0x74, 0x0d, 0xf2, 0x04, 0x32, 0xd4, 0x3e, 0x57,
+	0x32, 0xab, 0x99, 0xc7, 0xa5, 0x8d, 0xa5, 0x35, 0x5c, 0x81, 0xa8, 0xf7, 0xb5, 0xba, 0x7d, 0x5e,
+	0xb7, 0x03, 0xef, 0x42, 0x43, 0x19, 0xf2, 0x14, 0x0a, 0x3e, 0x9b, 0xa6, 0xaf, 0x64, 0x99, 0xb8,
+	0xcc, 0xc4, 0x63, 0x53, 0xd7, 0x42, 0x01, 0xf2, 0x0c, 0x08, 0x1b, 0x4a, 0xcb, 0xed, 0x59, 0x56,
+	0x2b, 0x6c, 0x56, 0x64, 0x9f, 0x96, 0x19, 0xe7, 0xb0, 0x67, 0x59, 0x4d, 0x21, 0x3d, 0x0f, 0x39,
+	0x3f, 0x30, 0x4c, 0x5b, 0xc9, 0x31, 0x01, 0x5e, 0x21, 0x77, 0xa0, 0x88, 0x63, 0xe6, 0x9c, 0x2a,
+	0xe3, 0x48, 0xd4, 0xf3, 0x9a, 0x8c, 0xf9, 0x0c, 0x88, 0xde, 0x6e, 0x53, 0x37, 0x68, 0x79, 0x34,
+	0xe8, 0x79, 0x76, 0xab, 0xed, 0x18, 0x54, 0xc9, 0xaf, 0x66, 0x1e, 0x67, 0x34, 0x99, 0x73, 0x34,
+	0xc6, 0xd8, 0x71, 0x0c, 0x8a, 0x1f, 0x30, 0xe8, 0x71, 0xef, 0x44, 0x29, 0xac, 0xa6, 0x1e, 0x4b,
+	0x1a, 0xaf, 0xe0, 0x42, 0xf5, 0x7c, 0xea, 0x29, 0xc0, 0x17, 0x0a, 0xcb, 0x64, 0x05, 0x4a, 0xef,
+	0x1c, 0xef, 0xcc, 0xb4, 0x4f, 0x5a, 0x86, 0xe9, 0x29, 0x25, 0xc6, 0x02, 0x41, 0xda, 0x35, 0x3d,
+	0xb2, 0x0c, 0x60, 0x38, 0xed, 0x33, 0xea, 0x75, 0x4c, 0x8b, 0x2a, 0x65, 0xce, 0xef, 0x53, 0x48,
+	0x13, 0x94, 0x80, 0x7a, 0x5d, 0xd3, 0x66, 0x7b, 0xad, 0x75, 0xe2, 0xe9, 0x6d, 0xda, 0x72, 0xa9,
+	0x67, 0x3a, 0x86, 0x32, 0xb3, 0x9a, 0x7a, 0x5c, 0xda, 0xb8, 0xbd, 0xc6, 0x77, 0xde, 0x5a, 0xb8,
+	0xf3, 0xd6, 0x76, 0xc5, 0xce, 0xd4, 0x16, 0x63, 0x4d, 0x5f, 0x62, 0xcb, 0x43, 0xd6, 0xb0, 0xf6,
+	0x05, 0x48, 0xe1, 0x5a, 0x84, 0x5b, 0x29, 0xd5, 0xdf, 0x4a, 0xf3, 0x90, 0x3b, 0xd7, 0xad, 0x1e,
+	0x15, 0xbb, 0x88, 0x57, 0x5e, 0xa4, 0x7f, 0x96, 0x52, 0x9f, 0x40, 0xee, 0x68, 0xaf, 0xe1, 0x1c,
+	0x93, 0x55, 0xc8, 0x07, 0x9d, 0xd6, 0x5b, 0xe7, 0x98, 0xb7, 0xdb, 0x2e, 0x7e, 0x78, 0xbf, 0xc2,
+	0x59, 0x5a, 0x2e, 0xe8, 0x34, 0x9c, 0x63, 0xb5, 0x06, 0xf9, 0xfa, 0x89, 0x47, 0x7d, 0x1f, 0x3f,
+	0xf0, 0x46, 0x3b, 0x08, 0x3f, 0xf0, 0x46, 0x3b, 0x50, 0xef, 0x41, 0x06, 0x3b, 0x59, 0x84, 0xb4,
+	0x69, 0x88, 0x0e, 0xf2, 0x1f, 0xde, 0xaf, 0xa4, 0xf7, 0x77, 0xb5, 0xb4, 0x69, 0xa8, 0xbf, 0x4f,
+	0x81, 0xf4, 0x3d, 0x0d, 0x74, 0x43, 0x0f, 0x74, 0xf2, 0x2d, 0x94, 0x74, 0xdb, 0x76, 0x02, 0x36,
+	0x07, 0x5f, 0x49, 0xb1, 0x9d, 0xb2, 0xcc, 0x76, 0x4a, 0x28, 0xb3, 0xb6, 0xd5, 0x17, 0xe0, 0xfb,
+	0x2b, 0xde, 0x84, 0x7c, 0x0a, 0x79, 0x4b, 0x3f, 0xa6, 0x96, 0xcf, 0x36, 0x30, 0xea, 0x2b, 0xd1,
+	0xf8, 0x80, 0xf1, 0x78, 0x3b, 0x21, 0x58, 0xfb, 0x1a, 0xe4, 0xc1, 0x3e, 0xaf, 0xa2, 0xa7, 0xda,
+	0xcf, 0xa1, 0x14, 0xeb, 0xf6, 0x4a, 0x2a, 0xfe, 0x63, 0x28, 0x34, 0xa9, 0x77, 0x6e, 0xb6, 0x29,
+	0x79, 0x00, 0x15, 0xd3, 0x0e, 0xa8, 0x67, 0xeb, 0x56, 0xcb, 0x75, 0xbc, 0x80, 0x75, 0x90, 0xd3,
+	0xca, 0x21, 0xf1, 0xd0, 0xf1, 0x02, 0x14, 0xa2, 0x3f, 0xc4, 0x85, 0xd2, 0x5c, 0x88, 0xfe, 0x10,
+	0x13, 0x42, 0x4d, 0xbb, 0x4a, 0x26, 0xa6, 0xe9, 0x43, 0x2d, 0x6d, 0xba, 0xb8, 0x63, 0x83, 0x0b,
+	0x97, 0x0a, 0x3b, 0xc2, 0xca, 0x2a, 0x85, 0x5c, 0xd3, 0x75, 0x7a, 0x01, 0xb9, 0x0b, 0x45, 0xe7,
+	0x9c, 0x7a, 0xef, 0x3c, 0x33, 0xe0, 0xf6, 0x40, 0xd2, 0xfa, 0x04, 0xf2, 0x08, 0x4f, 0x2f, 0x1b,
+	0x27, 0xfb, 0x62, 0x69, 0xa3, 0x2c, 0x4e, 0x2f, 0xa3, 0x69, 0x21, 0x93, 0x2c, 0x42, 0xbe, 0xab,
+	0x7b, 0x67, 0x34, 0xb2, 0x3b, 0xbc, 0xa6, 0xfe, 0x5b, 0x0a, 0xa4, 0xc3, 0xbd, 0xe6, 0xbe, 0xed,
+	0xf6, 0x46, 0x9b, 0x38, 0x02, 0x59, 0x8f, 0xba, 0x8e, 0xd0, 0x10, 0x2b, 0x63, 0x67, 0xc7, 0x9e,
+	0x6e, 0xb7, 0x4f, 0xc3, 0xce, 0x78, 0x0d, 0xe9, 0x6d, 0xa7, 0xdb, 0x35, 0x03, 0x31, 0x13, 0x51,
+	0xc3, 0x3e, 0x4e, 0x2c, 0xe7, 0x58, 0xc9, 0xf1, 0x3e, 0xb0, 0x8c, 0xa6, 0xeb, 0xad, 0x63, 0xda,
+	0x2d, 0xc7, 0x56, 0x24, 0x2e, 0x8c, 0xd5, 0xd7, 0x36, 0x0a, 0x5b, 0xfa, 0x6f, 0x2e, 0x94, 0x3c,
+	0x9b, 0x2a, 0x2b, 0xe3, 0xf1, 0x65, 0x4e, 0xa2, 0x85, 0x67, 0xd1, 0x17, 0xc7, 0x1d, 0x18, 0x69,
+	0x0f, 0x29, 0xa4, 0x0a, 0x69, 0x7f, 0x53, 0x29, 0x32, 0x7a, 0xda, 0xdf, 0x54, 0xff, 0x2e, 0x05,
+	0xc5, 0x1d, 0xcf, 0xb1, 0xaf, 0x3c, 0x2f, 0x31, 0xfe, 0xcc, 0xe0, 0xf8, 0x7d, 0x97, 0xb6, 0xc3,
+	0xf5, 0xc1, 0x72, 0x72, 0x59, 0xf2, 0x83, 0xcb, 0xf2, 0x1c, 0x4d, 0x9f, 0xee, 0x05, 0x6c, 0xca,
+	0xa5, 0x8d, 0xda, 0x90, 0x6d, 0x38, 0x0a, 0xdd, 0x9a, 0xc6, 0x05, 0x55, 0x13, 0xa4, 0x97, 0x66,
+	0x70, 0xf9, 0x78, 0x6f, 0x43, 0xa6, 0xe7, 0x59, 0x7c, 0xb8, 0xdb, 0x85, 0x0f, 0xef, 0x57, 0xf0,
+	0x08, 0x6b, 0x48, 0xbb, 0xea, 0x72, 0xa8, 0xff, 0x9a, 0x82, 0x1c, 0xff, 0xd0, 0x0a, 0x64, 0xdc,
+	0x8e, 0xcf, 0x86, 0x5f, 0xda, 0xa8, 0xb0, 0x9d, 0x13, 0x6e, 0x06, 0x0d, 0x39, 0x64, 0x19, 0xb2,
+	0xb8, 0x2c, 0x4a, 0x81, 0x1d, 0x59, 0x60, 0x12, 0x9c, 0xcd, 0xe8, 0x64, 0x15, 0x72, 0x6d, 0xcf,
+	0xf1, 0xc3, 0x33, 0x1d, 0x17, 0xe0, 0x0c, 0x94, 0xe8, 0xd9, 0xa6, 0x63, 0x2b, 0x99, 0x61, 0x09,
+	0xc6, 0x20, 0x2a, 0x64, 0xdb, 0x9e, 0x63, 0xb3, 0x41, 0x96, 0x36, 0xaa, 0x4c, 0x20, 0x5a, 0x3b,
+	0x8d, 0xf1, 0x70, 0xa0, 0x27, 0x66, 0xa8, 0x4d, 0x3e, 0xd0, 0x50, 0x5b, 0x1a, 0x72, 0xd4, 0x33,
+	0x90, 0x1a, 0xce, 0x71, 0x52, 0x7d, 0xd9, 0x98, 0xfa, 0x1e, 0x44, 0xba, 0x48, 0xb1, 0x3e, 0x4a,
+	0x6b, 0xe8, 0xe8, 0x77, 0x18, 0x69, 0x68, 0x9f, 0xa6, 0x63, 0xfb, 0x34, 0xdc, 0x8e, 0x99, 0xfe,
+	0x76, 0x54, 0xdf, 0xc0, 0xcc, 0xa1, 0xee, 0xe9, 0x96, 0x45, 0x2d, 0xd3, 0xef, 0x36, 0x71, 0x3b,
+	0xd4, 0x40, 0x6a, 0x3b, 0xb6, 0x1f, 0xe8, 0x36, 0x3f, 0xfa, 0x59, 0x2d, 0xaa, 0x93, 0x55, 0x28,
+	0xb5, 0x1d, 0xda, 0xe9, 0x98, 0x6d, 0x8c, 0x32, 0x58, 0x4f, 0x29, 0x2d, 0x4e, 0x6a, 0x64, 0xa5,
+	0x94, 0x9c, 0x56, 0x9f, 0x42, 0xf9, 0x17, 0xba, 0x7f, 0x1a, 0x78, 0x94, 0x0e, 0xf5, 0x99, 0x4a,
+	0xf6, 0xa9, 0x6e, 0x42, 0x91, 0x4d, 0x16, 0xb7, 0x3f, 0x8e, 0x91, 0x85, 0x1b, 0x62, 0xc2, 0x58,
+	0x46, 0xda, 0xa9, 0xee, 0x9f, 0x32, 0x95, 0x95, 0x35, 0x56, 0x56, 0xbf, 0x84, 0xdc, 0xae, 0x1e,
+	0xf4, 0xba, 0x97, 0x99, 0x7c, 0x52, 0x83, 0xcc, 0x5b, 0x31, 0xff, 0xd2, 0x86, 0xc4, 0xd4, 0x8c,
+	0xbe, 0x04, 0x89, 0xea, 0xef, 0x52, 0x50, 0x64, 0xad, 0xf7, 0xed, 0x8e, 0x83, 0xcb, 0x6a, 0x60,
+	0x45, 0xa8, 0x93, 0x2f, 0x2b, 0x63, 0x6b, 0x9c, 0x41, 0x1e, 0xb2, 0x23, 0x10, 0x70, 0xbb, 0x54,
+	0xdd, 0x98, 0xe9, 0x4b, 0x34, 0x91, 0xac, 0x71, 0x2e, 0xf9, 0x98, 0x8b, 0xf9, 0x4c, 0x2d, 0xa5,
+	0x8d, 0x59, 0xbe, 0x09, 0x3d, 0xa7, 0x4d, 0x7d, 0x1f, 0x05, 0x7d, 0x2e, 0xe8, 0x93, 0x47, 0x50,
+	0x74, 0x3b, 0x7e, 0x8b, 0xf7, 0xc9, 0xf7, 0x4a, 0x91, 0x2d, 0x22, 0xaa, 0x40, 0x93, 0xdc, 0x0e,
+	0x13, 0xa7, 0xe4, 0x3e, 0x64, 0xd1, 0xa1, 0xb0, 0xa0, 0x83, 0xed, 0x15, 0x21, 0x82, 0xc3, 0xd6,
+	0x18, 0x4b, 0xfd, 0xfb, 0x14, 0x14, 0xb7, 0x4e, 0x4e, 0x3c, 0x7a, 0x82, 0x0d, 0xe6, 0x21, 0xd7,
+	0xc6, 0x30, 0x87, 0x4d, 0x25, 0xa3, 0xf1, 0x0a, 0xea, 0xaf, 0x4b, 0x75, 0x9b, 0x8d, 0x3e, 0xa5,
+	0xb1, 0x32, 0x1e, 0x28, 0x3f, 0x30, 0x0c, 0x7a, 0x2e, 0xd6, 0x50, 0xd4, 0xc8, 0x13, 0x90, 0x3b,
+	0x66, 0x27, 0x38, 0xc5, 0x80, 0xa0, 0x4d, 0xed, 0xc0, 0xb4, 0xf8, 0x08, 0x53, 0xda, 0x0c, 0xa3,
+	0x1f, 0x46, 0x64, 0xf2, 0x05, 0x2c, 0xd9, 0xa6, 0x4d, 0x99, 0x29, 0x1b, 0x68, 0x91, 0x63, 0x2d,
+	0x16, 0x38, 0x7b, 0x2f, 0xd9, 0x4e, 0xfd, 0xb3, 0x34, 0x94, 0xe3, 0x5a, 0x21, 0x5f, 0x43, 0xc5,
+	0x70, 0xde, 0xd9, 0x96, 0xa3, 0x1b, 0x2d, 0x8c, 0x91, 0x95, 0xd4, 0xa4, 0x28, 0xa4, 0x1c, 0xca,
+	0xa3, 0xed, 0x21, 0x5f, 0x41, 0xd9, 0xe5, 0xfd, 0xf1, 0xe6, 0xe9, 0x49, 0xcd, 0x4b, 0x42, 0x9c,
+	0xb5, 0x7e, 0x01, 0xa5, 0x9e, 0xdb, 0xff, 0x76, 0x66, 0x52, 0x63, 0xe0, 0xd2, 0xac, 0xed, 0x43,
+	0xa8, 0x46, 0x23, 0x3f, 0xbe, 0x08, 0xa8, 0xcf, 0x74, 0x95, 0xd5, 0xa2, 0xf9, 0x6c, 0x23, 0x91,
+	0xdc, 0x87, 0x72, 0xcf, 0x8d, 0x09, 0xe5, 0x98, 0x90, 0xf8, 0x2c, 0x13, 0x51, 0xff, 0x32, 0x0d,
+	0x0b, 0xd1, 0x3a, 0x26, 0xb4, 0xb3, 0x39, 0x5a, 0x3b, 0xdc, 0xb8, 0x44, 0x4d, 0x06, 0x54, 0xf2,
+	0xe9, 0x48, 0x95, 0x0c, 0xb6, 0x49, 0xe8, 0x61, 0x7d, 0x94, 0x1e, 0x06, 0x5b, 0xc4, 0x27, 0xff,
+	0xf9, 0xc8, 0xc9, 0x0f, 0xb7, 0x19, 0x50, 0xc6, 0xa7, 0x23, 0x94, 0x31, 0x62, 0x68, 0x71, 0xe5,
+	0xfc, 0x63, 0x1a, 0xca, 0x7f, 0xe0, 0xa0, 0x93, 0x47, 0x95, 0xf4, 0x7c, 0xf2, 0x04, 0x8a, 0xef,
+	0x58, 0xbd, 0x15, 0x9d, 0xfd, 0xf2, 0x87, 0xf7, 0x2b, 0x12, 0x17, 0xda, 0xdf, 0xd5, 0x24, 0xce,
+	0xde, 0x37, 0x30, 0xae, 0x7c, 0xeb, 0x1c, 0xa3, 0x5c, 0xba, 0x1f, 0x57, 0xa2, 0x7d, 0xdd, 0xd5,
+	0x72, 0x6f, 0x9d, 0xe3, 0x7d, 0x03, 0x8d, 0x36, 0x3b, 0x65, 0xdc, 0xaa, 0x57, 0xfb, 0x56, 0x9d,
+	0x9d, 0x46, 0xc6, 0x23, 0x9f, 0x41, 0x81, 0xf9, 0x36, 0x6a, 0x28, 0xd9, 0x89, 0x6e, 0x30, 0x14,
+	0xed, 0x1b, 0x84, 0xdc, 0x04, 0x83, 0x70, 0x0f, 0xe0, 0xd7, 0x3d, 0xda, 0xa3, 0x2d, 0xdf, 0xfc,
+	0x0d, 0x77, 0xc1, 0x19, 0xad, 0xc8, 0x28, 0x4d, 0xf3, 0x37, 0x94, 0x6c, 0xc2, 0xe2, 0x3b, 0xdd,
+	0x0c, 0x30, 0xe4, 0xef, 0x38, 0x5e, 0xcb, 0xdf, 0x6c, 0xa1, 0x8e, 0xde, 0xe9, 0x17, 0x22, 0x7c,
+	0x98, 0x13, 0xdc, 0x3d, 0xc7, 0x6b, 0x6e, 0xbe, 0xe4, 0x2c, 0xd5, 0x83, 0xb2, 0x46, 0x7d, 0xa7,
+	0xe7, 0xb5, 0xb9, 0x09, 0xc6, 0x94, 0xcb, 0xed, 0x31, 0x6d, 0xa5, 0x35, 0x2c, 0xb2, 0x40, 0x8a,
+	0x76, 0x1d, 0xef, 0x42, 0x78, 0x09, 0x51, 0x23, 0xcb, 0x90, 0x39, 0x71, 0x7b, 0x4a, 0x2e, 0x16,
+	0x84, 0xbd, 0x3c, 0x7c, 0x83, 0x9d, 0x68, 0xc8, 0x40, 0x7b, 0x62, 0x98, 0xfe, 0x59, 0x68, 0xa3,
+	0xb1, 0xdc, 0xc8, 0x4a, 0x19, 0x39, 0xab, 0x7e, 0x0e, 0x05, 0x21, 0x19, 0x05, 0x82, 0xa9, 0x7e,
+	0x20, 0x88, 0x1f, 0xb4, 0x7b, 0xdd, 0x63, 0xea, 0xb1, 0x0f, 0x66, 0x34, 0x51, 0x53, 0x7f, 0x9f,
+	0x85, 0x52, 0x3d, 0x68, 0x1b, 0xcc, 0xed, 0x75, 0x9c, 0xd0, 0x76, 0xa7, 0x46, 0xd8, 0x6e, 0xf2,
+	0x04, 0x24, 0xd7, 0x74, 0xa9, 0x65, 0xda, 0xe1, 0xae, 0x16, 0xce, 0x5e, 0x10, 0xb5, 0x88, 0x4d,
+	0x9e, 0x43, 0xc5, 0xe9, 0x05, 0x6e, 0x2f, 0x68, 0xc5, 0x42, 0xa1, 0x01, 0x7f, 0x59, 0xe6, 0x12,
+	0xbc, 0x46, 0x14, 0x28, 0x78, 0x94, 0x47, 0x3b, 0xfc, 0x20, 0x87, 0x55, 0x76, 0xd2, 0xf5, 0x40,
+	0x6f, 0x89, 0x13, 0x43, 0x0d, 0xa6, 0x9e, 0x8c, 0x56, 0x41, 0xea, 0x61, 0x48, 0xc4, 0x93, 0xce,
+	0xc4, 0xfc, 0x33, 0xd3, 0x75, 0xa9, 0x21, 0x96, 0xb2, 0x84, 0xb4, 0x26, 0x27, 0xe1, 0x5a, 0x33,
+	0x91, 0xc0, 0x09, 0x74, 0x8b, 0x2d, 0x60, 0x46, 0x2b, 0x22, 0xe5, 0x08, 0x09, 0x18, 0x1f, 0x32,
+	0x76, 0x47, 0x37, 0x2d, 0x6a, 0xb0, 0x80, 0x32, 0xa3, 0xb1, 0x16, 0x7b, 0x8c, 0x12, 0x8d, 0xc4,
+	0xa3, 0x6d, 0x0c, 0xd2, 0x28, 0x4f, 0xda, 0xc4, 0x48, 0xb4, 0x90, 0x88, 0x51, 0x3c, 0x13, 0xa3,
+	0x3f, 0xb4, 0xad, 0x9e, 0x41, 0x0d, 0x45, 0x66, 0x52, 0x6c, 0x78, 0x75, 0x41, 0xeb, 0x6f, 0xd0,
+	0xe2, 0x84, 0x0d, 0xba, 0x06, 0x65, 0x56, 0x08, 0x35, 0x09, 0xc3, 0x9a, 0x2c, 0x31, 0x01, 0x5e,
+	0x21, 0x0f, 0x42, 0x8f, 0x59, 0x62, 0x1e, 0xb3, 0x12, 0xae, 0x61, 0xc2, 0x5f, 0x2e, 0x42, 0xde,
+	0xa3, 0xba, 0xef, 0xd8, 0x22, 0x49, 0x15, 0xb5, 0xf8, 0x61, 0xab, 0x4c, 0x7f, 0xd8, 0xbe, 0x00,
+	0xa9, 0x63, 0xda, 0xa6, 0x7f, 0x4a, 0x0d, 0xa5, 0x3a, 0xb1, 0x59, 0x24, 0xab, 0xfe, 0xb6, 0x0a,
+	0x85, 0x69, 0x36, 0xde, 0x33, 0x28, 0x06, 0x21, 0xee, 0x90, 0xb0, 0xa7, 0x11, 0x1a, 0xa1, 0xf5,
+	0x05, 0x12, 0xdb, 0x34, 0x33, 0x7e, 0x9b, 0x3e, 0x01, 0x39, 0x2c, 0xb7, 0xce, 0xa9, 0xe7, 0x63,
+	0x84, 0x59, 0x61, 0xbb, 0x6f, 0x26, 0xa4, 0xff, 0x92, 0x93, 0xc9, 0x33, 0x28, 0x61, 0xc4, 0x1e,
+	0xae, 0xc2, 0xfa, 0xf0, 0x2a, 0x00, 0xf2, 0x79, 0x99, 0x7c, 0x03, 0xb2, 0xdb, 0x8f, 0xed, 0x5a,
+	0xc8, 0x61, 0x9a, 0x2e, 0x6d, 0xcc, 0xf3, 0xb1, 0x24, 0x03, 0x3f, 0x6d, 0xc6, 0x4d, 0x12, 0x30,
+	0xd2, 0xa4, 0x2c, 0xe3, 0x16, 0xb8, 0x40, 0x89, 0x35, 0xe3, 0x49, 0xb8, 0x26, 0x58, 0xe4, 0x63,
+	0x00, 0x57, 0xf7, 0xa8, 0x1d, 0xb0, 0xe4, 0x3d, 0x3f, 0xa0, 0xba, 0x22, 0xe7, 0x61, 0x72, 0x1e,
+	0x5b, 0xd6, 0xc2, 0xf5, 0x96, 0x55, 0x9a, 0x7e, 0x59, 0x87, 0x0f, 0x7f, 0x71, 0xd2, 0xe1, 0x8f,
+	0xf6, 0x2c, 0x4c, 0xb5, 0x67, 0x1f, 0x24, 0xf6, 0x6c, 0x2c, 0x79, 0xad, 0x8e, 0x4b, 0x5e, 0x57,
+	0x21, 0xe7, 0xbb, 0x4e, 0x2f, 0x50, 0x3e, 0x89, 0x05, 0x9b, 0x2c, 0x3b, 0xd6, 0x38, 0x83, 0x3c,
+	0x85, 0x92, 0x18, 0x38, 0x4b, 0xea, 0x48, 0x2c, 0x3c, 0xd4, 0xa8, 0xeb, 0x68, 0xc0, 0xb9, 0x58,
+	0xc6, 0x43, 0x2e, 0x64, 0x45, 0xd6, 0x34, 0xcb, 0x06, 0x25, 0xe6, 0xb5, 0xcd, 0x68, 0x71, 0xa3,
+	0x36, 0x3f, 0xc9, 0xa8, 0x2d, 0x4e, 0x63, 0xd4, 0x96, 0x87, 0x8d, 0xda, 0x80, 0xd5, 0x7a, 0x3c,
+	0x85, 0xd5, 0x5a, 0x9b, 0xca, 0x6a, 0x7d, 0x3a, 0xc2, 0x6a, 0x25, 0x2d, 0xe8, 0xd2, 0xa0, 0x05,
+	0x8d, 0x8c, 0xda, 0xca, 0x04, 0xa3, 0xf6, 0x05, 0x54, 0x44, 0x14, 0xe1, 0xb3, 0xb0, 0x42, 0x51,
+	0x56, 0x33, 0x51, 0x83, 0x78, 0xbc, 0xa1, 0x95, 0xdf, 0xc5, 0x6a, 0xe4, 0x6b, 0x98, 0xf5, 0x84,
+	0x67, 0x6d, 0x79, 0xf4, 0xd7, 0x3d, 0xea, 0x07, 0xbe, 0x72, 0x3b, 0xf6, 0xb1, 0xb8, 0xdf, 0xd5,
+	0xe4, 0x50, 0x56, 0x13, 0xa2, 0xe4, 0x05, 0xcc, 0x44, 0xed, 0x2d, 0xb3, 0x6b, 0x06, 0xbe, 0xf2,
+	0xd1, 0x65, 0xad, 0xab, 0xa1, 0xe4, 0x01, 0x13, 0x24, 0xfb, 0xb0, 0xe4, 0x9b, 0x06, 0x6d, 0xeb,
+	0x5e, 0x6b, 0xb0, 0x8f, 0xe7, 0x97, 0xf5, 0xb1, 0x20, 0x5a, 0x68, 0xc9, 0xae, 0x56, 0x21, 0x67,
+	0x62, 0x98, 0xa3, 0xd4, 0x62, 0x5b, 0x51, 0xa4, 0xb3, 0x8c, 0x41, 0xd6, 0x00, 0x6c, 0xfa, 0x2e,
+	0xdc, 0x5b, 0x77, 0x98, 0xd8, 0x0c, 0xdb, 0x89, 0x7c, 0x6b, 0xb1, 0x3c, 0xa4, 0x68, 0xd3, 0x77,
+	0xbc, 0x3a, 0xe4, 0x25, 0xee, 0x4d, 0xf0, 0x12, 0xf7, 0xa1, 0x4c, 0x6d, 0xfd, 0xd8, 0xa2, 0x2d,
+	0xbe, 0x60, 0xab, 0x2c, 0x9a, 0x29, 0x71, 0x1a, 0x8f, 0x7e, 0x11, 0xaf, 0xd0, 0xad, 0x40, 0xb9,
+	0x2f, 0xf0, 0x0a, 0xdd, 0x0a, 0xc8, 0x27, 0x00, 0xed, 0xd3, 0x9e, 0x7d, 0xc6, 0x2d, 0xda, 0xc3,
+	0x78, 0xae, 0x8d, 0x64, 0x36, 0xe7, 0x62, 0x3b, 0x2c, 0xb2, 0xf4, 0x02, 0x73, 0x35, 0x16, 0xd7,
+	0xe2, 0xd1, 0x7b, 0x34, 0x39, 0xbd, 0x40, 0xf9, 0x23, 0x2e, 0x8e, 0x09, 0x02, 0x46, 0x90, 0x61,
+	0xeb, 0x8f, 0x27, 0xb5, 0x86, 0xb7, 0xce, 0x71, 0xd8, 0x96, 0x9f, 0x0b, 0xfc, 0xb6, 0x67, 0x52,
+	0x5f, 0x79, 0x12, 0x9d, 0x8b, 0x5e, 0xf7, 0x08, 0x29, 0xe4, 0x2b, 0x98, 0xf1, 0xdb, 0xa7, 0xd4,
+	0xe8, 0x59, 0x18, 0xdd, 0xb1, 0x09, 0x3d, 0x65, 0x1f, 0x98, 0xe3, 0x96, 0x21, 0xe2, 0xf1, 0xdd,
+	0xe0, 0x27, 0xea, 0xe4, 0x36, 0x48, 0xae, 0x63, 0xf0, 0x66, 0x3f, 0x61, 0x1a, 0x2a, 0xb8, 0x8e,
+	0xc1, 0x58, 0x77, 0xa0, 0x88, 0x2c, 0x57, 0x0f, 0xda, 0xa7, 0xca, 0x33, 0xc6, 0x43, 0xd9, 0x43,
+	0xac, 0x37, 0xb2, 0x52, 0x56, 0xce, 0x35, 0xb2, 0x52, 0x4e, 0xce, 0x37, 0xb2, 0xd2, 0x5d, 0xf9,
+	0x5e, 0x23, 0x2b, 0xa9, 0xf2, 0x03, 0x75, 0x17, 0xf2, 0x7c, 0xdf, 0x8f, 0xc4, 0x6d, 0x1e, 0x25,
+	0xd3, 0x60, 0x79, 0xe0, 0x9c, 0x84, 0x36, 0x52, 0xdd, 0x14, 0x00, 0x46, 0xc7, 0x41, 0xef, 0x20,
+	0xb1, 0xf0, 0xdb, 0xee, 0x38, 0x02, 0x69, 0x2d, 0x87, 0x76, 0x95, 0xed, 0x9e, 0xc2, 0x5b, 0x5e,
+	0x50, 0x97, 0x41, 0x0a, 0x7d, 0xe3, 0xa8, 0x8f, 0xab, 0xff, 0x93, 0x06, 0x19, 0x63, 0xc4, 0x50,
+	0x08, 0x1b, 0x91, 0xc7, 0xe1, 0x88, 0x52, 0x6c, 0x44, 0x24, 0xe1, 0x62, 0x2f, 0xb1, 0xdb, 0xd9,
+	0x84, 0xdd, 0x1e, 0xf0, 0xa8, 0xe9, 0xf1, 0x1e, 0x75, 0x07, 0x70, 0x71, 0x5b, 0x2c, 0xad, 0xf6,
+	0x45, 0xc2, 0xf0, 0x11, 0x77, 0x8a, 0x03, 0x43, 0xc3, 0x09, 0xee, 0x30, 0x31, 0x8e, 0x03, 0x17,
+	0xdf, 0x86, 0x75, 0x34, 0x5f, 0x7a, 0x2f, 0x38, 0x6d, 0x05, 0xce, 0x19, 0xb5, 0x05, 0x90, 0x58,
+	0x44, 0xca, 0x11, 0x12, 0xc8, 0x26, 0x54, 0x2d, 0xdd, 0x67, 0xde, 0x54, 0x20, 0x04, 0xf9, 0x51,
+	0xfe, 0xa8, 0x8c, 0x42, 0x61, 0x0d, 0x71, 0x99, 0x98, 0xf3, 0x66, 0xfe, 0x35, 0xab, 0xc5, 0x49,
+	0xb5, 0xaf, 0xa0, 0x9a, 0x1c, 0x52, 0x1c, 0x43, 0xce, 0x8d, 0xc0, 0x90, 0x73, 0x71, 0x0c, 0xf9,
+	0xaf, 0x67, 0xa1, 0x9c, 0xd0, 0x3c, 0x87, 0x5d, 0x66, 0x87, 0x60, 0x97, 0x78, 0xdc, 0x93, 0x1a,
+	0x1f, 0xf7, 0x28, 0x50, 0x08, 0xc3, 0x9d, 0x12, 0xf7, 0x4b, 0xe7, 0x51, 0x98, 0x73, 0x95, 0x50,
+	0xeb, 0x59, 0x74, 0x73, 0xb0, 0x16, 0x33, 0x64, 0xec, 0xea, 0x60, 0xf8, 0x16, 0x61, 0x64, 0x50,
+	0x04, 0x57, 0x09, 0x8a, 0xbe, 0x80, 0xca, 0xa9, 0x80, 0xb6, 0xe2, 0xe7, 0x95, 0xdb, 0xdd, 0x38,
+	0xe8, 0xa5, 0x95, 0x4f, 0x63, 0xb5, 0xe9, 0x82, 0xa9, 0x9f, 0x03, 0xb4, 0x3d, 0xaa, 0x07, 0xd4,
+	0x68, 0xe9, 0x81, 0x92, 0x9f, 0x18, 0xef, 0x14, 0x85, 0xf4, 0x56, 0xd0, 0x3f, 0x0b, 0x85, 0x49,
+	0x67, 0x41, 0xc1, 0x40, 0xcc, 0x61, 0xae, 0xfc, 0x11, 0xb3, 0xb8, 0x61, 0x15, 0x0d, 0xb2, 0x47,
+	0x11, 0xa7, 0x69, 0x51, 0xcf, 0x73, 0x3c, 0x01, 0x67, 0x97, 0x38, 0xad, 0x8e, 0x24, 0xf2, 0x13,
+	0x98, 0xe5, 0xce, 0xd0, 0x0f, 0x7d, 0x5f, 0xe4, 0xa5, 0x65, 0xc1, 0xd0, 0x42, 0x7a, 0x5c, 0x58,
+	0x3f, 0xd7, 0x4d, 0x0b, 0xed, 0xba, 0xb2, 0x91, 0x10, 0xde, 0x0a, 0xe9, 0xe4, 0x9b, 0xc4, 0xe1,
+	0x2a, 0xb2, 0xc3, 0xb5, 0x9a, 0x98, 0xc5, 0x84, 0x83, 0x35, 0x7c, 0x72, 0x7e, 0x32, 0xf9, 0xe4,
+	0x0c, 0x85, 0x50, 0xf2, 0x88, 0x10, 0x6a, 0xa4, 0xc7, 0x9f, 0xbb, 0x91, 0xc7, 0x5f, 0xf9, 0x11,
+	0x3c, 0xfe, 0xe6, 0x75, 0x3d, 0xfe, 0xfc, 0x65, 0x1e, 0x7f, 0x15, 0x4a, 0x06, 0xf5, 0xdb, 0x9e,
+	0xe9, 0xa2, 0x2b, 0x53, 0x16, 0xf8, 0xfa, 0xc7, 0x48, 0x68, 0xbd, 0xda, 0x7a, 0xfb, 0x54, 0x40,
+	0x15, 0x4b, 0xdc, 0x7a, 0x31, 0x0a, 0x83, 0x2a, 0x06, 0x5d, 0xba, 0x72, 0xb9, 0x4b, 0xbf, 0x1d,
+	0x73, 0xe9, 0x7d, 0xf3, 0x7c, 0x37, 0x61, 0x9e, 0x3f, 0x82, 0x6a, 0x57, 0xff, 0xa1, 0x15, 0x03,
+	0x47, 0xee, 0xf1, 0x80, 0xb0, 0xab, 0xff, 0xf0, 0xff, 0x23, 0x7c, 0x24, 0x16, 0x7c, 0x2f, 0xdf,
+	0x2c, 0xf8, 0x4e, 0x86, 0x16, 0xab, 0x57, 0x0e, 0x2d, 0xee, 0xdf, 0x28, 0xb4, 0x50, 0xaf, 0x12,
+	0x5a, 0xac, 0x43, 0xe9, 0xc4, 0x0c, 0x4e, 0x1d, 0xe7, 0xac, 0x85, 0xb7, 0x29, 0x2c, 0x1d, 0xd9,
+	0xae, 0x7e, 0x78, 0xbf, 0x02, 0x2f, 0x39, 0x19, 0x2f, 0x55, 0x40, 0x88, 0xbc, 0xf1, 0xac, 0x41,
+	0x57, 0xf7, 0xd1, 0x78, 0x57, 0xc7, 0x8c, 0x84, 0x6e, 0x1b, 0xc7, 0x17, 0xca, 0xc3, 0xd0, 0x48,
+	0xb0, 0xea, 0x60, 0x4c, 0xf3, 0xf1, 0x34, 0x31, 0xcd, 0xe3, 0xeb, 0xc5, 0x34, 0x4f, 0xa6, 0x8f,
+	0x69, 0xc8, 0x02, 0xe4, 0xfd, 0xcd, 0x96, 0xd3, 0xe3, 0x69, 0xb1, 0xa4, 0xe5, 0xfc, 0xcd, 0xd7,
+	0xbd, 0x00, 0x1d, 0x52, 0x57, 0x5c, 0xcc, 0x8a, 0x08, 0xb9, 0x92, 0xb8, 0xad, 0xd5, 0x22, 0x36,
+	0xd9, 0x84, 0xb2, 0xe5, 0x9c, 0xb4, 0x7c, 0xbd, 0xeb, 0xe2, 0x68, 0x94, 0xcf, 0x98, 0x38, 0x0f,
+	0x73, 0x0e, 0x9c, 0x93, 0xa6, 0xa0, 0x6b, 0x25, 0xab, 0x5f, 0x21, 0xbb, 0x20, 0x27, 0xe0, 0x59,
+	0x1c, 0xc0, 0xe7, 0x93, 0xd6, 0x71, 0x26, 0x0e, 0xd6, 0xe2, 0x62, 0x7e, 0x0b, 0xd5, 0x9e, 0x9b,
+	0xe8, 0xe3, 0x8b, 0x49, 0x7d, 0x54, 0x7a, 0x6e, 0xbc, 0x87, 0x7d, 0x98, 0xe7, 0xab, 0x82, 0x29,
+	0x58, 0xcf, 0xa3, 0x2d, 0xd7, 0xb1, 0xcc, 0xf6, 0x85, 0xf2, 0x53, 0x66, 0x02, 0x97, 0xfa, 0x57,
+	0x16, 0x7b, 0x9c, 0x7f, 0xc8, 0xd8, 0x1a, 0x31, 0x86, 0x68, 0x22, 0x09, 0xeb, 0x75, 0xc3, 0x2c,
+	0x4c, 0xf9, 0x19, 0x37, 0x89, 0x8c, 0x28, 0xb2, 0x30, 0xf2, 0x1c, 0x4a, 0xfe, 0x66, 0x8b, 0xda,
+	0x86, 0xeb, 0x98, 0x76, 0xa0, 0xfc, 0x3c, 0x4c, 0x0e, 0x70, 0x81, 0x37, 0xeb, 0x82, 0xac, 0x81,
+	0x1f, 0x95, 0x6f, 0x16, 0x81, 0x70, 0x80, 0x31, 0x0a, 0x5c, 0x17, 0xe5, 0xa5, 0x46, 0x56, 0xaa,
+	0xc9, 0x77, 0x1a, 0x59, 0xe9, 0x8e, 0x7c, 0xb7, 0x91, 0x95, 0x88, 0x3c, 0xa7, 0xbe, 0x84, 0x4a,
+	0xdc, 0x55, 0xb0, 0x0c, 0x2f, 0x82, 0x56, 0x62, 0x21, 0xe8, 0xec, 0x90, 0x57, 0xd1, 0xca, 0x6e,
+	0xac, 0xa6, 0xfe, 0x47, 0x0e, 0xe4, 0x1d, 0xe6, 0x59, 0x31, 0x72, 0xe0, 0x56, 0xfc, 0x46, 0xc8,
+	0xe3, 0xed, 0x2b, 0x20, 0x8f, 0xb5, 0x49, 0x49, 0xfa, 0x9d, 0x69, 0x92, 0xf4, 0xbb, 0x93, 0x90,
+	0xc7, 0x7b, 0x13, 0x90, 0xc7, 0xe5, 0x29, 0x72, 0xf8, 0x95, 0xa9, 0x72, 0xf8, 0x47, 0xe3, 0x90,
+	0xc7, 0xd5, 0x2b, 0x22, 0x8f, 0xf7, 0xa7, 0x45, 0x1e, 0xd5, 0x6b, 0xa0, 0x38, 0x31, 0x88, 0xea,
+	0xa3, 0xeb, 0x41, 0x54, 0x0f, 0xa7, 0x87, 0xa8, 0x06, 0xb6, 0x74, 0x4a, 0x4e, 0x37, 0xb2, 0x12,
+	0xc8, 0xa5, 0x46, 0x56, 0x2a, 0xc8, 0x52, 0x23, 0x2b, 0x15, 0x65, 0x68, 0x64, 0x25, 0x49, 0x2e,
+	0x36, 0xb2, 0x52, 0x59, 0xae, 0x34, 0xb2, 0x52, 0x49, 0x2e, 0x37, 0xb2, 0x52, 0x45, 0xae, 0x36,
+	0xb2, 0x52, 0x55, 0x9e, 0x69, 0x64, 0xa5, 0x05, 0x79, 0xb1, 0x91, 0x95, 0x66, 0x64, 0xb9, 0x91,
+	0x95, 0x64, 0x79, 0xb6, 0x91, 0x95, 0x66, 0x65, 0xc2, 0x8f, 0x43, 0x23, 0x2b, 0xcd, 0xc9, 0xf3,
+	0x8d, 0xac, 0x34, 0x2f, 0x2f, 0x44, 0x47, 0x66, 0x49, 0x56, 0x1a, 0x59, 0x49, 0x91, 0x6f, 0xab,
+	0x7f, 0x91, 0x82, 0xd9, 0x7d, 0x1b, 0xcd, 0x6c, 0x10, 0xdb, 0xe4, 0xe3, 0x10, 0xd0, 0xab, 0xe3,
+	0xe9, 0x2b, 0x50, 0x3a, 0xb6, 0x9c, 0xf6, 0x59, 0xab, 0x9f, 0x37, 0x4a, 0x1a, 0x30, 0x12, 0x8f,
+	0xbe, 0x08, 0x64, 0x3b, 0x3d, 0xcb, 0x62, 0x49, 0x99, 0xa4, 0xb1, 0xb2, 0xfa, 0x4f, 0x29, 0xa8,
+	0x1e, 0x98, 0x7e, 0x70, 0xc9, 0xd1, 0x9b, 0x90, 0x55, 0xac, 0x41, 0xd9, 0xb4, 0x63, 0x63, 0xe4,
+	0xb7, 0xf9, 0xc9, 0xfd, 0xc2, 0x04, 0xc4, 0x10, 0xaf, 0x75, 0x49, 0x70, 0x6a, 0xfa, 0x01, 0xde,
+	0x9b, 0x64, 0xd9, 0xce, 0x0e, 0xab, 0xd1, 0x6c, 0x72, 0xb1, 0xd9, 0xbc, 0x85, 0x99, 0x3d, 0xab,
+	0xe7, 0x9f, 0xc6, 0x66, 0xf3, 0x10, 0x0a, 0xfc, 0x5b, 0xe1, 0xe3, 0xa3, 0xc4, 0xc7, 0x42, 0x1e,
+	0x79, 0x0e, 0xe5, 0xc0, 0x69, 0x85, 0x13, 0x0b, 0xdf, 0x25, 0x0c, 0x4c, 0xbc, 0x14, 0x38, 0x61,
+	0xd9, 0x57, 0xd7, 0x40, 0xde, 0xa5, 0x16, 0x0d, 0xe8, 0x74, 0x0b, 0xaa, 0x3e, 0x83, 0x6a, 0x33,
+	0x70, 0xdc, 0x29, 0xa5, 0x7f, 0x9b, 0x81, 0x85, 0x37, 0xae, 0xc1, 0x8d, 0x22, 0x3f, 0x4e, 0x93,
+	0x5b, 0xf5, 0xcf, 0x63, 0x7a, 0xaa, 0xf3, 0x98, 0x49, 0x9c, 0xc7, 0xff, 0x8b, 0xfb, 0x98, 0x01,
+	0xb3, 0x57, 0x98, 0xc2, 0xec, 0x49, 0x53, 0x99, 0xbd, 0xd2, 0x44, 0xe8, 0xb2, 0x78, 0x29, 0x74,
+	0x09, 0xe3, 0xad, 0xa2, 0xfa, 0x9f, 0x29, 0xa8, 0xbe, 0xa4, 0xc1, 0x81, 0x73, 0xe2, 0x5f, 0xc3,
+	0x3d, 0x8d, 0x5b, 0xaf, 0x50, 0x63, 0x1d, 0xd3, 0x0a, 0xa8, 0xc7, 0x41, 0x8e, 0x22, 0xd7, 0xd8,
+	0x1e, 0x27, 0xf5, 0x1f, 0x4c, 0xe4, 0x2f, 0x7b, 0x30, 0xc1, 0x9e, 0x68, 0xf9, 0x01, 0xf5, 0xc4,
+	0x51, 0x10, 0x35, 0xa4, 0x77, 0x1c, 0xcb, 0x72, 0xde, 0x89, 0x8b, 0x4b, 0x51, 0x63, 0x97, 0x85,
+	0xba, 0x69, 0x09, 0xc5, 0xb2, 0x32, 0xb7, 0x8b, 0xea, 0x7f, 0xa5, 0x01, 0x0e, 0x9c, 0x93, 0xef,
+	0xa9, 0xef, 0xe3, 0xbb, 0xd1, 0x07, 0x31, 0x87, 0x1e, 0x83, 0x88, 0x22, 0xef, 0xfd, 0x0a, 0x71,
+	0xaa, 0xfe, 0x95, 0x6f, 0xe6, 0x92, 0x2b, 0xdf, 0xc4, 0xfd, 0x71, 0x61, 0xec, 0xfd, 0xf1, 0x23,
+	0x90, 0x78, 0x30, 0x64, 0x1a, 0x6c, 0xbd, 0x8a, 0xdb, 0xa5, 0x0f, 0xef, 0x57, 0x0a, 0xfc, 0xf9,
+	0xc8, 0xae, 0x56, 0x60, 0xcc, 0x7d, 0x23, 0x36, 0x65, 0x48, 0x4c, 0x39, 0xbc, 0x5d, 0xce, 0x8e,
+	0xb9, 0x5d, 0x0e, 0x9f, 0x79, 0x4a, 0xdc, 0x6e, 0x60, 0x99, 0x3c, 0x85, 0x74, 0x74, 0x71, 0x3c,
+	0xce, 0x9d, 0xa4, 0x03, 0x1f, 0x8f, 0x49, 0x97, 0x2b, 0x88, 0x2d, 0x49, 0x51, 0x0b, 0xab, 0x64,
+	0x1d, 0xf2, 0x1d, 0x93, 0x5a, 0x86, 0xcf, 0x76, 0x23, 0xbe, 0x9f, 0x1d, 0xec, 0xa9, 0xc9, 0xde,
+	0x14, 0x6b, 0x42, 0x4c, 0x3d, 0x82, 0x39, 0x8d, 0x1f, 0x31, 0xbe, 0xa0, 0x53, 0x9c, 0xf0, 0xc1,
+	0x1d, 0x93, 0x1e, 0xda, 0x31, 0xea, 0x4f, 0x61, 0x4e, 0xb8, 0x9a, 0x44, 0xaf, 0x13, 0x5f, 0xde,
+	0xa8, 0x2d, 0x90, 0xd1, 0x15, 0x4c, 0x3d, 0x16, 0xcc, 0x10, 0xf4, 0x13, 0x91, 0x2a, 0xf2, 0x4b,
+	0x66, 0x09, 0x09, 0x2c, 0x4d, 0x64, 0x6f, 0x8b, 0x4e, 0xf8, 0x7d, 0x5c, 0x46, 0x63, 0x65, 0xf5,
+	0x02, 0x66, 0x63, 0x1f, 0xf0, 0x5d, 0xc7, 0xf6, 0xd9, 0x53, 0x08, 0xb1, 0xe6, 0x18, 0x45, 0x2a,
+	0xa9, 0xd8, 0xd2, 0x45, 0xcf, 0x86, 0x44, 0xc6, 0xc3, 0xe3, 0xcc, 0x15, 0x28, 0xb1, 0x13, 0xdd,
+	0xc2, 0x3e, 0x7d, 0xf1, 0x61, 0x60, 0xa4, 0x43, 0xa4, 0x8c, 0xfc, 0xf4, 0x1f, 0xc1, 0x52, 0xf4,
+	0xe9, 0x66, 0xe0, 0x51, 0xbd, 0x3f, 0x80, 0x4f, 0x00, 0xfa, 0x03, 0x48, 0x3c, 0xf8, 0xe8, 0x7f,
+	0xbf, 0x18, 0x7d, 0xff, 0x7a, 0x9f, 0xdf, 0x86, 0x52, 0x2c, 0xa9, 0xc1, 0xc8, 0x9b, 0x9e, 0x53,
+	0xef, 0x22, 0x7c, 0x3a, 0xc4, 0x2a, 0x68, 0xaf, 0x5c, 0xbc, 0x1f, 0xa1, 0x6d, 0xc7, 0x36, 0x44,
+	0xc7, 0x45, 0x97, 0x7a, 0x4d, 0x46, 0x50, 0x0f, 0x00, 0xfa, 0xc1, 0x3e, 0x7b, 0x53, 0xd4, 0x3e,
+	0xa5, 0xd1, 0xa9, 0x14, 0x35, 0xf6, 0x7e, 0xcb, 0xf1, 0x83, 0xf0, 0x2d, 0x1a, 0x96, 0xd9, 0x88,
+	0x1c, 0x8f, 0x7b, 0xdb, 0x9c, 0xc6, 0xca, 0xea, 0x36, 0x14, 0xa3, 0x2c, 0x3b, 0xf6, 0x56, 0x20,
+	0x15, 0x7f, 0x2b, 0x80, 0x23, 0xc2, 0xc5, 0x15, 0x8f, 0x47, 0xc4, 0x88, 0x90, 0xc2, 0x9f, 0x8a,
+	0xfc, 0x73, 0x0a, 0xaa, 0xc9, 0x04, 0x93, 0x34, 0xa0, 0x62, 0x3b, 0x06, 0x6d, 0xf9, 0xd4, 0xa2,
+	0xed, 0xc0, 0xf1, 0xc4, 0x7a, 0x3e, 0x1c, 0x91, 0x8c, 0xae, 0xbd, 0x72, 0x0c, 0xda, 0x14, 0x72,
+	0x1c, 0x5f, 0x2a, 0xdb, 0x31, 0x12, 0x59, 0x83, 0x39, 0xd7, 0x33, 0x1d, 0xcf, 0x0c, 0x2e, 0x5a,
+	0x6d, 0x4b, 0xf7, 0x7d, 0x6e, 0x85, 0xf8, 0xcc, 0x66, 0x43, 0xd6, 0x0e, 0x72, 0xd0, 0x14, 0xd5,
+	0xbe, 0x81, 0xd9, 0xa1, 0x2e, 0xaf, 0xf4, 0x78, 0xf7, 0x1f, 0x2a, 0xb0, 0xc0, 0x33, 0x91, 0xc8,
+	0x8e, 0x5f, 0x3d, 0x26, 0xea, 0x23, 0xa4, 0x0f, 0xa6, 0x40, 0x48, 0xaf, 0x86, 0xbe, 0x8e, 0xc2,
+	0x53, 0x0b, 0x37, 0xc2, 0x53, 0x57, 0xae, 0x8a, 0xa7, 0x16, 0x2f, 0xc7, 0x53, 0x17, 0x21, 0xdf,
+	0x63, 0x21, 0x4b, 0xe8, 0x88, 0x78, 0x6d, 0x18, 0xf5, 0x83, 0x11, 0xa8, 0x5f, 0x1f, 0x51, 0xf8,
+	0x28, 0x8e, 0x28, 0x8c, 0x04, 0x03, 0xcb, 0x37, 0x02, 0x03, 0x17, 0x7f, 0x04, 0x30, 0x70, 0xfd,
+	0xba, 0x60, 0x60, 0x65, 0x4a, 0x30, 0xb0, 0x3a, 0x09, 0x0c, 0x94, 0x27, 0x81, 0x81, 0xb3, 0xc3,
+	0x60, 0xe0, 0x5d, 0x28, 0x7a, 0x54, 0x04, 0x71, 0xec, 0xae, 0x5b, 0xd2, 0xfa, 0x84, 0x11, 0xf0,
+	0xdf, 0xfc, 0x78, 0xf8, 0x6f, 0x61, 0x2a, 0xf8, 0xef, 0xfe, 0x74, 0xf0, 0xdf, 0xd2, 0x95, 0xe1,
+	0x3f, 0xe5, 0x46, 0xf0, 0xdf, 0xed, 0xab, 0xc0, 0x7f, 0x21, 0x8a, 0x5a, 0x8b, 0xa1, 0xa8, 0x31,
+	0xcc, 0xee, 0xce, 0x58, 0xcc, 0xee, 0xee, 0x34, 0x98, 0xdd, 0xbd, 0xeb, 0x61, 0x76, 0xcb, 0x63,
+	0x30, 0xbb, 0xd5, 0x01, 0xcc, 0x6e, 0x00, 0x92, 0x54, 0xc7, 0x43, 0x92, 0x71, 0x28, 0x6f, 0xed,
+	0x6a, 0x50, 0xde, 0xf3, 0xeb, 0x42, 0x79, 0x9f, 0xfe, 0x08, 0x50, 0xde, 0xc6, 0x8f, 0x04, 0xe5,
+	0x6d, 0xfe, 0x08, 0x50, 0xde, 0x67, 0x93, 0xa1, 0xbc, 0xcf, 0x27, 0x42, 0x79, 0x03, 0xc8, 0x05,
+	0x47, 0x25, 0x38, 0x06, 0x31, 0x27, 0xcf, 0xab, 0x3b, 0xb0, 0x28, 0xa2, 0xbd, 0xeb, 0xfb, 0x2c,
+	0xf5, 0x57, 0x30, 0x87, 0xd1, 0xd1, 0x0d, 0xbc, 0x5e, 0x2c, 0x4f, 0x4f, 0x27, 0xf2, 0x74, 0xf5,
+	0xcf, 0x53, 0xb0, 0xc0, 0x13, 0xe5, 0x1b, 0x74, 0x2f, 0x43, 0x46, 0x8f, 0x90, 0x0b, 0x2c, 0xa2,
+	0x17, 0xef, 0x38, 0x5e, 0x3b, 0xf4, 0x35, 0xbc, 0x82, 0x07, 0xe0, 0x8c, 0x52, 0x97, 0xbf, 0xe6,
+	0xe1, 0xbf, 0xae, 0x90, 0x90, 0xa0, 0x51, 0xd7, 0x69, 0x64, 0xa5, 0xb4, 0x9c, 0x11, 0x8f, 0x27,
+	0xb7, 0x60, 0xbe, 0x89, 0x81, 0xf7, 0x0d, 0x94, 0xf6, 0x2d, 0xcc, 0x61, 0x42, 0x7f, 0x83, 0x1e,
+	0xfe, 0x2a, 0x05, 0x44, 0xeb, 0xd9, 0x37, 0xd0, 0xcb, 0xe7, 0x00, 0xae, 0xe7, 0x9c, 0x53, 0x5b,
+	0xb7, 0xd9, 0x2f, 0x79, 0x30, 0xd6, 0x5a, 0x88, 0x1d, 0xe9, 0xc3, 0x88, 0xa9, 0xc5, 0x04, 0x63,
+	0x49, 0x5b, 0x76, 0x74, 0xd2, 0x26, 0xb4, 0xf4, 0x25, 0x54, 0xb5, 0x9e, 0x8d, 0x3f, 0xaa, 0xb8,
+	0xc6, 0xec, 0x9e, 0xc0, 0x1c, 0x0f, 0xa6, 0xf8, 0xef, 0xfa, 0xc2, 0x1e, 0x10, 0xb7, 0x31, 0x2d,
+	0xde, 0xba, 0xac, 0xb1, 0xb2, 0xfa, 0x02, 0xe6, 0xf8, 0x16, 0x49, 0x8a, 0x3e, 0x80, 0x3c, 0xff,
+	0xad, 0x60, 0xff, 0xc7, 0x17, 0xd1, 0x2f, 0x0c, 0x35, 0xc1, 0x52, 0xbf, 0x84, 0x79, 0x71, 0x00,
+	0xae, 0xd1, 0xf8, 0x2e, 0xe4, 0x39, 0x65, 0xe4, 0x33, 0x88, 0x3f, 0x4d, 0x01, 0x70, 0x36, 0x8b,
+	0xfc, 0xa7, 0xe9, 0x31, 0x7a, 0x8a, 0x9b, 0x8e, 0x3d, 0xc5, 0xdd, 0x07, 0xc2, 0xae, 0x8e, 0xf1,
+	0x17, 0x80, 0xd1, 0xef, 0x52, 0x95, 0xcc, 0xc4, 0x74, 0x73, 0x36, 0x6c, 0x15, 0x91, 0xd4, 0x6f,
+	0xa0, 0xd4, 0x1f, 0x91, 0xcf, 0xac, 0x09, 0xab, 0xc6, 0x11, 0xf7, 0x99, 0xd8, 0xb8, 0x78, 0xf6,
+	0xe4, 0x47, 0x65, 0xf5, 0x05, 0x2c, 0xbc, 0xd4, 0xbd, 0x63, 0xfd, 0x84, 0xee, 0x38, 0x16, 0x06,
+	0xca, 0xa1, 0xbe, 0xee, 0x43, 0x99, 0x3f, 0x49, 0x16, 0xd1, 0x3e, 0xcf, 0x04, 0x4a, 0x9c, 0xc6,
+	0xe3, 0x7d, 0x05, 0x16, 0x07, 0xdb, 0xf2, 0x1c, 0x4a, 0x5d, 0x80, 0xb9, 0xad, 0x76, 0x60, 0x9e,
+	0xeb, 0x01, 0xdd, 0xea, 0x05, 0xa7, 0xa2, 0x4f, 0x75, 0x11, 0xe6, 0x93, 0x64, 0x2e, 0xfe, 0xf4,
+	0x4f, 0x52, 0xec, 0xd5, 0x0a, 0x87, 0x25, 0x65, 0x28, 0x37, 0x5e, 0x6f, 0xb7, 0x9a, 0x47, 0x5b,
+	0xda, 0xd1, 0xfe, 0xab, 0x97, 0xf2, 0x2d, 0x32, 0x03, 0x25, 0xa4, 0x68, 0x6f, 0x5e, 0xbd, 0x42,
+	0x42, 0x2a, 0x24, 0xec, 0x6d, 0xed, 0x1f, 0xbc, 0xd1, 0xea, 0x72, 0x3a, 0x24, 0x34, 0xdf, 0xec,
+	0xec, 0xd4, 0x9b, 0x4d, 0x39, 0x43, 0xaa, 0x00, 0x48, 0xf8, 0x6e, 0xff, 0xe0, 0xa0, 0xbe, 0x2b,
+	0x67, 0x43, 0x81, 0xef, 0xeb, 0xda, 0x4b, 0xec, 0x22, 0x47, 0x66, 0xa1, 0x82, 0x84, 0xfa, 0x4b,
+	0xad, 0xde, 0x6c, 0x22, 0x29, 0xff, 0xf4, 0x35, 0x40, 0xff, 0x77, 0x25, 0x04, 0x20, 0x8f, 0xfd,
+	0xd7, 0x77, 0xe5, 0x5b, 0xa4, 0x04, 0x85, 0xb0, 0xeb, 0x14, 0xab, 0x7c, 0xb7, 0x7f, 0x78, 0x58,
+	0xdf, 0x95, 0xd3, 0xa4, 0x0c, 0x52, 0x34, 0xd0, 0x0c, 0xa9, 0x40, 0x51, 0xab, 0xef, 0xbc, 0xfe,
+	0x65, 0x5d, 0xc3, 0x8f, 0x3e, 0xfd, 0x06, 0x4a, 0xb1, 0x17, 0x3a, 0x38, 0x86, 0xc3, 0xd7, 0xbb,
+	0xd1, 0x34, 0x6e, 0x85, 0x84, 0x7e, 0xd7, 0x55, 0x00, 0x24, 0x88, 0xef, 0xa6, 0x9f, 0xfe, 0x6d,
+	0xaa, 0x7f, 0xa9, 0xc2, 0xfb, 0x58, 0x80, 0xd9, 0xc3, 0xfd, 0xc3, 0xfa, 0xc1, 0xfe, 0xab, 0x7a,
+	0x5c, 0x43, 0xf3, 0x20, 0x47, 0xe4, 0xbe, 0x9a, 0x96, 0x60, 0xae, 0x4f, 0xad, 0x47, 0xe2, 0xe9,
+	0x84, 0x78, 0xa8, 0xc4, 0x0c, 0x99, 0x83, 0x99, 0x88, 0x7a, 0xb8, 0xf5, 0xa6, 0xc9, 0x14, 0x17,
+	0x17, 0x6d, 0x1e, 0x6d, 0xbd, 0xda, 0xdd, 0xfe, 0x43, 0x39, 0x97, 0x18, 0xc6, 0x8e, 0xb6, 0xd5,
+	0xfc, 0x05, 0xd7, 0xe0, 0x77, 0x40, 0x86, 0x7d, 0x23, 0x6a, 0x05, 0x3f, 0xd2, 0xda, 0xdb, 0x6a,
+	0x1e, 0xf1, 0x59, 0xef, 0xbc, 0x3e, 0x38, 0xa8, 0xef, 0x1c, 0xb5, 0xb6, 0x0e, 0x0e, 0xe4, 0x14,
+	0x76, 0xb6, 0xb3, 0xf5, 0x6a, 0xa7, 0x7e, 0xd0, 0x7a, 0xfd, 0xaa, 0xbf, 0xa6, 0x1b, 0xff, 0x5d,
+	0x81, 0xcc, 0xd6, 0xe1, 0x3e, 0x59, 0x83, 0x22, 0xb7, 0x1b, 0x98, 0x1f, 0x2d, 0x88, 0x9f, 0x75,
+	0x25, 0xaf, 0x87, 0x6a, 0x11, 0x12, 0xa1, 0xde, 0x22, 0x9f, 0x01, 0xf4, 0xa1, 0x75, 0xb2, 0x28,
+	0x42, 0xeb, 0x01, 0xac, 0xbd, 0x96, 0x78, 0x09, 0xa5, 0xde, 0x22, 0xeb, 0x50, 0x10, 0xb8, 0x37,
+	0xe1, 0x51, 0x57, 0x12, 0x05, 0xaf, 0x55, 0xe2, 0xf2, 0xbe, 0x7a, 0x0b, 0x53, 0x27, 0x21, 0xc2,
+	0xf1, 0x83, 0xd1, 0xcd, 0x06, 0x3e, 0xf3, 0x3c, 0x45, 0x36, 0x40, 0x0a, 0x31, 0x69, 0xc2, 0xb3,
+	0xb4, 0x01, 0x88, 0x7a, 0x44, 0x9b, 0xaf, 0xa0, 0x18, 0x61, 0xcb, 0x42, 0x05, 0x83, 0x58, 0x73,
+	0x6d, 0x71, 0xc8, 0x70, 0xd4, 0xf1, 0x77, 0x8d, 0xea, 0x2d, 0xf2, 0x33, 0x28, 0x08, 0xa4, 0x59,
+	0x8c, 0x31, 0x89, 0x3b, 0x8f, 0x69, 0xf9, 0x02, 0xca, 0x71, 0xe8, 0x88, 0x28, 0x71, 0x65, 0xc6,
+	0x71, 0xa1, 0xda, 0x00, 0x40, 0xa2, 0xde, 0xc2, 0x31, 0x47, 0x08, 0x8b, 0x18, 0xf3, 0x20, 0x9a,
+	0x54, 0x5b, 0x1c, 0x24, 0x0b, 0xf3, 0x71, 0x8b, 0x34, 0x60, 0x66, 0x00, 0x9f, 0xb9, 0xac, 0x8f,
+	0xbb, 0x49, 0x72, 0x12, 0xcc, 0x61, 0xda, 0xdb, 0x66, 0x3f, 0xc6, 0x88, 0x60, 0x35, 0x31, 0x8b,
+	0x11, 0x48, 0xdb, 0x18, 0x4d, 0xec, 0x41, 0x35, 0x89, 0x04, 0x90, 0x5a, 0x6c, 0x27, 0x0e, 0x78,
+	0xec, 0x31, 0xfd, 0xec, 0xc0, 0xcc, 0x40, 0x78, 0x46, 0xee, 0xc4, 0x95, 0x3a, 0xd8, 0xd3, 0xf0,
+	0x6d, 0xa9, 0x7a, 0x8b, 0x7c, 0x0d, 0xe5, 0x78, 0x78, 0x26, 0x26, 0x34, 0x22, 0x62, 0xab, 0x91,
+	0xa1, 0xe6, 0x3e, 0x9f, 0x4c, 0x32, 0x02, 0x13, 0x93, 0x19, 0x19, 0x96, 0x8d, 0x99, 0xcc, 0x2e,
+	0x54, 0x12, 0x41, 0x13, 0xb9, 0x2d, 0xb6, 0xd7, 0x70, 0x20, 0x35, 0xa6, 0x97, 0x6d, 0x28, 0xc7,
+	0xe3, 0x26, 0x31, 0x9b, 0x11, 0xa1, 0xd4, 0x98, 0x3e, 0xbe, 0x85, 0x52, 0x2c, 0x70, 0x22, 0x3c,
+	0x4c, 0x1f, 0x0e, 0xa5, 0xc6, 0x1f, 0x12, 0x11, 0xda, 0x88, 0x43, 0x92, 0x0c, 0x74, 0xc6, 0x8f,
+	0x3f, 0x1e, 0xd7, 0x88, 0xf1, 0x8f, 0x08, 0x75, 0xc6, 0xf7, 0x11, 0x0f, 0x78, 0x44, 0x1f, 0x23,
+	0x62, 0xa0, 0xb1, 0x33, 0x00, 0xdc, 0x02, 0xa2, 0x87, 0x4b, 0xe4, 0x6a, 0xf2, 0x40, 0x30, 0x80,
+	0xfb, 0xe1, 0xff, 0x41, 0x25, 0x11, 0x32, 0x89, 0x75, 0x1c, 0x15, 0x46, 0xd5, 0x06, 0x83, 0x09,
+	0xd6, 0x5c, 0x58, 0xa7, 0x2d, 0xcb, 0xba, 0xf4, 0xbb, 0x97, 0x8f, 0x7b, 0x13, 0x0a, 0xe2, 0x36,
+	0x45, 0x68, 0x3e, 0x79, 0xb7, 0x22, 0xbe, 0xd8, 0xbf, 0x87, 0x60, 0x67, 0xfa, 0x3b, 0xa8, 0x26,
+	0x43, 0x0f, 0xb1, 0x85, 0x47, 0xc6, 0x32, 0xb5, 0x3b, 0x23, 0x79, 0x91, 0xb1, 0xa9, 0x43, 0x39,
+	0x1e, 0x96, 0x08, 0xed, 0x8f, 0x08, 0x60, 0x6a, 0xb7, 0x47, 0x70, 0xa2, 0x6e, 0xf6, 0xa0, 0x9a,
+	0xbc, 0xa2, 0x13, 0x63, 0x1a, 0x79, 0x6f, 0x77, 0xb9, 0x42, 0xb6, 0xbf, 0xfc, 0xdd, 0x87, 0xe5,
+	0xd4, 0xbf, 0x7c, 0x58, 0x4e, 0xfd, 0xfb, 0x87, 0xe5, 0xd4, 0xaf, 0x3e, 0xc1, 0x57, 0x44, 0xbd,
+	0xe3, 0xb5, 0xb6, 0xd3, 0x5d, 0x77, 0xf5, 0xf6, 0xe9, 0x85, 0x41, 0xbd, 0x78, 0xc9, 0xf7, 0xda,
+	0xeb, 0xfd, 0x7f, 0x92, 0x72, 0x9c, 0x67, 0xdd, 0x6d, 0xfe, 0xef, 0x00, 0x2b, 0x36, 0x95, 0xf3,
+	0x39, 0x45, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -7180,6 +7540,18 @@ func (m *Transform) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.TerminationGracePeriod != nil {
+		{
+			size, err := m.TerminationGracePeriod.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
 	if len(m.ErrStdin) > 0 {
 		for iNdEx := len(m.ErrStdin) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.ErrStdin[iNdEx])
@@ -7239,21 +7611,21 @@ func (m *Transform) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x38
 	}
 	if len(m.AcceptReturnCode) > 0 {
-		dAtA2 := make([]byte, len(m.AcceptReturnCode)*10)
-		var j1 int
+		dAtA3 := make([]byte, len(m.AcceptReturnCode)*10)
+		var j2 int
 		for _, num1 := range m.AcceptReturnCode {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j1++
+				j2++
 			}
-			dAtA2[j1] = uint8(num)
-			j1++
+			dAtA3[j2] = uint8(num)
+			j2++
 		}
-		i -= j1
-		copy(dAtA[i:], dAtA2[:j1])
-		i = encodeVarintPps(dAtA, i, uint64(j1))
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintPps(dAtA, i, uint64(j2))
 		i--
 		dAtA[i] = 0x32
 	}
@@ -8470,10 +8842,20 @@ func (m *WorkerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.QueueSize != 0 {
-		i = encodeVarintPps(dAtA, i, uint64(m.QueueSize))
+	if m.WaitingForS3Gateway {
 		i--
-		dAtA[i] = 0x30
+		if m.WaitingForS3Gateway {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.QueueSize != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.QueueSize))
+		i--
+		dAtA[i] = 0x30
 	}
 	if m.Stats != nil {
 		{
@@ -8652,6 +9034,13 @@ func (m *EtcdJobInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DataExcluded != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.DataExcluded))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
 	if m.DataRecovered != 0 {
 		i = encodeVarintPps(dAtA, i, uint64(m.DataRecovered))
 		i--
@@ -8805,6 +9194,13 @@ func (m *JobInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DataExcluded != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.DataExcluded))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x88
+	}
 	if m.SidecarResourceLimits != nil {
 		{
 			size, err := m.SidecarResourceLimits.MarshalToSizedBuffer(dAtA[:i])
@@ -9477,6 +9873,78 @@ func (m *PipelineInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.S3Endpoint != nil {
+		{
+			size, err := m.S3Endpoint.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xca
+	}
+	if len(m.DatumExclude) > 0 {
+		i -= len(m.DatumExclude)
+		copy(dAtA[i:], m.DatumExclude)
+		i = encodeVarintPps(dAtA, i, uint64(len(m.DatumExclude)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xc2
+	}
+	if m.DatumFailurePolicy != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.DatumFailurePolicy))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.UploadTimeout != nil {
+		{
+			size, err := m.UploadTimeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb2
+	}
+	if m.DownloadTimeout != nil {
+		{
+			size, err := m.DownloadTimeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xaa
+	}
+	if m.LogSampling != nil {
+		{
+			size, err := m.LogSampling.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa2
+	}
 	if m.SidecarResourceLimits != nil {
 		{
 			size, err := m.SidecarResourceLimits.MarshalToSizedBuffer(dAtA[:i])
@@ -9997,6 +10465,13 @@ func (m *CreateJobRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DataExcluded != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.DataExcluded))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xb0
+	}
 	if m.Finished != nil {
 		{
 			size, err := m.Finished.MarshalToSizedBuffer(dAtA[:i])
@@ -10448,6 +10923,11 @@ func (m *UpdateJobStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DataExcluded != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.DataExcluded))
+		i--
+		dAtA[i] = 0x58
+	}
 	if m.Stats != nil {
 		{
 			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
@@ -10638,6 +11118,18 @@ func (m *LogMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Fields != nil {
+		{
+			size, err := m.Fields.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.Master {
 		i--
 		if m.Master {
@@ -10958,6 +11450,89 @@ func (m *ListDatumStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *LogSampling) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogSampling) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogSampling) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.PerSecond != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.PerSecond))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Every != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.Every))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *S3Endpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *S3Endpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *S3Endpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Port != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.Port))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Host) > 0 {
+		i -= len(m.Host)
+		copy(dAtA[i:], m.Host)
+		i = encodeVarintPps(dAtA, i, uint64(len(m.Host)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Scheme) > 0 {
+		i -= len(m.Scheme)
+		copy(dAtA[i:], m.Scheme)
+		i = encodeVarintPps(dAtA, i, uint64(len(m.Scheme)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ChunkSpec) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -11072,6 +11647,78 @@ func (m *CreatePipelineRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.S3Endpoint != nil {
+		{
+			size, err := m.S3Endpoint.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xaa
+	}
+	if len(m.DatumExclude) > 0 {
+		i -= len(m.DatumExclude)
+		copy(dAtA[i:], m.DatumExclude)
+		i = encodeVarintPps(dAtA, i, uint64(len(m.DatumExclude)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.DatumFailurePolicy != 0 {
+		i = encodeVarintPps(dAtA, i, uint64(m.DatumFailurePolicy))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.UploadTimeout != nil {
+		{
+			size, err := m.UploadTimeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.DownloadTimeout != nil {
+		{
+			size, err := m.DownloadTimeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.LogSampling != nil {
+		{
+			size, err := m.LogSampling.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPps(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x82
+	}
 	if m.SidecarResourceLimits != nil {
 		{
 			size, err := m.SidecarResourceLimits.MarshalToSizedBuffer(dAtA[:i])
@@ -12239,6 +12886,10 @@ func (m *Transform) Size() (n int) {
 			n += 1 + l + sovPps(uint64(l))
 		}
 	}
+	if m.TerminationGracePeriod != nil {
+		l = m.TerminationGracePeriod.Size()
+		n += 1 + l + sovPps(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -12773,6 +13424,9 @@ func (m *WorkerStatus) Size() (n int) {
 	if m.QueueSize != 0 {
 		n += 1 + sovPps(uint64(m.QueueSize))
 	}
+	if m.WaitingForS3Gateway {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -12884,6 +13538,9 @@ func (m *EtcdJobInfo) Size() (n int) {
 	if m.DataRecovered != 0 {
 		n += 1 + sovPps(uint64(m.DataRecovered))
 	}
+	if m.DataExcluded != 0 {
+		n += 2 + sovPps(uint64(m.DataExcluded))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13048,6 +13705,9 @@ func (m *JobInfo) Size() (n int) {
 		l = m.SidecarResourceLimits.Size()
 		n += 2 + l + sovPps(uint64(l))
 	}
+	if m.DataExcluded != 0 {
+		n += 2 + sovPps(uint64(m.DataExcluded))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13311,6 +13971,29 @@ func (m *PipelineInfo) Size() (n int) {
 		l = m.SidecarResourceLimits.Size()
 		n += 2 + l + sovPps(uint64(l))
 	}
+	if m.LogSampling != nil {
+		l = m.LogSampling.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.DownloadTimeout != nil {
+		l = m.DownloadTimeout.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.UploadTimeout != nil {
+		l = m.UploadTimeout.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.DatumFailurePolicy != 0 {
+		n += 2 + sovPps(uint64(m.DatumFailurePolicy))
+	}
+	l = len(m.DatumExclude)
+	if l > 0 {
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.S3Endpoint != nil {
+		l = m.S3Endpoint.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13390,6 +14073,9 @@ func (m *CreateJobRequest) Size() (n int) {
 		l = m.Finished.Size()
 		n += 2 + l + sovPps(uint64(l))
 	}
+	if m.DataExcluded != 0 {
+		n += 2 + sovPps(uint64(m.DataExcluded))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13549,6 +14235,9 @@ func (m *UpdateJobStateRequest) Size() (n int) {
 		l = m.Stats.Size()
 		n += 1 + l + sovPps(uint64(l))
 	}
+	if m.DataExcluded != 0 {
+		n += 1 + sovPps(uint64(m.DataExcluded))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13636,6 +14325,10 @@ func (m *LogMessage) Size() (n int) {
 	if m.Master {
 		n += 2
 	}
+	if m.Fields != nil {
+		l = m.Fields.Size()
+		n += 1 + l + sovPps(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -13748,17 +14441,17 @@ func (m *ListDatumStreamResponse) Size() (n int) {
 	return n
 }
 
-func (m *ChunkSpec) Size() (n int) {
+func (m *LogSampling) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Number != 0 {
-		n += 1 + sovPps(uint64(m.Number))
+	if m.Every != 0 {
+		n += 1 + sovPps(uint64(m.Every))
 	}
-	if m.SizeBytes != 0 {
-		n += 1 + sovPps(uint64(m.SizeBytes))
+	if m.PerSecond != 0 {
+		n += 1 + sovPps(uint64(m.PerSecond))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13766,21 +14459,62 @@ func (m *ChunkSpec) Size() (n int) {
 	return n
 }
 
-func (m *SchedulingSpec) Size() (n int) {
+func (m *S3Endpoint) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.NodeSelector) > 0 {
-		for k, v := range m.NodeSelector {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovPps(uint64(len(k))) + 1 + len(v) + sovPps(uint64(len(v)))
-			n += mapEntrySize + 1 + sovPps(uint64(mapEntrySize))
-		}
+	l = len(m.Scheme)
+	if l > 0 {
+		n += 1 + l + sovPps(uint64(l))
 	}
-	l = len(m.PriorityClassName)
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovPps(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovPps(uint64(m.Port))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChunkSpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Number != 0 {
+		n += 1 + sovPps(uint64(m.Number))
+	}
+	if m.SizeBytes != 0 {
+		n += 1 + sovPps(uint64(m.SizeBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SchedulingSpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.NodeSelector) > 0 {
+		for k, v := range m.NodeSelector {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPps(uint64(len(k))) + 1 + len(v) + sovPps(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPps(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.PriorityClassName)
 	if l > 0 {
 		n += 1 + l + sovPps(uint64(l))
 	}
@@ -13913,6 +14647,29 @@ func (m *CreatePipelineRequest) Size() (n int) {
 		l = m.SidecarResourceLimits.Size()
 		n += 2 + l + sovPps(uint64(l))
 	}
+	if m.LogSampling != nil {
+		l = m.LogSampling.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.DownloadTimeout != nil {
+		l = m.DownloadTimeout.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.UploadTimeout != nil {
+		l = m.UploadTimeout.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.DatumFailurePolicy != 0 {
+		n += 2 + sovPps(uint64(m.DatumFailurePolicy))
+	}
+	l = len(m.DatumExclude)
+	if l > 0 {
+		n += 2 + l + sovPps(uint64(l))
+	}
+	if m.S3Endpoint != nil {
+		l = m.S3Endpoint.Size()
+		n += 2 + l + sovPps(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -14972,6 +15729,42 @@ func (m *Transform) Unmarshal(dAtA []byte) error {
 			}
 			m.ErrStdin = append(m.ErrStdin, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TerminationGracePeriod", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TerminationGracePeriod == nil {
+				m.TerminationGracePeriod = &types.Duration{}
+			}
+			if err := m.TerminationGracePeriod.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -18408,6 +19201,26 @@ func (m *WorkerStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WaitingForS3Gateway", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WaitingForS3Gateway = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -19149,6 +19962,25 @@ func (m *EtcdJobInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataExcluded", wireType)
+			}
+			m.DataExcluded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataExcluded |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -20452,6 +21284,25 @@ func (m *JobInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 49:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataExcluded", wireType)
+			}
+			m.DataExcluded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataExcluded |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -22411,63 +23262,9 @@ func (m *PipelineInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPps(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPps
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPps
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PipelineInfos) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPps
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PipelineInfos: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PipelineInfos: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 52:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PipelineInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSampling", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22494,68 +23291,16 @@ func (m *PipelineInfos) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PipelineInfo = append(m.PipelineInfo, &PipelineInfo{})
-			if err := m.PipelineInfo[len(m.PipelineInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.LogSampling == nil {
+				m.LogSampling = &LogSampling{}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPps(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.LogSampling.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPps
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPps
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateJobRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPps
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateJobRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 2:
+			iNdEx = postIndex
+		case 53:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DownloadTimeout", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22582,16 +23327,317 @@ func (m *CreateJobRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pipeline == nil {
-				m.Pipeline = &Pipeline{}
+			if m.DownloadTimeout == nil {
+				m.DownloadTimeout = &types.Duration{}
 			}
-			if err := m.Pipeline.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DownloadTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 25:
+		case 54:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OutputCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UploadTimeout == nil {
+				m.UploadTimeout = &types.Duration{}
+			}
+			if err := m.UploadTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 55:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumFailurePolicy", wireType)
+			}
+			m.DatumFailurePolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DatumFailurePolicy |= DatumFailurePolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 56:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumExclude", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatumExclude = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 57:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3Endpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.S3Endpoint == nil {
+				m.S3Endpoint = &S3Endpoint{}
+			}
+			if err := m.S3Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PipelineInfos) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PipelineInfos: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PipelineInfos: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PipelineInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PipelineInfo = append(m.PipelineInfo, &PipelineInfo{})
+			if err := m.PipelineInfo[len(m.PipelineInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateJobRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateJobRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pipeline == nil {
+				m.Pipeline = &Pipeline{}
+			}
+			if err := m.Pipeline.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputCommit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22934,6 +23980,25 @@ func (m *CreateJobRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 38:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataExcluded", wireType)
+			}
+			m.DataExcluded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataExcluded |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -23298,7 +24363,129 @@ func (m *ListJobRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Full = bool(v != 0)
+			m.Full = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FlushJobRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FlushJobRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FlushJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commits = append(m.Commits, &pfs.Commit{})
+			if err := m.Commits[len(m.Commits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToPipelines", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ToPipelines = append(m.ToPipelines, &Pipeline{})
+			if err := m.ToPipelines[len(m.ToPipelines)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -23324,7 +24511,7 @@ func (m *ListJobRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FlushJobRequest) Unmarshal(dAtA []byte) error {
+func (m *DeleteJobRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23347,15 +24534,15 @@ func (m *FlushJobRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FlushJobRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteJobRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FlushJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commits", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -23382,14 +24569,70 @@ func (m *FlushJobRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Commits = append(m.Commits, &pfs.Commit{})
-			if err := m.Commits[len(m.Commits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Job == nil {
+				m.Job = &Job{}
+			}
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPps(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StopJobRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StopJobRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StopJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToPipelines", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -23416,8 +24659,10 @@ func (m *FlushJobRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ToPipelines = append(m.ToPipelines, &Pipeline{})
-			if err := m.ToPipelines[len(m.ToPipelines)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Job == nil {
+				m.Job = &Job{}
+			}
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -23446,7 +24691,7 @@ func (m *FlushJobRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteJobRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23469,10 +24714,10 @@ func (m *DeleteJobRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteJobRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateJobStateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateJobStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -23511,63 +24756,174 @@ func (m *DeleteJobRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPps(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			if skippy < 0 {
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= JobState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPps
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthPps
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *StopJobRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPps
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Restart", wireType)
+			}
+			m.Restart = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Restart |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataProcessed", wireType)
+			}
+			m.DataProcessed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataProcessed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataSkipped", wireType)
+			}
+			m.DataSkipped = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataSkipped |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataFailed", wireType)
+			}
+			m.DataFailed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataFailed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataRecovered", wireType)
+			}
+			m.DataRecovered = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataRecovered |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataTotal", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.DataTotal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataTotal |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StopJobRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StopJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -23594,13 +24950,32 @@ func (m *StopJobRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Job == nil {
-				m.Job = &Job{}
+			if m.Stats == nil {
+				m.Stats = &ProcessStats{}
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataExcluded", wireType)
+			}
+			m.DataExcluded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataExcluded |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -23626,7 +25001,7 @@ func (m *StopJobRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
+func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23649,10 +25024,10 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateJobStateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetLogsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateJobStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -23692,10 +25067,10 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
 			}
-			m.State = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23705,14 +25080,31 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= JobState(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pipeline == nil {
+				m.Pipeline = &Pipeline{}
+			}
+			if err := m.Pipeline.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DataFilters", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -23740,13 +25132,13 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Reason = string(dAtA[iNdEx:postIndex])
+			m.DataFilters = append(m.DataFilters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Restart", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
 			}
-			m.Restart = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23756,16 +25148,17 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Restart |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataProcessed", wireType)
+			m.Master = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Datum", wireType)
 			}
-			m.DataProcessed = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23775,16 +25168,33 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DataProcessed |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Datum == nil {
+				m.Datum = &Datum{}
+			}
+			if err := m.Datum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataSkipped", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Follow", wireType)
 			}
-			m.DataSkipped = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23794,16 +25204,17 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DataSkipped |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			m.Follow = bool(v != 0)
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataFailed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tail", wireType)
 			}
-			m.DataFailed = 0
+			m.Tail = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23813,16 +25224,70 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DataFailed |= int64(b&0x7F) << shift
+				m.Tail |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataRecovered", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPps(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.DataRecovered = 0
+			if skippy < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPps
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LogMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPps
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PipelineName", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23832,16 +25297,29 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DataRecovered |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataTotal", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPps
 			}
-			m.DataTotal = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PipelineName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobID", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23851,14 +25329,27 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DataTotal |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -23885,70 +25376,14 @@ func (m *UpdateJobStateRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Stats == nil {
-				m.Stats = &ProcessStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Data = append(m.Data, &InputFile{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPps(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPps
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPps
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPps
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetLogsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetLogsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -23975,18 +25410,18 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Job == nil {
-				m.Job = &Job{}
+			if m.Ts == nil {
+				m.Ts = &types.Timestamp{}
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Ts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -23996,31 +25431,27 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPps
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPps
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pipeline == nil {
-				m.Pipeline = &Pipeline{}
-			}
-			if err := m.Pipeline.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataFilters", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkerID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24048,11 +25479,11 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DataFilters = append(m.DataFilters, string(dAtA[iNdEx:postIndex]))
+			m.WorkerID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -24069,12 +25500,12 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Master = bool(v != 0)
-		case 6:
+			m.User = bool(v != 0)
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Datum", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24084,31 +25515,27 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPps
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPps
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Datum == nil {
-				m.Datum = &Datum{}
-			}
-			if err := m.Datum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DatumID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 10:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Follow", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -24125,12 +25552,12 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Follow = bool(v != 0)
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tail", wireType)
+			m.Master = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
 			}
-			m.Tail = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24140,11 +25567,28 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Tail |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Fields == nil {
+				m.Fields = &types.Struct{}
+			}
+			if err := m.Fields.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -24170,7 +25614,7 @@ func (m *GetLogsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogMessage) Unmarshal(dAtA []byte) error {
+func (m *RestartDatumRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24193,113 +25637,15 @@ func (m *LogMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: RestartDatumRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RestartDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PipelineName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPps
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPps
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PipelineName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPps
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPps
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.JobID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPps
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPps
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data, &InputFile{})
-			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -24326,16 +25672,16 @@ func (m *LogMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Ts == nil {
-				m.Ts = &types.Timestamp{}
+			if m.Job == nil {
+				m.Job = &Job{}
 			}
-			if err := m.Ts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DataFilters", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -24363,65 +25709,67 @@ func (m *LogMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.DataFilters = append(m.DataFilters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkerID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPps(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthPps
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthPps
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.WorkerID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InspectDatumRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPps
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.User = bool(v != 0)
-		case 9:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InspectDatumRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InspectDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatumID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Datum", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24431,44 +25779,28 @@ func (m *LogMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPps
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPps
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DatumID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
+			if m.Datum == nil {
+				m.Datum = &Datum{}
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.Datum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Master = bool(v != 0)
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -24494,7 +25826,7 @@ func (m *LogMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RestartDatumRequest) Unmarshal(dAtA []byte) error {
+func (m *ListDatumRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24517,10 +25849,10 @@ func (m *RestartDatumRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RestartDatumRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListDatumRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RestartDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -24560,10 +25892,10 @@ func (m *RestartDatumRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataFilters", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
 			}
-			var stringLen uint64
+			m.PageSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24573,24 +25905,30 @@ func (m *RestartDatumRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.PageSize |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPps
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPps
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Page = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Page |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.DataFilters = append(m.DataFilters, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -24616,7 +25954,7 @@ func (m *RestartDatumRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InspectDatumRequest) Unmarshal(dAtA []byte) error {
+func (m *ListDatumResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24639,15 +25977,15 @@ func (m *InspectDatumRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InspectDatumRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListDatumResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InspectDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListDatumResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Datum", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumInfos", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -24674,13 +26012,49 @@ func (m *InspectDatumRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Datum == nil {
-				m.Datum = &Datum{}
-			}
-			if err := m.Datum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.DatumInfos = append(m.DatumInfos, &DatumInfo{})
+			if err := m.DatumInfos[len(m.DatumInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalPages", wireType)
+			}
+			m.TotalPages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalPages |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+			}
+			m.Page = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Page |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -24706,7 +26080,7 @@ func (m *InspectDatumRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListDatumRequest) Unmarshal(dAtA []byte) error {
+func (m *ListDatumStreamResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24729,15 +26103,15 @@ func (m *ListDatumRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListDatumRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListDatumStreamResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListDatumRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListDatumStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumInfo", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -24764,18 +26138,18 @@ func (m *ListDatumRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Job == nil {
-				m.Job = &Job{}
+			if m.DatumInfo == nil {
+				m.DatumInfo = &DatumInfo{}
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DatumInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalPages", wireType)
 			}
-			m.PageSize = 0
+			m.TotalPages = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24785,7 +26159,7 @@ func (m *ListDatumRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PageSize |= int64(b&0x7F) << shift
+				m.TotalPages |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -24834,7 +26208,7 @@ func (m *ListDatumRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListDatumResponse) Unmarshal(dAtA []byte) error {
+func (m *LogSampling) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24857,51 +26231,17 @@ func (m *ListDatumResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListDatumResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogSampling: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListDatumResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogSampling: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatumInfos", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPps
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPps
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPps
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DatumInfos = append(m.DatumInfos, &DatumInfo{})
-			if err := m.DatumInfos[len(m.DatumInfos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalPages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Every", wireType)
 			}
-			m.TotalPages = 0
+			m.Every = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24911,16 +26251,16 @@ func (m *ListDatumResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalPages |= int64(b&0x7F) << shift
+				m.Every |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PerSecond", wireType)
 			}
-			m.Page = 0
+			m.PerSecond = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -24930,7 +26270,7 @@ func (m *ListDatumResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Page |= int64(b&0x7F) << shift
+				m.PerSecond |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -24960,7 +26300,7 @@ func (m *ListDatumResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListDatumStreamResponse) Unmarshal(dAtA []byte) error {
+func (m *S3Endpoint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -24983,17 +26323,17 @@ func (m *ListDatumStreamResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListDatumStreamResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: S3Endpoint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListDatumStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: S3Endpoint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DatumInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheme", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -25003,33 +26343,29 @@ func (m *ListDatumStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPps
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPps
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DatumInfo == nil {
-				m.DatumInfo = &DatumInfo{}
-			}
-			if err := m.DatumInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Scheme = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalPages", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
 			}
-			m.TotalPages = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -25039,16 +26375,29 @@ func (m *ListDatumStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalPages |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
-			m.Page = 0
+			m.Port = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPps
@@ -25058,7 +26407,7 @@ func (m *ListDatumStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Page |= int64(b&0x7F) << shift
+				m.Port |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -26400,6 +27749,201 @@ func (m *CreatePipelineRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 48:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogSampling", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LogSampling == nil {
+				m.LogSampling = &LogSampling{}
+			}
+			if err := m.LogSampling.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 49:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownloadTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DownloadTimeout == nil {
+				m.DownloadTimeout = &types.Duration{}
+			}
+			if err := m.DownloadTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 50:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UploadTimeout == nil {
+				m.UploadTimeout = &types.Duration{}
+			}
+			if err := m.UploadTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 51:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumFailurePolicy", wireType)
+			}
+			m.DatumFailurePolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DatumFailurePolicy |= DatumFailurePolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 52:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumExclude", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatumExclude = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 53:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S3Endpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPps
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPps
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPps
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.S3Endpoint == nil {
+				m.S3Endpoint = &S3Endpoint{}
+			}
+			if err := m.S3Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPps(dAtA[iNdEx:])
@@ -239,6 +239,7 @@ func (a *apiServer) Extract(request *admin.ExtractRequest, extractServer admin.A
 					DataTotal:     ji.DataTotal,
 					DataFailed:    ji.DataFailed,
 					DataRecovered: ji.DataRecovered,
+					DataExcluded:  ji.DataExcluded,
 					Stats:         ji.Stats,
 					StatsCommit:   ji.StatsCommit,
 					State:         ji.State,
@@ -1651,6 +1651,72 @@ func TestGetScopeRequiresReader(t *testing.T) {
 	require.Matches(t, "not authorized", err.Error())
 }
 
+// TestCopyFile tests that CopyFile requires READER access to the source repo
+// and WRITER access to the destination repo, and that an authorized copy
+// actually copies the file
+func TestCopyFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice, bob := tu.UniqueString("alice"), tu.UniqueString("bob")
+	aliceClient, bobClient := getPachClient(t, alice), getPachClient(t, bob)
+
+	srcRepo, dstRepo := tu.UniqueString("src"), tu.UniqueString("dst")
+	require.NoError(t, aliceClient.CreateRepo(srcRepo))
+	_, err := aliceClient.PutFile(srcRepo, "master", "/file", strings.NewReader("content"))
+	require.NoError(t, err)
+	require.NoError(t, bobClient.CreateRepo(dstRepo))
+
+	// bob can't read the source repo
+	err = bobClient.CopyFile(srcRepo, "master", "/file", dstRepo, "master", "/file", false)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	// alice can't write to the destination repo
+	err = aliceClient.CopyFile(srcRepo, "master", "/file", dstRepo, "master", "/file", false)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	// neither attempt wrote anything
+	require.Equal(t, 0, CommitCnt(t, bobClient, dstRepo))
+
+	// once bob can read the source repo, the copy succeeds
+	_, err = aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Repo:     srcRepo,
+		Username: bob,
+		Scope:    auth.Scope_READER,
+	})
+	require.NoError(t, err)
+	require.NoError(t, bobClient.CopyFile(srcRepo, "master", "/file", dstRepo, "master", "/file", false))
+	buf := &bytes.Buffer{}
+	require.NoError(t, bobClient.GetFile(dstRepo, "master", "/file", 0, 0, buf))
+	require.Equal(t, "content", buf.String())
+}
+
+// TestFsckFix tests that only admins can fix PFS metadata with Fsck, since
+// it modifies every repo, while any user can check it
+func TestFsckFix(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice := tu.UniqueString("alice")
+	aliceClient, adminClient := getPachClient(t, alice), getPachClient(t, admin)
+
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, aliceClient.CreateRepo(repo))
+	_, err := aliceClient.PutFile(repo, "master", "/file", strings.NewReader("content"))
+	require.NoError(t, err)
+
+	noop := func(*pfs.FsckResponse) error { return nil }
+	require.NoError(t, aliceClient.Fsck(false, noop))
+	err = aliceClient.Fsck(true, noop)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	require.NoError(t, adminClient.Fsck(true, noop))
+}
+
 // TestListRepoNotLoggedInError makes sure that if a user isn't logged in, and
 // they call ListRepo(), they get an error.
 func TestListRepoNotLoggedInError(t *testing.T) {
@@ -2898,6 +2964,61 @@ func TestS3GatewayAuthRequests(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestS3GatewayMultipartUser checks that multipart uploads are listed with
+// the user that the listing request is authenticated as
+func TestS3GatewayMultipartUser(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice, bob := tu.UniqueString("alice"), tu.UniqueString("bob")
+	aliceClient, bobClient := getPachClient(t, alice), getPachClient(t, bob)
+
+	// the s3 gateway keeps multipart uploads in a repo of its own, which both
+	// users need to be able to write to
+	adminClient := getPachClient(t, admin)
+	multipartRepo := "_s3gateway_multipart_"
+	require.NoError(t, adminClient.CreateRepo(multipartRepo))
+	require.NoError(t, adminClient.CreateBranch(multipartRepo, "master", "", nil))
+	for _, user := range []string{alice, bob} {
+		_, err := adminClient.SetScope(adminClient.Ctx(), &auth.SetScopeRequest{
+			Repo:     multipartRepo,
+			Username: user,
+			Scope:    auth.Scope_WRITER,
+		})
+		require.NoError(t, err)
+	}
+
+	for user, userClient := range map[string]*client.APIClient{alice: aliceClient, bob: bobClient} {
+		repo := tu.UniqueString(user)
+		require.NoError(t, userClient.CreateRepo(repo))
+		require.NoError(t, userClient.CreateBranch(repo, "master", "", nil))
+		bucket := fmt.Sprintf("master.%s", repo)
+
+		authResp, err := userClient.GetAuthToken(userClient.Ctx(), &auth.GetAuthTokenRequest{})
+		require.NoError(t, err)
+		minioClient, err := minio.NewV4("127.0.0.1:30600", authResp.Token, authResp.Token, false)
+		require.NoError(t, err)
+		core := minio.Core{Client: minioClient}
+
+		uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+		require.NoError(t, err)
+		defer core.AbortMultipartUpload(bucket, "file", uploadID)
+
+		uploads, err := core.ListMultipartUploads(bucket, "", "", "", "", 1000)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(uploads.Uploads))
+		require.Equal(t, gh(user), uploads.Uploads[0].Initiator.ID)
+		require.Equal(t, gh(user), uploads.Uploads[0].Owner.ID)
+
+		parts, err := core.ListObjectParts(bucket, "file", uploadID, 0, 1000)
+		require.NoError(t, err)
+		require.Equal(t, gh(user), parts.Initiator.ID)
+		require.Equal(t, gh(user), parts.Owner.ID)
+	}
+}
+
 // TestDeleteFailedPipeline creates a pipeline with an invalid image and then
 // tries to delete it (which shouldn't be blocked by the auth system)
 func TestDeleteFailedPipeline(t *testing.T) {
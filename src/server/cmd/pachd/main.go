@@ -690,9 +690,15 @@ func doFullMode(config interface{}) (retErr error) {
 		return githook.RunGitHookServer(address, etcdAddress, path.Join(env.EtcdPrefix, env.PPSEtcdPrefix))
 	})
 	go waitForError("S3 Server", errChan, requireNoncriticalServers, func() error {
+		minPartSize, err := units.RAMInBytes(env.S3GatewayMinPartSize)
+		if err != nil {
+			return errors.Wrapf(err, "invalid S3GATEWAY_MIN_PART_SIZE")
+		}
 		server, err := s3.Server(env.S3GatewayPort, s3.NewMasterDriver(), func() (*client.APIClient, error) {
 			return client.NewFromAddress(fmt.Sprintf("localhost:%d", env.PeerPort))
-		})
+		}, s3.WithMultipartRepo(env.S3GatewayMultipartRepo, env.S3GatewayMultipartBranch),
+			s3.WithMinPartSize(minPartSize),
+			s3.WithCompleteMultipartConcurrency(env.S3GatewayCompleteMultipartConcurrency))
 		if err != nil {
 			return err
 		}
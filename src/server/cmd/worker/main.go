@@ -20,9 +20,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
 	"github.com/pachyderm/pachyderm/src/server/worker"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
 	workerserver "github.com/pachyderm/pachyderm/src/server/worker/server"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	units "github.com/docker/go-units"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -81,9 +84,34 @@ func do(config interface{}) error {
 		return errors.Wrapf(err, "error getting pipelineInfo")
 	}
 
+	logLevel, err := logs.ParseLevel(env.WorkerLogLevel)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing WORKER_LOG_LEVEL")
+	}
+	logs.SetLevel(logLevel)
+	datumLogMaxBytes, err := units.RAMInBytes(env.WorkerDatumLogMaxBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing WORKER_DATUM_LOG_MAX_BYTES")
+	}
+	logs.SetMaxObjectSize(datumLogMaxBytes)
+
 	// Construct worker API server.
 	workerRcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
-	workerInstance, err := worker.NewWorker(pachClient, env.GetEtcdClient(), env.PPSEtcdPrefix, pipelineInfo, env.PodName, env.Namespace, env.StorageRoot, "/")
+	chunkCacheMaxBytes, err := units.RAMInBytes(env.WorkerChunkCacheMaxBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing WORKER_CHUNK_CACHE_MAX_BYTES")
+	}
+	inputCacheMaxBytes, err := units.RAMInBytes(env.WorkerInputCacheMaxBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing WORKER_INPUT_CACHE_MAX_BYTES")
+	}
+	workerInstance, err := worker.NewWorker(pachClient, env.GetEtcdClient(), env.PPSEtcdPrefix, pipelineInfo, env.PodName, env.Namespace, env.StorageRoot, "/", driver.ChunkCacheOptions{
+		MaxEntries:  env.WorkerChunkCacheMaxEntries,
+		MaxBytes:    chunkCacheMaxBytes,
+		SpillToDisk: env.WorkerChunkCacheSpillToDisk,
+	}, driver.InputCacheOptions{
+		MaxBytes: inputCacheMaxBytes,
+	})
 	if err != nil {
 		return err
 	}
@@ -113,6 +113,7 @@ var (
 	commitDeletedRe           = regexp.MustCompile("commit [^ ]+/[^ ]+ was deleted")
 	commitFinishedRe          = regexp.MustCompile("commit [^ ]+ in repo [^ ]+ has already finished")
 	repoNotFoundRe            = regexp.MustCompile(`repos/ ?[a-zA-Z0-9.\-_]{1,255} not found`)
+	repoExistsRe              = regexp.MustCompile(`repo [a-zA-Z0-9.\-_]{1,255} already exists`)
 	branchNotFoundRe          = regexp.MustCompile(`branches/[a-zA-Z0-9.\-_]{1,255}/ [^ ]+ not found`)
 	fileNotFoundRe            = regexp.MustCompile(`file .+ not found`)
 	hasNoHeadRe               = regexp.MustCompile(`the branch .+ has no head \(create one with 'start commit'\)`)
@@ -155,6 +156,15 @@ func IsRepoNotFoundErr(err error) bool {
 	return repoNotFoundRe.MatchString(err.Error())
 }
 
+// IsRepoExistsErr returns true if 'err' is an error message about a repo
+// already existing
+func IsRepoExistsErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return repoExistsRe.MatchString(grpcutil.ScrubGRPC(err).Error())
+}
+
 // IsBranchNotFoundErr returns true if 'err' is an error message about a
 // branch not being found
 func IsBranchNotFoundErr(err error) bool {
@@ -20,4 +20,9 @@ func TestErrorMatching(t *testing.T) {
 	require.False(t, IsCommitFinishedErr(ErrCommitNotFound{c}))
 	require.False(t, IsCommitFinishedErr(ErrCommitDeleted{c}))
 	require.True(t, IsCommitFinishedErr(ErrCommitFinished{c}))
+
+	r := client.NewRepo("foo")
+	require.True(t, IsRepoExistsErr(ErrRepoExists{Repo: r}))
+	require.False(t, IsRepoExistsErr(ErrRepoNotFound{Repo: r}))
+	require.False(t, IsRepoExistsErr(ErrCommitExists{c}))
 }
@@ -4,8 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/auth"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/s2"
 )
 
 func (c *controller) SecretKey(r *http.Request, accessKey string, region *string) (*string, error) {
@@ -55,3 +57,17 @@ func (c *controller) CustomAuth(r *http.Request) (bool, error) {
 	// pachyderm auth is disabled
 	return !active, nil
 }
+
+// requestUser returns the S3 user of the principal that a request-scoped
+// pach client is authenticated as, or the default user if auth is not
+// activated
+func (c *controller) requestUser(pc *client.APIClient) (*s2.User, error) {
+	whoAmI, err := pc.WhoAmI(pc.Ctx(), &auth.WhoAmIRequest{})
+	if err != nil {
+		if auth.IsErrNotActivated(err) {
+			return &defaultUser, nil
+		}
+		return nil, err
+	}
+	return &s2.User{ID: whoAmI.Username, DisplayName: whoAmI.Username}, nil
+}
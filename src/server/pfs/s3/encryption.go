@@ -0,0 +1,62 @@
+package s3
+
+import (
+	"net/http"
+
+	"github.com/pachyderm/s2"
+)
+
+const (
+	sseHeader = "X-Amz-Server-Side-Encryption"
+	// The only server-side encryption mode that's supported. Content is
+	// stored as PFS stores everything else, so this is accepted so that
+	// clients configured to require encryption work, but is otherwise a
+	// passthrough.
+	sseAES256 = "AES256"
+)
+
+// The headers used for server-side encryption with customer-provided keys
+var sseCustomerHeaders = []string{
+	"X-Amz-Server-Side-Encryption-Customer-Algorithm",
+	"X-Amz-Server-Side-Encryption-Customer-Key",
+	"X-Amz-Server-Side-Encryption-Customer-Key-Md5",
+}
+
+func unsupportedEncryptionError(r *http.Request, message string) *s2.Error {
+	return s2.NewError(r, http.StatusNotImplemented, "NotImplemented", message)
+}
+
+// parseServerSideEncryption returns the server-side encryption mode requested
+// by `r`, or an empty string if none was requested. Only `AES256` is
+// supported; SSE-KMS and SSE-C are rejected, since we can't honor the keys
+// they specify.
+func parseServerSideEncryption(r *http.Request) (string, error) {
+	for _, header := range sseCustomerHeaders {
+		if r.Header.Get(header) != "" {
+			if r.Header.Get(sseHeader) != "" {
+				return "", s2.InvalidArgumentError(r)
+			}
+			return "", unsupportedEncryptionError(r, "Server-side encryption with customer-provided keys is not supported")
+		}
+	}
+
+	switch sse := r.Header.Get(sseHeader); sse {
+	case "", sseAES256:
+		return sse, nil
+	case "aws:kms":
+		return "", unsupportedEncryptionError(r, "Server-side encryption with AWS KMS is not supported")
+	default:
+		return "", s2.NewError(r, http.StatusBadRequest, "InvalidArgument", "The encryption method specified is not supported")
+	}
+}
+
+// setEncryptionHeader echoes the server-side encryption mode in the response
+// to `r`, if there is one
+func setEncryptionHeader(r *http.Request, sse string) {
+	if sse == "" {
+		return
+	}
+	if header, ok := r.Context().Value(responseHeaderKey{}).(http.Header); ok {
+		header.Set(sseHeader, sse)
+	}
+}
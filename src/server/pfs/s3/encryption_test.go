@@ -0,0 +1,61 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/s2"
+)
+
+func TestParseServerSideEncryption(t *testing.T) {
+	newRequest := func(headers map[string]string) *http.Request {
+		r := httptest.NewRequest("POST", "/bucket/key?uploads", nil)
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+		return r
+	}
+
+	sse, err := parseServerSideEncryption(newRequest(nil))
+	require.NoError(t, err)
+	require.Equal(t, "", sse)
+
+	sse, err = parseServerSideEncryption(newRequest(map[string]string{"x-amz-server-side-encryption": "AES256"}))
+	require.NoError(t, err)
+	require.Equal(t, "AES256", sse)
+
+	for _, c := range []struct {
+		headers map[string]string
+		code    string
+	}{
+		{map[string]string{"x-amz-server-side-encryption": "aws:kms"}, "NotImplemented"},
+		{map[string]string{"x-amz-server-side-encryption": "rot13"}, "InvalidArgument"},
+		{map[string]string{
+			"x-amz-server-side-encryption-customer-algorithm": "AES256",
+			"x-amz-server-side-encryption-customer-key":       "a2V5",
+			"x-amz-server-side-encryption-customer-key-MD5":   "bWQ1",
+		}, "NotImplemented"},
+		{map[string]string{
+			"x-amz-server-side-encryption":                    "AES256",
+			"x-amz-server-side-encryption-customer-algorithm": "AES256",
+		}, "InvalidArgument"},
+	} {
+		_, err := parseServerSideEncryption(newRequest(c.headers))
+		require.YesError(t, err)
+		require.Equal(t, c.code, err.(*s2.Error).Code)
+	}
+}
+
+func TestSetEncryptionHeader(t *testing.T) {
+	header := http.Header{}
+	r := httptest.NewRequest("POST", "/bucket/key?uploads", nil)
+	r = r.WithContext(context.WithValue(r.Context(), responseHeaderKey{}, header))
+
+	setEncryptionHeader(r, "")
+	require.Equal(t, "", header.Get("x-amz-server-side-encryption"))
+	setEncryptionHeader(r, "AES256")
+	require.Equal(t, "AES256", header.Get("x-amz-server-side-encryption"))
+}
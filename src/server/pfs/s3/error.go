@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/pachyderm/pachyderm/src/server/pfs"
@@ -23,6 +24,14 @@ func writeToOutputBranchError(r *http.Request) *s2.Error {
 	return s2.NewError(r, http.StatusBadRequest, "WriteToOutputBranch", "You cannot write to an output branch")
 }
 
+func invalidPartNumberError(r *http.Request, maxAllowedParts int) *s2.Error {
+	return s2.NewError(r, http.StatusBadRequest, "InvalidArgument", fmt.Sprintf("Part number must be an integer between 1 and %d, inclusive", maxAllowedParts))
+}
+
+func invalidRangeError(r *http.Request) *s2.Error {
+	return s2.NewError(r, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range is not satisfiable")
+}
+
 func maybeNotFoundError(r *http.Request, err error) *s2.Error {
 	if pfs.IsRepoNotFoundErr(err) || pfs.IsBranchNotFoundErr(err) {
 		return s2.NoSuchBucketError(r)
@@ -1,18 +1,27 @@
 package s3
 
 import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"path"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
 	minio "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+	"golang.org/x/sync/errgroup"
 )
 
 func masterListBuckets(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
@@ -196,6 +205,554 @@ func masterLargeObjects(t *testing.T, pachClient *client.APIClient, minioClient
 	require.Equal(t, inputFileHash, outputFileHash)
 }
 
+func masterUploadPartCopy(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testuploadpartcopy")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	// every part except for the last must be at least 5mb
+	largeContent := strings.Repeat("no tv and no beer make homer something something.\n", 120000)
+	_, err := pachClient.PutFile(repo, "master", "large", strings.NewReader(largeContent))
+	require.NoError(t, err)
+	_, err = pachClient.PutFile(repo, "master", "small", strings.NewReader("0123456789"))
+	require.NoError(t, err)
+
+	// copy the whole of the large object, and a range of the small one
+	dst, err := minio.NewDestinationInfo(bucket, "composed", nil, nil)
+	require.NoError(t, err)
+	large := minio.NewSourceInfo(bucket, "large", nil)
+	small := minio.NewSourceInfo(bucket, "small", nil)
+	require.NoError(t, small.SetRange(2, 5))
+	require.NoError(t, minioClient.ComposeObject(dst, []minio.SourceInfo{large, small}))
+
+	fetchedContent, err := getObject(t, minioClient, bucket, "composed")
+	require.NoError(t, err)
+	require.Equal(t, largeContent+"2345", fetchedContent)
+
+	// ranges beyond the end of the source object should be rejected
+	small = minio.NewSourceInfo(bucket, "small", nil)
+	require.NoError(t, small.SetRange(2, 50))
+	err = minioClient.ComposeObject(dst, []minio.SourceInfo{large, small})
+	require.YesError(t, err)
+}
+
+func masterListIncompleteUploads(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testlistincompleteuploads")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	core := minio.Core{Client: minioClient}
+	for _, key := range []string{"dir/a", "dir/b", "file", "other/dir/c"} {
+		uploadID, err := core.NewMultipartUpload(bucket, key, minio.PutObjectOptions{})
+		require.NoError(t, err)
+		defer core.AbortMultipartUpload(bucket, key, uploadID)
+	}
+
+	listKeys := func(prefix string, recursive bool) []string {
+		var keys []string
+		for upload := range minioClient.ListIncompleteUploads(bucket, prefix, recursive, make(chan struct{})) {
+			require.NoError(t, upload.Err)
+			keys = append(keys, upload.Key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	require.Equal(t, []string{"dir/a", "dir/b", "file", "other/dir/c"}, listKeys("", true))
+	require.Equal(t, []string{"dir/a", "dir/b"}, listKeys("dir/", true))
+	require.Equal(t, []string{"dir/", "file", "other/"}, listKeys("", false))
+	require.Equal(t, []string{"other/dir/"}, listKeys("other/", false))
+}
+
+func masterListMultipartPagination(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testlistmultipartpagination")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	// several uploads per key, so that pages end partway through a key and
+	// upload IDs of later keys sort both before and after the marker
+	core := minio.Core{Client: minioClient}
+	var expected []string
+	for _, key := range []string{"a", "b", "c"} {
+		for i := 0; i < 3; i++ {
+			uploadID, err := core.NewMultipartUpload(bucket, key, minio.PutObjectOptions{})
+			require.NoError(t, err)
+			defer core.AbortMultipartUpload(bucket, key, uploadID)
+			expected = append(expected, key+"/"+uploadID)
+		}
+	}
+	sort.Strings(expected)
+
+	for _, maxUploads := range []int{1, 2, 4} {
+		var listed []string
+		keyMarker, uploadIDMarker := "", ""
+		for {
+			result, err := core.ListMultipartUploads(bucket, "", keyMarker, uploadIDMarker, "", maxUploads)
+			require.NoError(t, err)
+			require.True(t, len(result.Uploads) <= maxUploads)
+			for _, upload := range result.Uploads {
+				listed = append(listed, upload.Key+"/"+upload.UploadID)
+			}
+			if !result.IsTruncated {
+				break
+			}
+			keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+		}
+		require.Equal(t, expected, listed)
+	}
+}
+
+func masterMultipartMetadata(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testmultipartmetadata")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	core := minio.Core{Client: minioClient}
+	uploadID, err := core.NewMultipartUpload(bucket, "dir/file.csv", minio.PutObjectOptions{
+		ContentType:  "text/csv",
+		UserMetadata: map[string]string{"source": "etl"},
+	})
+	require.NoError(t, err)
+	part, err := core.PutObjectPart(bucket, "dir/file.csv", uploadID, 1, strings.NewReader("a,b,c\n"), 6, "", "", nil)
+	require.NoError(t, err)
+	_, err = core.CompleteMultipartUpload(bucket, "dir/file.csv", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+	require.NoError(t, err)
+
+	info, err := minioClient.StatObject(bucket, "dir/file.csv", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "text/csv", info.ContentType)
+	require.Equal(t, "etl", info.Metadata.Get("X-Amz-Meta-Source"))
+
+	// the metadata shouldn't apply once the object is overwritten
+	_, err = minioClient.PutObject(bucket, "dir/file.csv", strings.NewReader("{}"), 2, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	info, err = minioClient.StatObject(bucket, "dir/file.csv", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.NotEqual(t, "text/csv", info.ContentType)
+	require.Equal(t, "", info.Metadata.Get("X-Amz-Meta-Source"))
+}
+
+func masterMultipartNotFound(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testmultipartnotfound")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+	missingBucket := fmt.Sprintf("master.%s", tu.UniqueString("testmultipartnotfoundmissing"))
+
+	core := minio.Core{Client: minioClient}
+	// each multipart operation on an upload, run against `bucket`
+	ops := map[string]func(bucket, uploadID string) error{
+		"AbortMultipartUpload": func(bucket, uploadID string) error {
+			return core.AbortMultipartUpload(bucket, "file", uploadID)
+		},
+		"CompleteMultipartUpload": func(bucket, uploadID string) error {
+			_, err := core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: "etag"}})
+			return err
+		},
+		"PutObjectPart": func(bucket, uploadID string) error {
+			_, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader("content"), 7, "", "", nil)
+			return err
+		},
+		"ListObjectParts": func(bucket, uploadID string) error {
+			_, err := core.ListObjectParts(bucket, "file", uploadID, 0, 1000)
+			return err
+		},
+	}
+	missingUploadID := uuid.NewWithoutDashes()
+	for name, op := range ops {
+		err := op(missingBucket, missingUploadID)
+		require.YesError(t, err, name)
+		require.Equal(t, "NoSuchBucket", minio.ToErrorResponse(err).Code, name)
+
+		err = op(bucket, missingUploadID)
+		require.YesError(t, err, name)
+		require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code, name)
+	}
+
+	// malformed upload IDs are rejected before they're used in any PFS path
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	defer core.AbortMultipartUpload(bucket, "file", uploadID)
+	for _, malformedID := range []string{"missing", "../" + uploadID, uploadID + "/..", uploadID + "x", "*"} {
+		for name, op := range ops {
+			err := op(bucket, malformedID)
+			require.YesError(t, err, name)
+			require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code, name)
+		}
+	}
+
+	_, err = core.ListMultipartUploads(missingBucket, "", "", "", "", 1000)
+	require.YesError(t, err)
+	require.Equal(t, "NoSuchBucket", minio.ToErrorResponse(err).Code)
+}
+
+func masterListParts(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testlistparts")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	// `startTime` and `endTime` will be used to ensure that each part's
+	// `LastModified` date is correct. A few minutes are subtracted/added to
+	// each to tolerate the node time not being the same as the host time.
+	startTime := time.Now().Add(time.Duration(-5) * time.Minute)
+	core := minio.Core{Client: minioClient}
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	defer core.AbortMultipartUpload(bucket, "file", uploadID)
+	sizes := []int{5 * 1024 * 1024, 7}
+	for i, size := range sizes {
+		_, err := core.PutObjectPart(bucket, "file", uploadID, i+1, strings.NewReader(strings.Repeat("a", size)), int64(size), "", "", nil)
+		require.NoError(t, err)
+	}
+	endTime := time.Now().Add(time.Duration(5) * time.Minute)
+
+	result, err := core.ListObjectParts(bucket, "file", uploadID, 0, 1000)
+	require.NoError(t, err)
+	require.Equal(t, len(sizes), len(result.ObjectParts))
+	for i, part := range result.ObjectParts {
+		require.Equal(t, i+1, part.PartNumber)
+		require.Equal(t, int64(sizes[i]), part.Size)
+		require.True(t, startTime.Before(part.LastModified))
+		require.True(t, endTime.After(part.LastModified))
+	}
+}
+
+func masterMultipartLegacyLayout(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testmultipartlegacylayout")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+	key := "dir/file"
+
+	// an upload that was in progress before keys were hex-encoded, with its
+	// first part uploaded
+	core := minio.Core{Client: minioClient}
+	_, err := core.ListMultipartUploads(bucket, "", "", "", "", 1000) // creates the multipart repo
+	require.NoError(t, err)
+	uploadID := uuid.NewWithoutDashes()
+	legacyPath, ok := legacyParentDirPath(repo, "master", key, uploadID)
+	require.True(t, ok)
+	firstPart := strings.Repeat("a", 5*1024*1024)
+	_, err = pachClient.PutFileOverwrite(multipartRepo, multipartBranch, path.Join(legacyPath, ".keep"), strings.NewReader(""), 0)
+	require.NoError(t, err)
+	_, err = pachClient.PutFileOverwrite(multipartRepo, multipartBranch, path.Join(legacyPath, "1"), strings.NewReader(firstPart), 0)
+	require.NoError(t, err)
+
+	// it's listed, and can be resumed and completed
+	result, err := core.ListMultipartUploads(bucket, "", "", "", "", 1000)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.Uploads))
+	require.Equal(t, key, result.Uploads[0].Key)
+	require.Equal(t, uploadID, result.Uploads[0].UploadID)
+	second, err := core.PutObjectPart(bucket, key, uploadID, 2, strings.NewReader("b"), 1, "", "", nil)
+	require.NoError(t, err)
+	parts, err := core.ListObjectParts(bucket, key, uploadID, 0, 1000)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(parts.ObjectParts))
+	_, err = core.CompleteMultipartUpload(bucket, key, uploadID, []minio.CompletePart{
+		{PartNumber: 1, ETag: parts.ObjectParts[0].ETag},
+		{PartNumber: 2, ETag: second.ETag},
+	})
+	require.NoError(t, err)
+	fetchedContent, err := getObject(t, minioClient, bucket, key)
+	require.NoError(t, err)
+	require.Equal(t, firstPart+"b", fetchedContent)
+
+	// and nothing is left in the legacy layout
+	_, err = pachClient.InspectFile(multipartRepo, multipartBranch, legacyPath)
+	require.YesError(t, err)
+	result, err = core.ListMultipartUploads(bucket, "", "", "", "", 1000)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(result.Uploads))
+}
+
+func masterMultipartEncryption(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testmultipartencryption")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	core := minio.Core{Client: minioClient}
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{ServerSideEncryption: encrypt.NewSSE()})
+	require.NoError(t, err)
+	part, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader("content"), 7, "", "", nil)
+	require.NoError(t, err)
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+	require.NoError(t, err)
+
+	info, err := minioClient.StatObject(bucket, "file", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "AES256", info.Metadata.Get("X-Amz-Server-Side-Encryption"))
+
+	// customer-provided keys can't be honored, so they should be rejected
+	sse, err := encrypt.NewSSEC([]byte("01234567890123456789012345678901"))
+	require.NoError(t, err)
+	_, err = core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{ServerSideEncryption: sse})
+	require.YesError(t, err)
+	require.Equal(t, "NotImplemented", minio.ToErrorResponse(err).Code)
+}
+
+func masterMultipartTagging(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testmultiparttagging")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	// minio-go can't set or get tags, so the requests are made directly; the
+	// test gateway doesn't require requests to be signed. A presigned URL is
+	// only used to get the gateway's address.
+	presigned, err := minioClient.Presign("GET", bucket, "file", time.Minute, nil)
+	require.NoError(t, err)
+	objectURL := fmt.Sprintf("%s://%s/%s/file", presigned.Scheme, presigned.Host, bucket)
+
+	req, err := http.NewRequest("POST", objectURL+"?uploads", nil)
+	require.NoError(t, err)
+	req.Header.Set("x-amz-tagging", "project=pachyderm&stage=dev%20test")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var initResult struct {
+		UploadID string `xml:"UploadId"`
+	}
+	require.NoError(t, xml.NewDecoder(resp.Body).Decode(&initResult))
+
+	core := minio.Core{Client: minioClient}
+	part, err := core.PutObjectPart(bucket, "file", initResult.UploadID, 1, strings.NewReader("content"), 7, "", "", nil)
+	require.NoError(t, err)
+	_, err = core.CompleteMultipartUpload(bucket, "file", initResult.UploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+	require.NoError(t, err)
+
+	info, err := minioClient.StatObject(bucket, "file", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "2", info.Metadata.Get("X-Amz-Tagging-Count"))
+
+	getTagging := func() taggingResult {
+		resp, err := http.Get(objectURL + "?tagging")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var result taggingResult
+		require.NoError(t, xml.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+	require.Equal(t, []tag{{Key: "project", Value: "pachyderm"}, {Key: "stage", Value: "dev test"}}, getTagging().TagSet)
+
+	// overwriting the object drops its tags
+	_, err = minioClient.PutObject(bucket, "file", strings.NewReader("overwritten"), 11, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(getTagging().TagSet))
+
+	// invalid tags should be rejected when the upload is initiated
+	req, err = http.NewRequest("POST", objectURL+"?uploads", nil)
+	require.NoError(t, err)
+	req.Header.Set("x-amz-tagging", "a=1&a=2")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func masterCompleteMultipartRetry(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testcompletemultipartretry")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	core := minio.Core{Client: minioClient}
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	part, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader("content"), 7, "", "", nil)
+	require.NoError(t, err)
+	parts := []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}}
+
+	// completing an upload again, as a retrying client would, should return
+	// the same result
+	etag, err := core.CompleteMultipartUpload(bucket, "file", uploadID, parts)
+	require.NoError(t, err)
+	retriedETag, err := core.CompleteMultipartUpload(bucket, "file", uploadID, parts)
+	require.NoError(t, err)
+	require.Equal(t, etag, retriedETag)
+
+	fetchedContent, err := getObject(t, minioClient, bucket, "file")
+	require.NoError(t, err)
+	require.Equal(t, "content", fetchedContent)
+
+	// but not with different parts
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{{PartNumber: 2, ETag: part.ETag}})
+	require.YesError(t, err)
+	require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code)
+
+	// nor once the object has been overwritten
+	_, err = minioClient.PutObject(bucket, "file", strings.NewReader("overwritten"), 11, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, parts)
+	require.YesError(t, err)
+	require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code)
+
+	// uploads that never existed still aren't found
+	_, err = core.CompleteMultipartUpload(bucket, "file", "nonexistent", parts)
+	require.YesError(t, err)
+	require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code)
+}
+
+func masterUploadPartDigest(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testuploadpartdigest")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	core := minio.Core{Client: minioClient}
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	contentMD5 := func(content string) string {
+		sum := md5.Sum([]byte(content))
+		return base64.StdEncoding.EncodeToString(sum[:])
+	}
+
+	// a part with the right digest is accepted
+	part, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader("content"), int64(len("content")), contentMD5("content"), "", nil)
+	require.NoError(t, err)
+
+	// while a corrupted re-upload of it is rejected, and leaves the part as
+	// it was
+	_, err = core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader("corrupt"), int64(len("corrupt")), contentMD5("content"), "", nil)
+	require.YesError(t, err)
+	require.Equal(t, "BadDigest", minio.ToErrorResponse(err).Code)
+	result, err := core.ListObjectParts(bucket, "file", uploadID, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.ObjectParts))
+	require.Equal(t, part.ETag, result.ObjectParts[0].ETag)
+
+	// a corrupted upload of a new part isn't kept either
+	_, err = core.PutObjectPart(bucket, "file", uploadID, 2, strings.NewReader("corrupt"), int64(len("corrupt")), contentMD5("content"), "", nil)
+	require.YesError(t, err)
+	require.Equal(t, "BadDigest", minio.ToErrorResponse(err).Code)
+	result, err = core.ListObjectParts(bucket, "file", uploadID, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.ObjectParts))
+
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+	require.NoError(t, err)
+	fetchedContent, err := getObject(t, minioClient, bucket, "file")
+	require.NoError(t, err)
+	require.Equal(t, "content", fetchedContent)
+}
+
+func masterCompleteMultipartEdgeCases(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testcompletemultipartedgecases")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+	core := minio.Core{Client: minioClient}
+
+	// single-part uploads may be of any size, including empty
+	for _, content := range []string{"", "a"} {
+		uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+		require.NoError(t, err)
+		part, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader(content), int64(len(content)), "", "", nil)
+		require.NoError(t, err)
+		_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+		require.NoError(t, err)
+		fetchedContent, err := getObject(t, minioClient, bucket, "file")
+		require.NoError(t, err)
+		require.Equal(t, content, fetchedContent)
+	}
+
+	// as may the last part of a multi-part upload
+	uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	first := strings.Repeat("a", 5*1024*1024)
+	part1, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader(first), int64(len(first)), "", "", nil)
+	require.NoError(t, err)
+	part2, err := core.PutObjectPart(bucket, "file", uploadID, 2, strings.NewReader(""), 0, "", "", nil)
+	require.NoError(t, err)
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{
+		{PartNumber: 1, ETag: part1.ETag},
+		{PartNumber: 2, ETag: part2.ETag},
+	})
+	require.NoError(t, err)
+	fetchedContent, err := getObject(t, minioClient, bucket, "file")
+	require.NoError(t, err)
+	require.Equal(t, len(first), len(fetchedContent))
+
+	// but completing an upload without any parts is malformed, and leaves
+	// the upload in place
+	uploadID, err = core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, nil)
+	require.YesError(t, err)
+	require.Equal(t, "MalformedXML", minio.ToErrorResponse(err).Code)
+	_, err = core.ListObjectParts(bucket, "file", uploadID, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, core.AbortMultipartUpload(bucket, "file", uploadID))
+}
+
+func masterCompleteMultipartPreconditions(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testcompletemultipartpreconditions")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+	bucket := fmt.Sprintf("master.%s", repo)
+
+	// minio-go can't set preconditions on CompleteMultipartUpload, so the
+	// requests are made directly; the test gateway doesn't require requests
+	// to be signed. A presigned URL is only used to get the gateway's address.
+	presigned, err := minioClient.Presign("GET", bucket, "file", time.Minute, nil)
+	require.NoError(t, err)
+	objectURL := fmt.Sprintf("%s://%s/%s/file", presigned.Scheme, presigned.Host, bucket)
+
+	core := minio.Core{Client: minioClient}
+	complete := func(content string, headers map[string]string) (int, string) {
+		uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+		require.NoError(t, err)
+		defer core.AbortMultipartUpload(bucket, "file", uploadID)
+		part, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader(content), int64(len(content)), "", "", nil)
+		require.NoError(t, err)
+
+		body := fmt.Sprintf("<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>%s</ETag></Part></CompleteMultipartUpload>", part.ETag)
+		req, err := http.NewRequest("POST", objectURL+"?uploadId="+uploadID, strings.NewReader(body))
+		require.NoError(t, err)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var result struct {
+			ETag string `xml:"ETag"`
+		}
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, xml.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, strings.Trim(result.ETag, `"`)
+	}
+
+	// create if absent
+	status, etag := complete("first", map[string]string{"If-None-Match": "*"})
+	require.Equal(t, http.StatusOK, status)
+	status, _ = complete("second", map[string]string{"If-None-Match": "*"})
+	require.Equal(t, http.StatusPreconditionFailed, status)
+
+	// overwrite if matches, using either the ETag returned by completing the
+	// upload or the one the object is served with
+	status, _ = complete("second", map[string]string{"If-Match": `"mismatched"`})
+	require.Equal(t, http.StatusPreconditionFailed, status)
+	status, _ = complete("second", map[string]string{"If-Match": fmt.Sprintf("%q", etag)})
+	require.Equal(t, http.StatusOK, status)
+	info, err := minioClient.StatObject(bucket, "file", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	status, _ = complete("third", map[string]string{"If-Match": fmt.Sprintf("%q", info.ETag)})
+	require.Equal(t, http.StatusOK, status)
+
+	fetchedContent, err := getObject(t, minioClient, bucket, "file")
+	require.NoError(t, err)
+	require.Equal(t, "third", fetchedContent)
+}
+
 func masterGetObjectNoHead(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
 	repo := tu.UniqueString("testgetobjectnohead")
 	require.NoError(t, pachClient.CreateRepo(repo))
@@ -453,6 +1010,45 @@ func TestMasterDriver(t *testing.T) {
 		t.Run("LargeObjects", func(t *testing.T) {
 			masterLargeObjects(t, pachClient, minioClient)
 		})
+		t.Run("UploadPartCopy", func(t *testing.T) {
+			masterUploadPartCopy(t, pachClient, minioClient)
+		})
+		t.Run("ListIncompleteUploads", func(t *testing.T) {
+			masterListIncompleteUploads(t, pachClient, minioClient)
+		})
+		t.Run("ListMultipartPagination", func(t *testing.T) {
+			masterListMultipartPagination(t, pachClient, minioClient)
+		})
+		t.Run("MultipartMetadata", func(t *testing.T) {
+			masterMultipartMetadata(t, pachClient, minioClient)
+		})
+		t.Run("MultipartNotFound", func(t *testing.T) {
+			masterMultipartNotFound(t, pachClient, minioClient)
+		})
+		t.Run("ListParts", func(t *testing.T) {
+			masterListParts(t, pachClient, minioClient)
+		})
+		t.Run("MultipartLegacyLayout", func(t *testing.T) {
+			masterMultipartLegacyLayout(t, pachClient, minioClient)
+		})
+		t.Run("MultipartEncryption", func(t *testing.T) {
+			masterMultipartEncryption(t, pachClient, minioClient)
+		})
+		t.Run("MultipartTagging", func(t *testing.T) {
+			masterMultipartTagging(t, pachClient, minioClient)
+		})
+		t.Run("CompleteMultipartRetry", func(t *testing.T) {
+			masterCompleteMultipartRetry(t, pachClient, minioClient)
+		})
+		t.Run("CompleteMultipartPreconditions", func(t *testing.T) {
+			masterCompleteMultipartPreconditions(t, pachClient, minioClient)
+		})
+		t.Run("UploadPartDigest", func(t *testing.T) {
+			masterUploadPartDigest(t, pachClient, minioClient)
+		})
+		t.Run("CompleteMultipartEdgeCases", func(t *testing.T) {
+			masterCompleteMultipartEdgeCases(t, pachClient, minioClient)
+		})
 		t.Run("GetObjectNoHead", func(t *testing.T) {
 			masterGetObjectNoHead(t, pachClient, minioClient)
 		})
@@ -500,3 +1096,100 @@ func TestMasterDriver(t *testing.T) {
 		})
 	})
 }
+
+func TestMasterDriverMultipartRepo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	multipartRepo := tu.UniqueString("custommultipart")
+	multipartBranch := "staging"
+	testRunner(t, "master", NewMasterDriver(), func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+		repo := tu.UniqueString("testmultipartrepo")
+		require.NoError(t, pachClient.CreateRepo(repo))
+		require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+		bucket := fmt.Sprintf("master.%s", repo)
+
+		core := minio.Core{Client: minioClient}
+		uploadID, err := core.NewMultipartUpload(bucket, "file", minio.PutObjectOptions{})
+		require.NoError(t, err)
+		part, err := core.PutObjectPart(bucket, "file", uploadID, 1, strings.NewReader("content"), int64(len("content")), "", "", nil)
+		require.NoError(t, err)
+
+		// the upload, its part and the part's ETag are in the configured repo
+		// and branch, and not in the default ones
+		for _, p := range []string{
+			keepPath(repo, "master", "file", uploadID),
+			chunkPath(repo, "master", "file", uploadID, 1),
+			chunkETagPath(repo, "master", "file", uploadID, 1),
+		} {
+			_, err := pachClient.InspectFile(multipartRepo, multipartBranch, p)
+			require.NoError(t, err)
+			_, err = pachClient.InspectFile("_s3gateway_multipart_", "master", p)
+			require.YesError(t, err)
+		}
+
+		_, err = core.CompleteMultipartUpload(bucket, "file", uploadID, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}})
+		require.NoError(t, err)
+		fetchedContent, err := getObject(t, minioClient, bucket, "file")
+		require.NoError(t, err)
+		require.Equal(t, "content", fetchedContent)
+
+		// as is the metadata of the completed object
+		_, err = pachClient.InspectFile(multipartRepo, multipartBranch, objectMetadataPath(repo, "master", "file"))
+		require.NoError(t, err)
+	}, WithMultipartRepo(multipartRepo, multipartBranch))
+}
+
+func TestMasterDriverConcurrentInitMultipart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	// the multipart repo doesn't exist yet, so that every request races to
+	// create it
+	multipartRepo := tu.UniqueString("concurrentmultipart")
+	testRunner(t, "master", NewMasterDriver(), func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+		repo := tu.UniqueString("testconcurrentinitmultipart")
+		require.NoError(t, pachClient.CreateRepo(repo))
+		require.NoError(t, pachClient.CreateBranch(repo, "master", "", nil))
+		bucket := fmt.Sprintf("master.%s", repo)
+
+		core := minio.Core{Client: minioClient}
+		const n = 20
+		uploadIDs := make([]string, n)
+		var eg errgroup.Group
+		for i := 0; i < n; i++ {
+			i := i
+			eg.Go(func() error {
+				var err error
+				uploadIDs[i], err = core.NewMultipartUpload(bucket, fmt.Sprintf("file%d", i), minio.PutObjectOptions{})
+				return err
+			})
+		}
+		require.NoError(t, eg.Wait())
+
+		// there's exactly one multipart repo and branch, and none of the
+		// uploads were lost
+		repoInfos, err := pachClient.ListRepo()
+		require.NoError(t, err)
+		count := 0
+		for _, repoInfo := range repoInfos {
+			if repoInfo.Repo.Name == multipartRepo {
+				count++
+			}
+		}
+		require.Equal(t, 1, count)
+		branchInfos, err := pachClient.ListBranch(multipartRepo)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(branchInfos))
+		require.Equal(t, "master", branchInfos[0].Name)
+
+		result, err := core.ListMultipartUploads(bucket, "", "", "", "", n)
+		require.NoError(t, err)
+		require.Equal(t, n, len(result.Uploads))
+		for i, uploadID := range uploadIDs {
+			require.NoError(t, core.AbortMultipartUpload(bucket, fmt.Sprintf("file%d", i), uploadID))
+		}
+	}, WithMultipartRepo(multipartRepo, "master"))
+}
@@ -0,0 +1,146 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	pfsClient "github.com/pachyderm/pachyderm/src/client/pfs"
+	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/s2"
+)
+
+// responseHeaderKey is the request context key of the response headers, so
+// that controller methods can set headers that s2 doesn't know about
+type responseHeaderKey struct{}
+
+// objectMetadata holds the metadata of an object that PFS has no place for,
+// i.e. its content type, user metadata, encryption mode and tags. It's stored
+// in the multipart repo, since objects only get metadata through multipart
+// uploads.
+type objectMetadata struct {
+	// Hash is the PFS hash of the object's contents when the metadata was
+	// written. If the object is later overwritten, its hash won't match, and
+	// the metadata is ignored.
+	Hash string `json:"hash,omitempty"`
+	// Headers are the response headers to serve with the object
+	Headers map[string]string `json:"headers"`
+	// Tags are the object's tags
+	Tags map[string]string `json:"tags,omitempty"`
+	// UploadID, ETag and Version record the multipart upload that assembled
+	// the object and the result it returned, so that retries of its
+	// completion can return the same result
+	UploadID string `json:"uploadID,omitempty"`
+	ETag     string `json:"etag,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+// completedBy returns whether the metadata was written by completing the
+// multipart upload `uploadID` with `parts`, for an object whose contents
+// still have the hash `hash`. Part ETags are optional in CompleteMultipart
+// requests, so they're only compared if all of them are given.
+func (m *objectMetadata) completedBy(uploadID, hash string, parts []*s2.Part) bool {
+	if m.UploadID == "" || m.UploadID != uploadID || m.Hash != hash {
+		return false
+	}
+	if !strings.HasSuffix(m.ETag, fmt.Sprintf("-%d", len(parts))) {
+		return false
+	}
+	partETags := make([]string, len(parts))
+	for i, part := range parts {
+		partETags[i] = strings.Trim(part.ETag, `"`)
+		if partETags[i] == "" {
+			return true
+		}
+	}
+	etag, err := multipartETag(partETags)
+	return err == nil && etag == m.ETag
+}
+
+// metadataHeaders returns the headers of a request that should be persisted
+// as metadata of the object
+func metadataHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string)
+	for name, values := range header {
+		name = http.CanonicalHeaderKey(name)
+		if len(values) == 0 {
+			continue
+		}
+		if name == "Content-Type" || name == sseHeader || strings.HasPrefix(name, "X-Amz-Meta-") {
+			headers[name] = values[0]
+		}
+	}
+	return headers
+}
+
+func uploadMetadataPath(repo, branch, key, uploadID string) string {
+	return path.Join(parentDirPath(repo, branch, key, uploadID), ".metadata")
+}
+
+// objectMetadataPath is the path of an object's metadata. The `.objects`
+// segment can't collide with multipart uploads, since their keys are
+// hex-encoded.
+func objectMetadataPath(repo, branch, key string) string {
+	return path.Join(repo, branch, ".objects", encodeMultipartKey(key))
+}
+
+func (c *controller) putMetadata(pc *client.APIClient, metadataPath string, metadata *objectMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return c.retry(func() error {
+		_, err := pc.PutFileOverwrite(c.repo, c.branch, metadataPath, bytes.NewReader(data), 0)
+		return err
+	})
+}
+
+// getMetadata reads metadata from the multipart repo, returning nil if there
+// is none
+func (c *controller) getMetadata(pc *client.APIClient, metadataPath string) (*objectMetadata, error) {
+	var buf bytes.Buffer
+	err := c.retry(func() error {
+		buf.Reset()
+		return pc.GetFile(c.repo, c.branch, metadataPath, 0, 0, &buf)
+	})
+	if err != nil {
+		if pfsServer.IsFileNotFoundErr(err) || pfsServer.IsRepoNotFoundErr(err) || pfsServer.IsNoHeadErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var metadata objectMetadata
+	if err := json.Unmarshal(buf.Bytes(), &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// setObjectHeaders sets the response headers of a request for an object from
+// its metadata, if it has any
+func (c *controller) setObjectHeaders(pc *client.APIClient, r *http.Request, repo, branch, key string, fileInfo *pfsClient.FileInfo) {
+	header, ok := r.Context().Value(responseHeaderKey{}).(http.Header)
+	if !ok {
+		return
+	}
+	metadata, err := c.getMetadata(pc, objectMetadataPath(repo, branch, key))
+	if err != nil {
+		// the object can still be served without its metadata
+		c.logger.Errorf("could not get metadata of %s@%s:%s: %v", repo, branch, key, err)
+		return
+	}
+	if metadata == nil || metadata.Hash != fmt.Sprintf("%x", fileInfo.Hash) {
+		return
+	}
+	for name, value := range metadata.Headers {
+		header.Set(name, value)
+	}
+	if len(metadata.Tags) > 0 {
+		header.Set("x-amz-tagging-count", strconv.Itoa(len(metadata.Tags)))
+	}
+}
@@ -0,0 +1,57 @@
+package s3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/s2"
+)
+
+func TestMetadataHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "text/csv")
+	header.Set("x-amz-meta-source", "etl")
+	header.Set("x-amz-server-side-encryption", "AES256")
+	header.Set("X-Amz-Meta-Run-Id", "42")
+	header.Set("Authorization", "secret")
+	header.Set("Content-Length", "0")
+
+	require.Equal(t, map[string]string{
+		"Content-Type":                 "text/csv",
+		"X-Amz-Meta-Source":            "etl",
+		"X-Amz-Meta-Run-Id":            "42",
+		"X-Amz-Server-Side-Encryption": "AES256",
+	}, metadataHeaders(header))
+	require.Equal(t, 0, len(metadataHeaders(http.Header{})))
+}
+
+func TestObjectMetadataPath(t *testing.T) {
+	// object metadata must never be mistaken for part of an upload
+	metadataPath := "/" + objectMetadataPath("repo", "branch", "dir/file")
+	_, _, _, _, err := multipartKeepArgs(metadataPath)
+	require.YesError(t, err)
+	_, _, _, _, _, err = multipartChunkArgs(metadataPath)
+	require.YesError(t, err)
+}
+
+func TestCompletedBy(t *testing.T) {
+	partETags := []string{"d41d8cd98f00b204e9800998ecf8427e", "0cc175b9c0f1b6a831c399e269772661"}
+	etag, err := multipartETag(partETags)
+	require.NoError(t, err)
+	metadata := &objectMetadata{Hash: "abc", UploadID: "upload", ETag: etag, Version: "commit"}
+
+	parts := []*s2.Part{{PartNumber: 1, ETag: `"` + partETags[0] + `"`}, {PartNumber: 2, ETag: partETags[1]}}
+	require.True(t, metadata.completedBy("upload", "abc", parts))
+	// part etags are optional
+	require.True(t, metadata.completedBy("upload", "abc", []*s2.Part{{PartNumber: 1}, {PartNumber: 2}}))
+
+	// a different upload, or an overwritten object
+	require.False(t, metadata.completedBy("other", "abc", parts))
+	require.False(t, metadata.completedBy("upload", "def", parts))
+	// different parts
+	require.False(t, metadata.completedBy("upload", "abc", parts[:1]))
+	require.False(t, metadata.completedBy("upload", "abc", []*s2.Part{parts[1], parts[0]}))
+	// metadata that wasn't written by a completed upload
+	require.False(t, (&objectMetadata{Hash: "abc"}).completedBy("", "abc", nil))
+}
@@ -0,0 +1,154 @@
+package s3
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pachyderm/s2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// resultSuccess is the code that successful requests are counted under
+const resultSuccess = "success"
+
+// This needs to be a global var, because the prometheus registration uses a
+// global namespace, and a process may run several gateways
+var (
+	defaultMultipartMetrics     *multipartMetrics
+	defaultMultipartMetricsErr  error
+	defaultMultipartMetricsOnce sync.Once
+)
+
+// multipartMetrics holds the prometheus metrics of multipart operations
+type multipartMetrics struct {
+	// the number of requests, by operation, bucket and result code
+	requests *prometheus.CounterVec
+	// the time spent serving requests, by operation and bucket
+	duration *prometheus.HistogramVec
+	// the size of successfully uploaded parts, by bucket
+	partBytes *prometheus.HistogramVec
+}
+
+// newMultipartMetrics creates the multipart metrics and registers them with
+// `registerer`
+func newMultipartMetrics(registerer prometheus.Registerer) (*multipartMetrics, error) {
+	m := &multipartMetrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "pachyderm",
+				Subsystem: "s3gateway",
+				Name:      "multipart_requests",
+				Help:      "multipart upload operations, count by operation, bucket and result code",
+			},
+			[]string{"operation", "bucket", "code"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "pachyderm",
+				Subsystem: "s3gateway",
+				Name:      "multipart_request_time",
+				Help:      "time spent on multipart upload operations, histogram by duration (seconds)",
+				Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+			},
+			[]string{"operation", "bucket"},
+		),
+		partBytes: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "pachyderm",
+				Subsystem: "s3gateway",
+				Name:      "multipart_part_bytes",
+				Help:      "size of uploaded multipart upload parts, histogram by size (bytes)",
+				// 1kb up to 4gb
+				Buckets: prometheus.ExponentialBuckets(1024, 4, 12),
+			},
+			[]string{"bucket"},
+		),
+	}
+	for _, collector := range []prometheus.Collector{m.requests, m.duration, m.partBytes} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// getDefaultMultipartMetrics returns the multipart metrics, registered with
+// the default prometheus registry, which pachd serves on its prometheus port
+func getDefaultMultipartMetrics() (*multipartMetrics, error) {
+	defaultMultipartMetricsOnce.Do(func() {
+		defaultMultipartMetrics, defaultMultipartMetricsErr = newMultipartMetrics(prometheus.DefaultRegisterer)
+	})
+	return defaultMultipartMetrics, defaultMultipartMetricsErr
+}
+
+// observe records a request for an operation on a bucket, which started at
+// `start`, and failed with `err` if it's non-nil
+func (m *multipartMetrics) observe(operation, bucket string, start time.Time, err error) {
+	code := resultSuccess
+	if err != nil {
+		if s3Err, ok := err.(*s2.Error); ok {
+			code = s3Err.Code
+		} else {
+			// s2 serves any other error as an internal error
+			code = "InternalError"
+		}
+	}
+	m.requests.WithLabelValues(operation, bucket, code).Inc()
+	m.duration.WithLabelValues(operation, bucket).Observe(time.Since(start).Seconds())
+}
+
+// countingReader counts the bytes read from a reader
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.n += int64(n)
+	return n, err
+}
+
+// instrumentedMultipartController wraps a multipart controller, reporting
+// metrics for the requests that it serves
+type instrumentedMultipartController struct {
+	s2.MultipartController
+	metrics *multipartMetrics
+}
+
+func (c *instrumentedMultipartController) ListMultipart(r *http.Request, bucket, keyMarker, uploadIDMarker string, maxUploads int) (result *s2.ListMultipartResult, err error) {
+	defer func(start time.Time) { c.metrics.observe("ListMultipart", bucket, start, err) }(time.Now())
+	return c.MultipartController.ListMultipart(r, bucket, keyMarker, uploadIDMarker, maxUploads)
+}
+
+func (c *instrumentedMultipartController) InitMultipart(r *http.Request, bucket, key string) (uploadID string, err error) {
+	defer func(start time.Time) { c.metrics.observe("InitMultipart", bucket, start, err) }(time.Now())
+	return c.MultipartController.InitMultipart(r, bucket, key)
+}
+
+func (c *instrumentedMultipartController) AbortMultipart(r *http.Request, bucket, key, uploadID string) (err error) {
+	defer func(start time.Time) { c.metrics.observe("AbortMultipart", bucket, start, err) }(time.Now())
+	return c.MultipartController.AbortMultipart(r, bucket, key, uploadID)
+}
+
+func (c *instrumentedMultipartController) CompleteMultipart(r *http.Request, bucket, key, uploadID string, parts []*s2.Part) (result *s2.CompleteMultipartResult, err error) {
+	defer func(start time.Time) { c.metrics.observe("CompleteMultipart", bucket, start, err) }(time.Now())
+	return c.MultipartController.CompleteMultipart(r, bucket, key, uploadID, parts)
+}
+
+func (c *instrumentedMultipartController) ListMultipartChunks(r *http.Request, bucket, key, uploadID string, partNumberMarker, maxParts int) (result *s2.ListMultipartChunksResult, err error) {
+	defer func(start time.Time) { c.metrics.observe("ListMultipartChunks", bucket, start, err) }(time.Now())
+	return c.MultipartController.ListMultipartChunks(r, bucket, key, uploadID, partNumberMarker, maxParts)
+}
+
+func (c *instrumentedMultipartController) UploadMultipartChunk(r *http.Request, bucket, key, uploadID string, partNumber int, reader io.Reader) (etag string, err error) {
+	defer func(start time.Time) { c.metrics.observe("UploadMultipartChunk", bucket, start, err) }(time.Now())
+	body := &countingReader{reader: reader}
+	etag, err = c.MultipartController.UploadMultipartChunk(r, bucket, key, uploadID, partNumber, body)
+	if err == nil {
+		c.metrics.partBytes.WithLabelValues(bucket).Observe(float64(body.n))
+	}
+	return etag, err
+}
@@ -0,0 +1,133 @@
+package s3
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/s2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// memoryMultipartController is a minimal in-memory multipart controller,
+// which supports a single upload at a time
+type memoryMultipartController struct {
+	uploadID string
+	parts    map[int]string
+}
+
+func (c *memoryMultipartController) ListMultipart(r *http.Request, bucket, keyMarker, uploadIDMarker string, maxUploads int) (*s2.ListMultipartResult, error) {
+	return &s2.ListMultipartResult{}, nil
+}
+
+func (c *memoryMultipartController) InitMultipart(r *http.Request, bucket, key string) (string, error) {
+	c.uploadID = "upload"
+	c.parts = map[int]string{}
+	return c.uploadID, nil
+}
+
+func (c *memoryMultipartController) AbortMultipart(r *http.Request, bucket, key, uploadID string) error {
+	if uploadID != c.uploadID {
+		return s2.NoSuchUploadError(r)
+	}
+	c.uploadID = ""
+	return nil
+}
+
+func (c *memoryMultipartController) CompleteMultipart(r *http.Request, bucket, key, uploadID string, parts []*s2.Part) (*s2.CompleteMultipartResult, error) {
+	if uploadID != c.uploadID {
+		return nil, s2.NoSuchUploadError(r)
+	}
+	c.uploadID = ""
+	return &s2.CompleteMultipartResult{Location: globalLocation, ETag: "etag"}, nil
+}
+
+func (c *memoryMultipartController) ListMultipartChunks(r *http.Request, bucket, key, uploadID string, partNumberMarker, maxParts int) (*s2.ListMultipartChunksResult, error) {
+	if uploadID != c.uploadID {
+		return nil, s2.NoSuchUploadError(r)
+	}
+	result := &s2.ListMultipartChunksResult{Initiator: &defaultUser, Owner: &defaultUser}
+	for partNumber := range c.parts {
+		result.Parts = append(result.Parts, &s2.Part{PartNumber: partNumber, ETag: "etag"})
+	}
+	return result, nil
+}
+
+func (c *memoryMultipartController) UploadMultipartChunk(r *http.Request, bucket, key, uploadID string, partNumber int, reader io.Reader) (string, error) {
+	if uploadID != c.uploadID {
+		return "", s2.NoSuchUploadError(r)
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	c.parts[partNumber] = string(data)
+	return "etag", nil
+}
+
+func TestMultipartMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := newMultipartMetrics(registry)
+	require.NoError(t, err)
+
+	s3Server := s2.NewS2(logrus.WithFields(logrus.Fields{}), maxRequestBodyLength, readBodyTimeout)
+	s3Server.Multipart = &instrumentedMultipartController{
+		MultipartController: &memoryMultipartController{},
+		metrics:             metrics,
+	}
+	router := s3Server.Router()
+	serve := func(method, target, body string) int {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// a full upload lifecycle, followed by a failed abort
+	require.Equal(t, http.StatusOK, serve("POST", "/bucket/key?uploads", ""))
+	require.Equal(t, http.StatusOK, serve("PUT", "/bucket/key?uploadId=upload&partNumber=1", "content"))
+	require.Equal(t, http.StatusOK, serve("PUT", "/bucket/key?uploadId=upload&partNumber=2", "more content"))
+	require.Equal(t, http.StatusOK, serve("GET", "/bucket/key?uploadId=upload", ""))
+	require.Equal(t, http.StatusOK, serve("GET", "/bucket/?uploads", ""))
+	complete := "<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>etag</ETag></Part><Part><PartNumber>2</PartNumber><ETag>etag</ETag></Part></CompleteMultipartUpload>"
+	require.Equal(t, http.StatusOK, serve("POST", "/bucket/key?uploadId=upload", complete))
+	require.Equal(t, http.StatusNotFound, serve("DELETE", "/bucket/key?uploadId=upload", ""))
+
+	// scrape the metrics
+	w := httptest.NewRecorder()
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	scraped := w.Body.String()
+
+	for _, c := range []struct {
+		operation, code string
+		count           int
+	}{
+		{"InitMultipart", resultSuccess, 1},
+		{"UploadMultipartChunk", resultSuccess, 2},
+		{"ListMultipartChunks", resultSuccess, 1},
+		{"ListMultipart", resultSuccess, 1},
+		{"CompleteMultipart", resultSuccess, 1},
+		{"AbortMultipart", "NoSuchUpload", 1},
+	} {
+		require.True(t, strings.Contains(scraped, fmt.Sprintf(
+			`pachyderm_s3gateway_multipart_requests{bucket="bucket",code=%q,operation=%q} %d`,
+			c.code, c.operation, c.count,
+		)), c.operation)
+		require.True(t, strings.Contains(scraped, fmt.Sprintf(
+			`pachyderm_s3gateway_multipart_request_time_count{bucket="bucket",operation=%q} %d`,
+			c.operation, c.count,
+		)), c.operation)
+	}
+	require.True(t, strings.Contains(scraped, `pachyderm_s3gateway_multipart_part_bytes_count{bucket="bucket"} 2`))
+	require.True(t, strings.Contains(scraped, fmt.Sprintf(`pachyderm_s3gateway_multipart_part_bytes_sum{bucket="bucket"} %d`, len("content")+len("more content"))))
+}
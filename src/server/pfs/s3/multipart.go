@@ -1,16 +1,28 @@
 package s3
 
 import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gogo/protobuf/types"
+	"github.com/gorilla/mux"
 	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/limit"
 	pfsClient "github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
@@ -18,10 +30,27 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 
 	"github.com/pachyderm/s2"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 )
 
-var multipartChunkPathMatcher = regexp.MustCompile(`([^/]+)/([^/]+)/(.+)/([^/]+)/(\d+)`)
-var multipartKeepPathMatcher = regexp.MustCompile(`([^/]+)/([^/]+)/(.+)/([^/]+)/\.keep`)
+// Multipart content is stored in paths of the form
+// `<repo>/<branch>/<encoded key>/<upload ID>/...`, where the key is
+// hex-encoded into a single path segment. This lets arbitrary keys (which
+// commonly contain slashes, and may contain characters that PFS doesn't allow
+// in paths) round trip, while preserving the ordering and prefixes of keys.
+var multipartChunkPathMatcher = regexp.MustCompile(`^/?([^/]+)/([^/]+)/([0-9a-f]+)/([^/]+)/(\d+)$`)
+var multipartKeepPathMatcher = regexp.MustCompile(`^/?([^/]+)/([^/]+)/([0-9a-f]+)/([^/]+)/\.keep$`)
+
+// Before keys were hex-encoded, multipart content was stored under the raw
+// key, in paths of the form `<repo>/<branch>/<key>/<upload ID>/...` on the
+// default multipart branch. Uploads that were in progress across that change
+// are moved to their current paths when they're next used (see
+// migrateLegacyUpload), and are listed until then.
+var legacyMultipartKeepPathMatcher = regexp.MustCompile(`^/?([^/]+)/([^/]+)/(.+)/([^/]+)/\.keep$`)
+
+// Upload IDs are issued by `uuid.NewWithoutDashes`
+var uploadIDMatcher = regexp.MustCompile(`^[0-9a-f]{12}4[0-9a-f]{19}$`)
 
 func multipartChunkArgs(path string) (repo string, branch string, key string, uploadID string, partNumber int, err error) {
 	match := multipartChunkPathMatcher.FindStringSubmatch(path)
@@ -33,7 +62,10 @@ func multipartChunkArgs(path string) (repo string, branch string, key string, up
 
 	repo = match[1]
 	branch = match[2]
-	key = match[3]
+	key, err = decodeMultipartKey(match[3])
+	if err != nil {
+		return
+	}
 	uploadID = match[4]
 	partNumber, err = strconv.Atoi(match[5])
 	if err != nil {
@@ -51,6 +83,33 @@ func multipartKeepArgs(path string) (repo string, branch string, key string, upl
 		return
 	}
 
+	repo = match[1]
+	branch = match[2]
+	key, err = decodeMultipartKey(match[3])
+	if err != nil {
+		return
+	}
+	uploadID = match[4]
+	return
+}
+
+// legacyMultipartKeepArgs parses the path of an upload's .keep file in the
+// legacy layout. A path that's also valid in the current layout is taken to
+// be in the current layout, so a legacy upload of a key that's a single
+// segment of hex digits (which decode to valid UTF-8) isn't listed, though it
+// can still be resumed.
+func legacyMultipartKeepArgs(path string) (repo string, branch string, key string, uploadID string, err error) {
+	if _, _, _, _, err := multipartKeepArgs(path); err == nil {
+		err = errors.New("invalid file path found in multipath bucket")
+		return "", "", "", "", err
+	}
+	match := legacyMultipartKeepPathMatcher.FindStringSubmatch(path)
+
+	if len(match) == 0 || !uploadIDMatcher.MatchString(match[4]) {
+		err = errors.New("invalid file path found in multipath bucket")
+		return
+	}
+
 	repo = match[1]
 	branch = match[2]
 	key = match[3]
@@ -58,8 +117,34 @@ func multipartKeepArgs(path string) (repo string, branch string, key string, upl
 	return
 }
 
+func encodeMultipartKey(key string) string {
+	return hex.EncodeToString([]byte(key))
+}
+
+func decodeMultipartKey(encoded string) (string, error) {
+	key, err := hex.DecodeString(encoded)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid file path found in multipath bucket")
+	}
+	// s3 keys are UTF-8, so this isn't an encoded key
+	if !utf8.Valid(key) {
+		return "", errors.New("invalid file path found in multipath bucket")
+	}
+	return string(key), nil
+}
+
 func parentDirPath(repo, branch, key, uploadID string) string {
-	return path.Join(repo, branch, key, uploadID)
+	return path.Join(repo, branch, encodeMultipartKey(key), uploadID)
+}
+
+// legacyParentDirPath is the path of an upload in the legacy layout. Keys
+// that path.Join would clean couldn't have round tripped through it, so they
+// have no legacy path.
+func legacyParentDirPath(repo, branch, key, uploadID string) (string, bool) {
+	if path.Clean("/"+key) != "/"+key {
+		return "", false
+	}
+	return path.Join(repo, branch, key, uploadID), true
 }
 
 func chunkPath(repo, branch, key, uploadID string, partNumber int) string {
@@ -70,25 +155,401 @@ func keepPath(repo, branch, key, uploadID string) string {
 	return path.Join(parentDirPath(repo, branch, key, uploadID), ".keep")
 }
 
-func (c *controller) ensureRepo(pc *client.APIClient) error {
-	_, err := pc.InspectBranch(c.repo, "master")
+// incomingChunkPath is the path that a part is streamed to before it's
+// verified. Each request uses its own path, so that a failed upload of a part
+// never replaces or removes an earlier upload of it.
+func incomingChunkPath(repo, branch, key, uploadID string, partNumber int, requestID string) string {
+	return path.Join(parentDirPath(repo, branch, key, uploadID), ".incoming", fmt.Sprintf("%d-%s", partNumber, requestID))
+}
+
+// validateUploadID checks that an upload ID has the format of the IDs issued
+// by InitMultipart, so that a client-supplied ID can't address any other path
+// in the multipart repo
+func validateUploadID(r *http.Request, uploadID string) error {
+	if !uploadIDMatcher.MatchString(uploadID) {
+		return s2.NoSuchUploadError(r)
+	}
+	return nil
+}
+
+// ensureUpload checks that a multipart upload exists. This should be called
+// after checking that the bucket exists, so that a missing bucket isn't
+// reported as a missing upload.
+func (c *controller) ensureUpload(pc *client.APIClient, r *http.Request, bucket *Bucket, key, uploadID string) error {
+	err := c.retry(func() error {
+		_, err := pc.InspectFile(c.repo, c.branch, keepPath(bucket.Repo, bucket.Commit, key, uploadID))
+		return err
+	})
 	if err != nil {
-		err = pc.UpdateRepo(c.repo)
+		if !pfsServer.IsFileNotFoundErr(err) && !pfsServer.IsNoHeadErr(err) {
+			return err
+		}
+		migrated, err := c.migrateLegacyUpload(pc, bucket, key, uploadID)
 		if err != nil {
 			return err
 		}
+		if !migrated {
+			return s2.NoSuchUploadError(r)
+		}
+	}
+	return nil
+}
 
-		err = pc.CreateBranch(c.repo, "master", "", nil)
+// migrateLegacyUpload moves an upload in the legacy layout to its current
+// path, so that uploads that were in progress across the change in layout
+// can be completed (or aborted) rather than being orphaned. It returns false
+// if there's no such legacy upload.
+func (c *controller) migrateLegacyUpload(pc *client.APIClient, bucket *Bucket, key, uploadID string) (bool, error) {
+	legacyPath, ok := legacyParentDirPath(bucket.Repo, bucket.Commit, key, uploadID)
+	if !ok {
+		return false, nil
+	}
+	err := c.retry(func() error {
+		_, err := pc.InspectFile(c.repo, multipartBranch, path.Join(legacyPath, ".keep"))
+		return err
+	})
+	if err != nil {
+		if pfsServer.IsFileNotFoundErr(err) || pfsServer.IsNoHeadErr(err) || pfsServer.IsBranchNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	c.logger.Infof("moving multipart upload %s of %q to its current path", uploadID, key)
+	// The copy overwrites, so that concurrent requests migrating the same
+	// upload don't duplicate its parts
+	if err := c.retry(func() error {
+		return pc.CopyFile(c.repo, multipartBranch, legacyPath, c.repo, c.branch, parentDirPath(bucket.Repo, bucket.Commit, key, uploadID), true)
+	}); err != nil {
+		return false, err
+	}
+	if err := c.retry(func() error {
+		return pc.DeleteFile(c.repo, multipartBranch, legacyPath)
+	}); err != nil && !pfsServer.IsFileNotFoundErr(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// legacyUploads returns the uploads of a bucket that are in the legacy
+// layout, sorted by key and upload ID, as ListMultipart lists uploads
+func (c *controller) legacyUploads(pc *client.APIClient, bucket *Bucket) ([]*s2.Upload, error) {
+	var uploads []*s2.Upload
+	globPattern := path.Join(bucket.Repo, bucket.Commit, "**", ".keep")
+	err := pc.GlobFileF(c.repo, multipartBranch, globPattern, func(fileInfo *pfsClient.FileInfo) error {
+		_, _, key, uploadID, err := legacyMultipartKeepArgs(fileInfo.File.Path)
 		if err != nil {
+			return nil
+		}
+		timestamp, err := types.TimestampFromProto(fileInfo.Committed)
+		if err != nil {
+			return err
+		}
+		uploads = append(uploads, &s2.Upload{Key: key, UploadID: uploadID, Initiated: timestamp})
+		return nil
+	})
+	if err != nil {
+		if pfsServer.IsBranchNotFoundErr(err) || pfsServer.IsNoHeadErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].Key != uploads[j].Key {
+			return uploads[i].Key < uploads[j].Key
+		}
+		return uploads[i].UploadID < uploads[j].UploadID
+	})
+	return uploads, nil
+}
+
+// validatePartOrder checks that the parts of a CompleteMultipart request are
+// listed in strictly ascending order. As in s3, part numbers needn't be
+// contiguous, so long as each listed part was uploaded.
+func (c *controller) validatePartOrder(r *http.Request, parts []*s2.Part) error {
+	for i, part := range parts {
+		if part.PartNumber < 1 || part.PartNumber > c.maxAllowedParts {
+			return s2.InvalidPartError(r)
+		}
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			// s2 checks the order of parts, but still lets duplicates through
+			return s2.InvalidPartOrderError(nil, r)
+		}
+	}
+	return nil
+}
+
+// validatePartSize checks that a part is at least the minimum part size,
+// unless it's the last part of the upload, which may be of any size (even
+// empty), as may the only part of a single-part upload.
+func (c *controller) validatePartSize(r *http.Request, last bool, size uint64) error {
+	if !last && size < c.minPartSize {
+		return s2.EntityTooSmallError(r)
+	}
+	return nil
+}
+
+// objectETags returns the ETags that identify the current contents of an
+// object: the ETag that it's served with, and, if it was assembled by a
+// multipart upload, the ETag that completing the upload returned
+func (c *controller) objectETags(pc *client.APIClient, bucket *Bucket, key string, fileInfo *pfsClient.FileInfo) ([]string, error) {
+	hash := fmt.Sprintf("%x", fileInfo.Hash)
+	etags := []string{hash}
+	metadata, err := c.getMetadata(pc, objectMetadataPath(bucket.Repo, bucket.Commit, key))
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil && metadata.Hash == hash && metadata.ETag != "" {
+		etags = append(etags, metadata.ETag)
+	}
+	return etags, nil
+}
+
+// checkWritePreconditions checks the If-Match and If-None-Match headers of a
+// request that writes an object, given whether the object exists and, if so,
+// its ETags. `If-None-Match: *` only allows creating the object, while
+// `If-Match` only allows replacing it if one of its ETags matches.
+func checkWritePreconditions(r *http.Request, exists bool, etags []string) error {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if exists && matchesETag(ifNoneMatch, etags) {
+			return s2.PreconditionFailedError(r)
+		}
+	}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !exists || !matchesETag(ifMatch, etags) {
+			return s2.PreconditionFailedError(r)
+		}
+	}
+	return nil
+}
+
+// matchesETag returns whether a conditional header, which holds either `*` or
+// a comma-separated list of (possibly quoted) ETags, matches any of `etags`
+func matchesETag(header string, etags []string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.Trim(strings.TrimSpace(candidate), `"`)
+		if candidate == "*" {
+			return true
+		}
+		for _, etag := range etags {
+			if candidate == etag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// chunkETagPath is the path of the file holding the ETag (the hex-encoded md5
+// of the contents, as in s3) of a part. These are kept separately from the
+// parts themselves, since PFS only tracks its own content hashes.
+func chunkETagPath(repo, branch, key, uploadID string, partNumber int) string {
+	return path.Join(parentDirPath(repo, branch, key, uploadID), ".etags", strconv.Itoa(partNumber))
+}
+
+// multipartETag computes the ETag that s3 assigns to an object assembled from
+// a multipart upload: the md5 of the concatenated binary ETags of each part,
+// followed by a '-' and the number of parts.
+func multipartETag(partETags []string) (string, error) {
+	h := md5.New()
+	for _, etag := range partETags {
+		b, err := hex.DecodeString(etag)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid part etag %q", etag)
+		}
+		h.Write(b)
+	}
+	return fmt.Sprintf("%x-%d", h.Sum(nil), len(partETags)), nil
+}
+
+// completeMultipartConcurrencyHeader is the header that a CompleteMultipart
+// request may set to lower the number of parts that are validated and copied
+// concurrently for it, below the controller's completeMultipartConcurrency
+const completeMultipartConcurrencyHeader = "x-pachyderm-complete-multipart-concurrency"
+
+// clampConcurrency returns 'concurrency' clamped to between 1 and 'max'
+func clampConcurrency(concurrency, max int) int {
+	if concurrency < 1 {
+		return 1
+	}
+	if concurrency > max {
+		return max
+	}
+	return concurrency
+}
+
+// completeMultipartConcurrencyFor returns the number of parts that are
+// validated and copied concurrently when completing the multipart upload of
+// the request 'r'. This is the controller's completeMultipartConcurrency,
+// unless the request overrides it with the completeMultipartConcurrencyHeader,
+// which is clamped so that a request can't exceed the controller's setting.
+func (c *controller) completeMultipartConcurrencyFor(r *http.Request) (int, error) {
+	header := r.Header.Get(completeMultipartConcurrencyHeader)
+	if header == "" {
+		return c.completeMultipartConcurrency, nil
+	}
+	concurrency, err := strconv.Atoi(header)
+	if err != nil {
+		return 0, s2.InvalidArgumentError(r)
+	}
+	return clampConcurrency(concurrency, c.completeMultipartConcurrency), nil
+}
+
+// copyParts appends `numParts` parts, in order, to the destination of a
+// multipart upload, running up to `concurrency` copies at once. Because each
+// copy appends to its destination, parts can't be copied to the destination
+// concurrently without scrambling their order. Instead, the parts are split
+// into up to `concurrency` contiguous runs, which are each assembled
+// concurrently by `copyToStaging` (overwriting any leftovers from a previous
+// attempt with the first part of the run), then appended to the destination
+// in order by `copyFromStaging`.
+func copyParts(numParts, concurrency int, copyToStaging func(run, part int, overwrite bool) error, copyFromStaging func(run int) error) error {
+	if numParts == 0 {
+		return nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	runSize := (numParts + concurrency - 1) / concurrency
+
+	var eg errgroup.Group
+	numRuns := 0
+	for start := 0; start < numParts; start += runSize {
+		run, start, end := numRuns, start, start+runSize
+		if end > numParts {
+			end = numParts
+		}
+		numRuns++
+		eg.Go(func() error {
+			for i := start; i < end; i++ {
+				if err := copyToStaging(run, i, i == start); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	for run := 0; run < numRuns; run++ {
+		if err := copyFromStaging(run); err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+// chunkETag returns the ETag of an uploaded part, and whether it was stored
+// when the part was uploaded. Parts uploaded before ETags were tracked fall
+// back to the PFS hash of their contents.
+func (c *controller) chunkETag(pc *client.APIClient, bucket *Bucket, key, uploadID string, partNumber int, fileInfo *pfsClient.FileInfo) (string, bool, error) {
+	var buf bytes.Buffer
+	err := c.retry(func() error {
+		buf.Reset()
+		return pc.GetFile(c.repo, c.branch, chunkETagPath(bucket.Repo, bucket.Commit, key, uploadID, partNumber), 0, 0, &buf)
+	})
+	if err != nil {
+		if pfsServer.IsFileNotFoundErr(err) {
+			return fmt.Sprintf("%x", fileInfo.Hash), false, nil
+		}
+		return "", false, err
+	}
+	return buf.String(), true, nil
+}
+
+// partETagMatches checks the ETag a client sent for a part against the part's
+// ETag. A fallback (PFS hash) ETag is only compared when the client's ETag has
+// the same length, as clients hold md5 ETags for parts uploaded before ETags
+// were tracked, and those would otherwise fail.
+func partETagMatches(clientETag, etag string, stored bool) bool {
+	clientETag = strings.Trim(clientETag, `"`)
+	if clientETag == "" {
+		return true
+	}
+	if !stored && len(clientETag) != len(etag) {
+		return true
+	}
+	return clientETag == etag
+}
 
+// validatePartNumber checks that a part number is within the range allowed by
+// s3, which is 1 through 10,000 inclusive by default.
+func (c *controller) validatePartNumber(r *http.Request, partNumber int) error {
+	if partNumber < 1 || partNumber > c.maxAllowedParts {
+		return invalidPartNumberError(r, c.maxAllowedParts)
+	}
 	return nil
 }
 
+// ensureRepo creates the repo and branch holding multipart content, if they
+// don't exist yet. It's safe to call concurrently, e.g. by concurrent
+// InitMultipart requests.
+func (c *controller) ensureRepo(pc *client.APIClient) error {
+	err := c.retry(func() error {
+		_, err := pc.InspectBranch(c.repo, c.branch)
+		return err
+	})
+	if err == nil {
+		return nil
+	} else if !pfsServer.IsRepoNotFoundErr(err) && !pfsServer.IsBranchNotFoundErr(err) {
+		return err
+	}
+
+	// another request may create the repo first, which is fine
+	err = c.retry(func() error {
+		return pc.CreateRepo(c.repo)
+	})
+	if err != nil && !pfsServer.IsRepoExistsErr(err) {
+		return err
+	}
+
+	// The branch is created by committing to it, rather than with
+	// CreateBranch, which would reset its head if another request created the
+	// branch and wrote to it in the meantime. Concurrent requests may each add
+	// an empty commit, which is harmless.
+	var commit *pfsClient.Commit
+	err = c.retry(func() error {
+		var err error
+		commit, err = pc.StartCommit(c.repo, c.branch)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return c.retry(func() error {
+		return pc.FinishCommit(c.repo, commit.ID)
+	})
+}
+
+// maxListLength is the maximum number of uploads or parts returned by a single
+// ListMultipartUploads or ListParts request, which is also the default, as in
+// s3
+const maxListLength = 1000
+
+// clampListLength returns the effective number of uploads or parts to list
+// for a requested maximum: the default if it's unset (zero or negative), and
+// at most `maxListLength`
+func clampListLength(requested int) int {
+	if requested <= 0 || requested > maxListLength {
+		return maxListLength
+	}
+	return requested
+}
+
+// afterUploadMarker returns whether an upload comes after the markers of a
+// ListMultipartUploads request. As in s3, the upload ID marker only applies to
+// uploads of the key marker, and is ignored if there's no key marker.
+func afterUploadMarker(key, uploadID, keyMarker, uploadIDMarker string) bool {
+	if key != keyMarker {
+		return key > keyMarker
+	}
+	return uploadIDMarker != "" && uploadID > uploadIDMarker
+}
+
 func (c *controller) ListMultipart(r *http.Request, bucketName, keyMarker, uploadIDMarker string, maxUploads int) (*s2.ListMultipartResult, error) {
 	c.logger.Debugf("ListMultipart: bucketName=%+v, keyMarker=%+v, uploadIDMarker=%+v, maxUploads=%+v", bucketName, keyMarker, uploadIDMarker, maxUploads)
+	maxUploads = clampListLength(maxUploads)
 
 	pc, err := c.requestClient(r)
 	if err != nil {
@@ -103,44 +564,107 @@ func (c *controller) ListMultipart(r *http.Request, bucketName, keyMarker, uploa
 	if err != nil {
 		return nil, err
 	}
+	// make sure the bucket exists
+	if _, err := c.driver.bucketCapabilities(pc, r, bucket); err != nil {
+		return nil, err
+	}
+
+	// s2 doesn't pass these through, so they're read from the request
+	prefix := r.FormValue("prefix")
+	delimiter := r.FormValue("delimiter")
+	if delimiter != "" && delimiter != "/" {
+		return nil, invalidDelimiterError(r)
+	}
+	commonPrefixes, _ := r.Context().Value(commonPrefixesKey{}).(*[]string)
+
+	user, err := c.requestUser(pc)
+	if err != nil {
+		return nil, err
+	}
 
 	result := s2.ListMultipartResult{
 		Uploads: []*s2.Upload{},
 	}
 
-	globPattern := path.Join(bucket.Repo, bucket.Commit, "*", "*", ".keep")
-	err = pc.GlobFileF(c.repo, "master", globPattern, func(fileInfo *pfsClient.FileInfo) error {
-		_, _, key, uploadID, err := multipartKeepArgs(fileInfo.File.Path)
-		if err != nil {
+	// Uploads in the legacy layout are listed in order among the others
+	legacyUploads, err := c.legacyUploads(pc, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// add adds an upload to the result, unless it's filtered out
+	add := func(key, uploadID string, initiated time.Time) error {
+		if !strings.HasPrefix(key, prefix) {
 			return nil
 		}
 
-		if key <= keyMarker || uploadID <= uploadIDMarker {
+		if commonPrefix, ok := commonPrefix(key, prefix, delimiter); ok {
+			// fold the upload into its common prefix
+			if commonPrefixes != nil && commonPrefix > keyMarker && (len(*commonPrefixes) == 0 || (*commonPrefixes)[len(*commonPrefixes)-1] != commonPrefix) {
+				*commonPrefixes = append(*commonPrefixes, commonPrefix)
+			}
 			return nil
 		}
 
-		if len(result.Uploads) >= maxUploads {
-			if maxUploads > 0 {
-				result.IsTruncated = true
-			}
-			return errutil.ErrBreak
+		if !afterUploadMarker(key, uploadID, keyMarker, uploadIDMarker) {
+			return nil
 		}
 
-		timestamp, err := types.TimestampFromProto(fileInfo.Committed)
-		if err != nil {
-			return err
+		if len(result.Uploads) >= maxUploads {
+			// there's at least one more beyond this page
+			result.IsTruncated = true
+			return errutil.ErrBreak
 		}
 
 		result.Uploads = append(result.Uploads, &s2.Upload{
 			Key:          key,
 			UploadID:     uploadID,
-			Initiator:    defaultUser,
+			Initiator:    *user,
+			Owner:        *user,
 			StorageClass: globalStorageClass,
-			Initiated:    timestamp,
+			Initiated:    initiated,
 		})
 
 		return nil
+	}
+	// addLegacy adds the legacy uploads that come before 'key' and 'uploadID'
+	addLegacy := func(key, uploadID string) error {
+		for len(legacyUploads) > 0 {
+			upload := legacyUploads[0]
+			if upload.Key > key || (upload.Key == key && upload.UploadID > uploadID) {
+				return nil
+			}
+			if err := add(upload.Key, upload.UploadID, upload.Initiated); err != nil {
+				return err
+			}
+			legacyUploads = legacyUploads[1:]
+		}
+		return nil
+	}
+
+	globPattern := path.Join(bucket.Repo, bucket.Commit, encodeMultipartKey(prefix)+"*", "*", ".keep")
+	err = pc.GlobFileF(c.repo, c.branch, globPattern, func(fileInfo *pfsClient.FileInfo) error {
+		_, _, key, uploadID, err := multipartKeepArgs(fileInfo.File.Path)
+		if err != nil {
+			return nil
+		}
+		if err := addLegacy(key, uploadID); err != nil {
+			return err
+		}
+
+		timestamp, err := types.TimestampFromProto(fileInfo.Committed)
+		if err != nil {
+			return err
+		}
+		return add(key, uploadID, timestamp)
 	})
+	if err == nil && !result.IsTruncated {
+		for _, upload := range legacyUploads {
+			if err := add(upload.Key, upload.UploadID, upload.Initiated); err == errutil.ErrBreak {
+				break
+			}
+		}
+	}
 
 	return &result, err
 }
@@ -169,19 +693,45 @@ func (c *controller) InitMultipart(r *http.Request, bucketName, key string) (str
 		return "", s2.NotImplementedError(r)
 	}
 
+	sse, err := parseServerSideEncryption(r)
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := parseTagging(r)
+	if err != nil {
+		return "", err
+	}
+
 	uploadID := uuid.NewWithoutDashes()
 
-	_, err = pc.PutFileOverwrite(c.repo, "master", keepPath(bucket.Repo, bucket.Commit, key, uploadID), strings.NewReader(""), 0)
+	// PFS has no place for the content type, user metadata, encryption mode
+	// and tags, so they're kept with the upload until it's completed
+	if headers := metadataHeaders(r.Header); len(headers) > 0 || len(tags) > 0 {
+		if err := c.putMetadata(pc, uploadMetadataPath(bucket.Repo, bucket.Commit, key, uploadID), &objectMetadata{Headers: headers, Tags: tags}); err != nil {
+			return "", err
+		}
+	}
+
+	err = c.retry(func() error {
+		_, err := pc.PutFileOverwrite(c.repo, c.branch, keepPath(bucket.Repo, bucket.Commit, key, uploadID), strings.NewReader(""), 0)
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
 
+	setEncryptionHeader(r, sse)
 	return uploadID, nil
 }
 
 func (c *controller) AbortMultipart(r *http.Request, bucketName, key, uploadID string) error {
 	c.logger.Debugf("AbortMultipart: bucketName=%+v, key=%+v, uploadID=%+v", bucketName, key, uploadID)
 
+	if err := validateUploadID(r, uploadID); err != nil {
+		return err
+	}
+
 	pc, err := c.requestClient(r)
 	if err != nil {
 		return err
@@ -195,13 +745,17 @@ func (c *controller) AbortMultipart(r *http.Request, bucketName, key, uploadID s
 	if err != nil {
 		return err
 	}
+	if _, err := c.driver.bucketCapabilities(pc, r, bucket); err != nil {
+		return err
+	}
 
-	_, err = pc.InspectFile(c.repo, "master", keepPath(bucket.Repo, bucket.Commit, key, uploadID))
-	if err != nil {
-		return s2.NoSuchUploadError(r)
+	if err := c.ensureUpload(pc, r, bucket, key, uploadID); err != nil {
+		return err
 	}
 
-	err = pc.DeleteFile(c.repo, "master", parentDirPath(bucket.Repo, bucket.Commit, key, uploadID))
+	err = c.retry(func() error {
+		return pc.DeleteFile(c.repo, c.branch, parentDirPath(bucket.Repo, bucket.Commit, key, uploadID))
+	})
 	if err != nil {
 		return s2.InternalError(r, err)
 	}
@@ -212,6 +766,10 @@ func (c *controller) AbortMultipart(r *http.Request, bucketName, key, uploadID s
 func (c *controller) CompleteMultipart(r *http.Request, bucketName, key, uploadID string, parts []*s2.Part) (*s2.CompleteMultipartResult, error) {
 	c.logger.Debugf("CompleteMultipart: bucketName=%+v, key=%+v, uploadID=%+v, parts=%+v", bucketName, key, uploadID, parts)
 
+	if err := validateUploadID(r, uploadID); err != nil {
+		return nil, err
+	}
+
 	pc, err := c.requestClient(r)
 	if err != nil {
 		return nil, err
@@ -233,54 +791,101 @@ func (c *controller) CompleteMultipart(r *http.Request, bucketName, key, uploadI
 		return nil, s2.NotImplementedError(r)
 	}
 
-	_, err = pc.InspectFile(c.repo, "master", keepPath(bucket.Repo, bucket.Commit, key, uploadID))
-	if err != nil {
-		if pfsServer.IsFileNotFoundErr(err) {
-			return nil, s2.NoSuchUploadError(r)
+	if err := c.ensureUpload(pc, r, bucket, key, uploadID); err != nil {
+		if s2Err, ok := err.(*s2.Error); ok && s2Err.Code == "NoSuchUpload" {
+			// the upload may be gone because it was already completed
+			result, completedErr := c.completedMultipart(pc, r, bucket, key, uploadID, parts)
+			if completedErr != nil {
+				return nil, completedErr
+			}
+			if result != nil {
+				return result, nil
+			}
 		}
 		return nil, err
 	}
 
-	// check if the destination file already exists, and if so, delete it
-	_, err = pc.InspectFile(bucket.Repo, bucket.Commit, key)
-	if err != nil && !pfsServer.IsFileNotFoundErr(err) && !pfsServer.IsNoHeadErr(err) {
+	// s3 requires at least one part
+	if len(parts) == 0 {
+		return nil, s2.MalformedXMLError(r)
+	}
+	if err := c.validatePartOrder(r, parts); err != nil {
+		return nil, err
+	}
+
+	metadata, err := c.getMetadata(pc, uploadMetadataPath(bucket.Repo, bucket.Commit, key, uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency, err := c.completeMultipartConcurrencyFor(r)
+	if err != nil {
 		return nil, err
-	} else if err == nil {
-		err = pc.DeleteFile(bucket.Repo, bucket.Commit, key)
-		if err != nil {
-			if errutil.IsWriteToOutputBranchError(err) {
-				return nil, writeToOutputBranchError(r)
-			}
-			return nil, err
-		}
 	}
 
+	// Validate all of the parts before touching the destination file
+	partETags := make([]string, len(parts))
+	var eg errgroup.Group
+	limiter := limit.New(concurrency)
 	for i, part := range parts {
-		srcPath := chunkPath(bucket.Repo, bucket.Commit, key, uploadID, part.PartNumber)
+		i, part := i, part
+		limiter.Acquire()
+		eg.Go(func() error {
+			defer limiter.Release()
+			srcPath := chunkPath(bucket.Repo, bucket.Commit, key, uploadID, part.PartNumber)
+
+			var fileInfo *pfsClient.FileInfo
+			err := c.retry(func() error {
+				var err error
+				fileInfo, err = pc.InspectFile(c.repo, c.branch, srcPath)
+				return err
+			})
+			if err != nil {
+				if pfsServer.IsFileNotFoundErr(err) {
+					return s2.InvalidPartError(r)
+				}
+				return err
+			}
 
-		fileInfo, err := pc.InspectFile(c.repo, "master", srcPath)
-		if err != nil {
-			if pfsServer.IsFileNotFoundErr(err) {
-				return nil, s2.InvalidPartError(r)
+			etag, stored, err := c.chunkETag(pc, bucket, key, uploadID, part.PartNumber, fileInfo)
+			if err != nil {
+				return err
 			}
-			return nil, err
-		}
+			if !partETagMatches(part.ETag, etag, stored) {
+				return s2.InvalidPartError(r)
+			}
+			partETags[i] = etag
 
-		// Only verify the ETag when it's of the same length as PFS file
-		// hashes. This is because s3 clients will generally use md5 for
-		// ETags, and would otherwise fail.
-		expectedETag := fmt.Sprintf("%x", fileInfo.Hash)
-		if len(part.ETag) == len(expectedETag) && part.ETag != expectedETag {
-			return nil, s2.InvalidPartError(r)
-		}
+			return c.validatePartSize(r, i == len(parts)-1, fileInfo.SizeBytes)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
 
-		if i < len(parts)-1 && fileInfo.SizeBytes < 5*1024*1024 {
-			// each part, except for the last, is expected to be at least 5mb
-			// in s3
-			return nil, s2.EntityTooSmallError(r)
+	// check if the destination file already exists, and if so, delete it
+	var existing *pfsClient.FileInfo
+	err = c.retry(func() error {
+		var err error
+		existing, err = pc.InspectFile(bucket.Repo, bucket.Commit, key)
+		return err
+	})
+	if err != nil && !pfsServer.IsFileNotFoundErr(err) && !pfsServer.IsNoHeadErr(err) {
+		return nil, err
+	}
+	var existingETags []string
+	if err == nil {
+		if existingETags, err = c.objectETags(pc, bucket, key, existing); err != nil {
+			return nil, err
 		}
-
-		err = pc.CopyFile(c.repo, "master", srcPath, bucket.Repo, bucket.Commit, key, false)
+	}
+	if err := checkWritePreconditions(r, existing != nil, existingETags); err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		err = c.retry(func() error {
+			return pc.DeleteFile(bucket.Repo, bucket.Commit, key)
+		})
 		if err != nil {
 			if errutil.IsWriteToOutputBranchError(err) {
 				return nil, writeToOutputBranchError(r)
@@ -289,27 +894,114 @@ func (c *controller) CompleteMultipart(r *http.Request, bucketName, key, uploadI
 		}
 	}
 
-	err = pc.DeleteFile(c.repo, "master", parentDirPath(bucket.Repo, bucket.Commit, key, uploadID))
+	stagingPath := func(run int) string {
+		return path.Join(parentDirPath(bucket.Repo, bucket.Commit, key, uploadID), ".staging", strconv.Itoa(run))
+	}
+	err = copyParts(len(parts), concurrency,
+		func(run, i int, overwrite bool) error {
+			srcPath := chunkPath(bucket.Repo, bucket.Commit, key, uploadID, parts[i].PartNumber)
+			copyToStaging := func() error {
+				return pc.CopyFile(c.repo, c.branch, srcPath, c.repo, c.branch, stagingPath(run), overwrite)
+			}
+			if overwrite {
+				// Only overwriting copies are retried, since repeating an
+				// appending copy could append a part twice
+				return c.retry(copyToStaging)
+			}
+			return copyToStaging()
+		},
+		func(run int) error {
+			return pc.CopyFile(c.repo, c.branch, stagingPath(run), bucket.Repo, bucket.Commit, key, false)
+		},
+	)
 	if err != nil {
+		if errutil.IsWriteToOutputBranchError(err) {
+			return nil, writeToOutputBranchError(r)
+		}
 		return nil, err
 	}
 
-	fileInfo, err := pc.InspectFile(bucket.Repo, bucket.Commit, key)
+	var fileInfo *pfsClient.FileInfo
+	err = c.retry(func() error {
+		var err error
+		fileInfo, err = pc.InspectFile(bucket.Repo, bucket.Commit, key)
+		return err
+	})
 	if err != nil && !pfsServer.IsOutputCommitNotFinishedErr(err) {
 		return nil, err
 	}
 
-	result := s2.CompleteMultipartResult{Location: globalLocation}
+	etag, err := multipartETag(partETags)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s2.CompleteMultipartResult{Location: globalLocation, ETag: etag}
 	if fileInfo != nil {
-		result.ETag = fmt.Sprintf("%x", fileInfo.Hash)
 		result.Version = fileInfo.File.Commit.ID
 	}
 
+	if metadata == nil {
+		metadata = &objectMetadata{}
+	}
+	setEncryptionHeader(r, metadata.Headers[sseHeader])
+	if fileInfo != nil {
+		// The metadata is written before the upload is deleted, so that a
+		// retried request can always tell that the upload was completed
+		metadata.Hash = fmt.Sprintf("%x", fileInfo.Hash)
+		metadata.UploadID = uploadID
+		metadata.ETag = result.ETag
+		metadata.Version = result.Version
+		if err := c.putMetadata(pc, objectMetadataPath(bucket.Repo, bucket.Commit, key), metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.retry(func() error {
+		return pc.DeleteFile(c.repo, c.branch, parentDirPath(bucket.Repo, bucket.Commit, key, uploadID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &result, nil
 }
 
+// completedMultipart returns the result of a multipart upload that has
+// already been completed, e.g. by an earlier attempt of a retried
+// CompleteMultipart request. It returns nil if the upload wasn't completed
+// with the same parts, or if the object has since been overwritten.
+func (c *controller) completedMultipart(pc *client.APIClient, r *http.Request, bucket *Bucket, key, uploadID string, parts []*s2.Part) (*s2.CompleteMultipartResult, error) {
+	var fileInfo *pfsClient.FileInfo
+	err := c.retry(func() error {
+		var err error
+		fileInfo, err = pc.InspectFile(bucket.Repo, bucket.Commit, key)
+		return err
+	})
+	if err != nil {
+		if pfsServer.IsFileNotFoundErr(err) || pfsServer.IsNoHeadErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	metadata, err := c.getMetadata(pc, objectMetadataPath(bucket.Repo, bucket.Commit, key))
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil || !metadata.completedBy(uploadID, fmt.Sprintf("%x", fileInfo.Hash), parts) {
+		return nil, nil
+	}
+	setEncryptionHeader(r, metadata.Headers[sseHeader])
+	return &s2.CompleteMultipartResult{Location: globalLocation, ETag: metadata.ETag, Version: metadata.Version}, nil
+}
+
 func (c *controller) ListMultipartChunks(r *http.Request, bucketName, key, uploadID string, partNumberMarker, maxParts int) (*s2.ListMultipartChunksResult, error) {
 	c.logger.Debugf("ListMultipartChunks: bucketName=%+v, key=%+v, uploadID=%+v, partNumberMarker=%+v, maxParts=%+v", bucketName, key, uploadID, partNumberMarker, maxParts)
+	maxParts = clampListLength(maxParts)
+
+	if err := validateUploadID(r, uploadID); err != nil {
+		return nil, err
+	}
 
 	pc, err := c.requestClient(r)
 	if err != nil {
@@ -324,16 +1016,35 @@ func (c *controller) ListMultipartChunks(r *http.Request, bucketName, key, uploa
 	if err != nil {
 		return nil, err
 	}
+	if _, err := c.driver.bucketCapabilities(pc, r, bucket); err != nil {
+		return nil, err
+	}
+
+	if err := c.ensureUpload(pc, r, bucket, key, uploadID); err != nil {
+		return nil, err
+	}
+
+	user, err := c.requestUser(pc)
+	if err != nil {
+		return nil, err
+	}
 
 	result := s2.ListMultipartChunksResult{
-		Initiator:    &defaultUser,
-		Owner:        &defaultUser,
+		Initiator:    user,
+		Owner:        user,
 		StorageClass: globalStorageClass,
 		Parts:        []*s2.Part{},
 	}
 
+	details, _ := r.Context().Value(partDetailsKey{}).(map[int]partDetails)
+
 	globPattern := path.Join(parentDirPath(bucket.Repo, bucket.Commit, key, uploadID), "*")
-	err = pc.GlobFileF(c.repo, "master", globPattern, func(fileInfo *pfsClient.FileInfo) error {
+	err = pc.GlobFileF(c.repo, c.branch, globPattern, func(fileInfo *pfsClient.FileInfo) error {
+		if fileInfo.FileType == pfsClient.FileType_DIR {
+			// skip the directory of part etags
+			return nil
+		}
+
 		_, _, _, _, partNumber, err := multipartChunkArgs(fileInfo.File.Path)
 		if err != nil {
 			return nil
@@ -344,16 +1055,23 @@ func (c *controller) ListMultipartChunks(r *http.Request, bucketName, key, uploa
 		}
 
 		if len(result.Parts) >= maxParts {
-			if maxParts > 0 {
-				result.IsTruncated = true
-			}
+			// there's at least one more beyond this page
+			result.IsTruncated = true
 			return errutil.ErrBreak
 		}
 
+		etag, _, err := c.chunkETag(pc, bucket, key, uploadID, partNumber, fileInfo)
+		if err != nil {
+			return err
+		}
+
 		result.Parts = append(result.Parts, &s2.Part{
 			PartNumber: partNumber,
-			ETag:       fmt.Sprintf("%x", fileInfo.Hash),
+			ETag:       etag,
 		})
+		if details != nil {
+			details[partNumber] = newPartDetails(fileInfo)
+		}
 
 		return nil
 	})
@@ -364,6 +1082,22 @@ func (c *controller) ListMultipartChunks(r *http.Request, bucketName, key, uploa
 func (c *controller) UploadMultipartChunk(r *http.Request, bucketName, key, uploadID string, partNumber int, reader io.Reader) (string, error) {
 	c.logger.Debugf("UploadMultipartChunk: bucketName=%+v, key=%+v, uploadID=%+v partNumber=%+v", bucketName, key, uploadID, partNumber)
 
+	if err := validateUploadID(r, uploadID); err != nil {
+		return "", err
+	}
+
+	if err := c.validatePartNumber(r, partNumber); err != nil {
+		return "", err
+	}
+	if r.Header.Get("x-amz-copy-source") == "" {
+		if err := c.validatePartLength(r); err != nil {
+			return "", err
+		}
+		if err := validateDigestHeaders(r); err != nil {
+			return "", err
+		}
+	}
+
 	pc, err := c.requestClient(r)
 	if err != nil {
 		return "", err
@@ -377,25 +1111,453 @@ func (c *controller) UploadMultipartChunk(r *http.Request, bucketName, key, uplo
 	if err != nil {
 		return "", err
 	}
+	if _, err := c.driver.bucketCapabilities(pc, r, bucket); err != nil {
+		return "", err
+	}
+
+	if err := c.ensureUpload(pc, r, bucket, key, uploadID); err != nil {
+		return "", err
+	}
 
-	_, err = pc.InspectFile(c.repo, "master", keepPath(bucket.Repo, bucket.Commit, key, uploadID))
+	// The encryption mode is set when the upload is initiated, but parts may
+	// still include unsupported headers
+	if _, err := parseServerSideEncryption(r); err != nil {
+		return "", err
+	}
+	metadata, err := c.getMetadata(pc, uploadMetadataPath(bucket.Repo, bucket.Commit, key, uploadID))
 	if err != nil {
-		if pfsServer.IsFileNotFoundErr(err) {
-			return "", s2.NoSuchUploadError(r)
-		}
 		return "", err
 	}
+	if metadata != nil {
+		setEncryptionHeader(r, metadata.Headers[sseHeader])
+	}
 
-	path := chunkPath(bucket.Repo, bucket.Commit, key, uploadID, partNumber)
-	_, err = pc.PutFileOverwrite(c.repo, "master", path, reader, 0)
+	if r.Header.Get("x-amz-copy-source") != "" {
+		// s2 routes UploadPartCopy requests here as well
+		return c.uploadMultipartChunkCopy(r, pc, bucket, key, uploadID, partNumber)
+	}
+
+	// The part is streamed to PFS, computing its md5 as it's uploaded, so that
+	// part ETags (and the ETag of the completed object) match what s3 would
+	// produce. It's only moved into place once its size and digests have been
+	// verified, so that, as in s3, a bad upload leaves any earlier upload of
+	// the part intact.
+	body := newPartReader(reader, c.maxPartSize)
+	incomingPath := incomingChunkPath(bucket.Repo, bucket.Commit, key, uploadID, partNumber, uuid.NewWithoutDashes())
+	err = c.putPart(body, func(reader io.Reader) error {
+		_, err := pc.PutFileOverwrite(c.repo, c.branch, incomingPath, reader, 0)
+		return err
+	})
+	if body.tooLarge() {
+		err = s2.EntityTooLargeError(r)
+	} else if err == nil {
+		err = body.verifyDigests(r)
+	}
+	if err == nil {
+		err = c.retry(func() error {
+			return pc.CopyFile(c.repo, c.branch, incomingPath, c.repo, c.branch, chunkPath(bucket.Repo, bucket.Commit, key, uploadID, partNumber), true)
+		})
+	}
+	// don't leave a partial, corrupt or already copied part behind
+	deleteErr := c.retry(func() error {
+		return pc.DeleteFile(c.repo, c.branch, incomingPath)
+	})
+	if deleteErr != nil {
+		c.logger.Errorf("could not delete incoming part %s: %v", incomingPath, deleteErr)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	etag := fmt.Sprintf("%x", body.md5.Sum(nil))
+	if err := c.putChunkETag(pc, bucket, key, uploadID, partNumber, etag); err != nil {
+		return "", err
+	}
+	return etag, nil
+}
+
+// uploadMultipartChunkCopy implements UploadPartCopy, where the contents of
+// a part come from (a range of) an existing object, rather than from the
+// request body.
+func (c *controller) uploadMultipartChunkCopy(r *http.Request, pc *client.APIClient, bucket *Bucket, key, uploadID string, partNumber int) (string, error) {
+	srcBucketName, srcKey, srcVersion, err := parseCopySource(r)
+	if err != nil {
+		return "", err
+	}
+
+	srcBucket, err := c.driver.bucket(pc, r, srcBucketName)
+	if err != nil {
+		return "", err
+	}
+	srcBucketCaps, err := c.driver.bucketCapabilities(pc, r, srcBucket)
 	if err != nil {
 		return "", err
 	}
+	if !srcBucketCaps.readable {
+		return "", s2.NoSuchKeyError(r)
+	}
+
+	if srcBucketCaps.historicVersions && srcVersion != "" {
+		commitInfo, err := pc.InspectCommit(srcBucket.Repo, srcVersion)
+		if err != nil {
+			return "", maybeNotFoundError(r, err)
+		}
+		if commitInfo.Branch.Name != srcBucket.Commit {
+			return "", s2.NoSuchVersionError(r)
+		}
+		srcBucket.Commit = commitInfo.Commit.ID
+	}
 
-	fileInfo, err := pc.InspectFile(c.repo, "master", path)
+	fileInfo, err := pc.InspectFile(srcBucket.Repo, srcBucket.Commit, srcKey)
+	if err != nil {
+		return "", maybeNotFoundError(r, err)
+	}
+	if fileInfo.FileType != pfsClient.FileType_FILE {
+		return "", s2.NoSuchKeyError(r)
+	}
+
+	// Clients (e.g. minio) use these to make sure the source object doesn't
+	// change between parts
+	srcETag := fmt.Sprintf("%x", fileInfo.Hash)
+	if ifMatch := strings.Trim(r.Header.Get("x-amz-copy-source-if-match"), `"`); ifMatch != "" && ifMatch != srcETag {
+		return "", s2.PreconditionFailedError(r)
+	}
+	if ifNoneMatch := strings.Trim(r.Header.Get("x-amz-copy-source-if-none-match"), `"`); ifNoneMatch != "" && ifNoneMatch == srcETag {
+		return "", s2.PreconditionFailedError(r)
+	}
+
+	offset, size, err := parseCopySourceRange(r, fileInfo.SizeBytes)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%x", fileInfo.Hash), nil
+	hash := md5.New()
+	path := chunkPath(bucket.Repo, bucket.Commit, key, uploadID, partNumber)
+	if offset == 0 && uint64(size) == fileInfo.SizeBytes {
+		err := c.retry(func() error {
+			return pc.CopyFile(srcBucket.Repo, srcBucket.Commit, srcKey, c.repo, c.branch, path, true)
+		})
+		if err != nil {
+			return "", err
+		}
+		err = c.retry(func() error {
+			hash.Reset()
+			return pc.GetFile(c.repo, c.branch, path, 0, 0, hash)
+		})
+		if err != nil {
+			return "", err
+		}
+	} else {
+		// PFS can't copy part of a file, so stream the range through instead
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(pc.GetFile(srcBucket.Repo, srcBucket.Commit, srcKey, offset, size, pw))
+		}()
+		_, err := pc.PutFileOverwrite(c.repo, c.branch, path, io.TeeReader(pr, hash), 0)
+		pr.CloseWithError(err)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	etag := fmt.Sprintf("%x", hash.Sum(nil))
+	if err := c.putChunkETag(pc, bucket, key, uploadID, partNumber, etag); err != nil {
+		return "", err
+	}
+	return etag, nil
+}
+
+func (c *controller) putChunkETag(pc *client.APIClient, bucket *Bucket, key, uploadID string, partNumber int, etag string) error {
+	return c.retry(func() error {
+		_, err := pc.PutFileOverwrite(c.repo, c.branch, chunkETagPath(bucket.Repo, bucket.Commit, key, uploadID, partNumber), strings.NewReader(etag), 0)
+		return err
+	})
+}
+
+// parseCopySource parses the `x-amz-copy-source` header of an UploadPartCopy
+// request, which is of the form `[/]<bucket>/<key>[?versionId=<version>]`.
+func parseCopySource(r *http.Request) (bucket, key, version string, err error) {
+	srcURL, err := url.Parse(r.Header.Get("x-amz-copy-source"))
+	if err != nil {
+		return "", "", "", s2.InvalidArgumentError(r)
+	}
+	srcPath := strings.SplitN(strings.TrimPrefix(srcURL.Path, "/"), "/", 2)
+	if len(srcPath) != 2 {
+		return "", "", "", s2.InvalidArgumentError(r)
+	}
+	if srcPath[0] == "" {
+		return "", "", "", s2.InvalidBucketNameError(r)
+	}
+	if srcPath[1] == "" || strings.HasSuffix(srcPath[1], "/") {
+		return "", "", "", s2.NoSuchKeyError(r)
+	}
+	return srcPath[0], srcPath[1], srcURL.Query().Get("versionId"), nil
+}
+
+// parseCopySourceRange parses the `x-amz-copy-source-range` header of an
+// UploadPartCopy request, which is of the form `bytes=<first>-<last>`, with
+// both offsets inclusive. It returns the offset and size of the range to
+// copy from an object of `objectSize` bytes; if the header isn't set, this is
+// the whole object.
+func parseCopySourceRange(r *http.Request, objectSize uint64) (int64, int64, error) {
+	header := r.Header.Get("x-amz-copy-source-range")
+	if header == "" {
+		return 0, int64(objectSize), nil
+	}
+
+	bounds := strings.SplitN(strings.TrimPrefix(header, "bytes="), "-", 2)
+	if !strings.HasPrefix(header, "bytes=") || len(bounds) != 2 {
+		return 0, 0, s2.InvalidArgumentError(r)
+	}
+	first, err := strconv.ParseUint(bounds[0], 10, 64)
+	if err != nil {
+		return 0, 0, s2.InvalidArgumentError(r)
+	}
+	last, err := strconv.ParseUint(bounds[1], 10, 64)
+	if err != nil {
+		return 0, 0, s2.InvalidArgumentError(r)
+	}
+	if first > last || last >= objectSize {
+		return 0, 0, invalidRangeError(r)
+	}
+	return int64(first), int64(last - first + 1), nil
+}
+
+// copyPartResult is the response body of a successful UploadPartCopy request
+type copyPartResult struct {
+	XMLName      xml.Name  `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyPartResult"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+}
+
+// uploadPartCopyResponseWriter wraps the response of an UploadPartCopy
+// request. s2 handles these as regular part uploads, which only set the ETag
+// header, whereas s3 clients expect the result in the response body.
+type uploadPartCopyResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *uploadPartCopyResponseWriter) WriteHeader(status int) {
+	w.status = status
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", "application/xml")
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// serveUploadPartCopy serves an UploadPartCopy request via `handler`, writing
+// a `CopyPartResult` body if the part was successfully copied.
+func serveUploadPartCopy(logger *logrus.Entry, handler http.Handler, w http.ResponseWriter, r *http.Request) {
+	cw := &uploadPartCopyResponseWriter{ResponseWriter: w}
+	handler.ServeHTTP(cw, r)
+	if cw.status != http.StatusOK {
+		return
+	}
+
+	result := copyPartResult{LastModified: time.Now(), ETag: w.Header().Get("ETag")}
+	body, err := xml.Marshal(result)
+	if err != nil {
+		logger.Errorf("could not marshal copy part result: %v", err)
+		return
+	}
+	if _, err := w.Write(append([]byte(xml.Header), body...)); err != nil {
+		logger.Errorf("could not write copy part result: %v", err)
+	}
+}
+
+// commonPrefix returns the common prefix that `key` is grouped under when
+// listing with `prefix` and `delimiter`, i.e. `key` up to and including the
+// first occurrence of the delimiter after the prefix, if any.
+func commonPrefix(key, prefix, delimiter string) (string, bool) {
+	if delimiter == "" {
+		return "", false
+	}
+	i := strings.Index(key[len(prefix):], delimiter)
+	if i < 0 {
+		return "", false
+	}
+	return key[:len(prefix)+i+len(delimiter)], true
+}
+
+// commonPrefixesKey is the request context key of the common prefixes found
+// by ListMultipart, which s2 has no way of returning itself
+type commonPrefixesKey struct{}
+
+// listMultipartUploadsResult is the response body of a ListMultipartUploads
+// request, as written by s2, with the addition of the prefix, delimiter and
+// common prefixes
+type listMultipartUploadsResult struct {
+	XMLName            xml.Name             `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListMultipartUploadsResult"`
+	Bucket             string               `xml:"Bucket"`
+	KeyMarker          string               `xml:"KeyMarker"`
+	UploadIDMarker     string               `xml:"UploadIdMarker"`
+	NextKeyMarker      string               `xml:"NextKeyMarker"`
+	NextUploadIDMarker string               `xml:"NextUploadIdMarker"`
+	Prefix             string               `xml:"Prefix"`
+	Delimiter          string               `xml:"Delimiter"`
+	MaxUploads         int                  `xml:"MaxUploads"`
+	IsTruncated        bool                 `xml:"IsTruncated"`
+	Uploads            []*s2.Upload         `xml:"Upload"`
+	CommonPrefixes     []*s2.CommonPrefixes `xml:"CommonPrefixes"`
+}
+
+// serveListMultipart serves a ListMultipartUploads request with a delimiter
+// via `handler`, adding the common prefixes found by ListMultipart to the
+// response.
+func serveListMultipart(logger *logrus.Entry, handler http.Handler, w http.ResponseWriter, r *http.Request) {
+	var commonPrefixes []string
+	r = r.WithContext(context.WithValue(r.Context(), commonPrefixesKey{}, &commonPrefixes))
+
+	var result listMultipartUploadsResult
+	serveRewrittenXML(logger, handler, w, r, &result, func() {
+		result.Prefix = r.FormValue("prefix")
+		result.Delimiter = r.FormValue("delimiter")
+		for _, prefix := range commonPrefixes {
+			result.CommonPrefixes = append(result.CommonPrefixes, &s2.CommonPrefixes{Prefix: prefix, Owner: defaultUser})
+		}
+	})
+}
+
+// partDetailsKey is the request context key of the sizes and modification
+// times of the parts found by ListMultipartChunks, which s2 has no way of
+// returning itself
+type partDetailsKey struct{}
+
+// partDetails are the details of a part that s2 doesn't include in
+// `s2.Part`
+type partDetails struct {
+	Size         uint64
+	LastModified *time.Time
+}
+
+// newPartDetails returns the details of a part from its file info. The last
+// modified time is left unset if the part's commit hasn't been finished.
+func newPartDetails(fileInfo *pfsClient.FileInfo) partDetails {
+	details := partDetails{Size: fileInfo.SizeBytes}
+	if fileInfo.Committed != nil {
+		if lastModified, err := types.TimestampFromProto(fileInfo.Committed); err == nil {
+			// some clients (e.g. minio-python) can't handle sub-seconds in
+			// datetime output
+			lastModified = lastModified.UTC().Round(time.Second)
+			details.LastModified = &lastModified
+		}
+	}
+	return details
+}
+
+// listPartsResult is the response body of a ListParts request, as written by
+// s2, with the addition of the size and modification time of each part
+type listPartsResult struct {
+	XMLName              xml.Name      `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListPartsResult"`
+	Bucket               string        `xml:"Bucket"`
+	Key                  string        `xml:"Key"`
+	UploadID             string        `xml:"UploadId"`
+	Initiator            *s2.User      `xml:"Initiator"`
+	Owner                *s2.User      `xml:"Owner"`
+	StorageClass         string        `xml:"StorageClass"`
+	PartNumberMarker     int           `xml:"PartNumberMarker"`
+	NextPartNumberMarker int           `xml:"NextPartNumberMarker"`
+	MaxParts             int           `xml:"MaxParts"`
+	IsTruncated          bool          `xml:"IsTruncated"`
+	Parts                []*partResult `xml:"Part"`
+}
+
+type partResult struct {
+	PartNumber   int        `xml:"PartNumber"`
+	LastModified *time.Time `xml:"LastModified,omitempty"`
+	ETag         string     `xml:"ETag"`
+	Size         uint64     `xml:"Size"`
+}
+
+// serveListParts serves a ListParts request via `handler`, adding the size
+// and modification time found by ListMultipartChunks to each part.
+func serveListParts(logger *logrus.Entry, handler http.Handler, w http.ResponseWriter, r *http.Request) {
+	details := make(map[int]partDetails)
+	r = r.WithContext(context.WithValue(r.Context(), partDetailsKey{}, details))
+
+	var result listPartsResult
+	serveRewrittenXML(logger, handler, w, r, &result, func() {
+		for _, part := range result.Parts {
+			if d, ok := details[part.PartNumber]; ok {
+				part.Size = d.Size
+				part.LastModified = d.LastModified
+			}
+		}
+	})
+}
+
+// isListParts returns whether a request is a ListParts request
+func isListParts(r *http.Request) bool {
+	_, ok := r.URL.Query()["uploadId"]
+	return ok && r.Method == http.MethodGet
+}
+
+// isListMultipartWithDelimiter returns whether a request is a
+// ListMultipartUploads request with a delimiter
+func isListMultipartWithDelimiter(r *http.Request) bool {
+	_, ok := r.URL.Query()["uploads"]
+	return ok && r.Method == http.MethodGet && r.URL.Query().Get("delimiter") != ""
+}
+
+// isUploadPartCopy returns whether a request is an UploadPartCopy request
+func isUploadPartCopy(r *http.Request) bool {
+	_, ok := r.URL.Query()["uploadId"]
+	return ok && r.Method == http.MethodPut && r.Header.Get("x-amz-copy-source") != ""
+}
+
+// completeMultipartPayload is the payload of a CompleteMultipartUpload request
+type completeMultipartPayload struct {
+	XMLName xml.Name   `xml:"CompleteMultipartUpload"`
+	Parts   []*s2.Part `xml:"Part"`
+}
+
+// attachCompleteMultipartHandler wraps s2's CompleteMultipartUpload handler,
+// so that requests without any parts are rejected with a MalformedXML error,
+// as in s3, rather than s2's InvalidPartOrder error. s2 has already read the
+// (small) request body into memory by the time the handler is called.
+func attachCompleteMultipartHandler(logger *logrus.Entry, router *mux.Router) error {
+	attached := false
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		pathTemplate, err := route.GetPathTemplate()
+		if err != nil || !strings.Contains(pathTemplate, "{key") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil || len(methods) != 1 || methods[0] != http.MethodPost {
+			return nil
+		}
+		queries, err := route.GetQueriesTemplates()
+		if err != nil {
+			return nil
+		}
+		for _, query := range queries {
+			if strings.HasPrefix(query, "uploadId=") {
+				handler := route.GetHandler()
+				route.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, err := ioutil.ReadAll(r.Body)
+					if err != nil {
+						s2.WriteError(logger, w, r, err)
+						return
+					}
+					var payload completeMultipartPayload
+					if err := xml.Unmarshal(body, &payload); err == nil && len(payload.Parts) == 0 {
+						s2.WriteError(logger, w, r, s2.MalformedXMLError(r))
+						return
+					}
+					r.Body = ioutil.NopCloser(bytes.NewReader(body))
+					handler.ServeHTTP(w, r)
+				})
+				attached = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !attached {
+		return errors.New("could not find the complete multipart upload route")
+	}
+	return nil
 }
@@ -0,0 +1,667 @@
+package s3
+
+import (
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	pfsClient "github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+	"github.com/pachyderm/s2"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMultipartPathArgs(t *testing.T) {
+	keys := []string{
+		"file",
+		"data/2023/file.csv",
+		"a/b/c/d/e/f",
+		"dir/123/456",
+		"dotted/../name/./.file.",
+		"trailing/slash/",
+		"/leading//double",
+		"ünïcødé/文件/🙂",
+		"glob*chars?[x]{y}",
+	}
+	for _, key := range keys {
+		// PFS returns paths with a leading slash
+		repo, branch, parsedKey, uploadID, partNumber, err := multipartChunkArgs("/" + chunkPath("repo", "branch", key, "uploadid", 42))
+		require.NoError(t, err)
+		require.Equal(t, "repo", repo)
+		require.Equal(t, "branch", branch)
+		require.Equal(t, key, parsedKey)
+		require.Equal(t, "uploadid", uploadID)
+		require.Equal(t, 42, partNumber)
+
+		repo, branch, parsedKey, uploadID, err = multipartKeepArgs("/" + keepPath("repo", "branch", key, "uploadid"))
+		require.NoError(t, err)
+		require.Equal(t, "repo", repo)
+		require.Equal(t, "branch", branch)
+		require.Equal(t, key, parsedKey)
+		require.Equal(t, "uploadid", uploadID)
+	}
+
+	// metadata stored alongside parts shouldn't be mistaken for parts
+	_, _, _, _, _, err := multipartChunkArgs("/" + chunkETagPath("repo", "branch", "key", "uploadid", 1))
+	require.YesError(t, err)
+	_, _, _, _, _, err = multipartChunkArgs("/" + keepPath("repo", "branch", "key", "uploadid"))
+	require.YesError(t, err)
+	_, _, _, _, err = multipartKeepArgs("/" + chunkPath("repo", "branch", "key", "uploadid", 1))
+	require.YesError(t, err)
+}
+
+func TestLegacyMultipartPathArgs(t *testing.T) {
+	uploadID := uuid.NewWithoutDashes()
+	for _, key := range []string{"file", "data/2023/file.csv", "ünïcødé/文件", "abc", "dir/cafe"} {
+		legacyPath, ok := legacyParentDirPath("repo", "branch", key, uploadID)
+		require.True(t, ok, key)
+		repo, branch, parsedKey, parsedUploadID, err := legacyMultipartKeepArgs("/" + path.Join(legacyPath, ".keep"))
+		require.NoError(t, err, key)
+		require.Equal(t, "repo", repo)
+		require.Equal(t, "branch", branch)
+		require.Equal(t, key, parsedKey)
+		require.Equal(t, uploadID, parsedUploadID)
+
+		// uploads in the current layout aren't mistaken for legacy ones
+		_, _, _, _, err = legacyMultipartKeepArgs("/" + keepPath("repo", "branch", key, uploadID))
+		require.YesError(t, err, key)
+	}
+
+	// a legacy key that happens to be valid hex which doesn't decode to UTF-8
+	// isn't mistaken for an upload in the current layout
+	legacyPath, ok := legacyParentDirPath("repo", "branch", "ff", uploadID)
+	require.True(t, ok)
+	_, _, _, _, err := multipartKeepArgs("/" + path.Join(legacyPath, ".keep"))
+	require.YesError(t, err)
+	_, _, key, _, err := legacyMultipartKeepArgs("/" + path.Join(legacyPath, ".keep"))
+	require.NoError(t, err)
+	require.Equal(t, "ff", key)
+
+	// keys that path.Join cleans have no legacy path
+	for _, key := range []string{"dotted/../name", "trailing/slash/", "/leading", "double//slash"} {
+		_, ok := legacyParentDirPath("repo", "branch", key, uploadID)
+		require.False(t, ok, key)
+	}
+
+	// nor do paths whose upload ID wasn't issued by InitMultipart
+	_, _, _, _, err = legacyMultipartKeepArgs("/repo/branch/key/uploadid/.keep")
+	require.YesError(t, err)
+}
+
+func TestValidateUploadID(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/bucket/key", nil)
+	require.NoError(t, validateUploadID(r, uuid.NewWithoutDashes()))
+
+	valid := uuid.NewWithoutDashes()
+	for _, uploadID := range []string{
+		"",
+		"missing",
+		"..",
+		"../" + valid,
+		valid + "/..",
+		valid + "/1",
+		"/" + valid,
+		"*",
+		valid + "*",
+		strings.ToUpper(valid),
+		uuid.New(),
+	} {
+		err := validateUploadID(r, uploadID)
+		require.YesError(t, err, uploadID)
+		require.Equal(t, "NoSuchUpload", err.(*s2.Error).Code)
+	}
+}
+
+func TestValidatePartNumber(t *testing.T) {
+	c := &controller{maxAllowedParts: maxAllowedParts}
+	r := httptest.NewRequest("PUT", "/bucket/key?uploadId=foo", nil)
+
+	for _, partNumber := range []int{1, 10000} {
+		require.NoError(t, c.validatePartNumber(r, partNumber))
+	}
+	for _, partNumber := range []int{-1, 0, 10001} {
+		err := c.validatePartNumber(r, partNumber)
+		require.YesError(t, err)
+		require.Equal(t, "InvalidArgument", err.(*s2.Error).Code)
+	}
+}
+
+func TestValidatePartOrder(t *testing.T) {
+	c := &controller{maxAllowedParts: maxAllowedParts}
+	r := httptest.NewRequest("POST", "/bucket/key?uploadId=foo", nil)
+	partList := func(partNumbers ...int) []*s2.Part {
+		var parts []*s2.Part
+		for _, partNumber := range partNumbers {
+			parts = append(parts, &s2.Part{PartNumber: partNumber})
+		}
+		return parts
+	}
+
+	// contiguous and gapped lists are both valid, as in s3
+	require.NoError(t, c.validatePartOrder(r, partList(1, 2, 3)))
+	require.NoError(t, c.validatePartOrder(r, partList(1, 3, 7)))
+
+	for _, parts := range [][]*s2.Part{partList(2, 1, 3), partList(1, 2, 2, 3), partList(1, 1)} {
+		err := c.validatePartOrder(r, parts)
+		require.YesError(t, err)
+		require.Equal(t, "InvalidPartOrder", err.(*s2.Error).Code)
+	}
+	for _, parts := range [][]*s2.Part{partList(0, 1), partList(1, 10001)} {
+		err := c.validatePartOrder(r, parts)
+		require.YesError(t, err)
+		require.Equal(t, "InvalidPart", err.(*s2.Error).Code)
+	}
+}
+
+func TestValidatePartSize(t *testing.T) {
+	r := httptest.NewRequest("POST", "/bucket/key?uploadId=foo", nil)
+	for _, minPartSize := range []uint64{defaultMinPartSize, 1024} {
+		c := &controller{minPartSize: minPartSize}
+		require.NoError(t, c.validatePartSize(r, false, minPartSize))
+		require.NoError(t, c.validatePartSize(r, false, minPartSize+1))
+		err := c.validatePartSize(r, false, minPartSize-1)
+		require.YesError(t, err)
+		require.Equal(t, "EntityTooSmall", err.(*s2.Error).Code)
+
+		// the last part is exempt
+		require.NoError(t, c.validatePartSize(r, true, minPartSize-1))
+		require.NoError(t, c.validatePartSize(r, true, 0))
+	}
+}
+
+func TestWithMinPartSize(t *testing.T) {
+	c := &controller{minPartSize: defaultMinPartSize, maxPartSize: defaultMaxPartSize}
+	for _, size := range []int64{1024, 0, defaultMaxPartSize} {
+		require.NoError(t, WithMinPartSize(size)(c))
+		require.Equal(t, uint64(size), c.minPartSize)
+	}
+	require.YesError(t, WithMinPartSize(-1)(c))
+	require.YesError(t, WithMinPartSize(defaultMaxPartSize+1)(c))
+	require.Equal(t, uint64(defaultMaxPartSize), c.minPartSize)
+}
+
+func TestCheckWritePreconditions(t *testing.T) {
+	etags := []string{"abc", "def-2"}
+	check := func(exists bool, headers map[string]string) error {
+		r := httptest.NewRequest("POST", "/bucket/key?uploadId=id", nil)
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+		if !exists {
+			return checkWritePreconditions(r, false, nil)
+		}
+		return checkWritePreconditions(r, true, etags)
+	}
+
+	// no preconditions
+	require.NoError(t, check(false, nil))
+	require.NoError(t, check(true, nil))
+
+	// create if absent
+	require.NoError(t, check(false, map[string]string{"If-None-Match": "*"}))
+	require.YesError(t, check(true, map[string]string{"If-None-Match": "*"}))
+	require.YesError(t, check(true, map[string]string{"If-None-Match": `"abc"`}))
+	require.NoError(t, check(true, map[string]string{"If-None-Match": `"xyz"`}))
+
+	// overwrite if matches
+	require.NoError(t, check(true, map[string]string{"If-Match": `"abc"`}))
+	require.NoError(t, check(true, map[string]string{"If-Match": `"xyz", "def-2"`}))
+	require.NoError(t, check(true, map[string]string{"If-Match": "*"}))
+	require.YesError(t, check(true, map[string]string{"If-Match": `"xyz"`}))
+	require.YesError(t, check(false, map[string]string{"If-Match": `"abc"`}))
+	require.YesError(t, check(false, map[string]string{"If-Match": "*"}))
+
+	err := check(true, map[string]string{"If-None-Match": "*"})
+	require.Equal(t, "PreconditionFailed", err.(*s2.Error).Code)
+}
+
+func TestMultipartETag(t *testing.T) {
+	parts := [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")}
+
+	// s3 computes the etag of a multipart object as the md5 of the
+	// concatenated binary md5s of each part, suffixed with the part count
+	var partETags []string
+	var concatenated []byte
+	for _, part := range parts {
+		sum := md5.Sum(part)
+		partETags = append(partETags, fmt.Sprintf("%x", sum))
+		concatenated = append(concatenated, sum[:]...)
+	}
+
+	etag, err := multipartETag(partETags)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%x-3", md5.Sum(concatenated)), etag)
+
+	_, err = multipartETag([]string{"not-hex"})
+	require.YesError(t, err)
+}
+
+func TestPartETagMatches(t *testing.T) {
+	md5ETag := fmt.Sprintf("%x", md5.Sum([]byte("foo")))
+	pfsHash := fmt.Sprintf("%x", sha512.Sum512([]byte("foo")))
+
+	// stored ETags are always compared
+	require.True(t, partETagMatches(md5ETag, md5ETag, true))
+	require.True(t, partETagMatches(`"`+md5ETag+`"`, md5ETag, true))
+	require.False(t, partETagMatches(md5ETag, fmt.Sprintf("%x", md5.Sum([]byte("bar"))), true))
+	require.False(t, partETagMatches(pfsHash, md5ETag, true))
+
+	// parts uploaded before ETags were tracked accept the md5 ETag the client
+	// was given, but are still checked against a PFS hash
+	require.True(t, partETagMatches(md5ETag, pfsHash, false))
+	require.True(t, partETagMatches(pfsHash, pfsHash, false))
+	require.False(t, partETagMatches(fmt.Sprintf("%x", sha512.Sum512([]byte("bar"))), pfsHash, false))
+
+	// a part without an ETag isn't checked
+	require.True(t, partETagMatches("", md5ETag, true))
+}
+
+func TestParseCopySource(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/bucket/key?uploadId=foo&partNumber=1", nil)
+	for _, copySource := range []string{"master.repo/dir/file", "/master.repo/dir/file", "/master.repo/dir%2Ffile?versionId=abc"} {
+		r.Header.Set("x-amz-copy-source", copySource)
+		bucket, key, _, err := parseCopySource(r)
+		require.NoError(t, err)
+		require.Equal(t, "master.repo", bucket)
+		require.Equal(t, "dir/file", key)
+	}
+
+	r.Header.Set("x-amz-copy-source", "/master.repo/file?versionId=abc")
+	_, _, version, err := parseCopySource(r)
+	require.NoError(t, err)
+	require.Equal(t, "abc", version)
+
+	for _, copySource := range []string{"master.repo", "/master.repo/", "//file", "/master.repo/dir/"} {
+		r.Header.Set("x-amz-copy-source", copySource)
+		_, _, _, err := parseCopySource(r)
+		require.YesError(t, err)
+	}
+}
+
+func TestParseCopySourceRange(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/bucket/key?uploadId=foo&partNumber=1", nil)
+
+	// no range copies the whole object
+	offset, size, err := parseCopySourceRange(r, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), offset)
+	require.Equal(t, int64(10), size)
+
+	r.Header.Set("x-amz-copy-source-range", "bytes=2-5")
+	offset, size, err = parseCopySourceRange(r, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), offset)
+	require.Equal(t, int64(4), size)
+
+	r.Header.Set("x-amz-copy-source-range", "bytes=0-9")
+	offset, size, err = parseCopySourceRange(r, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), offset)
+	require.Equal(t, int64(10), size)
+
+	for _, header := range []string{"2-5", "bytes=5", "bytes=a-5", "bytes=-5"} {
+		r.Header.Set("x-amz-copy-source-range", header)
+		_, _, err = parseCopySourceRange(r, 10)
+		require.YesError(t, err)
+		require.Equal(t, "InvalidArgument", err.(*s2.Error).Code)
+	}
+	for _, header := range []string{"bytes=5-2", "bytes=0-10"} {
+		r.Header.Set("x-amz-copy-source-range", header)
+		_, _, err = parseCopySourceRange(r, 10)
+		require.YesError(t, err)
+		require.Equal(t, "InvalidRange", err.(*s2.Error).Code)
+	}
+}
+
+func TestServeUploadPartCopy(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/bucket/key?uploadId=foo&partNumber=1", nil)
+	require.False(t, isUploadPartCopy(r))
+	r.Header.Set("x-amz-copy-source", "/master.repo/file")
+	require.True(t, isUploadPartCopy(r))
+
+	// a successful copy should include the result in the body
+	w := httptest.NewRecorder()
+	serveUploadPartCopy(logrus.NewEntry(logrus.New()), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(http.StatusOK)
+	}), w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+	require.True(t, strings.Contains(w.Body.String(), "<ETag>&#34;abc&#34;</ETag>"))
+
+	// errors should be passed through untouched
+	w = httptest.NewRecorder()
+	serveUploadPartCopy(logrus.NewEntry(logrus.New()), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s2.WriteError(logrus.NewEntry(logrus.New()), w, r, s2.NoSuchUploadError(r))
+	}), w, r)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.True(t, strings.Contains(w.Body.String(), "NoSuchUpload"))
+	require.False(t, strings.Contains(w.Body.String(), "CopyPartResult"))
+}
+
+func TestCopyParts(t *testing.T) {
+	for _, concurrency := range []int{1, 7, 10, 200} {
+		numParts := 100
+
+		// Simulate appending copies, tracking how many run at once
+		var mu sync.Mutex
+		staging := make(map[int][]int)
+		var dst []int
+		var inFlight, maxInFlight int32
+		copyToStaging := func(run, part int, overwrite bool) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if overwrite {
+				staging[run] = nil
+			}
+			staging[run] = append(staging[run], part)
+			return nil
+		}
+		copyFromStaging := func(run int) error {
+			dst = append(dst, staging[run]...)
+			return nil
+		}
+		// leftovers from a previous attempt should be overwritten
+		staging[0] = []int{-1}
+
+		require.NoError(t, copyParts(numParts, concurrency, copyToStaging, copyFromStaging))
+		require.Equal(t, numParts, len(dst))
+		for i, part := range dst {
+			require.Equal(t, i, part)
+		}
+		require.True(t, maxInFlight <= int32(concurrency))
+		if concurrency > 1 {
+			require.True(t, maxInFlight > 1)
+		}
+	}
+}
+
+func TestCompleteMultipartConcurrency(t *testing.T) {
+	// The controller's concurrency is clamped to sane bounds
+	c := &controller{completeMultipartConcurrency: defaultCompleteMultipartConcurrency}
+	for concurrency, expected := range map[int]int{
+		20:   20,
+		0:    1,
+		-5:   1,
+		1000: maxCompleteMultipartConcurrency,
+	} {
+		require.NoError(t, WithCompleteMultipartConcurrency(concurrency)(c))
+		require.Equal(t, expected, c.completeMultipartConcurrency)
+	}
+
+	// Requests use the controller's concurrency by default, and may lower it,
+	// but not raise it
+	require.NoError(t, WithCompleteMultipartConcurrency(20)(c))
+	for header, expected := range map[string]int{
+		"":   20,
+		"5":  5,
+		"20": 20,
+		"50": 20,
+		"0":  1,
+	} {
+		r := httptest.NewRequest("POST", "/bucket/key?uploadId=upload", nil)
+		if header != "" {
+			r.Header.Set(completeMultipartConcurrencyHeader, header)
+		}
+		concurrency, err := c.completeMultipartConcurrencyFor(r)
+		require.NoError(t, err)
+		require.Equal(t, expected, concurrency, header)
+	}
+
+	// A malformed override is rejected
+	r := httptest.NewRequest("POST", "/bucket/key?uploadId=upload", nil)
+	r.Header.Set(completeMultipartConcurrencyHeader, "many")
+	_, err := c.completeMultipartConcurrencyFor(r)
+	require.YesError(t, err)
+	require.Equal(t, "InvalidArgument", err.(*s2.Error).Code)
+}
+
+func TestAfterUploadMarker(t *testing.T) {
+	for _, c := range []struct {
+		key, uploadID, keyMarker, uploadIDMarker string
+		expected                                 bool
+	}{
+		{"a", "1", "", "", true},
+		{"a", "1", "", "2", true},
+		{"a", "1", "a", "", false},
+		{"a", "1", "a", "1", false},
+		{"a", "2", "a", "1", true},
+		{"b", "1", "a", "2", true},
+		{"a", "9", "b", "1", false},
+	} {
+		require.Equal(t, c.expected, afterUploadMarker(c.key, c.uploadID, c.keyMarker, c.uploadIDMarker))
+	}
+}
+
+func TestPaginateUploads(t *testing.T) {
+	type upload struct{ key, uploadID string }
+	// upload IDs deliberately don't sort in the same order as their keys
+	uploads := []upload{
+		{"a", "3"}, {"a", "7"}, {"b", "1"}, {"b", "5"}, {"b", "9"},
+		{"c", "2"}, {"d", "0"}, {"d", "8"}, {"e", "4"},
+	}
+
+	for maxUploads := 1; maxUploads <= len(uploads)+1; maxUploads++ {
+		var listed []upload
+		keyMarker, uploadIDMarker := "", ""
+		for {
+			// the same filtering as ListMultipart, over uploads in glob order
+			var page []upload
+			truncated := false
+			for _, u := range uploads {
+				if !afterUploadMarker(u.key, u.uploadID, keyMarker, uploadIDMarker) {
+					continue
+				}
+				if len(page) >= maxUploads {
+					truncated = true
+					break
+				}
+				page = append(page, u)
+			}
+			listed = append(listed, page...)
+			if !truncated {
+				break
+			}
+			last := page[len(page)-1]
+			keyMarker, uploadIDMarker = last.key, last.uploadID
+		}
+		require.Equal(t, uploads, listed)
+	}
+}
+
+func TestClampListLength(t *testing.T) {
+	// the same truncation as ListMultipart and ListMultipartChunks, over
+	// `available` uploads or parts
+	list := func(available, requested int) (int, bool) {
+		limit := clampListLength(requested)
+		listed := 0
+		for i := 0; i < available; i++ {
+			if listed >= limit {
+				return listed, true
+			}
+			listed++
+		}
+		return listed, false
+	}
+
+	for _, c := range []struct {
+		requested, expected int
+	}{
+		{0, 1000},
+		{-1, 1000},
+		{1, 1},
+		{1000, 1000},
+		{5000, 1000},
+	} {
+		require.Equal(t, c.expected, clampListLength(c.requested))
+
+		// exactly a page isn't truncated, while one more than a page is
+		listed, truncated := list(c.expected, c.requested)
+		require.Equal(t, c.expected, listed)
+		require.False(t, truncated)
+		listed, truncated = list(c.expected+1, c.requested)
+		require.Equal(t, c.expected, listed)
+		require.True(t, truncated)
+	}
+
+	listed, truncated := list(0, 0)
+	require.Equal(t, 0, listed)
+	require.False(t, truncated)
+}
+
+func TestCommonPrefix(t *testing.T) {
+	for _, c := range []struct {
+		key, prefix, delimiter, expected string
+	}{
+		{"a/b/c", "", "/", "a/"},
+		{"a/b/c", "a/", "/", "a/b/"},
+		{"a/b/c", "a", "/", "a/"},
+		{"a/b/c", "a/b/", "/", ""},
+		{"a/b/c", "", "", ""},
+		{"file", "", "/", ""},
+	} {
+		commonPrefix, ok := commonPrefix(c.key, c.prefix, c.delimiter)
+		require.Equal(t, c.expected != "", ok)
+		require.Equal(t, c.expected, commonPrefix)
+	}
+}
+
+func TestServeListMultipart(t *testing.T) {
+	r := httptest.NewRequest("GET", "/bucket/?uploads&prefix=dir/&delimiter=/", nil)
+	require.True(t, isListMultipartWithDelimiter(r))
+	require.False(t, isListMultipartWithDelimiter(httptest.NewRequest("GET", "/bucket/?uploads", nil)))
+
+	w := httptest.NewRecorder()
+	serveListMultipart(logrus.NewEntry(logrus.New()), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commonPrefixes := r.Context().Value(commonPrefixesKey{}).(*[]string)
+		*commonPrefixes = append(*commonPrefixes, "dir/a/", "dir/b/")
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<ListMultipartUploadsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Bucket>bucket</Bucket><Upload><Key>dir/file</Key><UploadId>foo</UploadId></Upload></ListMultipartUploadsResult>`))
+	}), w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result listMultipartUploadsResult
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, "bucket", result.Bucket)
+	require.Equal(t, "dir/", result.Prefix)
+	require.Equal(t, "/", result.Delimiter)
+	require.Equal(t, 1, len(result.Uploads))
+	require.Equal(t, "dir/file", result.Uploads[0].Key)
+	require.Equal(t, 2, len(result.CommonPrefixes))
+	require.Equal(t, "dir/a/", result.CommonPrefixes[0].Prefix)
+	require.Equal(t, "dir/b/", result.CommonPrefixes[1].Prefix)
+}
+
+func TestNewPartDetails(t *testing.T) {
+	committed := time.Date(2020, 6, 1, 12, 30, 15, 600000000, time.UTC)
+	committedProto, err := types.TimestampProto(committed)
+	require.NoError(t, err)
+
+	details := newPartDetails(&pfsClient.FileInfo{SizeBytes: 42, Committed: committedProto})
+	require.Equal(t, uint64(42), details.Size)
+	require.Equal(t, committed.Round(time.Second), *details.LastModified)
+
+	// parts in unfinished commits have no modification time
+	details = newPartDetails(&pfsClient.FileInfo{SizeBytes: 42})
+	require.Equal(t, uint64(42), details.Size)
+	require.Nil(t, details.LastModified)
+}
+
+func TestServeListParts(t *testing.T) {
+	r := httptest.NewRequest("GET", "/bucket/key?uploadId=foo", nil)
+	require.True(t, isListParts(r))
+	require.False(t, isListParts(httptest.NewRequest("PUT", "/bucket/key?uploadId=foo&partNumber=1", nil)))
+
+	lastModified := time.Date(2020, 6, 1, 12, 30, 15, 0, time.UTC)
+	w := httptest.NewRecorder()
+	serveListParts(logrus.NewEntry(logrus.New()), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		details := r.Context().Value(partDetailsKey{}).(map[int]partDetails)
+		details[1] = partDetails{Size: 5 * 1024 * 1024, LastModified: &lastModified}
+		details[2] = partDetails{Size: 10}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<ListPartsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Key>key</Key><UploadId>foo</UploadId><Part><PartNumber>1</PartNumber><ETag>"a"</ETag></Part><Part><PartNumber>2</PartNumber><ETag>"b"</ETag></Part></ListPartsResult>`))
+	}), w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result listPartsResult
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, "key", result.Key)
+	require.Equal(t, "foo", result.UploadID)
+	require.Equal(t, 2, len(result.Parts))
+	require.Equal(t, `"a"`, result.Parts[0].ETag)
+	require.Equal(t, uint64(5*1024*1024), result.Parts[0].Size)
+	require.Equal(t, lastModified, *result.Parts[0].LastModified)
+	require.Equal(t, uint64(10), result.Parts[1].Size)
+	require.Nil(t, result.Parts[1].LastModified)
+	require.False(t, strings.Contains(w.Body.String(), "0001-01-01"))
+}
+
+func TestWithMultipartRepo(t *testing.T) {
+	c := &controller{repo: multipartRepo, branch: multipartBranch}
+	require.NoError(t, WithMultipartRepo("custom_multipart-repo", "staging")(c))
+	require.Equal(t, "custom_multipart-repo", c.repo)
+	require.Equal(t, "staging", c.branch)
+
+	for _, names := range [][2]string{
+		{"", "master"},
+		{"repo with spaces", "master"},
+		{"repo", ""},
+		{"repo", "branch/name"},
+	} {
+		c := &controller{repo: multipartRepo, branch: multipartBranch}
+		require.YesError(t, WithMultipartRepo(names[0], names[1])(c))
+		require.Equal(t, multipartRepo, c.repo)
+		require.Equal(t, multipartBranch, c.branch)
+	}
+}
+
+func TestAttachCompleteMultipartHandler(t *testing.T) {
+	logger := logrus.WithFields(logrus.Fields{})
+	s3Server := s2.NewS2(logger, maxRequestBodyLength, readBodyTimeout)
+	multipart := &memoryMultipartController{}
+	s3Server.Multipart = multipart
+	router := s3Server.Router()
+	require.NoError(t, attachCompleteMultipartHandler(logger, router))
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	// completing without any parts is malformed, as in s3
+	w := serve("POST", "/bucket/key?uploadId=upload", "<CompleteMultipartUpload></CompleteMultipartUpload>")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.True(t, strings.Contains(w.Body.String(), "MalformedXML"))
+
+	// while other requests reach the controller
+	require.Equal(t, http.StatusOK, serve("POST", "/bucket/key?uploads", "").Code)
+	w = serve("POST", "/bucket/key?uploadId=upload", "<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>etag</ETag></Part></CompleteMultipartUpload>")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "", multipart.uploadID)
+}
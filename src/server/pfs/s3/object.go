@@ -36,6 +36,8 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 		return nil, s2.NoSuchKeyError(r)
 	}
 
+	// metadata is stored by branch, rather than by version
+	branch := bucket.Commit
 	if bucketCaps.historicVersions && version != "" {
 		commitInfo, err := pc.InspectCommit(bucket.Repo, version)
 		if err != nil {
@@ -62,6 +64,8 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 		return nil, err
 	}
 
+	c.setObjectHeaders(pc, r, bucket.Repo, branch, file, fileInfo)
+
 	result := s2.GetObjectResult{
 		ModTime:      modTime,
 		Content:      content,
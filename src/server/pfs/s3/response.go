@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// bufferedResponseWriter holds onto a response so that it can be rewritten
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// serveRewrittenXML serves a request via `handler`, filling in parts of the
+// response that s2 has no way of returning. If the request succeeds, the XML
+// response body is unmarshalled into `result`, `rewrite` is called, and
+// `result` is written in place of the original body. Otherwise, the response
+// is passed through untouched.
+func serveRewrittenXML(logger *logrus.Entry, handler http.Handler, w http.ResponseWriter, r *http.Request, result interface{}, rewrite func()) {
+	bw := &bufferedResponseWriter{header: w.Header()}
+	handler.ServeHTTP(bw, r)
+
+	body := bw.body.Bytes()
+	if bw.status == http.StatusOK {
+		if err := xml.Unmarshal(body, result); err != nil {
+			logger.Errorf("could not unmarshal response to rewrite: %v", err)
+		} else {
+			rewrite()
+			if rewritten, err := xml.Marshal(result); err != nil {
+				logger.Errorf("could not marshal rewritten response: %v", err)
+			} else {
+				body = append([]byte(xml.Header), rewritten...)
+			}
+		}
+	}
+
+	w.Header().Del("Content-Length")
+	if bw.status != 0 {
+		w.WriteHeader(bw.status)
+	}
+	if _, err := w.Write(body); err != nil {
+		logger.Errorf("could not write response: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package s3
+
+import (
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/s2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newPFSBackOff returns the backoff used to retry PFS calls. It gives up well
+// within the request timeout, so that clients get a response either way.
+func newPFSBackOff() backoff.BackOff {
+	b := backoff.New10sBackOff()
+	b.MaxElapsedTime = requestTimeout / 2
+	return b
+}
+
+// permanentError wraps an error that shouldn't be retried, regardless of its
+// cause
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// permanent marks an error as one that shouldn't be retried, e.g. because the
+// operation that failed can't safely be repeated
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// isRetryableErr returns whether a failed PFS call may succeed if it's
+// retried. Errors that won't go away on their own, like missing files or
+// failed authorization, aren't retried.
+func isRetryableErr(err error) bool {
+	switch err.(type) {
+	case *permanentError, *s2.Error:
+		return false
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return true
+		}
+		return false
+	}
+	return !(errutil.IsNotFoundError(err) ||
+		errutil.IsAlreadyExistError(err) ||
+		errutil.IsWriteToOutputBranchError(err) ||
+		errutil.IsInvalidPathError(err) ||
+		errutil.IsNotADirectoryError(err) ||
+		pfsServer.IsFileNotFoundErr(err) ||
+		pfsServer.IsRepoNotFoundErr(err) ||
+		pfsServer.IsBranchNotFoundErr(err) ||
+		pfsServer.IsCommitNotFoundErr(err) ||
+		pfsServer.IsCommitDeletedErr(err) ||
+		pfsServer.IsCommitFinishedErr(err) ||
+		pfsServer.IsNoHeadErr(err) ||
+		pfsServer.IsOutputCommitNotFinishedErr(err) ||
+		auth.IsErrNotActivated(err) ||
+		auth.IsErrNotSignedIn(err) ||
+		auth.IsErrBadToken(err) ||
+		auth.IsErrNotAuthorized(err) ||
+		auth.IsErrInvalidPrincipal(err))
+}
+
+// retry runs a PFS operation, retrying it with a bounded backoff while it
+// fails with retryable errors. The operation must be safe to repeat, or mark
+// its errors as permanent once it no longer is.
+func (c *controller) retry(operation func() error) error {
+	err := backoff.RetryNotify(operation, c.pfsBackOff(), func(err error, d time.Duration) error {
+		if !isRetryableErr(err) {
+			return err
+		}
+		c.logger.Warnf("retrying PFS call in %v: %v", d, err)
+		return nil
+	})
+	if p, ok := err.(*permanentError); ok {
+		return p.err
+	}
+	return err
+}
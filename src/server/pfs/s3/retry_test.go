@@ -0,0 +1,137 @@
+package s3
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/s2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newRetryTestController() *controller {
+	return &controller{
+		logger: logrus.WithFields(logrus.Fields{}),
+		pfsBackOff: func() backoff.BackOff {
+			return backoff.RetryEvery(time.Millisecond).For(100 * time.Millisecond)
+		},
+	}
+}
+
+func TestIsRetryableErr(t *testing.T) {
+	for _, err := range []error{
+		status.Error(codes.Unavailable, "connection refused"),
+		status.Error(codes.Aborted, "transaction aborted"),
+		errors.New("transport is closing"),
+	} {
+		require.True(t, isRetryableErr(err), err.Error())
+	}
+
+	for _, err := range []error{
+		status.Error(codes.InvalidArgument, "bad request"),
+		pfsServer.ErrFileNotFound{File: client.NewFile("repo", "master", "file")},
+		pfsServer.ErrRepoNotFound{Repo: client.NewRepo("repo")},
+		&auth.ErrNotAuthorized{Subject: "robot:test", Repo: "repo", Required: auth.Scope_WRITER},
+		s2.NoSuchUploadError(httptest.NewRequest("GET", "/bucket/key?uploadId=id", nil)),
+		permanent(errors.New("transport is closing")),
+	} {
+		require.False(t, isRetryableErr(err), err.Error())
+	}
+}
+
+// flakyPFS is a stub PFS call that fails with the given errors before
+// succeeding
+type flakyPFS struct {
+	errs  []error
+	calls int
+}
+
+func (f *flakyPFS) call() error {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func TestRetry(t *testing.T) {
+	c := newRetryTestController()
+
+	// a single transient error is retried transparently
+	pfs := &flakyPFS{errs: []error{status.Error(codes.Unavailable, "connection refused")}}
+	require.NoError(t, c.retry(pfs.call))
+	require.Equal(t, 2, pfs.calls)
+
+	// errors that won't go away fail fast
+	notFound := pfsServer.ErrFileNotFound{File: client.NewFile("repo", "master", "file")}
+	pfs = &flakyPFS{errs: []error{notFound}}
+	require.Equal(t, notFound, c.retry(pfs.call))
+	require.Equal(t, 1, pfs.calls)
+
+	// as do errors marked as permanent, which are unwrapped
+	transient := errors.New("transport is closing")
+	pfs = &flakyPFS{errs: []error{permanent(transient)}}
+	require.Equal(t, transient, c.retry(pfs.call))
+	require.Equal(t, 1, pfs.calls)
+
+	// retries are bounded
+	pfs = &flakyPFS{}
+	for i := 0; i < 1000; i++ {
+		pfs.errs = append(pfs.errs, transient)
+	}
+	require.Equal(t, transient, c.retry(pfs.call))
+	require.True(t, pfs.calls > 1)
+	require.True(t, pfs.calls < 1000)
+}
+
+func TestPutPart(t *testing.T) {
+	c := newRetryTestController()
+	content := "content"
+	transient := status.Error(codes.Unavailable, "connection refused")
+
+	// a transient failure before any of the part is read is retried, and the
+	// part's digests only cover its contents once
+	body := newPartReader(strings.NewReader(content), defaultMaxPartSize)
+	pfs := &flakyPFS{errs: []error{transient}}
+	var uploaded string
+	require.NoError(t, c.putPart(body, func(reader io.Reader) error {
+		if err := pfs.call(); err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(reader)
+		uploaded = string(data)
+		return err
+	}))
+	require.Equal(t, 2, pfs.calls)
+	require.Equal(t, content, uploaded)
+	expected := newPartReader(strings.NewReader(content), defaultMaxPartSize)
+	_, err := ioutil.ReadAll(expected)
+	require.NoError(t, err)
+	require.Equal(t, expected.md5.Sum(nil), body.md5.Sum(nil))
+
+	// but once some of the part has been read, it isn't
+	body = newPartReader(strings.NewReader(content), defaultMaxPartSize)
+	calls := 0
+	err = c.putPart(body, func(reader io.Reader) error {
+		calls++
+		if _, err := io.ReadFull(reader, make([]byte, 3)); err != nil {
+			return err
+		}
+		return transient
+	})
+	require.Equal(t, transient, err)
+	require.Equal(t, 1, calls)
+}
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"context"
 	"fmt"
 	stdlog "log"
 	"net/http"
@@ -8,6 +9,9 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 
 	"github.com/pachyderm/s2"
 	"github.com/sirupsen/logrus"
@@ -19,11 +23,26 @@ type ClientFactory = func() (*client.APIClient, error)
 
 const (
 	multipartRepo        = "_s3gateway_multipart_"
+	multipartBranch      = "master"
 	maxAllowedParts      = 10000
 	maxRequestBodyLength = 128 * 1024 * 1024 //128mb
 	requestTimeout       = 10 * time.Second
 	readBodyTimeout      = 5 * time.Second
 
+	// The default minimum size of each part of a multipart upload, except for
+	// the last, which is 5mb in s3
+	defaultMinPartSize = 5 * 1024 * 1024
+
+	// The default maximum size of each part of a multipart upload, which is
+	// 5gb in s3
+	defaultMaxPartSize = 5 * 1024 * 1024 * 1024
+
+	// The default number of parts that are validated and copied concurrently
+	// when completing a multipart upload, and the most that it may be
+	// configured to
+	defaultCompleteMultipartConcurrency = 10
+	maxCompleteMultipartConcurrency     = 100
+
 	// The S3 storage class that all PFS content will be reported to be stored in
 	globalStorageClass = "STANDARD"
 
@@ -37,13 +56,30 @@ var defaultUser = s2.User{ID: "00000000000000000000000000000000", DisplayName: "
 type controller struct {
 	logger *logrus.Entry
 
-	// Name of the PFS repo holding multipart content
-	repo string
+	// Name of the PFS repo, and the branch in it, holding multipart content
+	repo   string
+	branch string
 
 	// the maximum number of allowed parts that can be associated with any
-	// given file
+	// given file. Part numbers must be in the range [1, maxAllowedParts], as
+	// in s3.
 	maxAllowedParts int
 
+	// the minimum size, in bytes, of each part of a multipart upload except
+	// for the last one
+	minPartSize uint64
+
+	// the maximum size, in bytes, of each part of a multipart upload
+	maxPartSize uint64
+
+	// the maximum number of parts that are validated and copied concurrently
+	// when completing a multipart upload. Requests may lower it with the
+	// completeMultipartConcurrencyHeader.
+	completeMultipartConcurrency int
+
+	// returns the backoff used to retry PFS calls that fail transiently
+	pfsBackOff func() backoff.BackOff
+
 	driver Driver
 
 	clientFactory ClientFactory
@@ -68,6 +104,52 @@ func (c *controller) requestClient(r *http.Request) (*client.APIClient, error) {
 	return pc, nil
 }
 
+// ServerOption is an option that may be passed to Server()
+type ServerOption func(*controller) error
+
+// WithMultipartRepo configures the PFS repo and branch that the gateway keeps
+// the content of in-progress multipart uploads in. By default, this is the
+// master branch of the `_s3gateway_multipart_` repo.
+func WithMultipartRepo(repo, branch string) ServerOption {
+	return func(c *controller) error {
+		if err := ancestry.ValidateName(repo); err != nil {
+			return errors.Wrapf(err, "invalid multipart repo")
+		}
+		if err := ancestry.ValidateName(branch); err != nil {
+			return errors.Wrapf(err, "invalid multipart branch")
+		}
+		c.repo = repo
+		c.branch = branch
+		return nil
+	}
+}
+
+// WithMinPartSize configures the minimum size, in bytes, of each part of a
+// multipart upload except for the last one. It's defaultMinPartSize (5mb, as
+// in s3) by default, and may be as large as the maximum part size; 0 allows
+// parts of any size.
+func WithMinPartSize(size int64) ServerOption {
+	return func(c *controller) error {
+		if size < 0 || uint64(size) > c.maxPartSize {
+			return errors.Errorf("invalid minimum part size %d: must be between 0 and %d bytes", size, c.maxPartSize)
+		}
+		c.minPartSize = uint64(size)
+		return nil
+	}
+}
+
+// WithCompleteMultipartConcurrency configures the number of parts that are
+// validated and copied concurrently when completing a multipart upload, which
+// bounds the PFS load of each completion. It's clamped to between 1 and
+// maxCompleteMultipartConcurrency, and is defaultCompleteMultipartConcurrency
+// by default.
+func WithCompleteMultipartConcurrency(concurrency int) ServerOption {
+	return func(c *controller) error {
+		c.completeMultipartConcurrency = clampConcurrency(concurrency, maxCompleteMultipartConcurrency)
+		return nil
+	}
+}
+
 // Server runs an HTTP server with an S3-like API for PFS. This allows you to
 // use s3 clients to access PFS contents.
 //
@@ -90,17 +172,32 @@ func (c *controller) requestClient(r *http.Request) (*client.APIClient, error) {
 // Note: In `s3cmd`, you must set the access key and secret key, even though
 // this API will ignore them - otherwise, you'll get an opaque config error:
 // https://github.com/s3tools/s3cmd/issues/845#issuecomment-464885959
-func Server(port uint16, driver Driver, clientFactory ClientFactory) (*http.Server, error) {
+func Server(port uint16, driver Driver, clientFactory ClientFactory, opts ...ServerOption) (*http.Server, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"source": "s3gateway",
 	})
 
 	c := &controller{
-		logger:          logger,
-		repo:            multipartRepo,
-		maxAllowedParts: maxAllowedParts,
-		driver:          driver,
-		clientFactory:   clientFactory,
+		logger:                       logger,
+		repo:                         multipartRepo,
+		branch:                       multipartBranch,
+		maxAllowedParts:              maxAllowedParts,
+		minPartSize:                  defaultMinPartSize,
+		maxPartSize:                  defaultMaxPartSize,
+		completeMultipartConcurrency: defaultCompleteMultipartConcurrency,
+		pfsBackOff:                   newPFSBackOff,
+		driver:                       driver,
+		clientFactory:                clientFactory,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	metrics, err := getDefaultMultipartMetrics()
+	if err != nil {
+		return nil, err
 	}
 
 	s3Server := s2.NewS2(logger, maxRequestBodyLength, readBodyTimeout)
@@ -108,8 +205,17 @@ func Server(port uint16, driver Driver, clientFactory ClientFactory) (*http.Serv
 	s3Server.Service = c
 	s3Server.Bucket = c
 	s3Server.Object = c
-	s3Server.Multipart = c
+	s3Server.Multipart = &instrumentedMultipartController{MultipartController: c, metrics: metrics}
+	// Parts are streamed to PFS, rather than being read into memory by s2,
+	// and UploadMultipartChunk verifies their digests
+	s3Server.StreamBodies(isUploadPart, c.maxPartSize)
 	router := s3Server.Router()
+	if err := c.attachObjectTaggingHandler(router); err != nil {
+		return nil, err
+	}
+	if err := attachCompleteMultipartHandler(logger, router); err != nil {
+		return nil, err
+	}
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -118,6 +224,19 @@ func Server(port uint16, driver Driver, clientFactory ClientFactory) (*http.Serv
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Log that a request was made
 			logger.Infof("http request: %s %s", r.Method, r.RequestURI)
+			r = r.WithContext(context.WithValue(r.Context(), responseHeaderKey{}, w.Header()))
+			if isUploadPartCopy(r) {
+				serveUploadPartCopy(logger, router, w, r)
+				return
+			}
+			if isListMultipartWithDelimiter(r) {
+				serveListMultipart(logger, router, w, r)
+				return
+			}
+			if isListParts(r) {
+				serveListParts(logger, router, w, r)
+				return
+			}
 			router.ServeHTTP(w, r)
 		}),
 		// NOTE: this is not closed. If the standard logger gets customized, this will need to be fixed
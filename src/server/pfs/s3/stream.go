@@ -0,0 +1,121 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/s2"
+)
+
+// errPartTooLarge is returned by partReader once a part exceeds the maximum
+// part size
+var errPartTooLarge = errors.New("part exceeds the maximum part size")
+
+// isUploadPart returns whether a request is an UploadPart request, i.e. one
+// whose body holds the contents of the part
+func isUploadPart(r *http.Request) bool {
+	_, ok := r.URL.Query()["uploadId"]
+	return ok && r.Method == http.MethodPut && r.Header.Get("x-amz-copy-source") == ""
+}
+
+// validatePartLength rejects a part whose declared length exceeds the
+// maximum part size, without reading any of it
+func (c *controller) validatePartLength(r *http.Request) error {
+	if r.ContentLength > 0 && uint64(r.ContentLength) > c.maxPartSize {
+		return s2.EntityTooLargeError(r)
+	}
+	return nil
+}
+
+// partReader reads the body of an UploadPart request, computing its digests
+// as it's streamed, and failing if it exceeds `max` bytes. This matters when
+// the length of the body isn't known up front.
+type partReader struct {
+	reader io.Reader
+	md5    hash.Hash
+	sha256 hash.Hash
+	n      uint64
+	max    uint64
+}
+
+func newPartReader(reader io.Reader, max uint64) *partReader {
+	return &partReader{
+		reader: reader,
+		md5:    md5.New(),
+		sha256: sha256.New(),
+		max:    max,
+	}
+}
+
+func (p *partReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.n += uint64(n)
+	if p.n > p.max {
+		return 0, errPartTooLarge
+	}
+	p.md5.Write(b[:n])
+	p.sha256.Write(b[:n])
+	return n, err
+}
+
+// tooLarge returns whether the part exceeded the maximum part size
+func (p *partReader) tooLarge() bool {
+	return p.n > p.max
+}
+
+// putPart uploads the body of an UploadPart request with `put`. Failed
+// uploads are only retried if none of the body was read, since the body
+// can't be read again, and uploading the rest of it would change the part's
+// contents (and ETag).
+func (c *controller) putPart(body *partReader, put func(io.Reader) error) error {
+	return c.retry(func() error {
+		err := put(body)
+		if err != nil && body.n > 0 {
+			return permanent(err)
+		}
+		return err
+	})
+}
+
+// validateDigestHeaders checks the format of the digest headers of a request,
+// so that malformed headers are rejected before the body is read
+func validateDigestHeaders(r *http.Request) error {
+	if values, ok := r.Header["Content-Md5"]; ok {
+		decoded, err := base64.StdEncoding.DecodeString(values[0])
+		if len(values) != 1 || err != nil || len(decoded) != md5.Size {
+			return s2.InvalidDigestError(r)
+		}
+	}
+	if values, ok := r.Header["X-Amz-Content-Sha256"]; ok {
+		if len(values) != 1 || len(values[0]) != hex.EncodedLen(sha256.Size) {
+			return s2.InvalidDigestError(r)
+		}
+	}
+	return nil
+}
+
+// verifyDigests checks the digests of a part that was read with the digest
+// headers of its request, as s2 does for the request bodies it reads. It
+// assumes that the headers have already been validated by
+// `validateDigestHeaders`.
+func (p *partReader) verifyDigests(r *http.Request) error {
+	if expected := r.Header.Get("Content-Md5"); expected != "" {
+		decoded, _ := base64.StdEncoding.DecodeString(expected)
+		if !bytes.Equal(decoded, p.md5.Sum(nil)) {
+			return s2.BadDigestError(r)
+		}
+	}
+	if expected := r.Header.Get("x-amz-content-sha256"); expected != "" {
+		if expected != hex.EncodeToString(p.sha256.Sum(nil)) {
+			return s2.BadDigestError(r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package s3
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/s2"
+	"github.com/sirupsen/logrus"
+)
+
+func TestValidatePartLength(t *testing.T) {
+	c := &controller{maxPartSize: 10}
+	newRequest := func(length int) *http.Request {
+		return httptest.NewRequest("PUT", "/bucket/key?uploadId=id&partNumber=1", strings.NewReader(strings.Repeat("a", length)))
+	}
+	require.NoError(t, c.validatePartLength(newRequest(9)))
+	require.NoError(t, c.validatePartLength(newRequest(10)))
+	err := c.validatePartLength(newRequest(11))
+	require.YesError(t, err)
+	require.Equal(t, "EntityTooLarge", err.(*s2.Error).Code)
+}
+
+func TestPartReader(t *testing.T) {
+	// just under and at the cap
+	for _, length := range []int{9, 10} {
+		body := newPartReader(strings.NewReader(strings.Repeat("a", length)), 10)
+		data, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, length, len(data))
+		require.False(t, body.tooLarge())
+	}
+
+	// just over the cap
+	body := newPartReader(strings.NewReader(strings.Repeat("a", 11)), 10)
+	_, err := ioutil.ReadAll(body)
+	require.YesError(t, err)
+	require.Equal(t, errPartTooLarge, err)
+	require.True(t, body.tooLarge())
+}
+
+func TestVerifyDigests(t *testing.T) {
+	content := "content"
+	md5Sum := md5.Sum([]byte(content))
+	sha256Sum := sha256.Sum256([]byte(content))
+	newRequest := func(headers map[string]string) *http.Request {
+		r := httptest.NewRequest("PUT", "/bucket/key?uploadId=id&partNumber=1", strings.NewReader(content))
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+		return r
+	}
+	verify := func(r *http.Request) error {
+		if err := validateDigestHeaders(r); err != nil {
+			return err
+		}
+		body := newPartReader(r.Body, defaultMaxPartSize)
+		_, err := io.Copy(ioutil.Discard, body)
+		require.NoError(t, err)
+		return body.verifyDigests(r)
+	}
+
+	require.NoError(t, verify(newRequest(nil)))
+	require.NoError(t, verify(newRequest(map[string]string{
+		"Content-MD5":          base64.StdEncoding.EncodeToString(md5Sum[:]),
+		"x-amz-content-sha256": hex.EncodeToString(sha256Sum[:]),
+	})))
+
+	for _, c := range []struct {
+		headers map[string]string
+		code    string
+	}{
+		{map[string]string{"Content-MD5": "not base64"}, "InvalidDigest"},
+		{map[string]string{"Content-MD5": base64.StdEncoding.EncodeToString([]byte("short"))}, "InvalidDigest"},
+		{map[string]string{"x-amz-content-sha256": "abc"}, "InvalidDigest"},
+		{map[string]string{"Content-MD5": base64.StdEncoding.EncodeToString(make([]byte, md5.Size))}, "BadDigest"},
+		{map[string]string{"x-amz-content-sha256": strings.Repeat("0", 64)}, "BadDigest"},
+	} {
+		err := verify(newRequest(c.headers))
+		require.YesError(t, err)
+		require.Equal(t, c.code, err.(*s2.Error).Code)
+	}
+}
+
+// streamingMultipartController records the body of UploadPart requests
+type streamingMultipartController struct {
+	s2.MultipartController
+	body io.Reader
+}
+
+func (c *streamingMultipartController) UploadMultipartChunk(r *http.Request, bucket, key, uploadID string, partNumber int, reader io.Reader) (string, error) {
+	c.body = reader
+	return "etag", nil
+}
+
+func TestStreamUploadPart(t *testing.T) {
+	// s2 buffers request bodies up to its maximum request body length, and
+	// rejects larger ones
+	content := strings.Repeat("a", 16)
+	s3Server := s2.NewS2(logrus.WithFields(logrus.Fields{}), 8, readBodyTimeout)
+	multipart := &streamingMultipartController{}
+	s3Server.Multipart = multipart
+	router := s3Server.Router()
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("PUT", "/bucket/key?uploadId=id&partNumber=1", strings.NewReader(content))
+		r.Header.Set("Content-Length", strconv.Itoa(len(content)))
+		return r
+	}
+
+	r := newRequest()
+	require.True(t, isUploadPart(r))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Nil(t, multipart.body)
+
+	// once streamed, the body reaches the controller without being read, and
+	// with its headers intact
+	s3Server.StreamBodies(isUploadPart, 32)
+	router = s3Server.Router()
+	r = newRequest()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	read, err := ioutil.ReadAll(multipart.body)
+	require.NoError(t, err)
+	require.Equal(t, content, string(read))
+	require.Equal(t, []string{strconv.Itoa(len(content))}, r.Header["Content-Length"])
+
+	// but streamed bodies are still limited, to the streamed body length
+	multipart.body = nil
+	s3Server.StreamBodies(isUploadPart, 8)
+	router = s3Server.Router()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest())
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Matches(t, "EntityTooLarge", w.Body.String())
+	require.Nil(t, multipart.body)
+
+	copyRequest := newRequest()
+	copyRequest.Header.Set("x-amz-copy-source", "bucket/other")
+	require.False(t, isUploadPart(copyRequest))
+}
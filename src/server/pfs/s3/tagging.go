@@ -0,0 +1,173 @@
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/s2"
+)
+
+const (
+	// The limits s3 places on object tags
+	maxTagsPerObject = 10
+	maxTagKeyLength  = 128
+	maxTagValLength  = 256
+)
+
+func invalidTagError(r *http.Request, message string) *s2.Error {
+	return s2.NewError(r, http.StatusBadRequest, "InvalidTag", message)
+}
+
+// parseTagging parses the `x-amz-tagging` header of a request, which holds
+// URL query-encoded tags, e.g. `key1=value1&key2=value2`.
+func parseTagging(r *http.Request) (map[string]string, error) {
+	header := r.Header.Get("x-amz-tagging")
+	if header == "" {
+		return nil, nil
+	}
+	values, err := url.ParseQuery(header)
+	if err != nil {
+		return nil, invalidTagError(r, "The tag set could not be parsed")
+	}
+	if len(values) > maxTagsPerObject {
+		return nil, s2.NewError(r, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Object tags cannot be greater than %d", maxTagsPerObject))
+	}
+	tags := make(map[string]string, len(values))
+	for key, vals := range values {
+		if len(vals) != 1 {
+			return nil, invalidTagError(r, "Cannot provide multiple Tags with the same key")
+		}
+		if key == "" || utf8.RuneCountInString(key) > maxTagKeyLength {
+			return nil, invalidTagError(r, "The TagKey you have provided is invalid")
+		}
+		if utf8.RuneCountInString(vals[0]) > maxTagValLength {
+			return nil, invalidTagError(r, "The TagValue you have provided is invalid")
+		}
+		tags[key] = vals[0]
+	}
+	return tags, nil
+}
+
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// taggingResult is the response body of a GetObjectTagging request
+type taggingResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Tagging"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+// GetObjectTagging returns the tags of an object, sorted by key
+func (c *controller) GetObjectTagging(r *http.Request, bucketName, key string) ([]tag, error) {
+	c.logger.Debugf("GetObjectTagging: bucketName=%+v, key=%+v", bucketName, key)
+
+	pc, err := c.requestClient(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(key, "/") {
+		return nil, invalidFilePathError(r)
+	}
+
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	bucketCaps, err := c.driver.bucketCapabilities(pc, r, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !bucketCaps.readable {
+		return nil, s2.NoSuchKeyError(r)
+	}
+
+	fileInfo, err := pc.InspectFile(bucket.Repo, bucket.Commit, key)
+	if err != nil {
+		return nil, maybeNotFoundError(r, err)
+	}
+
+	tags := []tag{}
+	metadata, err := c.getMetadata(pc, objectMetadataPath(bucket.Repo, bucket.Commit, key))
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil || metadata.Hash != fmt.Sprintf("%x", fileInfo.Hash) {
+		return tags, nil
+	}
+	for k, v := range metadata.Tags {
+		tags = append(tags, tag{Key: k, Value: v})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return tags, nil
+}
+
+// objectTaggingHandler serves object tagging requests. Only reading tags is
+// supported; tags are set when a multipart upload is initiated.
+func (c *controller) objectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s2.WriteError(c.logger, w, r, s2.NotImplementedError(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	tags, err := c.GetObjectTagging(r, vars["bucket"], vars["key"])
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+
+	body, err := xml.Marshal(taggingResult{TagSet: tags})
+	if err != nil {
+		s2.WriteError(c.logger, w, r, s2.InternalError(r, err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(xml.Header)+len(body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(append([]byte(xml.Header), body...)); err != nil {
+		c.logger.Errorf("could not write tagging result: %v", err)
+	}
+}
+
+// attachObjectTaggingHandler replaces s2's handler for object tagging routes,
+// which s2 doesn't implement, with objectTaggingHandler. The routes are kept,
+// rather than added in front of s2's router, so that s2's middleware (e.g.
+// auth) still applies.
+func (c *controller) attachObjectTaggingHandler(router *mux.Router) error {
+	attached := false
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		pathTemplate, err := route.GetPathTemplate()
+		if err != nil || !strings.Contains(pathTemplate, "{key") {
+			return nil
+		}
+		queries, err := route.GetQueriesTemplates()
+		if err != nil {
+			return nil
+		}
+		for _, query := range queries {
+			if query == "tagging=" {
+				route.HandlerFunc(c.objectTaggingHandler)
+				attached = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !attached {
+		return errors.New("could not find the object tagging route")
+	}
+	return nil
+}
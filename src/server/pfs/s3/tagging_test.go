@@ -0,0 +1,86 @@
+package s3
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/s2"
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseTagging(t *testing.T) {
+	newRequest := func(tagging string) *http.Request {
+		r := httptest.NewRequest("POST", "/bucket/key?uploads", nil)
+		if tagging != "" {
+			r.Header.Set("x-amz-tagging", tagging)
+		}
+		return r
+	}
+
+	tags, err := parseTagging(newRequest(""))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(tags))
+
+	tags, err = parseTagging(newRequest("project=pachyderm&stage=dev%20test&empty="))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"project": "pachyderm", "stage": "dev test", "empty": ""}, tags)
+
+	tooMany := url.Values{}
+	for i := 0; i <= maxTagsPerObject; i++ {
+		tooMany.Set(fmt.Sprintf("key%d", i), "value")
+	}
+
+	for _, c := range []struct {
+		tagging string
+		code    string
+	}{
+		{tooMany.Encode(), "BadRequest"},
+		{"a=1&a=2", "InvalidTag"},
+		{"=value", "InvalidTag"},
+		{"%zz=value", "InvalidTag"},
+		{strings.Repeat("k", maxTagKeyLength+1) + "=value", "InvalidTag"},
+		{"key=" + strings.Repeat("v", maxTagValLength+1), "InvalidTag"},
+	} {
+		_, err := parseTagging(newRequest(c.tagging))
+		require.YesError(t, err)
+		s2Err, ok := err.(*s2.Error)
+		require.True(t, ok)
+		require.Equal(t, c.code, s2Err.Code)
+	}
+
+	// keys and values at the limits are allowed
+	_, err = parseTagging(newRequest(strings.Repeat("k", maxTagKeyLength) + "=" + strings.Repeat("v", maxTagValLength)))
+	require.NoError(t, err)
+}
+
+func TestAttachObjectTaggingHandler(t *testing.T) {
+	c := &controller{
+		logger: logrus.WithFields(logrus.Fields{}),
+		clientFactory: func() (*client.APIClient, error) {
+			return nil, errors.New("no pachyderm client")
+		},
+	}
+	s3Server := s2.NewS2(c.logger, maxRequestBodyLength, readBodyTimeout)
+	router := s3Server.Router()
+	require.NoError(t, c.attachObjectTaggingHandler(router))
+
+	// s2 would respond with NotImplemented, whereas the tagging handler tries
+	// to get a pachyderm client
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/bucket/key?tagging", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// Setting and deleting tags isn't supported
+	for _, method := range []string{"PUT", "DELETE"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, "/bucket/key?tagging", nil))
+		require.Equal(t, http.StatusNotImplemented, w.Code)
+	}
+}
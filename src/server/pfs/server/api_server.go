@@ -43,8 +43,9 @@ func newAPIServer(
 	treeCache *hashtree.Cache,
 	storageRoot string,
 	memoryRequest int64,
+	opts ...Option,
 ) (*apiServer, error) {
-	d, err := newDriver(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest)
+	d, err := newDriver(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +59,14 @@ func newAPIServer(
 	return s, nil
 }
 
+// getPachClient returns a pachyderm client for serving a request with 'ctx',
+// which memoizes the driver's authorization checks for the duration of the
+// request. Long-lived streams (FlushCommit, SubscribeCommit) don't use it, so
+// that changes to a repo's ACL take effect while they're open.
+func (a *apiServer) getPachClient(ctx context.Context) *client.APIClient {
+	return withAuthCache(a.env.GetPachClient(ctx))
+}
+
 // CreateRepoInTransaction is identical to CreateRepo except that it can run
 // inside an existing etcd STM transaction.  This is not an RPC.
 func (a *apiServer) CreateRepoInTransaction(
@@ -112,7 +121,7 @@ func (a *apiServer) ListRepo(ctx context.Context, request *pfs.ListRepoRequest)
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	repoInfos, err := a.driver.listRepo(a.env.GetPachClient(ctx), true)
+	repoInfos, err := a.driver.listRepo(a.getPachClient(ctx), true)
 	return repoInfos, err
 }
 
@@ -148,7 +157,7 @@ func (a *apiServer) Fsck(request *pfs.FsckRequest, fsckServer pfs.API_FsckServer
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("stream containing %d messages", sent), retErr, time.Since(start))
 	}(time.Now())
-	if err := a.driver.fsck(a.env.GetPachClient(fsckServer.Context()), request.Fix, func(resp *pfs.FsckResponse) error {
+	if err := a.driver.fsck(a.getPachClient(fsckServer.Context()), request.Fix, func(resp *pfs.FsckResponse) error {
 		sent++
 		return fsckServer.Send(resp)
 	}); err != nil {
@@ -249,7 +258,7 @@ func (a *apiServer) InspectCommit(ctx context.Context, request *pfs.InspectCommi
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	return a.driver.inspectCommit(a.env.GetPachClient(ctx), request.Commit, request.BlockState)
+	return a.driver.inspectCommit(a.getPachClient(ctx), request.Commit, request.BlockState)
 }
 
 // ListCommit implements the protobuf pfs.ListCommit RPC
@@ -257,7 +266,7 @@ func (a *apiServer) ListCommit(ctx context.Context, request *pfs.ListCommitReque
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	commitInfos, err := a.driver.listCommit(a.env.GetPachClient(ctx), request.Repo, request.To, request.From, request.Number, request.Reverse)
+	commitInfos, err := a.driver.listCommit(a.getPachClient(ctx), request.Repo, request.To, request.From, request.Number, request.Reverse)
 	if err != nil {
 		return nil, err
 	}
@@ -273,7 +282,7 @@ func (a *apiServer) ListCommitStream(request *pfs.ListCommitRequest, respServer
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("stream containing %d commits", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.listCommitF(a.env.GetPachClient(respServer.Context()), request.Repo, request.To, request.From, request.Number, request.Reverse, func(ci *pfs.CommitInfo) error {
+	return a.driver.listCommitF(a.getPachClient(respServer.Context()), request.Repo, request.To, request.From, request.Number, request.Reverse, func(ci *pfs.CommitInfo) error {
 		sent++
 		return respServer.Send(ci)
 	})
@@ -322,7 +331,7 @@ func (a *apiServer) ListBranch(ctx context.Context, request *pfs.ListBranchReque
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	branches, err := a.driver.listBranch(a.env.GetPachClient(ctx), request.Repo, request.Reverse)
+	branches, err := a.driver.listBranch(a.getPachClient(ctx), request.Repo, request.Reverse)
 	if err != nil {
 		return nil, err
 	}
@@ -409,7 +418,7 @@ func (a *apiServer) PutFile(putFileServer pfs.API_PutFileServer) (retErr error)
 			retErr = err
 		}
 	}()
-	pachClient := a.env.GetPachClient(s.Context())
+	pachClient := a.getPachClient(s.Context())
 	return a.driver.putFiles(pachClient, s)
 }
 
@@ -417,7 +426,7 @@ func (a *apiServer) PutFile(putFileServer pfs.API_PutFileServer) (retErr error)
 func (a *apiServer) CopyFile(ctx context.Context, request *pfs.CopyFileRequest) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
-	if err := a.driver.copyFile(a.env.GetPachClient(ctx), request.Src, request.Dst, request.Overwrite); err != nil {
+	if err := a.driver.copyFile(a.getPachClient(ctx), request.Src, request.Dst, request.Overwrite); err != nil {
 		return nil, err
 	}
 	return &types.Empty{}, nil
@@ -435,7 +444,7 @@ func (a *apiServer) GetFile(request *pfs.GetFileRequest, apiGetFileServer pfs.AP
 
 		a.Log(request, nil, retErr, time.Since(start))
 	}(time.Now())
-	file, err := a.driver.getFile(a.env.GetPachClient(apiGetFileServer.Context()), request.File, request.OffsetBytes, request.SizeBytes)
+	file, err := a.driver.getFile(a.getPachClient(apiGetFileServer.Context()), request.File, request.OffsetBytes, request.SizeBytes)
 	if err != nil {
 		return err
 	}
@@ -455,7 +464,7 @@ func (a *apiServer) InspectFile(ctx context.Context, request *pfs.InspectFileReq
 		}
 	}(time.Now())
 
-	return a.driver.inspectFile(a.env.GetPachClient(ctx), request.File)
+	return a.driver.inspectFile(a.getPachClient(ctx), request.File)
 }
 
 // ListFile implements the protobuf pfs.ListFile RPC
@@ -471,7 +480,7 @@ func (a *apiServer) ListFile(ctx context.Context, request *pfs.ListFileRequest)
 	}(time.Now())
 
 	var fileInfos []*pfs.FileInfo
-	if err := a.driver.listFile(a.env.GetPachClient(ctx), request.File, request.Full, request.History, func(fi *pfs.FileInfo) error {
+	if err := a.driver.listFile(a.getPachClient(ctx), request.File, request.Full, request.History, func(fi *pfs.FileInfo) error {
 		fileInfos = append(fileInfos, fi)
 		return nil
 	}); err != nil {
@@ -489,7 +498,7 @@ func (a *apiServer) ListFileStream(request *pfs.ListFileRequest, respServer pfs.
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.listFile(a.env.GetPachClient(respServer.Context()), request.File, request.Full, request.History, func(fi *pfs.FileInfo) error {
+	return a.driver.listFile(a.getPachClient(respServer.Context()), request.File, request.Full, request.History, func(fi *pfs.FileInfo) error {
 		sent++
 		return respServer.Send(fi)
 	})
@@ -502,7 +511,7 @@ func (a *apiServer) WalkFile(request *pfs.WalkFileRequest, server pfs.API_WalkFi
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.walkFile(a.env.GetPachClient(server.Context()), request.File, func(fi *pfs.FileInfo) error {
+	return a.driver.walkFile(a.getPachClient(server.Context()), request.File, func(fi *pfs.FileInfo) error {
 		sent++
 		return server.Send(fi)
 	})
@@ -521,7 +530,7 @@ func (a *apiServer) GlobFile(ctx context.Context, request *pfs.GlobFileRequest)
 	}(time.Now())
 
 	var fileInfos []*pfs.FileInfo
-	if err := a.driver.globFile(a.env.GetPachClient(ctx), request.Commit, request.Pattern, func(fi *pfs.FileInfo) error {
+	if err := a.driver.globFile(a.getPachClient(ctx), request.Commit, request.Pattern, func(fi *pfs.FileInfo) error {
 		fileInfos = append(fileInfos, fi)
 		return nil
 	}); err != nil {
@@ -539,7 +548,7 @@ func (a *apiServer) GlobFileStream(request *pfs.GlobFileRequest, respServer pfs.
 	defer func(start time.Time) {
 		a.Log(request, fmt.Sprintf("response stream with %d objects", sent), retErr, time.Since(start))
 	}(time.Now())
-	return a.driver.globFile(a.env.GetPachClient(respServer.Context()), request.Commit, request.Pattern, func(fi *pfs.FileInfo) error {
+	return a.driver.globFile(a.getPachClient(respServer.Context()), request.Commit, request.Pattern, func(fi *pfs.FileInfo) error {
 		sent++
 		return respServer.Send(fi)
 	})
@@ -559,7 +568,7 @@ func (a *apiServer) DiffFile(ctx context.Context, request *pfs.DiffFileRequest)
 			a.Log(request, response, retErr, time.Since(start))
 		}
 	}(time.Now())
-	newFileInfos, oldFileInfos, err := a.driver.diffFile(a.env.GetPachClient(ctx), request.NewFile, request.OldFile, request.Shallow)
+	newFileInfos, oldFileInfos, err := a.driver.diffFile(a.getPachClient(ctx), request.NewFile, request.OldFile, request.Shallow)
 	if err != nil {
 		return nil, err
 	}
@@ -574,7 +583,7 @@ func (a *apiServer) DeleteFile(ctx context.Context, request *pfs.DeleteFileReque
 	func() { a.Log(request, nil, nil, 0) }()
 	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
 
-	err := a.driver.deleteFile(a.env.GetPachClient(ctx), request.File)
+	err := a.driver.deleteFile(a.getPachClient(ctx), request.File)
 	if err != nil {
 		return nil, err
 	}
@@ -32,12 +32,13 @@ func newAPIServerV2(
 	treeCache *hashtree.Cache,
 	storageRoot string,
 	memoryRequest int64,
+	opts ...Option,
 ) (*apiServerV2, error) {
-	s1, err := newAPIServer(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest)
+	s1, err := newAPIServer(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
-	d, err := newDriverV2(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest)
+	d, err := newDriverV2(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
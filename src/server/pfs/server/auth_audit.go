@@ -0,0 +1,87 @@
+package server
+
+import (
+	"github.com/pachyderm/pachyderm/src/client/auth"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// AuthDecision is a record of PFS deciding whether a user may access a repo
+type AuthDecision struct {
+	// Subject is the user that requested access. It's empty if the auth service
+	// couldn't say who the user is.
+	Subject string
+	// Repo is the repo that the user requested access to
+	Repo string
+	// Required is the scope that the user needed
+	Required auth.Scope
+	// Allowed is whether access was granted
+	Allowed bool
+	// Operation is the RPC that was being served, e.g. "/pfs.API/GetFile". It's
+	// empty if the check wasn't made while serving an RPC.
+	Operation string
+	// Reason explains why access was granted or denied if it wasn't decided by
+	// the auth service (e.g. because the auth service was unreachable)
+	Reason string
+}
+
+// AuthAuditSink receives a record of every authorization decision that PFS
+// makes while auth is activated. RecordAuthDecision is called on the path of
+// the operation being authorized, so it must not block, and it may be called
+// concurrently.
+type AuthAuditSink interface {
+	RecordAuthDecision(*AuthDecision)
+}
+
+// WithAuthAuditSink makes a PFS API server record its authorization decisions
+// in 'sink'. By default, they're logged: denials at the info level, and
+// allowed accesses, which are far more common, at the debug level.
+func WithAuthAuditSink(sink AuthAuditSink) Option {
+	return func(d *driver) {
+		d.authAuditSink = sink
+	}
+}
+
+// logAuthAuditSink is the default AuthAuditSink, which logs decisions
+type logAuthAuditSink struct{}
+
+func (logAuthAuditSink) RecordAuthDecision(d *AuthDecision) {
+	fields := logrus.Fields{
+		"subject":   d.Subject,
+		"repo":      d.Repo,
+		"required":  d.Required.String(),
+		"allowed":   d.Allowed,
+		"operation": d.Operation,
+	}
+	if d.Reason != "" {
+		fields["reason"] = d.Reason
+	}
+	if d.Allowed {
+		logrus.WithFields(fields).Debug("pfs authorization decision")
+		return
+	}
+	logrus.WithFields(fields).Info("pfs authorization decision")
+}
+
+// recordAuthDecision records an authorization decision made while serving a
+// request with 'ctx' in the driver's AuthAuditSink. 'me' may be nil if WhoAmI
+// failed.
+func (d *driver) recordAuthDecision(ctx context.Context, me *auth.WhoAmIResponse, repo string, required auth.Scope, allowed bool, reason string) {
+	decision := &AuthDecision{
+		Repo:     repo,
+		Required: required,
+		Allowed:  allowed,
+		Reason:   reason,
+	}
+	if me != nil {
+		decision.Subject = me.Username
+	}
+	decision.Operation, _ = grpc.Method(ctx)
+	sink := d.authAuditSink
+	if sink == nil {
+		sink = logAuthAuditSink{}
+	}
+	sink.RecordAuthDecision(decision)
+}
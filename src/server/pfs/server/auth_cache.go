@@ -0,0 +1,117 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// authCacheKey is the context key under which a request's authCache is stored
+type authCacheKey struct{}
+
+// authDecisionKey identifies an authorization decision. Decisions are keyed
+// by the caller's token as well, so that a cache can't grant one user's
+// access to another, even if a client's token is changed mid-request.
+type authDecisionKey struct {
+	token string
+	repo  string
+	scope auth.Scope
+}
+
+// whoAmIResult is a cached WhoAmI response. 'notActivated' is set if auth
+// isn't activated, in which case 'me' is nil.
+type whoAmIResult struct {
+	me           *auth.WhoAmIResponse
+	notActivated bool
+}
+
+// authCache memoizes the results of WhoAmI and Authorize calls made while
+// serving a single request, so that driver operations that check the same
+// repo several times (e.g. diffFile, which checks both of its files and
+// then inspects their commits) only call the auth service once. Errors are
+// never cached, so a failed call is retried by the next check.
+//
+// An authCache must not outlive the request that it's created for, or
+// changes to a repo's ACL would not be seen by the request's later checks.
+type authCache struct {
+	mu        sync.Mutex
+	whoAmI    map[string]whoAmIResult
+	decisions map[authDecisionKey]bool
+}
+
+// withAuthCache returns a copy of 'pachClient' whose context carries a new,
+// empty authCache. Checks made with the returned client (or clients derived
+// from its context) share the cache.
+func withAuthCache(pachClient *client.APIClient) *client.APIClient {
+	cache := &authCache{
+		whoAmI:    make(map[string]whoAmIResult),
+		decisions: make(map[authDecisionKey]bool),
+	}
+	return pachClient.WithCtx(context.WithValue(pachClient.Ctx(), authCacheKey{}, cache))
+}
+
+// getAuthCache returns the authCache in 'ctx', or nil if there isn't one.
+// The methods of a nil authCache always call through to the auth service.
+func getAuthCache(ctx context.Context) *authCache {
+	cache, _ := ctx.Value(authCacheKey{}).(*authCache)
+	return cache
+}
+
+// authToken returns the auth token that requests made with 'ctx' carry
+func authToken(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if tokens := md[auth.ContextTokenKey]; len(tokens) > 0 {
+		return tokens[0]
+	}
+	return ""
+}
+
+// getWhoAmI returns the cached WhoAmI result for 'token', calling
+// 'whoAmI' to fill the cache if there isn't one
+func (c *authCache) getWhoAmI(token string, whoAmI func() (*auth.WhoAmIResponse, error)) (*auth.WhoAmIResponse, error) {
+	if c != nil {
+		c.mu.Lock()
+		result, ok := c.whoAmI[token]
+		c.mu.Unlock()
+		if ok {
+			if result.notActivated {
+				return nil, auth.ErrNotActivated
+			}
+			return result.me, nil
+		}
+	}
+	me, err := whoAmI()
+	if c != nil && (err == nil || auth.IsErrNotActivated(err)) {
+		c.mu.Lock()
+		c.whoAmI[token] = whoAmIResult{me: me, notActivated: err != nil}
+		c.mu.Unlock()
+	}
+	return me, err
+}
+
+// getDecision returns the cached authorization decision for 'key', calling
+// 'authorize' to fill the cache if there isn't one
+func (c *authCache) getDecision(key authDecisionKey, authorize func() (bool, error)) (bool, error) {
+	if c != nil {
+		c.mu.Lock()
+		authorized, ok := c.decisions[key]
+		c.mu.Unlock()
+		if ok {
+			return authorized, nil
+		}
+	}
+	authorized, err := authorize()
+	if err != nil {
+		return false, err
+	}
+	if c != nil {
+		c.mu.Lock()
+		c.decisions[key] = authorized
+		c.mu.Unlock()
+	}
+	return authorized, nil
+}
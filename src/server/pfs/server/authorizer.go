@@ -0,0 +1,63 @@
+package server
+
+import (
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+
+	"golang.org/x/net/context"
+)
+
+// Authorizer decides whether the callers of PFS requests may access repos. By
+// default, PFS asks the Pachyderm auth service, but a custom Authorizer may be
+// given to NewAPIServer with WithAuthorizer, to enforce some other policy.
+type Authorizer interface {
+	// WhoAmI returns the caller of the request with 'ctx'. If it returns an
+	// error that auth.IsErrNotActivated matches, all operations are allowed.
+	WhoAmI(ctx context.Context) (*auth.WhoAmIResponse, error)
+	// Authorize returns whether the caller of the request with 'ctx' has at
+	// least 'scope' access to 'repo'
+	Authorize(ctx context.Context, repo string, scope auth.Scope) (bool, error)
+	// GetScope returns the caller's access to 'repo', which is reported when
+	// Authorize denies them access
+	GetScope(ctx context.Context, repo string) (auth.Scope, error)
+}
+
+// Option configures a PFS API server
+type Option func(*driver)
+
+// WithAuthorizer makes a PFS API server use 'authorizer' to authorize its
+// operations, instead of the auth service. Operations run in transactions are
+// authorized by it too.
+func WithAuthorizer(authorizer Authorizer) Option {
+	return func(d *driver) {
+		d.authorizer = authorizer
+	}
+}
+
+// clientAuthorizer is the default Authorizer, which asks the auth service
+type clientAuthorizer struct {
+	client auth.APIClient
+}
+
+func (a clientAuthorizer) WhoAmI(ctx context.Context) (*auth.WhoAmIResponse, error) {
+	return a.client.WhoAmI(ctx, &auth.WhoAmIRequest{})
+}
+
+func (a clientAuthorizer) Authorize(ctx context.Context, repo string, scope auth.Scope) (bool, error) {
+	resp, err := a.client.Authorize(ctx, &auth.AuthorizeRequest{Repo: repo, Scope: scope})
+	if err != nil {
+		return false, err
+	}
+	return resp.Authorized, nil
+}
+
+func (a clientAuthorizer) GetScope(ctx context.Context, repo string) (auth.Scope, error) {
+	resp, err := a.client.GetScope(ctx, &auth.GetScopeRequest{Repos: []string{repo}})
+	if err != nil {
+		return auth.Scope_NONE, err
+	}
+	if len(resp.Scopes) != 1 {
+		return auth.Scope_NONE, errors.Errorf("expected one scope for repo \"%s\", but got %d", repo, len(resp.Scopes))
+	}
+	return resp.Scopes[0], nil
+}
@@ -49,6 +49,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -111,6 +113,13 @@ type driver struct {
 	putObjectLimiter limit.ConcurrencyLimiter
 	// limits the total parallelism for uploading files over GRPC or loading from external sources
 	putFileLimiter limit.ConcurrencyLimiter
+	// limits how long each call to the auth service may take when checking an
+	// operation, if it's positive
+	authCheckTimeout time.Duration
+	// makes authorization decisions, if set. Otherwise, the auth service does.
+	authorizer Authorizer
+	// records authorization decisions, if set. Otherwise, they're logged.
+	authAuditSink AuthAuditSink
 
 	// New storage layer.
 	storage         *fileset.Storage
@@ -127,6 +136,7 @@ func newDriver(
 	treeCache *hashtree.Cache,
 	storageRoot string,
 	memoryRequest int64,
+	opts ...Option,
 ) (*driver, error) {
 	// Validate arguments
 	if treeCache == nil {
@@ -156,6 +166,16 @@ func newDriver(
 		putFileLimiter:   limit.New(env.StoragePutFileConcurrencyLimit),
 		// TODO: set maxFanIn based on downward API.
 	}
+	if env.PFSAuthCheckTimeout != "" {
+		timeout, err := time.ParseDuration(env.PFSAuthCheckTimeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse PFS_AUTH_CHECK_TIMEOUT")
+		}
+		d.authCheckTimeout = timeout
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
 
 	// Create spec repo (default repo)
 	repo := client.NewRepo(ppsconsts.SpecRepo)
@@ -174,43 +194,206 @@ func newDriver(
 
 // checkIsAuthorizedInTransaction is identicalto checkIsAuthorized except that
 // it performs reads consistent with the latest state of the STM transaction.
+// If the driver has a custom Authorizer, it's used instead, and can't see the
+// transaction's state.
 func (d *driver) checkIsAuthorizedInTransaction(txnCtx *txnenv.TransactionContext, r *pfs.Repo, s auth.Scope) error {
+	if d.authorizer != nil {
+		return d.checkIsAuthorizedForAll(txnCtx.ClientContext, d.authorizer, authRequirement{repo: r, scope: s})
+	}
 	me, err := txnCtx.Client.WhoAmI(txnCtx.ClientContext, &auth.WhoAmIRequest{})
 	if auth.IsErrNotActivated(err) {
 		return nil
 	}
+	if err != nil {
+		d.recordAuthDecision(txnCtx.ClientContext, nil, r.Name, s, false, "authorization check failed")
+		return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check for operation on \"%s\"", r.Name)
+	}
 
 	req := &auth.AuthorizeRequest{Repo: r.Name, Scope: s}
 	resp, err := txnCtx.Auth().AuthorizeInTransaction(txnCtx, req)
 	if err != nil {
+		d.recordAuthDecision(txnCtx.ClientContext, me, r.Name, s, false, "authorization check failed")
 		return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check for operation on \"%s\"", r.Name)
 	}
+	d.recordAuthDecision(txnCtx.ClientContext, me, r.Name, s, resp.Authorized, "")
 	if !resp.Authorized {
-		return &auth.ErrNotAuthorized{Subject: me.Username, Repo: r.Name, Required: s}
+		var actual *auth.Scope
+		scopeResp, err := txnCtx.Auth().GetScopeInTransaction(txnCtx, &auth.GetScopeRequest{Repos: []string{r.Name}})
+		if err == nil && len(scopeResp.Scopes) == 1 {
+			actual = actualScope(r.Name, scopeResp.Scopes[0], nil)
+		}
+		return &auth.ErrNotAuthorized{Subject: me.GetUsername(), Repo: r.Name, Required: s, Actual: actual}
 	}
 	return nil
 }
 
 // checkIsAuthorized returns an error if the current user (in 'pachClient') has
-// authorization scope 's' for repo 'r'
+// authorization scope 's' for repo 'r'. If 'pachClient' carries an authCache
+// (see withAuthCache), the results of the auth calls are memoized in it.
+// Unless auth isn't activated, the decision is recorded in the AuthAuditSink.
 func (d *driver) checkIsAuthorized(pachClient *client.APIClient, r *pfs.Repo, s auth.Scope) error {
-	ctx := pachClient.Ctx()
-	me, err := pachClient.WhoAmI(ctx, &auth.WhoAmIRequest{})
+	return d.checkIsAuthorizedForAll(pachClient.Ctx(), d.getAuthorizer(pachClient), authRequirement{repo: r, scope: s})
+}
+
+// getAuthorizer returns the Authorizer for checks of requests made with
+// 'pachClient', which is the auth service unless the driver has a custom one
+func (d *driver) getAuthorizer(pachClient *client.APIClient) Authorizer {
+	if d.authorizer != nil {
+		return d.authorizer
+	}
+	return clientAuthorizer{client: pachClient.AuthAPIClient}
+}
+
+// authRequirement is an authorization scope that an operation requires on a
+// repo
+type authRequirement struct {
+	repo  *pfs.Repo
+	scope auth.Scope
+}
+
+// checkIsAuthorizedForAll is like checkIsAuthorized, but checks that the
+// caller of the request with 'ctx' meets every requirement in 'reqs', which
+// operations on several repos need, using 'authorizer'. This isn't a single
+// check: the auth API can only authorize one repo at a time, so it still makes
+// one Authorize call per requirement (run concurrently, and cached per request
+// like checkIsAuthorized's), and only the WhoAmI call is shared between them.
+// If any requirement isn't met, the error names the first one (in the order
+// given) that isn't.
+func (d *driver) checkIsAuthorizedForAll(ctx context.Context, authorizer Authorizer, reqs ...authRequirement) error {
+	cache, token := getAuthCache(ctx), authToken(ctx)
+	me, err := cache.getWhoAmI(token, func() (*auth.WhoAmIResponse, error) {
+		authCtx, cancel := d.authCallContext(ctx)
+		defer cancel()
+		return authorizer.WhoAmI(authCtx)
+	})
 	if auth.IsErrNotActivated(err) {
 		return nil
 	}
+	if err != nil {
+		return d.whoAmIFailed(ctx, err, reqs)
+	}
 
-	req := &auth.AuthorizeRequest{Repo: r.Name, Scope: s}
-	resp, err := pachClient.AuthAPIClient.Authorize(ctx, req)
+	errs := make([]error, len(reqs))
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		i, req := i, req
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.authorize(ctx, authorizer, cache, token, me, req)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// whoAmIFailed handles a failed WhoAmI call for checkIsAuthorizedForAll. As
+// when Authorize fails, 'reqs' are allowed if the auth service is unavailable
+// and PFS_AUTH_FAIL_OPEN is set, and denied otherwise.
+func (d *driver) whoAmIFailed(ctx context.Context, err error, reqs []authRequirement) error {
+	reason := "authorization check failed"
+	if isAuthUnavailableErr(ctx, err) {
+		reason = "auth service unavailable"
+		if d.env.PFSAuthFailOpen {
+			for _, req := range reqs {
+				logrus.Warnf("auth service unavailable; allowing %v access to repo \"%s\" because PFS_AUTH_FAIL_OPEN is set: %v", req.scope, req.repo.Name, err)
+				d.recordAuthDecision(ctx, nil, req.repo.Name, req.scope, true, reason)
+			}
+			return nil
+		}
+		logrus.Errorf("auth service unavailable; denying access: %v", err)
+	}
+	for _, req := range reqs {
+		d.recordAuthDecision(ctx, nil, req.repo.Name, req.scope, false, reason)
+	}
+	if len(reqs) == 0 {
+		return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check")
+	}
+	return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check for %v access to \"%s\"", reqs[0].scope, reqs[0].repo.Name)
+}
+
+// authorize checks a single requirement for checkIsAuthorizedForAll
+func (d *driver) authorize(ctx context.Context, authorizer Authorizer, cache *authCache, token string, me *auth.WhoAmIResponse, req authRequirement) error {
+	r, s := req.repo, req.scope
+	key := authDecisionKey{token: token, repo: r.Name, scope: s}
+	authorized, err := cache.getDecision(key, func() (bool, error) {
+		authCtx, cancel := d.authCallContext(ctx)
+		defer cancel()
+		return authorizer.Authorize(authCtx, r.Name, s)
+	})
 	if err != nil {
-		return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check for operation on \"%s\"", r.Name)
+		reason := "authorization check failed"
+		if isAuthUnavailableErr(ctx, err) {
+			reason = "auth service unavailable"
+			if d.env.PFSAuthFailOpen {
+				logrus.Warnf("auth service unavailable; allowing %v access to repo \"%s\" because PFS_AUTH_FAIL_OPEN is set: %v", s, r.Name, err)
+				d.recordAuthDecision(ctx, me, r.Name, s, true, reason)
+				return nil
+			}
+			logrus.Errorf("auth service unavailable; denying %v access to repo \"%s\": %v", s, r.Name, err)
+		}
+		d.recordAuthDecision(ctx, me, r.Name, s, false, reason)
+		return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check for %v access to \"%s\"", s, r.Name)
 	}
-	if !resp.Authorized {
-		return &auth.ErrNotAuthorized{Subject: me.Username, Repo: r.Name, Required: s}
+	d.recordAuthDecision(ctx, me, r.Name, s, authorized, "")
+	if !authorized {
+		authCtx, cancel := d.authCallContext(ctx)
+		defer cancel()
+		scope, err := authorizer.GetScope(authCtx, r.Name)
+		actual := actualScope(r.Name, scope, err)
+		return &auth.ErrNotAuthorized{Subject: me.GetUsername(), Repo: r.Name, Required: s, Actual: actual}
 	}
 	return nil
 }
 
+// actualScope returns the scope that a user who was denied access to 'repo'
+// has on it, for reporting in the error, given the result of a GetScope call
+// for 'repo' made on the user's behalf. It returns nil if the scope isn't
+// known.
+func actualScope(repo string, scope auth.Scope, err error) *auth.Scope {
+	if repo == ppsconsts.SpecRepo {
+		// All users may read the spec repo, regardless of its ACL
+		scope = auth.Scope_READER
+		return &scope
+	}
+	if err != nil {
+		return nil
+	}
+	return &scope
+}
+
+// authCallContext returns the context for a call to the auth service made
+// while serving a request with 'ctx', which is limited to the driver's
+// authCheckTimeout
+func (d *driver) authCallContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.authCheckTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d.authCheckTimeout)
+}
+
+// isAuthUnavailableErr returns true if 'err', returned by a call to the auth
+// service made while serving a request with 'ctx', means that the auth service
+// couldn't be reached or didn't respond in time, as opposed to it having
+// failed to serve the call. An auth service that's only partially activated is
+// reachable, even though it reports that it's unavailable, so that isn't
+// treated as unreachable.
+func isAuthUnavailableErr(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return !auth.IsErrPartiallyActivated(err)
+	case codes.DeadlineExceeded:
+		// The call timed out, rather than the request that it was made for
+		return ctx.Err() == nil
+	}
+	return false
+}
+
 func (d *driver) createRepo(txnCtx *txnenv.TransactionContext, repo *pfs.Repo, description string, update bool) error {
 	// Validate arguments
 	if repo == nil {
@@ -238,7 +421,7 @@ func (d *driver) createRepo(txnCtx *txnenv.TransactionContext, repo *pfs.Repo, d
 	if err != nil && !col.IsErrNotFound(err) {
 		return errors.Wrapf(err, "error checking whether \"%s\" exists", repo.Name)
 	} else if err == nil && !update {
-		return errors.Errorf("cannot create \"%s\" as it already exists", repo.Name)
+		return pfsserver.ErrRepoExists{Repo: repo}
 	}
 
 	// Create ACL for new repo
@@ -535,6 +718,20 @@ func (e ErrProvenanceOfSubvenance) Error() string {
 // If fix is true it will attempt to fix as many of these issues as it can.
 func (d *driver) fsck(pachClient *client.APIClient, fix bool, cb func(*pfs.FsckResponse) error) error {
 	ctx := pachClient.Ctx()
+
+	// fixing modifies the metadata of every repo, so only admins may do it
+	if fix {
+		if me, err := pachClient.WhoAmI(ctx, &auth.WhoAmIRequest{}); err == nil {
+			if !me.IsAdmin {
+				return &auth.ErrNotAuthorized{
+					Subject: me.Username,
+					AdminOp: "Fsck with fix",
+				}
+			}
+		} else if !auth.IsErrNotActivated(err) {
+			return errors.Wrapf(grpcutil.ScrubGRPC(err), "error during authorization check")
+		}
+	}
 	repos := d.repos.ReadOnly(ctx)
 	key := path.Join
 
@@ -3187,10 +3384,10 @@ func (d *driver) copyFile(pachClient *client.APIClient, src *pfs.File, dst *pfs.
 		return errors.New("dst commit repo cannot be nil")
 	}
 
-	if err := d.checkIsAuthorized(pachClient, src.Commit.Repo, auth.Scope_READER); err != nil {
-		return err
-	}
-	if err := d.checkIsAuthorized(pachClient, dst.Commit.Repo, auth.Scope_WRITER); err != nil {
+	if err := d.checkIsAuthorizedForAll(pachClient.Ctx(), d.getAuthorizer(pachClient),
+		authRequirement{repo: src.Commit.Repo, scope: auth.Scope_READER},
+		authRequirement{repo: dst.Commit.Repo, scope: auth.Scope_WRITER},
+	); err != nil {
 		return err
 	}
 	if err := d.checkFilePath(dst.Path); err != nil {
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
+	txnenv "github.com/pachyderm/pachyderm/src/server/pkg/transactionenv"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// prefixAuthorizer is an Authorizer with a bespoke policy: users may write to
+// the repos whose names begin with their username, and read all others
+type prefixAuthorizer struct{}
+
+func (prefixAuthorizer) WhoAmI(ctx context.Context) (*auth.WhoAmIResponse, error) {
+	return &auth.WhoAmIResponse{Username: authToken(ctx)}, nil
+}
+
+func (a prefixAuthorizer) Authorize(ctx context.Context, repo string, scope auth.Scope) (bool, error) {
+	actual, err := a.GetScope(ctx, repo)
+	return actual >= scope, err
+}
+
+func (prefixAuthorizer) GetScope(ctx context.Context, repo string) (auth.Scope, error) {
+	if strings.HasPrefix(repo, authToken(ctx)) {
+		return auth.Scope_WRITER, nil
+	}
+	return auth.Scope_READER, nil
+}
+
+func TestCustomAuthorizer(t *testing.T) {
+	d := &driver{}
+	WithAuthorizer(prefixAuthorizer{})(d)
+	// The client has no auth API client, so only the custom authorizer can
+	// authorize its requests. Its token is the username that the authorizer
+	// reports.
+	pachClient := &client.APIClient{}
+	pachClient.SetAuthToken("alice")
+
+	require.NoError(t, d.checkIsAuthorized(pachClient, client.NewRepo("alice-data"), auth.Scope_WRITER))
+	require.NoError(t, d.checkIsAuthorized(pachClient, client.NewRepo("bob-data"), auth.Scope_READER))
+	err := d.checkIsAuthorized(pachClient, client.NewRepo("bob-data"), auth.Scope_WRITER)
+	require.YesError(t, err)
+	require.Matches(t, "alice is not authorized.*bob-data.*must have at least WRITER access but has READER access", err.Error())
+
+	// Transactional checks use it too
+	txnCtx := &txnenv.TransactionContext{ClientContext: pachClient.Ctx()}
+	require.NoError(t, d.checkIsAuthorizedInTransaction(txnCtx, client.NewRepo("alice-data"), auth.Scope_WRITER))
+	require.YesError(t, d.checkIsAuthorizedInTransaction(txnCtx, client.NewRepo("bob-data"), auth.Scope_WRITER))
+}
+
+// whoAmIFailingAuthorizer is an Authorizer that can't identify callers, and
+// denies them access to every repo
+type whoAmIFailingAuthorizer struct {
+	err error
+}
+
+func (a whoAmIFailingAuthorizer) WhoAmI(context.Context) (*auth.WhoAmIResponse, error) {
+	return nil, a.err
+}
+
+func (whoAmIFailingAuthorizer) Authorize(context.Context, string, auth.Scope) (bool, error) {
+	return false, nil
+}
+
+func (whoAmIFailingAuthorizer) GetScope(context.Context, string) (auth.Scope, error) {
+	return auth.Scope_NONE, nil
+}
+
+func TestWhoAmIFailure(t *testing.T) {
+	newDriver := func(authorizer Authorizer, failOpen bool) *driver {
+		d := &driver{env: &serviceenv.ServiceEnv{Configuration: &serviceenv.Configuration{
+			PachdSpecificConfiguration: &serviceenv.PachdSpecificConfiguration{PFSAuthFailOpen: failOpen},
+		}}}
+		WithAuthorizer(authorizer)(d)
+		return d
+	}
+	pachClient := &client.APIClient{}
+	repo := client.NewRepo("data")
+
+	// A failed WhoAmI call fails the check, rather than the denial that
+	// follows it being reported for a nil user
+	for _, failOpen := range []bool{false, true} {
+		d := newDriver(whoAmIFailingAuthorizer{err: status.Error(codes.Internal, "internal error")}, failOpen)
+		err := d.checkIsAuthorized(pachClient, repo, auth.Scope_READER)
+		require.YesError(t, err)
+		require.False(t, auth.IsErrNotAuthorized(err))
+		require.Matches(t, "error during authorization check.*internal error", err.Error())
+	}
+
+	// If the auth service is unavailable, PFS_AUTH_FAIL_OPEN applies
+	unavailable := whoAmIFailingAuthorizer{err: status.Error(codes.Unavailable, "connection refused")}
+	err := newDriver(unavailable, false).checkIsAuthorized(pachClient, repo, auth.Scope_READER)
+	require.YesError(t, err)
+	require.Matches(t, "connection refused", err.Error())
+	require.NoError(t, newDriver(unavailable, true).checkIsAuthorized(pachClient, repo, auth.Scope_READER))
+
+	// An Authorizer that returns no user still gets a denial
+	err = newDriver(whoAmIFailingAuthorizer{}, false).checkIsAuthorized(pachClient, repo, auth.Scope_READER)
+	require.YesError(t, err)
+	require.True(t, auth.IsErrNotAuthorized(err))
+}
@@ -51,8 +51,9 @@ func newDriverV2(
 	treeCache *hashtree.Cache,
 	storageRoot string,
 	memoryRequest int64,
+	opts ...Option,
 ) (*driverV2, error) {
-	d1, err := newDriver(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest)
+	d1, err := newDriver(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -36,11 +36,12 @@ func NewAPIServer(
 	treeCache *hashtree.Cache,
 	storageRoot string,
 	memoryRequest int64,
+	opts ...Option,
 ) (APIServer, error) {
 	if env.StorageV2 {
-		return newAPIServerV2(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest)
+		return newAPIServerV2(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest, opts...)
 	}
-	return newAPIServer(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest)
+	return newAPIServer(env, txnEnv, etcdPrefix, treeCache, storageRoot, memoryRequest, opts...)
 }
 
 // NewBlockAPIServer creates a BlockAPIServer using the credentials it finds in
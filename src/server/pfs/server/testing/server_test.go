@@ -20,13 +20,16 @@ import (
 	"time"
 
 	pclient "github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs/server"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
 	"github.com/pachyderm/pachyderm/src/server/pkg/sql"
 	pfssync "github.com/pachyderm/pachyderm/src/server/pkg/sync"
 	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
@@ -36,6 +39,8 @@ import (
 	"github.com/gogo/protobuf/types"
 	"golang.org/x/net/context"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -6056,6 +6061,294 @@ func TestFsckFix(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestAuthChecksMemoized tests that the auth calls made while serving a
+// request are memoized for the duration of that request only
+func TestAuthChecksMemoized(t *testing.T) {
+	t.Parallel()
+	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		repo := tu.UniqueString("TestAuthChecksMemoized")
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		_, err := env.PachClient.PutFile(repo, "master", "foo", strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		_, err = env.PachClient.PutFile(repo, "master", "foo", strings.NewReader("bar\n"))
+		require.NoError(t, err)
+
+		var whoAmICalls, authorizeCalls int64
+		env.MockPachd.Auth.WhoAmI.Use(func(context.Context, *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+			atomic.AddInt64(&whoAmICalls, 1)
+			return &auth.WhoAmIResponse{Username: "alice"}, nil
+		})
+		env.MockPachd.Auth.Authorize.Use(func(context.Context, *auth.AuthorizeRequest) (*auth.AuthorizeResponse, error) {
+			atomic.AddInt64(&authorizeCalls, 1)
+			return &auth.AuthorizeResponse{Authorized: true}, nil
+		})
+
+		// DiffFile checks both of its files, and then inspects their commits,
+		// all of which are in the same repo
+		_, _, err = env.PachClient.DiffFile(repo, "master", "", repo, "master^", "", false)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), atomic.LoadInt64(&whoAmICalls))
+		require.Equal(t, int64(1), atomic.LoadInt64(&authorizeCalls))
+
+		// The results aren't reused by later requests
+		_, _, err = env.PachClient.DiffFile(repo, "master", "", repo, "master^", "", false)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), atomic.LoadInt64(&whoAmICalls))
+		require.Equal(t, int64(2), atomic.LoadInt64(&authorizeCalls))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+// TestAuthServiceUnavailable tests that PFS operations fail if the auth
+// service can't be reached, unless PFSAuthFailOpen is set
+func TestAuthServiceUnavailable(t *testing.T) {
+	t.Parallel()
+	for _, failOpen := range []bool{false, true} {
+		failOpen := failOpen
+		t.Run(fmt.Sprintf("FailOpen=%t", failOpen), func(t *testing.T) {
+			t.Parallel()
+			config := &serviceenv.PachdFullConfiguration{}
+			config.PFSAuthFailOpen = failOpen
+			err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+				repo := tu.UniqueString("TestAuthServiceUnavailable")
+				require.NoError(t, env.PachClient.CreateRepo(repo))
+				_, err := env.PachClient.PutFile(repo, "master", "foo", strings.NewReader("foo\n"))
+				require.NoError(t, err)
+
+				env.MockPachd.Auth.WhoAmI.Use(func(context.Context, *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+					return &auth.WhoAmIResponse{Username: "alice"}, nil
+				})
+				useAuthorize := func(resp *auth.AuthorizeResponse, err error) {
+					env.MockPachd.Auth.Authorize.Use(func(context.Context, *auth.AuthorizeRequest) (*auth.AuthorizeResponse, error) {
+						return resp, err
+					})
+				}
+
+				// The auth service can't be reached
+				useAuthorize(nil, status.Error(codes.Unavailable, "connection refused"))
+				_, err = env.PachClient.InspectFile(repo, "master", "foo")
+				if failOpen {
+					require.NoError(t, err)
+				} else {
+					require.YesError(t, err)
+					require.Matches(t, "connection refused", err.Error())
+				}
+
+				// The policy doesn't apply to the auth service's own errors, or to
+				// an auth service that's only partially activated
+				for _, authErr := range []error{
+					status.Error(codes.Internal, "internal error"),
+					auth.ErrPartiallyActivated,
+				} {
+					useAuthorize(nil, authErr)
+					_, err = env.PachClient.InspectFile(repo, "master", "foo")
+					require.YesError(t, err)
+				}
+
+				// Nor does it apply to genuine denials
+				useAuthorize(&auth.AuthorizeResponse{Authorized: false}, nil)
+				_, err = env.PachClient.InspectFile(repo, "master", "foo")
+				require.YesError(t, err)
+				require.True(t, auth.IsErrNotAuthorized(err))
+				return nil
+			}, config)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestAuthCheckTimeout tests that PFS doesn't wait longer than
+// PFSAuthCheckTimeout for the auth service, and that a timeout is treated
+// like an unreachable auth service
+func TestAuthCheckTimeout(t *testing.T) {
+	t.Parallel()
+	for _, failOpen := range []bool{false, true} {
+		failOpen := failOpen
+		t.Run(fmt.Sprintf("FailOpen=%t", failOpen), func(t *testing.T) {
+			t.Parallel()
+			config := &serviceenv.PachdFullConfiguration{}
+			config.PFSAuthCheckTimeout = "200ms"
+			config.PFSAuthFailOpen = failOpen
+			err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+				repo := tu.UniqueString("TestAuthCheckTimeout")
+				require.NoError(t, env.PachClient.CreateRepo(repo))
+				_, err := env.PachClient.PutFile(repo, "master", "foo", strings.NewReader("foo\n"))
+				require.NoError(t, err)
+
+				env.MockPachd.Auth.WhoAmI.Use(func(context.Context, *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+					return &auth.WhoAmIResponse{Username: "alice"}, nil
+				})
+				env.MockPachd.Auth.Authorize.Use(func(ctx context.Context, _ *auth.AuthorizeRequest) (*auth.AuthorizeResponse, error) {
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(time.Minute):
+						return &auth.AuthorizeResponse{Authorized: true}, nil
+					}
+				})
+
+				start := time.Now()
+				_, err = env.PachClient.InspectFile(repo, "master", "foo")
+				require.True(t, time.Since(start) < 10*time.Second)
+				if failOpen {
+					require.NoError(t, err)
+				} else {
+					require.YesError(t, err)
+					require.Matches(t, "deadline exceeded", err.Error())
+				}
+				return nil
+			}, config)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestCopyFileAuthorization tests that CopyFile checks both of its repos,
+// and that a failed check names the repo and scope that the user lacks, and
+// the scope that they have
+func TestCopyFileAuthorization(t *testing.T) {
+	t.Parallel()
+	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		src, dst := tu.UniqueString("TestCopyFileAuthorizationSrc"), tu.UniqueString("TestCopyFileAuthorizationDst")
+		require.NoError(t, env.PachClient.CreateRepo(src))
+		require.NoError(t, env.PachClient.CreateRepo(dst))
+		_, err := env.PachClient.PutFile(src, "master", "foo", strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		_, err = env.PachClient.PutFile(dst, "master", "bar", strings.NewReader("bar\n"))
+		require.NoError(t, err)
+
+		var whoAmICalls int64
+		env.MockPachd.Auth.WhoAmI.Use(func(context.Context, *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+			atomic.AddInt64(&whoAmICalls, 1)
+			return &auth.WhoAmIResponse{Username: "alice"}, nil
+		})
+		var mu sync.Mutex
+		var scopes map[string]auth.Scope
+		setScopes := func(s map[string]auth.Scope) {
+			mu.Lock()
+			defer mu.Unlock()
+			scopes = s
+		}
+		env.MockPachd.Auth.Authorize.Use(func(_ context.Context, req *auth.AuthorizeRequest) (*auth.AuthorizeResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return &auth.AuthorizeResponse{Authorized: scopes[req.Repo] >= req.Scope}, nil
+		})
+		env.MockPachd.Auth.GetScope.Use(func(_ context.Context, req *auth.GetScopeRequest) (*auth.GetScopeResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			resp := &auth.GetScopeResponse{}
+			for _, repo := range req.Repos {
+				resp.Scopes = append(resp.Scopes, scopes[repo])
+			}
+			return resp, nil
+		})
+		copyFile := func() error {
+			return env.PachClient.CopyFile(src, "master", "foo", dst, "master", "foo", true)
+		}
+
+		// alice can't write to dst
+		setScopes(map[string]auth.Scope{src: auth.Scope_OWNER, dst: auth.Scope_READER})
+		err = copyFile()
+		require.YesError(t, err)
+		require.True(t, auth.IsErrNotAuthorized(err))
+		require.Matches(t, dst, err.Error())
+		require.Matches(t, "must have at least WRITER access but has READER access", err.Error())
+		require.Equal(t, int64(1), atomic.LoadInt64(&whoAmICalls))
+
+		// alice can't read src
+		setScopes(map[string]auth.Scope{src: auth.Scope_NONE, dst: auth.Scope_WRITER})
+		err = copyFile()
+		require.YesError(t, err)
+		require.True(t, auth.IsErrNotAuthorized(err))
+		require.Matches(t, src, err.Error())
+		require.Matches(t, "must have at least READER access but has NONE access", err.Error())
+
+		// alice can't do either, and the first requirement (reading src) is named
+		setScopes(map[string]auth.Scope{})
+		err = copyFile()
+		require.YesError(t, err)
+		require.Matches(t, src, err.Error())
+		require.False(t, strings.Contains(err.Error(), dst))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+// recordingAuthAuditSink is an AuthAuditSink that keeps the decisions made
+type recordingAuthAuditSink struct {
+	mu        sync.Mutex
+	decisions []pfsserver.AuthDecision
+}
+
+func (s *recordingAuthAuditSink) RecordAuthDecision(d *pfsserver.AuthDecision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decisions = append(s.decisions, *d)
+}
+
+func (s *recordingAuthAuditSink) take() []pfsserver.AuthDecision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	decisions := s.decisions
+	s.decisions = nil
+	return decisions
+}
+
+// TestAuthAudit tests that authorization decisions are recorded in the
+// AuthAuditSink, unless auth isn't activated
+func TestAuthAudit(t *testing.T) {
+	t.Parallel()
+	sink := &recordingAuthAuditSink{}
+	err := testpachd.WithRealEnvPFSOptions(func(env *testpachd.RealEnv) error {
+		repo := tu.UniqueString("TestAuthAudit")
+
+		// Auth isn't activated, so there are no decisions to record
+		require.NoError(t, env.PachClient.CreateRepo(repo))
+		_, err := env.PachClient.PutFile(repo, "master", "foo", strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		_, err = env.PachClient.InspectFile(repo, "master", "foo")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(sink.take()))
+
+		env.MockPachd.Auth.WhoAmI.Use(func(context.Context, *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+			return &auth.WhoAmIResponse{Username: "alice"}, nil
+		})
+		var authorized int32 = 1
+		env.MockPachd.Auth.Authorize.Use(func(context.Context, *auth.AuthorizeRequest) (*auth.AuthorizeResponse, error) {
+			return &auth.AuthorizeResponse{Authorized: atomic.LoadInt32(&authorized) == 1}, nil
+		})
+
+		_, err = env.PachClient.InspectFile(repo, "master", "foo")
+		require.NoError(t, err)
+		decisions := sink.take()
+		require.True(t, len(decisions) > 0)
+		for _, d := range decisions {
+			require.Equal(t, pfsserver.AuthDecision{
+				Subject:   "alice",
+				Repo:      repo,
+				Required:  auth.Scope_READER,
+				Allowed:   true,
+				Operation: "/pfs.API/InspectFile",
+			}, d)
+		}
+
+		atomic.StoreInt32(&authorized, 0)
+		_, err = env.PachClient.InspectFile(repo, "master", "foo")
+		require.YesError(t, err)
+		require.Equal(t, []pfsserver.AuthDecision{{
+			Subject:   "alice",
+			Repo:      repo,
+			Required:  auth.Scope_READER,
+			Allowed:   false,
+			Operation: "/pfs.API/InspectFile",
+		}}, sink.take())
+		return nil
+	}, []pfsserver.Option{pfsserver.WithAuthAuditSink(sink)})
+	require.NoError(t, err)
+}
+
 func TestPutFileAtomic(t *testing.T) {
 	t.Parallel()
 	err := testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
@@ -0,0 +1,98 @@
+package backoff
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+/*
+DecorrelatedJitterBackOff is a backoff implementation that randomizes each
+backoff period based on the previous one, so that clients retrying the same
+failing dependency don't retry in lockstep. This is the "decorrelated jitter"
+algorithm from
+https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/:
+
+ backoff = min(Cap, random value in range [Base, previous backoff * 3))
+
+where the first previous backoff is Base. Every backoff period is therefore in
+the range [Base, Cap].
+
+If the time elapsed since a DecorrelatedJitterBackOff instance is created goes
+past the MaxElapsedTime, then the method NextBackOff() starts returning
+backoff.Stop.
+
+Note: Implementation is not thread-safe.
+*/
+type DecorrelatedJitterBackOff struct {
+	Base time.Duration
+	Cap  time.Duration
+	// After MaxElapsedTime the DecorrelatedJitterBackOff stops.
+	// It never stops if MaxElapsedTime == 0.
+	MaxElapsedTime time.Duration
+	Clock          Clock
+
+	previous  time.Duration
+	startTime time.Time
+	rand      *rand.Rand
+}
+
+// jitterSeed distinguishes the random sources of instances created at the same
+// time
+var jitterSeed int64
+
+// NewDecorrelatedJitterBackOff creates an instance of DecorrelatedJitterBackOff
+// whose backoff periods are in the range ['base', 'cap']. Each instance has its
+// own random source, so that instances' backoff periods are independent.
+func NewDecorrelatedJitterBackOff(base, cap time.Duration) *DecorrelatedJitterBackOff {
+	if cap < base {
+		cap = base
+	}
+	seed := time.Now().UnixNano() + atomic.AddInt64(&jitterSeed, 1)
+	b := &DecorrelatedJitterBackOff{
+		Base:  base,
+		Cap:   cap,
+		Clock: SystemClock,
+		rand:  rand.New(rand.NewSource(seed)),
+	}
+	b.Reset()
+	return b
+}
+
+// For sets b.MaxElapsedTime to 'maxElapsed' and returns b
+func (b *DecorrelatedJitterBackOff) For(maxElapsed time.Duration) *DecorrelatedJitterBackOff {
+	b.MaxElapsedTime = maxElapsed
+	return b
+}
+
+// Reset the previous backoff period back to Base and restarts the timer.
+func (b *DecorrelatedJitterBackOff) Reset() {
+	b.previous = b.Base
+	b.startTime = b.Clock.Now()
+}
+
+// NextBackOff calculates the next backoff period using the formula:
+// 	backoff = min(Cap, random value in range [Base, previous backoff * 3))
+func (b *DecorrelatedJitterBackOff) NextBackOff() time.Duration {
+	// Make sure we have not gone over the maximum elapsed time.
+	if b.MaxElapsedTime != 0 && b.GetElapsedTime() > b.MaxElapsedTime {
+		return Stop
+	}
+	upper := b.previous * 3
+	// Check for overflow, and stay within Cap
+	if upper < b.previous || upper > b.Cap {
+		upper = b.Cap
+	}
+	next := b.Base
+	if upper > b.Base {
+		next += time.Duration(b.rand.Int63n(int64(upper - b.Base)))
+	}
+	b.previous = next
+	return next
+}
+
+// GetElapsedTime returns the elapsed time since a DecorrelatedJitterBackOff
+// instance is created and is reset when Reset() is called.
+func (b *DecorrelatedJitterBackOff) GetElapsedTime() time.Duration {
+	return b.Clock.Now().Sub(b.startTime)
+}
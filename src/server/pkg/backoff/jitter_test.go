@@ -0,0 +1,84 @@
+package backoff
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecorrelatedJitterBackOff(t *testing.T) {
+	var (
+		testBase = 100 * time.Millisecond
+		testCap  = 5 * time.Second
+	)
+	b := NewDecorrelatedJitterBackOff(testBase, testCap)
+	previous := testBase
+	var reachedCap bool
+	for i := 0; i < 1000; i++ {
+		next := b.NextBackOff()
+		// Each backoff is within the configured bounds, and at most three
+		// times the previous one
+		if next < testBase || next > testCap {
+			t.Fatalf("backoff %d: %v is outside of [%v, %v]", i, next, testBase, testCap)
+		}
+		if next > 3*previous {
+			t.Fatalf("backoff %d: %v is more than 3 * %v", i, next, previous)
+		}
+		if next > testCap/2 {
+			reachedCap = true
+		}
+		previous = next
+	}
+	if !reachedCap {
+		t.Errorf("backoff never grew towards its cap of %v", testCap)
+	}
+
+	// Reset starts again from the base
+	b.Reset()
+	if next := b.NextBackOff(); next < testBase || next >= 3*testBase {
+		t.Errorf("backoff after reset: %v is outside of [%v, %v)", next, testBase, 3*testBase)
+	}
+}
+
+func TestDecorrelatedJitterRandomized(t *testing.T) {
+	// Instances created together don't back off in lockstep
+	var sequences [][]time.Duration
+	for i := 0; i < 10; i++ {
+		b := NewDecorrelatedJitterBackOff(100*time.Millisecond, 10*time.Second)
+		var sequence []time.Duration
+		for j := 0; j < 5; j++ {
+			sequence = append(sequence, b.NextBackOff())
+		}
+		sequences = append(sequences, sequence)
+	}
+	distinct := make(map[time.Duration]bool)
+	for _, sequence := range sequences {
+		distinct[sequence[len(sequence)-1]] = true
+	}
+	if len(distinct) < 5 {
+		t.Errorf("expected randomized backoffs, but saw %v", sequences)
+	}
+}
+
+func TestDecorrelatedJitterBounds(t *testing.T) {
+	// A cap below the base is raised to it
+	b := NewDecorrelatedJitterBackOff(time.Second, time.Millisecond)
+	for i := 0; i < 10; i++ {
+		assertEquals(t, time.Second, b.NextBackOff())
+	}
+
+	// Large backoffs don't overflow
+	b = NewDecorrelatedJitterBackOff(math.MaxInt64/4, math.MaxInt64)
+	for i := 0; i < 100; i++ {
+		if next := b.NextBackOff(); next < math.MaxInt64/4 {
+			t.Fatalf("backoff %d: %v is below the base", i, next)
+		}
+	}
+}
+
+func TestDecorrelatedJitterMaxElapsedTime(t *testing.T) {
+	b := NewDecorrelatedJitterBackOff(time.Millisecond, time.Second).For(time.Minute)
+	b.Clock = &TestClock{start: time.Time{}.Add(10000 * time.Second)}
+	b.startTime = time.Time{}
+	assertEquals(t, Stop, b.NextBackOff())
+}
@@ -0,0 +1,40 @@
+package backoff
+
+import "time"
+
+// WithMaxTries returns a BackOff that follows 'b', but stops once an operation
+// has been tried 'maxTries' times (i.e. after 'maxTries'-1 retries), so that
+// Retry and RetryNotify return the error from the last try. A 'maxTries' of 0
+// means that the number of tries isn't limited beyond 'b' itself.
+//
+// Note: Implementation is not thread-safe
+func WithMaxTries(b BackOff, maxTries uint64) BackOff {
+	return &backOffTries{
+		delegate: b,
+		maxTries: maxTries,
+	}
+}
+
+type backOffTries struct {
+	delegate BackOff
+	maxTries uint64
+	numTries uint64
+}
+
+// NextBackOff ...
+func (b *backOffTries) NextBackOff() time.Duration {
+	if b.maxTries > 0 {
+		// Each call follows a failed try
+		b.numTries++
+		if b.numTries >= b.maxTries {
+			return Stop
+		}
+	}
+	return b.delegate.NextBackOff()
+}
+
+// Reset ...
+func (b *backOffTries) Reset() {
+	b.numTries = 0
+	b.delegate.Reset()
+}
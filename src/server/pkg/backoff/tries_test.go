@@ -0,0 +1,71 @@
+package backoff
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMaxTries(t *testing.T) {
+	const maxTries = 5
+	var tries int
+	err := Retry(func() error {
+		tries++
+		return fmt.Errorf("error %d", tries)
+	}, WithMaxTries(&ZeroBackOff{}, maxTries))
+	if tries != maxTries {
+		t.Errorf("expected %d tries, but saw %d", maxTries, tries)
+	}
+	if err == nil || err.Error() != fmt.Sprintf("error %d", maxTries) {
+		t.Errorf("expected the error from the last try, but got %v", err)
+	}
+
+	// Retry resets the count, so the same BackOff can be reused
+	b := WithMaxTries(&ZeroBackOff{}, maxTries)
+	for i := 0; i < 2; i++ {
+		tries = 0
+		Retry(func() error {
+			tries++
+			return fmt.Errorf("error")
+		}, b)
+		if tries != maxTries {
+			t.Errorf("expected %d tries, but saw %d", maxTries, tries)
+		}
+	}
+
+	// Operations that succeed stop being retried
+	tries = 0
+	err = Retry(func() error {
+		tries++
+		if tries < 3 {
+			return fmt.Errorf("error")
+		}
+		return nil
+	}, WithMaxTries(&ZeroBackOff{}, maxTries))
+	if err != nil || tries != 3 {
+		t.Errorf("expected success after 3 tries, but saw %d (%v)", tries, err)
+	}
+}
+
+func TestMaxTriesDelegate(t *testing.T) {
+	// The wrapped BackOff's delays are used, and it can still stop early
+	b := WithMaxTries(NewConstantBackOff(time.Second), 3)
+	b.Reset()
+	for i, expected := range []time.Duration{time.Second, time.Second, Stop, Stop} {
+		if next := b.NextBackOff(); next != expected {
+			t.Errorf("backoff %d: got %v, expected %v", i, next, expected)
+		}
+	}
+	b = WithMaxTries(&StopBackOff{}, 3)
+	if next := b.NextBackOff(); next != Stop {
+		t.Errorf("got %v, expected %v", next, Stop)
+	}
+
+	// 0 doesn't limit the number of tries
+	b = WithMaxTries(&ZeroBackOff{}, 0)
+	for i := 0; i < 100; i++ {
+		if next := b.NextBackOff(); next != 0 {
+			t.Fatalf("backoff %d: got %v, expected 0", i, next)
+		}
+	}
+}
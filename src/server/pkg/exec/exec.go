@@ -33,6 +33,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // Error records the name of a binary that failed to be executed
@@ -107,6 +108,12 @@ type Cmd struct {
 	// Run passes it to os.StartProcess as the os.ProcAttr's Sys field.
 	SysProcAttr *syscall.SysProcAttr
 
+	// GracePeriod is how long the process is given to exit after being sent
+	// SIGTERM when the command's context becomes done, before it is sent
+	// SIGKILL. If GracePeriod is zero, or the process cannot be signalled
+	// (e.g. on Windows), the process is killed immediately.
+	GracePeriod time.Duration
+
 	// Process is the underlying process, once started.
 	Process *os.Process
 
@@ -157,7 +164,9 @@ func Command(name string, arg ...string) *Cmd {
 //
 // The provided context is used to kill the process (by calling
 // os.Process.Kill) if the context becomes done before the command
-// completes on its own.
+// completes on its own. If the Cmd's GracePeriod is set, the process is
+// first sent SIGTERM and only killed if it has not exited by the end of the
+// grace period.
 func CommandContext(ctx context.Context, name string, arg ...string) *Cmd {
 	if ctx == nil {
 		panic("nil Context")
@@ -387,7 +396,7 @@ func (c *Cmd) Start() error {
 		go func() {
 			select {
 			case <-c.ctx.Done():
-				c.Process.Kill()
+				c.terminate()
 			case <-c.waitDone:
 			}
 		}()
@@ -396,6 +405,21 @@ func (c *Cmd) Start() error {
 	return nil
 }
 
+// terminate stops the process, first asking it to exit with SIGTERM if a
+// GracePeriod is set, and killing it if it is still running afterwards.
+func (c *Cmd) terminate() {
+	if c.GracePeriod > 0 && c.Process.Signal(syscall.SIGTERM) == nil {
+		timer := time.NewTimer(c.GracePeriod)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-c.waitDone:
+			return
+		}
+	}
+	c.Process.Kill()
+}
+
 // An ExitError reports an unsuccessful exit by a command.
 type ExitError struct {
 	*os.ProcessState
@@ -2,11 +2,15 @@ package hashtree
 
 import (
 	"io"
+	"io/ioutil"
+	"os"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/hashicorp/golang-lru/simplelru"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/localcache"
 	"github.com/sirupsen/logrus"
 )
@@ -124,29 +128,174 @@ func (c *Cache) GetOrAdd(key interface{}, generator func() (HashTree, error)) (H
 	return newValue, nil
 }
 
-// MergeCache is an unbounded hashtree cache that can merge the hashtrees in the cache.
+// MergeCache is a hashtree cache that can merge the hashtrees in the cache.
+// It's unbounded unless it's created with NewBoundedMergeCache, in which case
+// the least recently used hashtrees are evicted to keep it under its limits.
+// It's safe for concurrent use.
 type MergeCache struct {
 	*localcache.Cache
+	root       string
+	maxEntries int
+	maxBytes   int64
+
+	// mu guards the fields below, as well as evictions, so that hashtrees
+	// aren't evicted while they're being opened
+	mu      sync.Mutex
+	entries map[string]*mergeCacheEntry
+	size    int64
+	clock   uint64
+}
+
+type mergeCacheEntry struct {
+	size     int64
+	lastUsed uint64
 }
 
-// NewMergeCache creates a new cache.
+// NewMergeCache creates a new unbounded cache.
 func NewMergeCache(root string) (*MergeCache, error) {
+	return NewBoundedMergeCache(root, 0, 0)
+}
+
+// NewBoundedMergeCache creates a new cache that holds at most maxEntries
+// hashtrees, totalling at most maxBytes bytes. A limit of 0 means that there
+// is no limit. The most recently put hashtree is never evicted, so a single
+// hashtree larger than maxBytes is still cached.
+//
+// Evicted hashtrees are no longer merged by Merge, so a bounded cache should
+// only hold hashtrees that can be fetched again (see MergeKeys).
+func NewBoundedMergeCache(root string, maxEntries int, maxBytes int64) (*MergeCache, error) {
 	cache, err := localcache.NewCache(root)
 	if err != nil {
 		return nil, err
 	}
 
-	return &MergeCache{Cache: cache}, nil
+	return &MergeCache{
+		Cache:      cache,
+		root:       root,
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*mergeCacheEntry),
+	}, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
 }
 
 // Put puts an id/hashtree pair in the cache and reads the hashtree from the passed in io.Reader.
 func (c *MergeCache) Put(id string, tree io.Reader) (retErr error) {
-	return c.Cache.Put(id, tree)
+	r := &countingReader{r: tree}
+	if err := c.Cache.Put(id, r); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[id]; ok {
+		c.size -= entry.size
+		delete(c.entries, id)
+	}
+	// The hashtree may have been deleted since it was put
+	if !c.Cache.Has(id) {
+		return nil
+	}
+	c.entries[id] = &mergeCacheEntry{size: r.n}
+	c.size += r.n
+	c.touch(id)
+	return c.evict(id)
+}
+
+// PutFromStream is like Put, but it first spills the hashtree to a temporary
+// file in the cache's directory. Put holds the cache's lock while it reads the
+// hashtree, so hashtrees read from slow sources (e.g. other workers) should
+// be put with PutFromStream, rather than buffered in memory and then Put.
+func (c *MergeCache) PutFromStream(id string, tree io.Reader) (retErr error) {
+	f, err := ioutil.TempFile(c.root, ".spill-")
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+		if err := os.Remove(f.Name()); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	buf := grpcutil.GetBuffer()
+	defer grpcutil.PutBuffer(buf)
+	if _, err := io.CopyBuffer(f, tree, buf); err != nil {
+		return errors.EnsureStack(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return c.Put(id, f)
+}
+
+// touch marks a hashtree as the most recently used. c.mu must be held.
+func (c *MergeCache) touch(id string) {
+	if entry, ok := c.entries[id]; ok {
+		c.clock++
+		entry.lastUsed = c.clock
+	}
+}
+
+// evict removes the least recently used hashtrees, other than 'keep', from
+// the cache until it's within its limits. c.mu must be held.
+func (c *MergeCache) evict(keep string) error {
+	for len(c.entries) > 1 && c.overLimit() {
+		var lru string
+		var lruEntry *mergeCacheEntry
+		for id, entry := range c.entries {
+			if id != keep && (lruEntry == nil || entry.lastUsed < lruEntry.lastUsed) {
+				lru, lruEntry = id, entry
+			}
+		}
+		if err := c.remove(lru); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *MergeCache) overLimit() bool {
+	return (c.maxEntries > 0 && len(c.entries) > c.maxEntries) ||
+		(c.maxBytes > 0 && c.size > c.maxBytes)
+}
+
+// remove deletes a hashtree from the cache. c.mu must be held.
+func (c *MergeCache) remove(id string) error {
+	if entry, ok := c.entries[id]; ok {
+		c.size -= entry.size
+		delete(c.entries, id)
+	}
+	return c.Cache.Delete(id)
+}
+
+// open opens the hashtree with the given id and marks it as the most recently
+// used. c.mu must be held.
+func (c *MergeCache) open(id string) (io.ReadCloser, error) {
+	r, err := c.Cache.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	c.touch(id)
+	return r, nil
 }
 
 // Get does a filtered write of id's hashtree to the passed in io.Writer.
 func (c *MergeCache) Get(id string, w io.Writer, filter Filter) (retErr error) {
-	r, err := c.Cache.Get(id)
+	c.mu.Lock()
+	r, err := c.open(id)
+	c.mu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -160,7 +309,18 @@ func (c *MergeCache) Get(id string, w io.Writer, filter Filter) (retErr error) {
 
 // Delete deletes a hashtree from the cache.
 func (c *MergeCache) Delete(id string) error {
-	return c.Cache.Delete(id)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(id)
+}
+
+// Clear deletes all of the hashtrees from the cache.
+func (c *MergeCache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*mergeCacheEntry)
+	c.size = 0
+	return c.Cache.Clear()
 }
 
 // Has returns true if the given id is present in the cache, false otherwise.
@@ -177,21 +337,94 @@ func (c *MergeCache) Keys() []string {
 // The results are written to the passed in *Writer.
 // The base field is used as the base hashtree if it is non-nil
 func (c *MergeCache) Merge(w *Writer, base io.Reader, filter Filter) (retErr error) {
-	var trees []*Reader
+	// Skip hashtrees that are evicted before they're opened
+	return c.MergeKeys(w, base, filter, c.Keys(), func(string) (io.ReadCloser, error) {
+		return nil, nil
+	})
+}
+
+// MergeKeys does a filtered merge of the hashtrees with the given ids, like
+// Merge. Hashtrees that aren't in the cache (e.g. because they were evicted)
+// are read with fetch instead (and skipped if it returns a nil reader), or
+// cause an error if fetch is nil.
+func (c *MergeCache) MergeKeys(w *Writer, base io.Reader, filter Filter, ids []string, fetch func(id string) (io.ReadCloser, error)) error {
+	var bases []io.Reader
 	if base != nil {
+		bases = append(bases, base)
+	}
+	return c.mergeKeys(bases, filter, ids, fetch, func(trees []*Reader) error {
+		return Merge(w, trees)
+	})
+}
+
+// MergeShards merges the hashtrees with the given ids, like MergeKeys, but
+// into one writer per shard in 'ws', in a single pass over the hashtrees.
+// Each of the bases (e.g. the shards' parent hashtrees) is merged too.
+func (c *MergeCache) MergeShards(ws map[int64]*Writer, numTrees int64, bases []io.Reader, ids []string, fetch func(id string) (io.ReadCloser, error)) error {
+	var shards []int64
+	for shard := range ws {
+		shards = append(shards, shard)
+	}
+	filter := NewShardsFilter(numTrees, shards...)
+	return c.mergeKeys(bases, filter, ids, fetch, func(trees []*Reader) error {
+		return MergeShards(ws, numTrees, trees)
+	})
+}
+
+func (c *MergeCache) mergeKeys(bases []io.Reader, filter Filter, ids []string, fetch func(id string) (io.ReadCloser, error), merge func([]*Reader) error) (retErr error) {
+	var trees []*Reader
+	for _, base := range bases {
 		trees = append(trees, NewReader(base, filter))
 	}
-	for _, key := range c.Keys() {
-		r, err := c.Cache.Get(key)
-		if err != nil {
-			return err
-		}
-		defer func() {
+	readers := make([]io.ReadCloser, len(ids))
+	defer func() {
+		for _, r := range readers {
+			if r == nil {
+				continue
+			}
 			if err := r.Close(); err != nil && retErr == nil {
 				retErr = err
 			}
-		}()
-		trees = append(trees, NewReader(r, filter))
+		}
+	}()
+	ids = append([]string(nil), ids...)
+	sort.Strings(ids)
+	// Open all of the cached hashtrees at once, so that none of them can be
+	// evicted part-way through
+	if err := func() error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, id := range ids {
+			if !c.Cache.Has(id) {
+				continue
+			}
+			r, err := c.open(id)
+			if err != nil {
+				return err
+			}
+			readers[i] = r
+		}
+		return nil
+	}(); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if readers[i] != nil {
+			continue
+		}
+		if fetch == nil {
+			return errors.Errorf("hashtree %v not found in cache", id)
+		}
+		r, err := fetch(id)
+		if err != nil {
+			return err
+		}
+		readers[i] = r
+	}
+	for _, r := range readers {
+		if r != nil {
+			trees = append(trees, NewReader(r, filter))
+		}
 	}
-	return Merge(w, trees)
+	return merge(trees)
 }
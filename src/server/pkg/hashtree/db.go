@@ -980,7 +980,10 @@ type Reader struct {
 	filter Filter
 }
 
-// NewReader creates a new hashtree reader.
+// NewReader creates a new hashtree reader. The serialized hashtree is read
+// sequentially, and each node is copied out as it's read, so 'r' may be a
+// one-shot stream (e.g. a chunk fetched from another worker); it doesn't
+// need to be buffered or seekable.
 func NewReader(r io.Reader, filter Filter) *Reader {
 	return &Reader{
 		pbr:    pbutil.NewReader(r),
@@ -1026,12 +1029,34 @@ func (r *Reader) Read() (*MergeNode, error) {
 
 // Writer can write a serialized hashtree from a sequence of merge nodes.
 type Writer struct {
-	pbw    pbutil.Writer
-	size   uint64
-	idxs   []*Index
-	offset uint64
+	pbw     pbutil.Writer
+	idxw    pbutil.Writer
+	size    uint64
+	idxs    []*Index
+	numIdxs int
+	offset  uint64
+
+	progress      ProgressFunc
+	nodes         uint64
+	reportedNodes uint64
+	reportedBytes uint64
+}
+
+// Progress is how much of a hashtree a writer has written so far.
+type Progress struct {
+	// Nodes is the number of nodes written
+	Nodes uint64
+	// Bytes is the number of serialized bytes written
+	Bytes uint64
 }
 
+// ProgressFunc is called with a writer's progress as it writes a hashtree.
+type ProgressFunc func(Progress)
+
+// progressInterval is the number of serialized bytes a writer writes between
+// calls to its ProgressFunc.
+var progressInterval = 64 * IndexSize
+
 // NewWriter creates a new hashtree writer.
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{
@@ -1039,6 +1064,36 @@ func NewWriter(w io.Writer) *Writer {
 	}
 }
 
+// NewIndexedWriter creates a new hashtree writer that streams its index to
+// 'idxW' as index entries are created, rather than holding them until the
+// tree is written. The bytes written to 'idxW' are the same as the bytes
+// Index would return for a writer created with NewWriter.
+func NewIndexedWriter(w, idxW io.Writer) *Writer {
+	return &Writer{
+		pbw:  pbutil.NewWriter(w),
+		idxw: pbutil.NewWriter(idxW),
+	}
+}
+
+// WithProgress sets a function that's called periodically with the writer's
+// progress while it's written to, and once more when a Merge, MergeShards or
+// Copy into it finishes. It returns the writer so that it can be chained with
+// NewWriter. A nil function (the default) isn't called.
+func (w *Writer) WithProgress(f ProgressFunc) *Writer {
+	w.progress = f
+	return w
+}
+
+// reportProgress calls the writer's ProgressFunc, if it has written anything
+// since the last call.
+func (w *Writer) reportProgress() {
+	if w.progress == nil || w.nodes == w.reportedNodes {
+		return
+	}
+	w.reportedNodes, w.reportedBytes = w.nodes, w.offset
+	w.progress(Progress{Nodes: w.nodes, Bytes: w.offset})
+}
+
 // Write writes the next merge node.
 func (w *Writer) Write(n *MergeNode) error {
 	// Marshal node if it was merged
@@ -1060,11 +1115,19 @@ func (w *Writer) Write(n *MergeNode) error {
 		w.size = uint64(n.nodeProto.SubtreeSize)
 	}
 	// Write index for every index size bytes
-	if w.offset > uint64(len(w.idxs)+1)*IndexSize {
-		w.idxs = append(w.idxs, &Index{
+	if w.offset > uint64(w.numIdxs+1)*IndexSize {
+		idx := &Index{
 			K:      n.k,
 			Offset: w.offset,
-		})
+		}
+		w.numIdxs++
+		if w.idxw != nil {
+			if _, err := w.idxw.Write(idx); err != nil {
+				return errors.EnsureStack(err)
+			}
+		} else {
+			w.idxs = append(w.idxs, idx)
+		}
 	}
 	b, err := w.pbw.WriteBytes(n.k)
 	if err != nil {
@@ -1076,6 +1139,10 @@ func (w *Writer) Write(n *MergeNode) error {
 		return errors.EnsureStack(err)
 	}
 	w.offset += uint64(b)
+	w.nodes++
+	if w.progress != nil && w.offset-w.reportedBytes >= progressInterval {
+		w.reportProgress()
+	}
 	return nil
 }
 
@@ -1085,6 +1152,7 @@ func (w *Writer) Copy(r *Reader) error {
 		n, err := r.Read()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
+				w.reportProgress()
 				return nil
 			}
 			return errors.EnsureStack(err)
@@ -1101,7 +1169,8 @@ func (w *Writer) Size() uint64 {
 	return w.size
 }
 
-// Index returns the index for a hashtree writer.
+// Index returns the index for a hashtree writer. It is empty for a writer
+// created with NewIndexedWriter, as its index has already been streamed.
 func (w *Writer) Index() ([]byte, error) {
 	buf := &bytes.Buffer{}
 	pbw := pbutil.NewWriter(buf)
@@ -1178,6 +1247,21 @@ func NewFilter(numTrees int64, tree int64) Filter {
 	}
 }
 
+// NewShardsFilter creates a filter for a set of hashtree shards, e.g. the
+// range of shards that a worker is responsible for.
+func NewShardsFilter(numTrees int64, trees ...int64) Filter {
+	if len(trees) == 1 {
+		return NewFilter(numTrees, trees[0])
+	}
+	set := make(map[uint64]bool)
+	for _, tree := range trees {
+		set[uint64(tree)] = true
+	}
+	return func(k []byte) bool {
+		return set[pathToTree(k, numTrees)]
+	}
+}
+
 // PathToTree computes the hashtree shard for a path.
 func PathToTree(path string, numTrees int64) uint64 {
 	path = clean(path)
@@ -1191,6 +1275,9 @@ func pathToTree(k []byte, numTrees int64) uint64 {
 type nodeStream struct {
 	node *MergeNode
 	r    *Reader
+	// idx is the stream's position in the merge's readers, which orders the
+	// nodes that share a path
+	idx int
 }
 
 type mergePQ struct {
@@ -1227,18 +1314,29 @@ func (mq *mergePQ) insert(s *nodeStream) error {
 }
 
 func (mq *mergePQ) next() ([]*MergeNode, error) {
-	ns := []*MergeNode{mq.q[1].node}
+	type indexedNode struct {
+		node *MergeNode
+		idx  int
+	}
+	ns := []indexedNode{{mq.q[1].node, mq.q[1].idx}}
 	if err := mq.fill(); err != nil {
 		return nil, err
 	}
 	// Keep popping nodes off the queue if they share the same path
-	for mq.q[1] != nil && bytes.Equal(mq.k(1), ns[0].k) {
-		ns = append(ns, mq.q[1].node)
+	for mq.q[1] != nil && bytes.Equal(mq.k(1), ns[0].node.k) {
+		ns = append(ns, indexedNode{mq.q[1].node, mq.q[1].idx})
 		if err := mq.fill(); err != nil {
 			return nil, err
 		}
 	}
-	return ns, nil
+	// Merge the nodes in the order of their readers, rather than the order
+	// they're popped in, which depends on the state of the queue
+	sort.SliceStable(ns, func(i, j int) bool { return ns[i].idx < ns[j].idx })
+	result := make([]*MergeNode, len(ns))
+	for i, n := range ns {
+		result[i] = n.node
+	}
+	return result, nil
 }
 
 func merge(ns []*MergeNode) (*MergeNode, error) {
@@ -1310,13 +1408,42 @@ func (mq *mergePQ) swap(i, j int) {
 
 // Merge merges a collection of hashtree readers into a hashtree writer.
 func Merge(w *Writer, rs []*Reader) error {
+	if err := mergeReaders(rs, w.Write); err != nil {
+		return err
+	}
+	w.reportProgress()
+	return nil
+}
+
+// MergeShards merges a collection of hashtree readers into one hashtree writer
+// per shard, in a single pass over the readers. 'ws' maps each shard to its
+// writer, and every node that's read must belong to one of them (e.g. because
+// the readers are filtered with a NewShardsFilter for the same shards).
+func MergeShards(ws map[int64]*Writer, numTrees int64, rs []*Reader) error {
+	if err := mergeReaders(rs, func(n *MergeNode) error {
+		tree := int64(pathToTree(n.k, numTrees))
+		w, ok := ws[tree]
+		if !ok {
+			return errors.Errorf("path \"%s\" is in shard %d, which isn't being merged", s(n.k), tree)
+		}
+		return w.Write(n)
+	}); err != nil {
+		return err
+	}
+	for _, w := range ws {
+		w.reportProgress()
+	}
+	return nil
+}
+
+func mergeReaders(rs []*Reader, write func(*MergeNode) error) error {
 	if len(rs) == 0 {
 		return nil
 	}
 	mq := &mergePQ{q: make([]*nodeStream, len(rs)+1)}
 	// Setup first set of nodes
-	for _, r := range rs {
-		if err := mq.insert(&nodeStream{r: r}); err != nil {
+	for i, r := range rs {
+		if err := mq.insert(&nodeStream{r: r, idx: i}); err != nil {
 			return err
 		}
 	}
@@ -1332,7 +1459,7 @@ func Merge(w *Writer, rs []*Reader) error {
 			return err
 		}
 		// Write out result
-		if err := w.Write(n); err != nil {
+		if err := write(n); err != nil {
 			return errors.EnsureStack(err)
 		}
 	}
@@ -1342,8 +1469,8 @@ func Merge(w *Writer, rs []*Reader) error {
 func nodes(rs []io.ReadCloser, f func(path string, nodeProto *NodeProto) error) error {
 	mq := &mergePQ{q: make([]*nodeStream, len(rs)+1)}
 	// Setup first set of nodes
-	for _, r := range rs {
-		if err := mq.insert(&nodeStream{r: NewReader(r, nil)}); err != nil {
+	for i, r := range rs {
+		if err := mq.insert(&nodeStream{r: NewReader(r, nil), idx: i}); err != nil {
 			return err
 		}
 	}
@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
 	"testing"
+	"testing/iotest"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
@@ -12,6 +16,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 
 	bolt "github.com/coreos/bbolt"
+	"golang.org/x/sync/errgroup"
 )
 
 // obj parses a string as an Object
@@ -769,3 +774,427 @@ func TestMergeFiles(t *testing.T) {
 
 	require.Equal(t, expectedBuf, resultBuf)
 }
+
+func TestMergeCacheEviction(t *testing.T) {
+	c, err := NewBoundedMergeCache("merge-cache-eviction-test", 2, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, c.Close())
+	}()
+
+	tree := func(path string) *bytes.Buffer {
+		u := NewUnordered("")
+		u.PutFile(path, []byte(path), 1, blocks(``)...)
+		buf := &bytes.Buffer{}
+		require.NoError(t, u.Ordered().Serialize(buf))
+		return buf
+	}
+	require.NoError(t, c.Put("0", tree("/foo")))
+	require.NoError(t, c.Put("1", tree("/bar")))
+
+	// Using "0" makes "1" the least recently used, so it's evicted first
+	require.NoError(t, c.Get("0", &bytes.Buffer{}, nil))
+	require.NoError(t, c.Put("2", tree("/buzz")))
+	require.Equal(t, []string{"0", "2"}, c.Keys())
+	require.NoError(t, c.Put("3", tree("/fizz")))
+	require.Equal(t, []string{"2", "3"}, c.Keys())
+
+	// Putting an existing hashtree replaces it rather than evicting another
+	require.NoError(t, c.Put("2", tree("/buzz")))
+	require.Equal(t, []string{"2", "3"}, c.Keys())
+
+	// A byte limit evicts hashtrees too, but never the one just put
+	b, err := NewBoundedMergeCache("merge-cache-eviction-bytes-test", 0, 1)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, b.Close())
+	}()
+	require.NoError(t, b.Put("0", tree("/foo")))
+	require.Equal(t, []string{"0"}, b.Keys())
+	require.NoError(t, b.Put("1", tree("/bar")))
+	require.Equal(t, []string{"1"}, b.Keys())
+}
+
+func TestMergeCacheEvictedMerge(t *testing.T) {
+	c, err := NewMergeCache("merge-cache-unbounded-test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, c.Close())
+	}()
+	b, err := NewBoundedMergeCache("merge-cache-bounded-test", 1, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, b.Close())
+	}()
+
+	trees := make(map[string][]byte)
+	for i, path := range []string{"/foo", "/dir/bar", "/dir/buzz", "/foo"} {
+		u := NewUnordered("")
+		u.PutFile(path, []byte(fmt.Sprint(i)), 1, blocks(``)...)
+		buf := &bytes.Buffer{}
+		require.NoError(t, u.Ordered().Serialize(buf))
+		id := fmt.Sprint(i)
+		trees[id] = buf.Bytes()
+		require.NoError(t, c.Put(id, bytes.NewReader(buf.Bytes())))
+		require.NoError(t, b.Put(id, bytes.NewReader(buf.Bytes())))
+	}
+	require.Equal(t, []string{"3"}, b.Keys())
+
+	// Merging the bounded cache, re-fetching its evicted hashtrees, gives the
+	// same result as merging the unbounded one
+	expectedBuf, resultBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	require.NoError(t, c.Merge(NewWriter(expectedBuf), nil, nil))
+	var fetched []string
+	require.NoError(t, b.MergeKeys(NewWriter(resultBuf), nil, nil, []string{"3", "2", "1", "0"}, func(id string) (io.ReadCloser, error) {
+		fetched = append(fetched, id)
+		return ioutil.NopCloser(bytes.NewReader(trees[id])), nil
+	}))
+	require.Equal(t, []string{"0", "1", "2"}, fetched)
+	require.Equal(t, expectedBuf, resultBuf)
+
+	// Without a way to fetch them, evicted hashtrees can't be merged
+	require.YesError(t, b.MergeKeys(NewWriter(&bytes.Buffer{}), nil, nil, []string{"0", "3"}, nil))
+}
+
+func TestMergeCachePutFromStream(t *testing.T) {
+	root := "merge-cache-stream-test"
+	c, err := NewMergeCache(root)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, c.Close())
+	}()
+
+	trees := make(map[string][]byte)
+	for i, path := range []string{"/foo", "/dir/bar", "/dir/buzz"} {
+		u := NewUnordered("")
+		u.PutFile(path, []byte(fmt.Sprint(i)), 1, blocks(``)...)
+		buf := &bytes.Buffer{}
+		require.NoError(t, u.Ordered().Serialize(buf))
+		id := fmt.Sprint(i)
+		trees[id] = buf.Bytes()
+		require.NoError(t, c.PutFromStream(id, bytes.NewReader(buf.Bytes())))
+	}
+	require.Equal(t, []string{"0", "1", "2"}, c.Keys())
+
+	// The spilled copies are removed once they're cached
+	files, err := ioutil.ReadDir(root)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(files))
+
+	// Streamed hashtrees are merged like ones that are put
+	expected, err := NewMergeCache("merge-cache-put-test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, expected.Close())
+	}()
+	for id, tree := range trees {
+		require.NoError(t, expected.Put(id, bytes.NewReader(tree)))
+	}
+	expectedBuf, resultBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	require.NoError(t, expected.Merge(NewWriter(expectedBuf), nil, nil))
+	require.NoError(t, c.Merge(NewWriter(resultBuf), nil, nil))
+	require.Equal(t, expectedBuf, resultBuf)
+
+	require.NoError(t, c.Delete("1"))
+	require.Equal(t, []string{"0", "2"}, c.Keys())
+	files, err = ioutil.ReadDir(root)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+
+	// A stream that fails isn't cached
+	require.YesError(t, c.PutFromStream("3", io.MultiReader(bytes.NewReader(trees["0"]), &errReader{})))
+	require.False(t, c.Has("3"))
+	files, err = ioutil.ReadDir(root)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+}
+
+type errReader struct{}
+
+func (*errReader) Read([]byte) (int, error) {
+	return 0, errors.Errorf("stream failed")
+}
+
+func TestMergeCacheConcurrency(t *testing.T) {
+	u := NewUnordered("")
+	u.PutFile("/foo", []byte("foo"), 1, blocks(``)...)
+	buf := &bytes.Buffer{}
+	require.NoError(t, u.Ordered().Serialize(buf))
+	tree := buf.Bytes()
+
+	for _, maxEntries := range []int{0, 10} {
+		c, err := NewBoundedMergeCache(fmt.Sprintf("merge-cache-concurrency-test-%d", maxEntries), maxEntries, 0)
+		require.NoError(t, err)
+
+		// Each goroutine puts its own hashtrees, deleting every other one, while
+		// the others read the cache
+		var eg errgroup.Group
+		for g := 0; g < 8; g++ {
+			g := g
+			eg.Go(func() error {
+				for i := 0; i < 50; i++ {
+					id := fmt.Sprintf("%d-%02d", g, i)
+					put := c.Put
+					if i%4 == 0 {
+						put = c.PutFromStream
+					}
+					if err := put(id, bytes.NewReader(tree)); err != nil {
+						return err
+					}
+					c.Has(id)
+					c.Keys()
+					if i%2 == 1 {
+						if err := c.Delete(id); err != nil {
+							return err
+						}
+					}
+					if err := c.Merge(NewWriter(ioutil.Discard), nil, nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		require.NoError(t, eg.Wait())
+
+		keys := c.Keys()
+		if maxEntries == 0 {
+			require.Equal(t, 8*25, len(keys))
+			for _, key := range keys {
+				var g, i int
+				_, err := fmt.Sscanf(key, "%d-%d", &g, &i)
+				require.NoError(t, err)
+				require.Equal(t, 0, i%2)
+			}
+		} else {
+			require.True(t, len(keys) <= maxEntries)
+		}
+		files, err := ioutil.ReadDir(c.root)
+		require.NoError(t, err)
+		require.Equal(t, len(keys), len(files))
+		require.NoError(t, c.Close())
+	}
+}
+
+func TestMergeShards(t *testing.T) {
+	numTrees := int64(4)
+	c, err := NewMergeCache("merge-cache-shards-test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, c.Close())
+	}()
+	var paths []string
+	for i := 0; i < 3; i++ {
+		u := NewUnordered("")
+		for j := 0; j < 20; j++ {
+			path := fmt.Sprintf("/dir-%d/file-%d", j%5, j)
+			u.PutFile(path, []byte(fmt.Sprintf("%d-%d", i, j)), 1, blocks(``)...)
+			paths = append(paths, path)
+		}
+		buf := &bytes.Buffer{}
+		require.NoError(t, u.Ordered().Serialize(buf))
+		require.NoError(t, c.Put(fmt.Sprint(i), buf))
+	}
+
+	// A multi-shard filter accepts exactly the union of its shards' paths
+	filter := NewShardsFilter(numTrees, 1, 2)
+	for _, path := range paths {
+		k := b(clean(path))
+		require.Equal(t, NewFilter(numTrees, 1)(k) || NewFilter(numTrees, 2)(k), filter(k), path)
+	}
+
+	// Merging several shards in one pass gives the same hashtrees as merging
+	// each of them separately
+	shards := []int64{0, 1, 2}
+	bufs := make(map[int64]*bytes.Buffer)
+	ws := make(map[int64]*Writer)
+	for _, shard := range shards {
+		bufs[shard] = &bytes.Buffer{}
+		ws[shard] = NewWriter(bufs[shard])
+	}
+	require.NoError(t, c.MergeShards(ws, numTrees, nil, c.Keys(), nil))
+	var size uint64
+	for _, shard := range shards {
+		expectedBuf := &bytes.Buffer{}
+		w := NewWriter(expectedBuf)
+		require.NoError(t, c.Merge(w, nil, NewFilter(numTrees, shard)))
+		require.Equal(t, expectedBuf.Bytes(), bufs[shard].Bytes(), fmt.Sprint(shard))
+		require.Equal(t, w.Size(), ws[shard].Size())
+		size += ws[shard].Size()
+	}
+	require.True(t, size > 0)
+
+	// Every node read must belong to one of the shards being merged
+	ws = map[int64]*Writer{0: NewWriter(&bytes.Buffer{})}
+	var rs []*Reader
+	for _, key := range c.Keys() {
+		r, err := c.Cache.Get(key)
+		require.NoError(t, err)
+		defer r.Close()
+		rs = append(rs, NewReader(r, nil))
+	}
+	require.YesError(t, MergeShards(ws, numTrees, rs))
+}
+
+func TestMergeStreams(t *testing.T) {
+	var trees [][]byte
+	for i := 0; i < 3; i++ {
+		u := NewUnordered("")
+		for j := 0; j < 50; j++ {
+			u.PutFile(fmt.Sprintf("/dir-%d/file-%d", j%5, j), []byte(fmt.Sprintf("%d-%d", i, j)), 1, blocks(``)...)
+		}
+		buf := &bytes.Buffer{}
+		require.NoError(t, u.Ordered().Serialize(buf))
+		trees = append(trees, buf.Bytes())
+	}
+	// stream returns a one-shot reader for a hashtree, which is written to a
+	// pipe a few bytes at a time, so it's never held in full by the reader
+	stream := func(tree []byte) io.ReadCloser {
+		pr, pw := io.Pipe()
+		go func() {
+			r := iotest.HalfReader(bytes.NewReader(tree))
+			_, err := io.CopyBuffer(pw, r, make([]byte, 7))
+			pw.CloseWithError(err)
+		}()
+		return pr
+	}
+
+	expectedBuf := &bytes.Buffer{}
+	var rs []*Reader
+	for _, tree := range trees {
+		rs = append(rs, NewReader(bytes.NewReader(tree), nil))
+	}
+	require.NoError(t, Merge(NewWriter(expectedBuf), rs))
+
+	// Merging streams gives the same hashtree as merging buffered hashtrees
+	resultBuf := &bytes.Buffer{}
+	rs = nil
+	for _, tree := range trees {
+		r := stream(tree)
+		defer r.Close()
+		rs = append(rs, NewReader(iotest.OneByteReader(r), nil))
+	}
+	require.NoError(t, Merge(NewWriter(resultBuf), rs))
+	require.Equal(t, expectedBuf, resultBuf)
+
+	// Including when they're fetched for a MergeCache, without being cached
+	c, err := NewMergeCache("merge-cache-streams-test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, c.Close())
+	}()
+	require.NoError(t, c.Put("0", bytes.NewReader(trees[0])))
+	resultBuf = &bytes.Buffer{}
+	require.NoError(t, c.MergeKeys(NewWriter(resultBuf), nil, nil, []string{"0", "1", "2"}, func(id string) (io.ReadCloser, error) {
+		i, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, err
+		}
+		return stream(trees[i]), nil
+	}))
+	require.Equal(t, expectedBuf, resultBuf)
+	require.Equal(t, []string{"0"}, c.Keys())
+
+	// A stream that's cut off part-way through a node is an error
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write(trees[1][:len(trees[1])/2])
+		pw.Close()
+	}()
+	require.YesError(t, Merge(NewWriter(&bytes.Buffer{}), []*Reader{NewReader(pr, nil)}))
+}
+
+func TestIndexedWriter(t *testing.T) {
+	u := NewUnordered("")
+	// Large enough to span several index chunks
+	for i := 0; i < 30000; i++ {
+		u.PutFile(fmt.Sprintf("/dir-%d/file-with-a-long-name-%d", i%10, i), []byte(fmt.Sprint(i)), 1, blocks(``)...)
+	}
+	treeBuf := &bytes.Buffer{}
+	require.NoError(t, u.Ordered().Serialize(treeBuf))
+
+	expectedBuf := &bytes.Buffer{}
+	expectedW := NewWriter(expectedBuf)
+	require.NoError(t, expectedW.Copy(NewReader(bytes.NewReader(treeBuf.Bytes()), nil)))
+	expectedIndex, err := expectedW.Index()
+	require.NoError(t, err)
+	require.True(t, len(expectedIndex) > 0)
+
+	// The index is streamed as the hashtree is written, before the end of it
+	resultBuf, indexBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	w := NewIndexedWriter(resultBuf, indexBuf)
+	r := NewReader(bytes.NewReader(treeBuf.Bytes()), nil)
+	var streamedEarly bool
+	for {
+		n, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, w.Write(n))
+		if indexBuf.Len() > 0 && indexBuf.Len() < len(expectedIndex) {
+			streamedEarly = true
+		}
+	}
+	require.True(t, streamedEarly)
+
+	// And it's the same as the index returned once the hashtree is written
+	require.Equal(t, expectedBuf.Bytes(), resultBuf.Bytes())
+	require.Equal(t, expectedIndex, indexBuf.Bytes())
+	require.Equal(t, expectedW.Size(), w.Size())
+	index, err := w.Index()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(index))
+}
+
+func TestMergeProgress(t *testing.T) {
+	defer func(interval uint64) { progressInterval = interval }(progressInterval)
+	progressInterval = 1024
+	var trees [][]byte
+	for i := 0; i < 3; i++ {
+		u := NewUnordered("")
+		for j := 0; j < 500; j++ {
+			u.PutFile(fmt.Sprintf("/dir-%d/file-%d", j%5, j), []byte(fmt.Sprintf("%d-%d", i, j)), 1, blocks(``)...)
+		}
+		buf := &bytes.Buffer{}
+		require.NoError(t, u.Ordered().Serialize(buf))
+		trees = append(trees, buf.Bytes())
+	}
+	readers := func() []*Reader {
+		var rs []*Reader
+		for _, tree := range trees {
+			rs = append(rs, NewReader(bytes.NewReader(tree), nil))
+		}
+		return rs
+	}
+
+	// Progress is reported periodically, increases monotonically, and ends at
+	// the total size of the merged hashtree
+	var progress []Progress
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf).WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	})
+	require.NoError(t, Merge(w, readers()))
+	require.True(t, len(progress) > 2)
+	for i := 1; i < len(progress); i++ {
+		require.True(t, progress[i].Nodes > progress[i-1].Nodes)
+		require.True(t, progress[i].Bytes > progress[i-1].Bytes)
+	}
+	var nodes uint64
+	r := NewReader(bytes.NewReader(buf.Bytes()), nil)
+	for {
+		_, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		nodes++
+	}
+	require.Equal(t, Progress{Nodes: nodes, Bytes: uint64(buf.Len())}, progress[len(progress)-1])
+
+	// Setting a progress function doesn't change the merged hashtree
+	expectedBuf := &bytes.Buffer{}
+	require.NoError(t, Merge(NewWriter(expectedBuf), readers()))
+	require.Equal(t, expectedBuf.Bytes(), buf.Bytes())
+}
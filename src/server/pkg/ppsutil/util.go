@@ -266,6 +266,12 @@ func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequ
 		Standby:               pipelineInfo.Standby,
 		S3Out:                 pipelineInfo.S3Out,
 		Metadata:              pipelineInfo.Metadata,
+		LogSampling:           pipelineInfo.LogSampling,
+		DownloadTimeout:       pipelineInfo.DownloadTimeout,
+		UploadTimeout:         pipelineInfo.UploadTimeout,
+		DatumFailurePolicy:    pipelineInfo.DatumFailurePolicy,
+		DatumExclude:          pipelineInfo.DatumExclude,
+		S3Endpoint:            pipelineInfo.S3Endpoint,
 	}
 }
 
@@ -345,6 +351,37 @@ func SidecarS3GatewayService(jobID string) string {
 	return "s3-" + jobID
 }
 
+// ReservedUserCodeEnv returns the names of the env vars that the worker sets
+// in the environment of the user code of a pipeline with the input 'input',
+// which can't be overridden by the pipeline's transform. These are the job and
+// output commit IDs, the path and commit of each input and, if the pipeline
+// has S3 inputs or outputs ('s3Out'), the S3 gateway endpoint.
+func ReservedUserCodeEnv(input *pps.Input, s3Out bool) map[string]bool {
+	reserved := map[string]bool{
+		client.JobIDEnv:          true,
+		client.OutputCommitIDEnv: true,
+	}
+	if ContainsS3Inputs(input) || s3Out {
+		reserved[client.S3EndpointEnv] = true
+	}
+	pps.VisitInput(input, func(input *pps.Input) {
+		var name string
+		switch {
+		case input.Pfs != nil:
+			name = input.Pfs.Name
+		case input.Cron != nil:
+			name = input.Cron.Name
+		case input.Git != nil:
+			name = input.Git.Name
+		default:
+			return
+		}
+		reserved[name] = true
+		reserved[name+"_COMMIT"] = true
+	})
+	return reserved
+}
+
 // ErrorState returns true if s is an error state for a pipeline, that is, a
 // state that users should be aware of and one which will have a "Reason" set
 // for why it's in this state.
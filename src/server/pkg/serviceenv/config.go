@@ -21,6 +21,38 @@ type GlobalConfiguration struct {
 	Namespace     string `env:"PACH_NAMESPACE,default=default"`
 	StorageRoot   string `env:"PACH_ROOT,default=/pach"`
 
+	// The PFS repo and branch that the S3 gateway keeps the content of
+	// in-progress multipart uploads in
+	S3GatewayMultipartRepo   string `env:"S3GATEWAY_MULTIPART_REPO,default=_s3gateway_multipart_"`
+	S3GatewayMultipartBranch string `env:"S3GATEWAY_MULTIPART_BRANCH,default=master"`
+	// The minimum size of each part of a multipart upload to the S3 gateway,
+	// except for the last, e.g. "5MB"
+	S3GatewayMinPartSize string `env:"S3GATEWAY_MIN_PART_SIZE,default=5MB"`
+	// The number of parts that the S3 gateway validates and copies
+	// concurrently when completing a multipart upload
+	S3GatewayCompleteMultipartConcurrency int `env:"S3GATEWAY_COMPLETE_MULTIPART_CONCURRENCY,default=10"`
+
+	// Configuration of the hashtree chunk caches that workers keep for each
+	// job: bounds on their size (0 means unbounded), and whether chunks are
+	// spilled to disk, rather than buffered in memory, as they're fetched. Set
+	// on pachd, and propagated to workers.
+	WorkerChunkCacheMaxEntries  int    `env:"WORKER_CHUNK_CACHE_MAX_ENTRIES,default=0"`
+	WorkerChunkCacheMaxBytes    string `env:"WORKER_CHUNK_CACHE_MAX_BYTES,default=0"`
+	WorkerChunkCacheSpillToDisk bool   `env:"WORKER_CHUNK_CACHE_SPILL_TO_DISK,default=false"`
+	// The maximum size of the cache that workers keep downloaded inputs in,
+	// e.g. "10G" (0 disables the cache). Set on pachd, and propagated to
+	// workers.
+	WorkerInputCacheMaxBytes string `env:"WORKER_INPUT_CACHE_MAX_BYTES,default=0"`
+
+	// The minimum level of the statements that workers log for their pipeline
+	// ("debug", "info", "warn" or "error"). Set on pachd, and propagated to
+	// workers.
+	WorkerLogLevel string `env:"WORKER_LOG_LEVEL,default=info"`
+	// The maximum size of the logs that workers store for each datum when stats
+	// are enabled, e.g. "100M" (0 means unbounded). Set on pachd, and propagated
+	// to workers.
+	WorkerDatumLogMaxBytes string `env:"WORKER_DATUM_LOG_MAX_BYTES,default=0"`
+
 	// PPSSpecCommitID is only set for workers and sidecar pachd instances.
 	// Because both pachd and worker need to know the spec commit (the worker so
 	// that it can avoid jobs for other versions of the same pipelines and the
@@ -63,6 +95,13 @@ type PachdSpecificConfiguration struct {
 	DeploymentID               string `env:"CLUSTER_DEPLOYMENT_ID,default="`
 	RequireCriticalServersOnly bool   `env:"REQUIRE_CRITICAL_SERVERS_ONLY",default=false"`
 	MetricsEndpoint            string `env:"METRICS_ENDPOINT",default="`
+
+	// If set, PFS allows operations when the auth service can't be reached to
+	// check them, rather than failing them
+	PFSAuthFailOpen bool `env:"PFS_AUTH_FAIL_OPEN,default=false"`
+	// How long PFS waits for each call to the auth service when checking an
+	// operation, e.g. "30s". A duration of 0 or less means no limit.
+	PFSAuthCheckTimeout string `env:"PFS_AUTH_CHECK_TIMEOUT,default=30s"`
 }
 
 // StorageConfiguration contains the storage configuration.
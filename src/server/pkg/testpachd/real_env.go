@@ -40,6 +40,12 @@ const (
 // environment in order to spin up pipelines, which is not yet supported by this
 // package, but the other API servers work.
 func WithRealEnv(cb func(*RealEnv) error, customConfig ...*serviceenv.PachdFullConfiguration) error {
+	return WithRealEnvPFSOptions(cb, nil, customConfig...)
+}
+
+// WithRealEnvPFSOptions is like WithRealEnv, but constructs the PFS API server
+// with 'pfsOpts'
+func WithRealEnvPFSOptions(cb func(*RealEnv) error, pfsOpts []pfsserver.Option, customConfig ...*serviceenv.PachdFullConfiguration) error {
 	return WithMockEnv(func(mockEnv *MockEnv) (err error) {
 		realEnv := &RealEnv{MockEnv: *mockEnv}
 
@@ -91,6 +97,7 @@ func WithRealEnv(cb func(*RealEnv) error, customConfig ...*serviceenv.PachdFullC
 			realEnv.treeCache,
 			realEnv.LocalStorageDirectory,
 			64*1024*1024,
+			pfsOpts...,
 		)
 		if err != nil {
 			return err
@@ -16,6 +16,9 @@ const (
 
 var (
 	remapThreshold = 10000
+	// agingInterval is how long a task waits for each increase to the
+	// priority of its subtasks
+	agingInterval = time.Minute
 )
 
 type subtaskFunc func(context.Context)
@@ -25,6 +28,16 @@ type taskEntry struct {
 	ctx             context.Context
 	cancel          context.CancelFunc
 	subtaskFuncChan chan subtaskFunc
+	priority        int64
+	created         time.Time
+}
+
+// effectivePriority is the task's priority, aged by how long it's been since
+// the task was created, so that tasks with lower priorities eventually get processed.
+// Older tasks never have a lower effective priority than newer tasks with the
+// same priority.
+func (te *taskEntry) effectivePriority(now time.Time) int64 {
+	return te.priority + int64(now.Sub(te.created)/agingInterval)
 }
 
 // runSubtask sends a subtask to be run in the task queue.
@@ -51,6 +64,8 @@ func (te *taskEntry) runSubtaskBlock(subtask subtaskBlockFunc) error {
 // has a much lower memory footprint at scale, and our use case is such that the number of tasks in general will be
 // significantly lower than the number of subtasks. Also, we are not concerned with the ordering of subtasks within a task,
 // only the ordering of subtasks across tasks.
+// Subtasks from tasks with a higher (aged) priority are processed first, and
+// tasks with the same priority are processed in order of creation.
 type taskQueue struct {
 	tasks                  *ordered_map.OrderedMap
 	mu                     sync.Mutex
@@ -63,11 +78,11 @@ func newTaskQueue(ctx context.Context) *taskQueue {
 	}
 	// The next subtask to process is determined by iterating through the ordered map and checking the
 	// subtask function channel for each task entry to see if the next subtask is ready to be processed.
-	// If a subtask function is received, then it is executed.
-	// After processing a subtask, the iteration starts from the beginning (new subtasks from earlier
-	// tasks should be processed first).
+	// The subtask function of the ready task entry with the highest priority (the earliest, if
+	// there's a tie) is received and executed.
+	// After processing a subtask, the iteration starts from the beginning (new subtasks from
+	// higher priority or earlier tasks should be processed first).
 	go func() {
-	NextSubtask:
 		for {
 			select {
 			case <-ctx.Done():
@@ -75,19 +90,28 @@ func newTaskQueue(ctx context.Context) *taskQueue {
 			default:
 			}
 			tq.mu.Lock()
+			now := time.Now()
+			var next *taskEntry
+			var nextPriority int64
 			iter := tq.tasks.IterFunc()
 			for kv, ok := iter(); ok; kv, ok = iter() {
 				te := kv.Value.(*taskEntry)
-				select {
-				case f := <-te.subtaskFuncChan:
-					tq.mu.Unlock()
-					f(te.ctx)
-					continue NextSubtask
-				default:
+				// This goroutine is the only receiver, so a buffered subtask
+				// function will still be there when it's received below.
+				if len(te.subtaskFuncChan) == 0 {
+					continue
+				}
+				if priority := te.effectivePriority(now); next == nil || priority > nextPriority {
+					next, nextPriority = te, priority
 				}
 			}
 			tq.mu.Unlock()
-			time.Sleep(waitTime)
+			if next == nil {
+				time.Sleep(waitTime)
+				continue
+			}
+			f := <-next.subtaskFuncChan
+			f(next.ctx)
 		}
 	}()
 	return tq
@@ -96,8 +120,9 @@ func newTaskQueue(ctx context.Context) *taskQueue {
 // runTask runs a new task in the task queue.
 // The task code should be contained within the passed in callback.
 // The callback will receive a taskEntry, which should be used for running subtasks in the task queue.
+// Subtasks are prioritized according to 'priority', aged from when the task was 'created'.
 // The task state will be cleaned up upon return of the callback.
-func (tq *taskQueue) runTask(ctx context.Context, taskID string, f func(*taskEntry)) error {
+func (tq *taskQueue) runTask(ctx context.Context, taskID string, priority int64, created time.Time, f func(*taskEntry)) error {
 	tq.mu.Lock()
 	defer tq.mu.Unlock()
 	if _, ok := tq.tasks.Get(taskID); ok {
@@ -108,6 +133,8 @@ func (tq *taskQueue) runTask(ctx context.Context, taskID string, f func(*taskEnt
 		ctx:             ctx,
 		cancel:          cancel,
 		subtaskFuncChan: make(chan subtaskFunc, 1),
+		priority:        priority,
+		created:         created,
 	}
 	tq.tasks.Set(taskID, te)
 	go func() {
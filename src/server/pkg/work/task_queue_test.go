@@ -32,7 +32,7 @@ func TestTaskQueue(t *testing.T) {
 	}
 	for i := 0; i < numTasks; i++ {
 		i := i
-		require.NoError(t, tq.runTask(context.Background(), strconv.Itoa(i), func(taskEntry *taskEntry) {
+		require.NoError(t, tq.runTask(context.Background(), strconv.Itoa(i), 0, time.Now(), func(taskEntry *taskEntry) {
 			for j := 0; j < numSubtasks; j++ {
 				if i == 0 {
 					// The first task will create subtasks that sleep a bit to allow the the subtasks
@@ -63,3 +63,45 @@ func TestTaskQueue(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskQueuePriority(t *testing.T) {
+	defer func(interval time.Duration) { agingInterval = interval }(agingInterval)
+	agingInterval = 10 * time.Millisecond
+	tq := newTaskQueue(context.Background())
+	// Block the task queue with a subtask, so that the subtasks of the tasks
+	// below are all waiting when it's unblocked
+	blocked, unblock := make(chan struct{}), make(chan struct{})
+	require.NoError(t, tq.runTask(context.Background(), "blocker", 1000, time.Now(), func(taskEntry *taskEntry) {
+		taskEntry.runSubtaskBlock(func(_ context.Context) error {
+			close(blocked)
+			<-unblock
+			return nil
+		})
+	}))
+	<-blocked
+	order := make(chan string, 4)
+	runTask := func(taskID string, priority int64, created time.Time) {
+		queued := make(chan struct{})
+		require.NoError(t, tq.runTask(context.Background(), taskID, priority, created, func(taskEntry *taskEntry) {
+			done := make(chan struct{})
+			taskEntry.runSubtask(func(_ context.Context) {
+				order <- taskID
+				close(done)
+			})
+			close(queued)
+			<-done
+		}))
+		<-queued
+	}
+	// 'aged' was created long enough before the others for its priority to be
+	// boosted past theirs, even though it's run last (e.g. by a worker that
+	// restarted)
+	runTask("low", 0, time.Now())
+	runTask("high", 5, time.Now())
+	runTask("low-2", 0, time.Now())
+	runTask("aged", 0, time.Now().Add(-20*agingInterval))
+	close(unblock)
+	for _, expected := range []string{"aged", "high", "low", "low-2"} {
+		require.Equal(t, expected, <-order)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"path"
 	"sync/atomic"
+	"time"
 
 	etcd "github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
@@ -22,8 +24,10 @@ const (
 )
 
 // TaskQueue manages a set of parallel tasks, and provides an interface for running tasks.
-// Priority of tasks (and therefore subtasks) is based on task creation time, so tasks created
-// earlier will be prioritized over tasks that were created later.
+// Priority of tasks (and therefore subtasks) is based on the priority they're run with, and then
+// on task creation time, so tasks created earlier will be prioritized over tasks with the same
+// priority that were created later. The priority of a task increases the longer it exists, so
+// lower priority tasks are not starved by a stream of higher priority tasks.
 type TaskQueue struct {
 	*taskEtcd
 	taskQueue *taskQueue
@@ -71,8 +75,27 @@ func newCollection(etcdClient *etcd.Client, etcdPrefix string, template proto.Me
 // The task code should be contained within the passed in callback.
 // The callback will receive a Master, which should be used for running subtasks in the task queue.
 // The task state will be cleaned up upon return of the callback.
-func (tq *TaskQueue) RunTask(ctx context.Context, f func(*Master)) (retErr error) {
-	task := &Task{ID: uuid.NewWithoutDashes()}
+func (tq *TaskQueue) RunTask(ctx context.Context, f func(*Master)) error {
+	return tq.RunTaskWithPriority(ctx, 0, f)
+}
+
+// RunTaskWithPriority is similar to RunTask, but the task's subtasks are processed ahead of
+// those of tasks with a lower priority.
+// Priorities only order the tasks of a single TaskQueue (i.e. namespace). In particular, each
+// pipeline version has its own namespace, and the transform registry runs every job's task at
+// the default priority, so nothing in PPS sets a priority yet; this is the scheduler support for
+// callers that can tell urgent tasks apart.
+func (tq *TaskQueue) RunTaskWithPriority(ctx context.Context, priority int64, f func(*Master)) (retErr error) {
+	created := time.Now()
+	createdProto, err := types.TimestampProto(created)
+	if err != nil {
+		return err
+	}
+	task := &Task{
+		ID:       uuid.NewWithoutDashes(),
+		Priority: priority,
+		Created:  createdProto,
+	}
 	if _, err := col.NewSTM(ctx, tq.etcdClient, func(stm col.STM) error {
 		return tq.taskCol.ReadWrite(stm).Put(task.ID, task)
 	}); err != nil {
@@ -85,7 +108,17 @@ func (tq *TaskQueue) RunTask(ctx context.Context, f func(*Master)) (retErr error
 			}
 		}
 	}()
-	return tq.taskQueue.runTask(ctx, task.ID, func(te *taskEntry) {
+	return tq.taskQueue.runTask(ctx, task.ID, task.Priority, created, func(te *taskEntry) {
+		// Tell the workers to stop processing the task's subtasks as soon as
+		// the task is canceled, rather than once the callback returns.
+		go func() {
+			<-te.ctx.Done()
+			if ctx.Err() != nil {
+				if err := tq.cancelTask(task.ID); err != nil && !col.IsErrNotFound(err) {
+					fmt.Printf("errored canceling task %v: %v\n", task.ID, err)
+				}
+			}
+		}()
 		defer func() {
 			if err := tq.deleteTask(task.ID); err != nil {
 				fmt.Printf("errored deleting task %v: %v\n", task.ID, err)
@@ -230,6 +263,17 @@ func (m *Master) deleteSubtasks() error {
 	return err
 }
 
+func (tq *TaskQueue) cancelTask(taskID string) error {
+	_, err := col.NewSTM(context.Background(), tq.etcdClient, func(stm col.STM) error {
+		task := &Task{}
+		return tq.taskCol.ReadWrite(stm).Update(taskID, task, func() error {
+			task.Canceled = true
+			return nil
+		})
+	})
+	return err
+}
+
 func (tq *TaskQueue) deleteTask(taskID string) error {
 	_, err := col.NewSTM(context.Background(), tq.etcdClient, func(stm col.STM) error {
 		tq.subtaskCol.ReadWrite(stm).DeleteAllPrefix(taskID)
@@ -267,6 +311,7 @@ type ProcessFunc func(context.Context, *Task) error
 
 // Run runs the worker with the given context.
 // The worker will continue to watch the task collection until the context is canceled.
+// The context passed to processFunc is canceled if the subtask's task is canceled or deleted.
 func (w *Worker) Run(ctx context.Context, processFunc ProcessFunc) error {
 	taskQueue := newTaskQueue(ctx)
 	return w.taskCol.ReadOnly(ctx).WatchF(func(e *watch.Event) error {
@@ -275,11 +320,17 @@ func (w *Worker) Run(ctx context.Context, processFunc ProcessFunc) error {
 		if err := e.Unmarshal(&taskID, task); err != nil {
 			return err
 		}
-		if e.Type == watch.EventDelete {
+		if e.Type == watch.EventDelete || task.Canceled {
 			taskQueue.deleteTask(taskID)
 			return nil
 		}
-		return taskQueue.runTask(ctx, taskID, func(taskEntry *taskEntry) {
+		// Tasks created by older masters don't have a creation time, so they're
+		// aged from when they're first seen
+		created, err := types.TimestampFromProto(task.Created)
+		if err != nil {
+			created = time.Now()
+		}
+		return taskQueue.runTask(ctx, taskID, task.Priority, created, func(taskEntry *taskEntry) {
 			if err := w.taskFunc(task, taskEntry, processFunc); err != nil && taskEntry.ctx.Err() != context.Canceled {
 				fmt.Printf("errored in task callback: %v\n", err)
 			}
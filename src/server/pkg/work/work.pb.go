@@ -53,11 +53,22 @@ func (State) EnumDescriptor() ([]byte, []int) {
 }
 
 type Task struct {
-	ID                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Data                 *types.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	ID   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data *types.Any `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// priority is the priority of a task's subtasks relative to other tasks'
+	// (higher is processed first). Tasks that have been waiting longer are
+	// boosted, so that low priority tasks aren't starved.
+	Priority int64 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	// canceled is set when the task's context is canceled, so that workers stop
+	// processing its subtasks without waiting for the task to be deleted.
+	Canceled bool `protobuf:"varint,4,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	// created is when the task was created, which its priority is aged from, so
+	// that a worker that sees the task late (e.g. because it restarted) doesn't
+	// reset its age.
+	Created              *types.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *Task) Reset()         { *m = Task{} }
@@ -107,6 +118,27 @@ func (m *Task) GetData() *types.Any {
 	return nil
 }
 
+func (m *Task) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Task) GetCanceled() bool {
+	if m != nil {
+		return m.Canceled
+	}
+	return false
+}
+
+func (m *Task) GetCreated() *types.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
 type TaskInfo struct {
 	Task                 *Task    `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	State                State    `protobuf:"varint,2,opt,name=state,proto3,enum=work.State" json:"state,omitempty"`
@@ -267,28 +299,32 @@ func init() {
 func init() { proto.RegisterFile("server/pkg/work/work.proto", fileDescriptor_58a68e4647f78187) }
 
 var fileDescriptor_58a68e4647f78187 = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xcb, 0x6a, 0xe3, 0x30,
-	0x14, 0x86, 0xc7, 0x1e, 0xe7, 0x76, 0x0c, 0x43, 0x10, 0x21, 0x64, 0xc2, 0xe0, 0x49, 0xbd, 0x32,
-	0x5d, 0xd8, 0xe0, 0xbe, 0x40, 0x73, 0x6b, 0x6b, 0x28, 0x59, 0xc8, 0xc9, 0xa6, 0x3b, 0xc5, 0x56,
-	0x1c, 0xe3, 0xc4, 0x32, 0x92, 0xd2, 0x92, 0x37, 0xec, 0xb2, 0x4f, 0x50, 0x8a, 0x9f, 0xa4, 0x48,
-	0x6e, 0x69, 0xe9, 0x46, 0xfc, 0x97, 0xc3, 0xc7, 0x91, 0x04, 0x63, 0x41, 0xf9, 0x23, 0xe5, 0x41,
-	0x55, 0x64, 0xc1, 0x13, 0xe3, 0x85, 0x3e, 0xfc, 0x8a, 0x33, 0xc9, 0x90, 0xa5, 0xf4, 0x78, 0x90,
-	0xb1, 0x8c, 0xe9, 0x20, 0x50, 0xaa, 0xe9, 0xc6, 0x7f, 0x33, 0xc6, 0xb2, 0x03, 0x0d, 0xb4, 0xdb,
-	0x9e, 0x76, 0x01, 0x29, 0xcf, 0x4d, 0xe5, 0xde, 0x81, 0xb5, 0x26, 0xa2, 0x40, 0x43, 0x30, 0xf3,
-	0x74, 0x64, 0x4c, 0x0c, 0xaf, 0x37, 0x6b, 0xd7, 0xaf, 0xff, 0xcd, 0x68, 0x81, 0xcd, 0x3c, 0x45,
-	0x1e, 0x58, 0x29, 0x91, 0x64, 0x64, 0x4e, 0x0c, 0xcf, 0x0e, 0x07, 0x7e, 0x43, 0xf2, 0x3f, 0x49,
-	0xfe, 0xb4, 0x3c, 0x63, 0x3d, 0xe1, 0x52, 0xe8, 0x2a, 0x52, 0x54, 0xee, 0x18, 0x72, 0xc0, 0x92,
-	0x44, 0x14, 0x9a, 0x67, 0x87, 0xe0, 0xeb, 0x3d, 0x55, 0x8b, 0x75, 0x8e, 0x2e, 0xa0, 0x25, 0x24,
-	0x91, 0x54, 0x63, 0xff, 0x84, 0x76, 0x33, 0x10, 0xab, 0x08, 0x37, 0x0d, 0x1a, 0x42, 0x9b, 0x53,
-	0x22, 0x58, 0x39, 0xfa, 0xad, 0x96, 0xc2, 0x1f, 0xce, 0xed, 0x40, 0x6b, 0x7e, 0x20, 0xf9, 0xd1,
-	0xf5, 0xa0, 0xbb, 0xa6, 0x42, 0x2e, 0x88, 0x24, 0xe8, 0x1f, 0xf4, 0x2a, 0xce, 0x12, 0x2a, 0x04,
-	0x6d, 0x2e, 0xd1, 0xc5, 0x5f, 0xc1, 0xa5, 0x0f, 0x2d, 0x8d, 0x46, 0x36, 0x74, 0xf0, 0x66, 0xb5,
-	0x8a, 0x56, 0xb7, 0xfd, 0x5f, 0xca, 0xc4, 0x9b, 0xf9, 0x7c, 0x19, 0xc7, 0x7d, 0x43, 0x99, 0x9b,
-	0x69, 0x74, 0xbf, 0xc1, 0xcb, 0xbe, 0x39, 0xbb, 0x7e, 0xae, 0x1d, 0xe3, 0xa5, 0x76, 0x8c, 0xb7,
-	0xda, 0x31, 0x1e, 0xc2, 0x2c, 0x97, 0xfb, 0xd3, 0xd6, 0x4f, 0xd8, 0x31, 0xa8, 0x48, 0xb2, 0x3f,
-	0xa7, 0x94, 0x7f, 0x57, 0x82, 0x27, 0xc1, 0x8f, 0x7f, 0xd9, 0xb6, 0xf5, 0xfb, 0x5c, 0xbd, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0xec, 0xad, 0x45, 0x2a, 0xb1, 0x01, 0x00, 0x00,
+	// 395 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x52, 0xdd, 0x6a, 0xdb, 0x30,
+	0x14, 0x9e, 0x5c, 0x27, 0x71, 0x4e, 0x60, 0x04, 0x51, 0x8a, 0x67, 0x86, 0xeb, 0xf9, 0xca, 0xec,
+	0xc2, 0x06, 0x6f, 0x0f, 0xb0, 0x36, 0xed, 0x86, 0x61, 0xe4, 0x42, 0x4e, 0x6e, 0x76, 0xa7, 0xd8,
+	0xaa, 0x6b, 0x1c, 0x5b, 0x46, 0x52, 0x37, 0xfc, 0x62, 0x7b, 0x86, 0x5d, 0xee, 0x09, 0xc6, 0xf0,
+	0x93, 0x0c, 0xc9, 0x4d, 0x37, 0xba, 0x1b, 0x71, 0xbe, 0x1f, 0xce, 0xf9, 0xce, 0x41, 0xe0, 0x49,
+	0x26, 0xbe, 0x32, 0x91, 0xf4, 0x4d, 0x95, 0x7c, 0xe3, 0xa2, 0x31, 0x4f, 0xdc, 0x0b, 0xae, 0x38,
+	0xb6, 0x75, 0xed, 0x9d, 0x57, 0xbc, 0xe2, 0x86, 0x48, 0x74, 0x35, 0x69, 0xde, 0xab, 0x8a, 0xf3,
+	0xea, 0xc8, 0x12, 0x83, 0x0e, 0x0f, 0x77, 0x09, 0xed, 0x86, 0x47, 0xe9, 0xf2, 0xb9, 0xa4, 0xea,
+	0x96, 0x49, 0x45, 0xdb, 0x7e, 0x32, 0x84, 0xdf, 0x11, 0xd8, 0x3b, 0x2a, 0x1b, 0x7c, 0x01, 0x56,
+	0x5d, 0xba, 0x28, 0x40, 0xd1, 0xf2, 0x7a, 0x3e, 0xfe, 0xba, 0xb4, 0xb2, 0x1b, 0x62, 0xd5, 0x25,
+	0x8e, 0xc0, 0x2e, 0xa9, 0xa2, 0xae, 0x15, 0xa0, 0x68, 0x95, 0x9e, 0xc7, 0x53, 0xc3, 0xf8, 0xd4,
+	0x30, 0xbe, 0xea, 0x06, 0x62, 0x1c, 0xd8, 0x03, 0xa7, 0x17, 0x35, 0x17, 0xb5, 0x1a, 0xdc, 0xb3,
+	0x00, 0x45, 0x67, 0xe4, 0x09, 0x6b, 0xad, 0xa0, 0x5d, 0xc1, 0x8e, 0xac, 0x74, 0xed, 0x00, 0x45,
+	0x0e, 0x79, 0xc2, 0xf8, 0x3d, 0x2c, 0x0a, 0xc1, 0xa8, 0x62, 0xa5, 0x3b, 0x33, 0x43, 0xbc, 0xff,
+	0x86, 0xec, 0x4e, 0xa9, 0xc9, 0xc9, 0x1a, 0x32, 0x70, 0x74, 0xee, 0xac, 0xbb, 0xe3, 0xd8, 0x07,
+	0x5b, 0x51, 0xd9, 0x98, 0xf4, 0xab, 0x14, 0x62, 0x73, 0x37, 0xad, 0x12, 0xc3, 0xe3, 0x37, 0x30,
+	0x93, 0x8a, 0x2a, 0x66, 0x96, 0x78, 0x99, 0xae, 0x26, 0x43, 0xae, 0x29, 0x32, 0x29, 0xf8, 0x02,
+	0xe6, 0x82, 0x51, 0xc9, 0x3b, 0x13, 0x7d, 0x49, 0x1e, 0x51, 0xb8, 0x80, 0xd9, 0xe6, 0x48, 0xeb,
+	0x36, 0x8c, 0xc0, 0xd9, 0x31, 0xa9, 0x6e, 0xf4, 0xa6, 0xaf, 0x61, 0xd9, 0x0b, 0x5e, 0x30, 0x29,
+	0xd9, 0x74, 0x32, 0x87, 0xfc, 0x25, 0xde, 0xc6, 0x30, 0x33, 0xad, 0xf1, 0x0a, 0x16, 0x64, 0xbf,
+	0xdd, 0x66, 0xdb, 0x4f, 0xeb, 0x17, 0x1a, 0xe4, 0xfb, 0xcd, 0xe6, 0x36, 0xcf, 0xd7, 0x48, 0x83,
+	0x8f, 0x57, 0xd9, 0xe7, 0x3d, 0xb9, 0x5d, 0x5b, 0xd7, 0x1f, 0x7e, 0x8c, 0x3e, 0xfa, 0x39, 0xfa,
+	0xe8, 0xf7, 0xe8, 0xa3, 0x2f, 0x69, 0x55, 0xab, 0xfb, 0x87, 0x43, 0x5c, 0xf0, 0x36, 0xe9, 0x69,
+	0x71, 0x3f, 0x94, 0x4c, 0xfc, 0x5b, 0x49, 0x51, 0x24, 0xcf, 0xfe, 0xc9, 0x61, 0x6e, 0x0e, 0xf5,
+	0xee, 0xcf, 0x00, 0xc0, 0x9d, 0x3d, 0x10, 0x41, 0x02, 0x00, 0x00,
 }
 
 func (m *Task) Marshal() (dAtA []byte, err error) {
@@ -315,6 +351,33 @@ func (m *Task) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Created != nil {
+		{
+			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWork(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Canceled {
+		i--
+		if m.Canceled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Priority != 0 {
+		i = encodeVarintWork(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Data != nil {
 		{
 			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
@@ -477,6 +540,16 @@ func (m *Task) Size() (n int) {
 		l = m.Data.Size()
 		n += 1 + l + sovWork(uint64(l))
 	}
+	if m.Priority != 0 {
+		n += 1 + sovWork(uint64(m.Priority))
+	}
+	if m.Canceled {
+		n += 2
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovWork(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -636,6 +709,81 @@ func (m *Task) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Canceled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Canceled = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &types.Timestamp{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWork(dAtA[iNdEx:])
@@ -12,6 +12,7 @@ import (
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/testetcd"
 	"golang.org/x/sync/errgroup"
 )
@@ -200,3 +201,107 @@ func TestRunZeroSubtasks(t *testing.T) {
 		})
 	}))
 }
+
+func TestCancelInFlightSubtask(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		workerCtx, workerCancel := context.WithCancel(context.Background())
+		defer workerCancel()
+		started, canceled := make(chan struct{}), make(chan struct{})
+		go NewWorker(env.EtcdClient, "", "").Run(workerCtx, func(ctx context.Context, _ *Task) error {
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+			return nil
+		})
+		tq, err := NewTaskQueue(context.Background(), env.EtcdClient, "", "")
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		require.NoError(t, tq.RunTask(ctx, func(m *Master) {
+			go m.RunSubtasks([]*Task{{ID: "subtask"}}, nil)
+			// The task isn't deleted until the callback returns, so the
+			// subtask has to be canceled through the task
+			<-release
+		}))
+		select {
+		case <-started:
+		case <-time.After(30 * time.Second):
+			t.Fatal("subtask was not started")
+		}
+		cancel()
+		select {
+		case <-canceled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("in-flight subtask was not canceled")
+		}
+		return nil
+	}))
+}
+
+func TestRunTaskWithPriority(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		workerCtx, workerCancel := context.WithCancel(context.Background())
+		defer workerCancel()
+		blocked, unblock := make(chan struct{}), make(chan struct{})
+		order := make(chan string, 2)
+		go NewWorker(env.EtcdClient, "", "").Run(workerCtx, func(_ context.Context, subtask *Task) error {
+			if subtask.ID == "blocker" {
+				close(blocked)
+				<-unblock
+				return nil
+			}
+			order <- subtask.ID
+			return nil
+		})
+		tq, err := NewTaskQueue(context.Background(), env.EtcdClient, "", "")
+		require.NoError(t, err)
+		release := make(chan struct{})
+		defer close(release)
+		runTask := func(subtaskID string, priority int64) {
+			require.NoError(t, tq.RunTaskWithPriority(context.Background(), priority, func(m *Master) {
+				go m.RunSubtasks([]*Task{{ID: subtaskID}}, nil)
+				<-release
+			}))
+		}
+		// Occupy the worker, so that the subtasks below are all waiting when
+		// it's free
+		runTask("blocker", 0)
+		select {
+		case <-blocked:
+		case <-time.After(30 * time.Second):
+			t.Fatal("blocking subtask was not started")
+		}
+		runTask("low", 0)
+		runTask("high", 5)
+		// Wait for both subtasks to be created, and give the worker time to
+		// see them
+		require.NoErrorWithinTRetry(t, 30*time.Second, func() error {
+			count, err := tq.subtaskCol.ReadOnly(context.Background()).Count()
+			if err != nil {
+				return err
+			}
+			if count != 3 {
+				return errors.Errorf("expected 3 subtasks, but there are %d", count)
+			}
+			return nil
+		})
+		// Each task records when it was created, which workers age it from
+		task := &Task{}
+		require.NoError(t, tq.taskCol.ReadOnly(context.Background()).List(task, col.DefaultOptions, func(string) error {
+			require.NotNil(t, task.Created)
+			return nil
+		}))
+		time.Sleep(time.Second)
+		close(unblock)
+		for _, expected := range []string{"high", "low"} {
+			select {
+			case subtaskID := <-order:
+				require.Equal(t, expected, subtaskID)
+			case <-time.After(30 * time.Second):
+				t.Fatalf("subtask %q was not processed", expected)
+			}
+		}
+		return nil
+	}))
+}
@@ -90,6 +90,9 @@ func PrintWorkerStatusHeader(w io.Writer) {
 func PrintWorkerStatus(w io.Writer, workerStatus *ppsclient.WorkerStatus, fullTimestamps bool) {
 	fmt.Fprintf(w, "%s\t", workerStatus.WorkerID)
 	fmt.Fprintf(w, "%s\t", workerStatus.JobID)
+	if workerStatus.WaitingForS3Gateway {
+		fmt.Fprintf(w, "(waiting for S3 gateway)")
+	}
 	for _, datum := range workerStatus.Data {
 		fmt.Fprintf(w, datum.Path)
 	}
@@ -132,6 +135,7 @@ Processed: {{.DataProcessed}}
 Failed: {{.DataFailed}}
 Skipped: {{.DataSkipped}}
 Recovered: {{.DataRecovered}}
+Excluded: {{.DataExcluded}}
 Total: {{.DataTotal}}
 Data Downloaded: {{prettySize .Stats.DownloadBytes}}
 Data Uploaded: {{prettySize .Stats.UploadBytes}}
@@ -48,6 +48,7 @@ import (
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/types"
 	opentracing "github.com/opentracing/opentracing-go"
+	globlib "github.com/pachyderm/ohmyglob"
 	"github.com/robfig/cron"
 	logrus "github.com/sirupsen/logrus"
 	"github.com/willf/bloom"
@@ -292,6 +293,15 @@ func validateTransform(transform *pps.Transform) error {
 	if transform.Image == "" {
 		return errors.Errorf("pipeline transform must contain an image")
 	}
+	if transform.TerminationGracePeriod != nil {
+		gracePeriod, err := types.DurationFromProto(transform.TerminationGracePeriod)
+		if err != nil {
+			return err
+		}
+		if gracePeriod < 0 {
+			return errors.Errorf("pipeline transform termination_grace_period cannot be negative")
+		}
+	}
 	return nil
 }
 
@@ -527,6 +537,7 @@ func (a *apiServer) UpdateJobStateInTransaction(txnCtx *txnenv.TransactionContex
 	jobPtr.DataSkipped = request.DataSkipped
 	jobPtr.DataFailed = request.DataFailed
 	jobPtr.DataRecovered = request.DataRecovered
+	jobPtr.DataExcluded = request.DataExcluded
 	jobPtr.DataTotal = request.DataTotal
 	jobPtr.Stats = request.Stats
 
@@ -559,6 +570,7 @@ func (a *apiServer) CreateJob(ctx context.Context, request *pps.CreateJobRequest
 			DataTotal:     request.DataTotal,
 			DataFailed:    request.DataFailed,
 			DataRecovered: request.DataRecovered,
+			DataExcluded:  request.DataExcluded,
 			StatsCommit:   request.StatsCommit,
 			Started:       request.Started,
 			Finished:      request.Finished,
@@ -783,6 +795,7 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 		DataTotal:     jobPtr.DataTotal,
 		DataFailed:    jobPtr.DataFailed,
 		DataRecovered: jobPtr.DataRecovered,
+		DataExcluded:  jobPtr.DataExcluded,
 		Stats:         jobPtr.Stats,
 		StatsCommit:   jobPtr.StatsCommit,
 		State:         jobPtr.State,
@@ -1620,6 +1633,12 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 	if err := validateTransform(pipelineInfo.Transform); err != nil {
 		return errors.Wrapf(err, "invalid transform")
 	}
+	reservedEnv := ppsutil.ReservedUserCodeEnv(pipelineInfo.Input, pipelineInfo.S3Out)
+	for name := range pipelineInfo.Transform.Env {
+		if reservedEnv[name] {
+			return errors.Errorf("invalid transform: env var %q is set by Pachyderm, and can't be overridden", name)
+		}
+	}
 	if err := a.validateInput(pachClient, pipelineInfo.Pipeline.Name, pipelineInfo.Input, false); err != nil {
 		return err
 	}
@@ -1659,6 +1678,34 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 			return err
 		}
 	}
+	if pipelineInfo.DownloadTimeout != nil {
+		_, err := types.DurationFromProto(pipelineInfo.DownloadTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.UploadTimeout != nil {
+		_, err := types.DurationFromProto(pipelineInfo.UploadTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.DatumExclude != "" {
+		if _, err := globlib.Compile(pipelineInfo.DatumExclude, '/'); err != nil {
+			return errors.Wrapf(err, "malformed DatumExclude %q", pipelineInfo.DatumExclude)
+		}
+	}
+	if endpoint := pipelineInfo.S3Endpoint; endpoint != nil {
+		if endpoint.Scheme != "" && endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+			return errors.Errorf("S3Endpoint.Scheme must be \"http\" or \"https\", got %q", endpoint.Scheme)
+		}
+		if strings.ContainsAny(endpoint.Host, "/:") {
+			return errors.Errorf("S3Endpoint.Host must be a hostname without a scheme or port, got %q", endpoint.Host)
+		}
+		if endpoint.Port < 0 || endpoint.Port > math.MaxUint16 {
+			return errors.Errorf("S3Endpoint.Port must be a valid port, got %d", endpoint.Port)
+		}
+	}
 	if pipelineInfo.PodSpec != "" && !json.Valid([]byte(pipelineInfo.PodSpec)) {
 		return errors.Errorf("malformed PodSpec")
 	}
@@ -2044,6 +2091,12 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 		PodPatch:              request.PodPatch,
 		S3Out:                 request.S3Out,
 		Metadata:              request.Metadata,
+		LogSampling:           request.LogSampling,
+		DownloadTimeout:       request.DownloadTimeout,
+		UploadTimeout:         request.UploadTimeout,
+		DatumFailurePolicy:    request.DatumFailurePolicy,
+		DatumExclude:          request.DatumExclude,
+		S3Endpoint:            request.S3Endpoint,
 	}
 	if err := setPipelineDefaults(pipelineInfo); err != nil {
 		return nil, err
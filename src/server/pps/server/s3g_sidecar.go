@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	units "github.com/docker/go-units"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pps"
@@ -211,12 +212,20 @@ func (s *s3InstanceCreatingJobHandler) OnCreate(ctx context.Context, jobInfo *pp
 	// server will forward requests based on the request hostname
 	port := s.s.apiServer.env.S3GatewayPort
 	strport := strconv.FormatInt(int64(port), 10)
+	minPartSize, err := units.RAMInBytes(s.s.apiServer.env.S3GatewayMinPartSize)
+	if err != nil {
+		logrus.Errorf("invalid S3GATEWAY_MIN_PART_SIZE for the sidecar s3 gateway of %q: %v", jobID, err)
+		return // give up. Worker will fail the job
+	}
 	var server *http.Server
-	err := backoff.RetryNotify(func() error {
+	err = backoff.RetryNotify(func() error {
 		var err error
+		env := s.s.apiServer.env
 		server, err = s3.Server(port, driver, func() (*client.APIClient, error) {
-			return s.s.apiServer.env.GetPachClient(s.s.pachClient.Ctx()), nil // clones s.pachClient
-		})
+			return env.GetPachClient(s.s.pachClient.Ctx()), nil // clones s.pachClient
+		}, s3.WithMultipartRepo(env.S3GatewayMultipartRepo, env.S3GatewayMultipartBranch),
+			s3.WithMinPartSize(minPartSize),
+			s3.WithCompleteMultipartConcurrency(env.S3GatewayCompleteMultipartConcurrency))
 		if err != nil {
 			return errors.Wrapf(err, "couldn't initialize s3 gateway server")
 		}
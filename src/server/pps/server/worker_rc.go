@@ -170,6 +170,44 @@ func (a *apiServer) workerPodSpec(options *workerOptions) (v1.PodSpec, error) {
 			Value: strconv.FormatUint(uint64(options.s3GatewayPort), 10),
 		})
 	}
+	// Propagate the s3 gateway's multipart repo to the sidecar, so that its
+	// uploads are kept in the same place as pachd's, along with its
+	// multipart configuration
+	sidecarEnv = append(sidecarEnv, []v1.EnvVar{
+		{Name: "S3GATEWAY_MULTIPART_REPO", Value: a.env.S3GatewayMultipartRepo},
+		{Name: "S3GATEWAY_MULTIPART_BRANCH", Value: a.env.S3GatewayMultipartBranch},
+		{Name: "S3GATEWAY_MIN_PART_SIZE", Value: a.env.S3GatewayMinPartSize},
+		{Name: "S3GATEWAY_COMPLETE_MULTIPART_CONCURRENCY", Value: strconv.Itoa(a.env.S3GatewayCompleteMultipartConcurrency)},
+	}...)
+	// Propagate the configuration of the workers' hashtree chunk caches
+	if a.env.WorkerChunkCacheMaxEntries != 0 {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: "WORKER_CHUNK_CACHE_MAX_ENTRIES", Value: strconv.Itoa(a.env.WorkerChunkCacheMaxEntries)})
+	}
+	if a.env.WorkerChunkCacheMaxBytes != "0" {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: "WORKER_CHUNK_CACHE_MAX_BYTES", Value: a.env.WorkerChunkCacheMaxBytes})
+	}
+	if a.env.WorkerChunkCacheSpillToDisk {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: "WORKER_CHUNK_CACHE_SPILL_TO_DISK", Value: "true"})
+	}
+	// Propagate the size of the workers' input download caches
+	if a.env.WorkerInputCacheMaxBytes != "0" {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: "WORKER_INPUT_CACHE_MAX_BYTES", Value: a.env.WorkerInputCacheMaxBytes})
+	}
+	// Propagate the minimum level of the workers' pipeline logs
+	if a.env.WorkerLogLevel != "info" {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: "WORKER_LOG_LEVEL", Value: a.env.WorkerLogLevel})
+	}
+	if a.env.WorkerDatumLogMaxBytes != "0" {
+		workerEnv = append(workerEnv, v1.EnvVar{Name: "WORKER_DATUM_LOG_MAX_BYTES", Value: a.env.WorkerDatumLogMaxBytes})
+	}
+	// Propagate the PFS auth settings to the sidecar, which serves PFS for the
+	// pipeline's workers
+	if a.env.PFSAuthFailOpen {
+		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "PFS_AUTH_FAIL_OPEN", Value: "true"})
+	}
+	if a.env.PFSAuthCheckTimeout != "" {
+		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "PFS_AUTH_CHECK_TIMEOUT", Value: a.env.PFSAuthCheckTimeout})
+	}
 	// Propagate feature flags to worker and sidecar
 	if a.env.StorageV2 {
 		sidecarEnv = append(sidecarEnv, v1.EnvVar{Name: "STORAGE_V2", Value: "true"})
@@ -23,14 +23,27 @@ type workerCache struct {
 	// hashtreeStorage is the where we store on disk hashtrees
 	hashtreeStorage string
 
+	// maxEntries and maxBytes bound each job's cache (0 means unbounded)
+	maxEntries int
+	maxBytes   int64
+
 	caches map[string]*hashtree.MergeCache
 }
 
 // NewWorkerCache constructs a WorkerCache for maintaining hashtree caches for
 // multiple concurrent jobs
 func NewWorkerCache(hashtreeStorage string) WorkerCache {
+	return NewBoundedWorkerCache(hashtreeStorage, 0, 0)
+}
+
+// NewBoundedWorkerCache constructs a WorkerCache whose hashtree caches each
+// hold at most maxEntries hashtrees, totalling at most maxBytes bytes (see
+// hashtree.NewBoundedMergeCache)
+func NewBoundedWorkerCache(hashtreeStorage string, maxEntries int, maxBytes int64) WorkerCache {
 	return &workerCache{
 		hashtreeStorage: hashtreeStorage,
+		maxEntries:      maxEntries,
+		maxBytes:        maxBytes,
 		caches:          make(map[string]*hashtree.MergeCache),
 	}
 }
@@ -43,7 +56,7 @@ func (wc *workerCache) GetOrCreateCache(jobID string) (*hashtree.MergeCache, err
 		return cache, nil
 	}
 
-	newCache, err := hashtree.NewMergeCache(filepath.Join(wc.hashtreeStorage, jobID))
+	newCache, err := hashtree.NewBoundedMergeCache(filepath.Join(wc.hashtreeStorage, jobID), wc.maxEntries, wc.maxBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +86,19 @@ type Driver interface {
 	// Returns the pachd API client for the driver
 	PachClient() *client.APIClient
 
+	// InspectObject returns the ObjectInfo of the object with the given hash.
+	// Results are cached, as an object's block ref never changes.
+	InspectObject(string) (*pfs.ObjectInfo, error)
+
+	// Returns the object storage that hashtree chunks are uploaded to and
+	// merged from. This is PFS's object API, via PachClient, unless it's been
+	// replaced with WithObjectStorage.
+	ObjectStorage() ObjectStorage
+
+	// WithObjectStorage clones the current driver and replaces its object
+	// storage with the given backend.
+	WithObjectStorage(ObjectStorage) Driver
+
 	// Returns the number of workers to be used
 	ExpectedNumWorkers() (int64, error)
 
@@ -137,6 +150,10 @@ type Driver interface {
 	ChunkCaches() cache.WorkerCache
 	ChunkStatsCaches() cache.WorkerCache
 
+	// Returns whether fetched hashtree chunks should be spilled to disk, rather
+	// than buffered in memory, before they're put in the chunk caches
+	SpillChunksToDisk() bool
+
 	// WithDatumCache calls the given callback with two hashtree merge caches, one
 	// for datums and one for datum stats. The lifetime of these caches will be
 	// bound to the callback, and any resources will be cleaned up upon return.
@@ -180,6 +197,32 @@ type driver struct {
 	// These caches are used for storing and merging hashtrees from jobs until the
 	// job is complete
 	chunkCaches, chunkStatsCaches cache.WorkerCache
+
+	// spillChunks is whether fetched hashtree chunks are spilled to disk,
+	// rather than buffered in memory, before they're put in the chunk caches
+	spillChunks bool
+
+	// objectStorage replaces PFS's object API for hashtree chunks, if it's set
+	objectStorage ObjectStorage
+
+	// inputCache caches downloaded inputs, if it's enabled
+	inputCache *inputCache
+
+	// objectInfos caches InspectObject results, and is shared by clones of
+	// the driver
+	objectInfos *objectInfoCache
+}
+
+// ChunkCacheOptions configures the caches that workers keep hashtree chunks
+// in until a job is complete
+type ChunkCacheOptions struct {
+	// MaxEntries and MaxBytes bound each job's chunk caches, which evict the
+	// least recently used chunks to stay within them (0 means unbounded)
+	MaxEntries int
+	MaxBytes   int64
+	// SpillToDisk makes fetched chunks be spilled to disk, rather than buffered
+	// in memory, before they're put in the chunk caches
+	SpillToDisk bool
 }
 
 // NewDriver constructs a Driver object using the given clients and pipeline
@@ -194,6 +237,8 @@ func NewDriver(
 	hashtreePath string,
 	rootPath string,
 	namespace string,
+	chunkCacheOptions ChunkCacheOptions,
+	inputCacheOptions InputCacheOptions,
 ) (Driver, error) {
 
 	pfsPath := filepath.Join(rootPath, client.PPSInputPrefix)
@@ -236,11 +281,20 @@ func NewDriver(
 		rootDir:          rootPath,
 		inputDir:         pfsPath,
 		hashtreeDir:      hashtreePath,
-		chunkCaches:      cache.NewWorkerCache(chunkCachePath),
-		chunkStatsCaches: cache.NewWorkerCache(chunkStatsCachePath),
+		chunkCaches:      cache.NewBoundedWorkerCache(chunkCachePath, chunkCacheOptions.MaxEntries, chunkCacheOptions.MaxBytes),
+		chunkStatsCaches: cache.NewBoundedWorkerCache(chunkStatsCachePath, chunkCacheOptions.MaxEntries, chunkCacheOptions.MaxBytes),
+		spillChunks:      chunkCacheOptions.SpillToDisk,
+		objectInfos:      newObjectInfoCache(defaultObjectInfoCacheSize),
 		namespace:        namespace,
 	}
 
+	if inputCacheOptions.MaxBytes > 0 {
+		result.inputCache, err = newInputCache(filepath.Join(hashtreePath, "input"), inputCacheOptions.MaxBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if pipelineInfo.Transform.User != "" {
 		user, err := lookupDockerUser(pipelineInfo.Transform.User)
 		if err != nil && !os.IsNotExist(err) {
@@ -269,6 +323,7 @@ func NewDriver(
 	} else {
 		result.exportStats = resp.State == enterprise.State_ACTIVE
 	}
+	logs.SetExportStepTimes(result.exportStats)
 
 	return result, nil
 }
@@ -404,6 +459,27 @@ func (d *driver) PachClient() *client.APIClient {
 	return d.pachClient
 }
 
+func (d *driver) InspectObject(hash string) (*pfs.ObjectInfo, error) {
+	return d.objectInfos.inspectObject(hash, d.pachClient.InspectObject)
+}
+
+func (d *driver) ObjectStorage() ObjectStorage {
+	if d.objectStorage != nil {
+		return d.objectStorage
+	}
+	return &pachObjectStorage{
+		pachClient:  d.pachClient,
+		objectInfos: d.objectInfos,
+	}
+}
+
+func (d *driver) WithObjectStorage(objectStorage ObjectStorage) Driver {
+	result := &driver{}
+	*result = *d
+	result.objectStorage = objectStorage
+	return result
+}
+
 func (d *driver) ChunkCaches() cache.WorkerCache {
 	return d.chunkCaches
 }
@@ -412,6 +488,10 @@ func (d *driver) ChunkStatsCaches() cache.WorkerCache {
 	return d.chunkStatsCaches
 }
 
+func (d *driver) SpillChunksToDisk() bool {
+	return d.spillChunks
+}
+
 // This is broken out into its own function because its scope is small and it
 // can easily be used by the mock driver for testing purposes.
 func withDatumCache(storageRoot string, cb func(*hashtree.MergeCache, *hashtree.MergeCache) error) (retErr error) {
@@ -609,9 +689,27 @@ func (d *driver) downloadData(
 			parent, _ := filepath.Split(statsRoot)
 			statsTree.MkdirAll(parent)
 		}
-		if err := puller.Pull(
+		if err := d.pullInput(puller, input, fullInputPath, statsTree, statsRoot); err != nil {
+			return "", err
+		}
+	}
+	return scratchPath, nil
+}
+
+// pullInput downloads 'input' to 'path', reusing a previous download of the
+// same content from the input cache if there is one.
+func (d *driver) pullInput(
+	puller *filesync.Puller,
+	input *common.Input,
+	path string,
+	statsTree *hashtree.Ordered,
+	statsRoot string,
+) error {
+	file := input.FileInfo.File
+	pull := func() error {
+		return errors.EnsureStack(puller.Pull(
 			d.pachClient,
-			fullInputPath,
+			path,
 			file.Commit.Repo.Name,
 			file.Commit.ID,
 			file.Path,
@@ -620,11 +718,21 @@ func (d *driver) downloadData(
 			concurrency,
 			statsTree,
 			statsRoot,
-		); err != nil {
-			return "", errors.EnsureStack(err)
-		}
+		))
 	}
-	return scratchPath, nil
+	// Lazy and empty inputs aren't really downloaded, and the stats tree is
+	// filled in by the puller, so only plain downloads go through the cache
+	if d.inputCache == nil || input.Lazy || input.EmptyFiles || statsTree != nil || len(input.FileInfo.Hash) == 0 {
+		return pull()
+	}
+	key := pfs.EncodeHash(input.FileInfo.Hash)
+	if ok, err := d.inputCache.get(key, path); err != nil || ok {
+		return err
+	}
+	if err := pull(); err != nil {
+		return err
+	}
+	return d.inputCache.put(key, path)
 }
 
 func (d *driver) downloadGitData(scratchPath string, input *common.Input) error {
@@ -690,6 +798,21 @@ func (d *driver) downloadGitData(scratchPath string, input *common.Input) error
 	return nil
 }
 
+// setGracePeriod configures cmd to be sent SIGTERM, rather than being killed
+// outright, when its context is cancelled (e.g. because the datum timed out or
+// was cancelled by the user), if the pipeline specifies a grace period.
+func (d *driver) setGracePeriod(cmd *exec.Cmd) error {
+	if d.pipelineInfo.Transform.TerminationGracePeriod == nil {
+		return nil
+	}
+	gracePeriod, err := types.DurationFromProto(d.pipelineInfo.Transform.TerminationGracePeriod)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	cmd.GracePeriod = gracePeriod
+	return nil
+}
+
 // Run user code and return the combined output of stdout and stderr.
 func (d *driver) RunUserCode(
 	logger logs.TaggedLogger,
@@ -734,6 +857,9 @@ func (d *driver) RunUserCode(
 		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
 	}
 	cmd.Dir = filepath.Join(d.rootDir, d.pipelineInfo.Transform.WorkingDir)
+	if err := d.setGracePeriod(cmd); err != nil {
+		return err
+	}
 	err := cmd.Start()
 	if err != nil {
 		return errors.EnsureStack(err)
@@ -799,6 +925,9 @@ func (d *driver) RunUserErrorHandlingCode(logger logs.TaggedLogger, environ []st
 		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
 	}
 	cmd.Dir = d.pipelineInfo.Transform.WorkingDir
+	if err := d.setGracePeriod(cmd); err != nil {
+		return err
+	}
 	err := cmd.Start()
 	if err != nil {
 		return errors.EnsureStack(err)
@@ -1134,7 +1263,7 @@ func (d *driver) UploadOutput(
 							}
 							var blockRefs []*pfs.BlockRef
 							for _, object := range fileInfo.Objects {
-								objectInfo, err := d.pachClient.InspectObject(object.Hash)
+								objectInfo, err := d.InspectObject(object.Hash)
 								if err != nil {
 									return errors.EnsureStack(err)
 								}
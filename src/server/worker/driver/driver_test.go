@@ -10,12 +10,14 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/prometheus/client_golang/prometheus"
 	prometheus_proto "github.com/prometheus/client_model/go"
+	"golang.org/x/sync/errgroup"
 	"gopkg.in/go-playground/webhooks.v5/github"
 
 	"github.com/pachyderm/pachyderm/src/client"
@@ -24,6 +26,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
 	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
@@ -74,6 +77,8 @@ func withTestEnv(cb func(*testEnv)) error {
 			filepath.Clean(filepath.Join(env.Directory, "hashtrees")),
 			filepath.Clean(filepath.Join(env.Directory, "pfs")),
 			"namespace",
+			ChunkCacheOptions{},
+			InputCacheOptions{},
 		)
 		if err != nil {
 			return err
@@ -231,6 +236,73 @@ func TestUpdateCounter(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestInspectObjectCache(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		var mu sync.Mutex
+		calls := make(map[string]int)
+		env.MockPachd.Object.InspectObject.Use(func(ctx context.Context, req *pfs.Object) (*pfs.ObjectInfo, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[req.Hash]++
+			if req.Hash == "missing" {
+				return nil, errors.Errorf("object %s not found", req.Hash)
+			}
+			return &pfs.ObjectInfo{
+				Object:   req,
+				BlockRef: &pfs.BlockRef{Block: client.NewBlock(req.Hash + "-block")},
+			}, nil
+		})
+
+		// A job re-inspects the same few objects, concurrently
+		var eg errgroup.Group
+		for i := 0; i < 10; i++ {
+			d := env.driver.WithContext(env.Context)
+			eg.Go(func() error {
+				for j := 0; j < 100; j++ {
+					hash := fmt.Sprintf("object-%d", j%5)
+					info, err := d.InspectObject(hash)
+					if err != nil {
+						return err
+					}
+					if info.BlockRef.Block.Hash != hash+"-block" {
+						return errors.Errorf("wrong block for %s: %s", hash, info.BlockRef.Block.Hash)
+					}
+				}
+				return nil
+			})
+		}
+		require.NoError(t, eg.Wait())
+
+		// Each object should only be inspected a few times, rather than 200 times
+		// (concurrent misses can each make the call)
+		mu.Lock()
+		require.Equal(t, 5, len(calls))
+		for hash, n := range calls {
+			require.True(t, n <= 10, "%s was inspected %d times", hash, n)
+		}
+		mu.Unlock()
+
+		// Errors aren't cached
+		for i := 0; i < 3; i++ {
+			_, err := env.driver.InspectObject("missing")
+			require.YesError(t, err)
+		}
+		require.Equal(t, 3, calls["missing"])
+
+		// The cache is bounded, evicting the least recently used objects
+		env.driver.objectInfos = newObjectInfoCache(2)
+		for _, hash := range []string{"a", "b", "a", "c", "a", "b"} {
+			_, err := env.driver.InspectObject(hash)
+			require.NoError(t, err)
+		}
+		require.Equal(t, 1, calls["a"])
+		require.Equal(t, 2, calls["b"])
+		require.Equal(t, 1, calls["c"])
+	})
+	require.NoError(t, err)
+}
+
 func TestUpdateHistogram(t *testing.T) {
 	t.Parallel()
 	err := withTestEnv(func(env *testEnv) {
@@ -483,6 +555,81 @@ func TestWithDataDownload(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Check that inputs that were already downloaded for an earlier datum are
+// copied out of the input cache, rather than downloaded again, until they're
+// evicted.
+func TestWithDataInputCache(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		var mu sync.Mutex
+		downloads := make(map[string]int)
+		env.MockPachd.PFS.WalkFile.Use(func(req *pfs.WalkFileRequest, serv pfs.API_WalkFileServer) error {
+			return serv.Send(&pfs.FileInfo{
+				File:     req.File,
+				FileType: pfs.FileType_FILE,
+			})
+		})
+		env.MockPachd.PFS.GetFile.Use(func(req *pfs.GetFileRequest, serv pfs.API_GetFileServer) error {
+			mu.Lock()
+			downloads[req.File.Commit.Repo.Name]++
+			mu.Unlock()
+			return serv.Send(&types.BytesValue{Value: []byte(fmt.Sprintf("%s-data", req.File.Commit.Repo.Name))})
+		})
+
+		// The cache fits two of the (10 byte) inputs
+		var err error
+		env.driver.inputCache, err = newInputCache(filepath.Join(env.Directory, "input-cache"), 20)
+		require.NoError(t, err)
+
+		cachedInput := func(repo string, path string) *common.Input {
+			input := newInput(repo, path)
+			input.FileInfo.Hash = []byte(repo)
+			return input
+		}
+		withData := func(inputs []*common.Input, expected []*inputData) {
+			_, err := env.driver.WithData(inputs, nil, logs.NewMockLogger(),
+				func(dir string, stats *pps.ProcessStats) error {
+					requireContents(t, dir, expected)
+					return nil
+				},
+			)
+			require.NoError(t, err)
+			requireEmptyScratch(t, env.driver.InputDir())
+		}
+
+		withData(
+			[]*common.Input{cachedInput("repoA", "input.txt"), cachedInput("repoB", "input.md")},
+			[]*inputData{newInputData("repoA/input.txt", "repoA-data"), newInputData("repoB/input.md", "repoB-data")},
+		)
+		// A second datum sharing repoA's input doesn't download it again, and
+		// caching repoC's input evicts repoB's, which is least recently used
+		withData(
+			[]*common.Input{cachedInput("repoA", "input.txt"), cachedInput("repoC", "input.csv")},
+			[]*inputData{newInputData("repoA/input.txt", "repoA-data"), newInputData("repoC/input.csv", "repoC-data")},
+		)
+		withData(
+			[]*common.Input{cachedInput("repoA", "input.txt"), cachedInput("repoB", "input.md")},
+			[]*inputData{newInputData("repoA/input.txt", "repoA-data"), newInputData("repoB/input.md", "repoB-data")},
+		)
+		require.Equal(t, map[string]int{"repoA": 1, "repoB": 2, "repoC": 1}, downloads)
+
+		// Lazy inputs are never cached
+		lazyInput := cachedInput("repoD", "input.txt")
+		lazyInput.Lazy = true
+		for i := 0; i < 2; i++ {
+			_, err := env.driver.WithData([]*common.Input{lazyInput}, nil, logs.NewMockLogger(),
+				func(dir string, stats *pps.ProcessStats) error {
+					_, err := ioutil.ReadFile(filepath.Join(dir, "repoD", "input.txt"))
+					return err
+				},
+			)
+			require.NoError(t, err)
+		}
+		require.Equal(t, 2, downloads["repoD"])
+	})
+	require.NoError(t, err)
+}
+
 // Create several files and directories inside WithData and verify that they are
 // cleaned up after WithData returns.
 func TestWithActiveDataCleanup(t *testing.T) {
@@ -729,6 +876,51 @@ func TestRunUserCodeTimeout(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test that user code is sent SIGTERM and given the configured grace period to
+// clean up before being killed when its datum times out
+func TestRunUserCodeGracePeriod(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		marker := filepath.Join(env.Directory, "terminated")
+		env.driver.pipelineInfo.Transform.Cmd = []string{"sh", "-c",
+			fmt.Sprintf("trap 'sleep 0.2; touch %s; exit 0' TERM; sleep 10 >/dev/null 2>&1 & wait", marker),
+		}
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		env.driver.pipelineInfo.Transform.TerminationGracePeriod = types.DurationProto(5 * time.Second)
+		timeout := types.DurationProto(100 * time.Millisecond)
+		start := time.Now()
+		err := env.driver.RunUserCode(logs.NewMockLogger(), []string{}, &pps.ProcessStats{}, timeout)
+		require.YesError(t, err)
+		require.True(t, time.Since(start) < 5*time.Second)
+
+		// The process should have been able to finish its cleanup
+		require.NoError(t, backoff.Retry(func() error {
+			_, err := os.Stat(marker)
+			return err
+		}, backoff.NewTestingBackOff()))
+	})
+	require.NoError(t, err)
+}
+
+// Test that user code which ignores SIGTERM is killed at the end of its grace
+// period
+func TestRunUserCodeGracePeriodKill(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		env.driver.pipelineInfo.Transform.Cmd = []string{"sh", "-c", "trap '' TERM; while true; do sleep 0.05; done"}
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		env.driver.pipelineInfo.Transform.TerminationGracePeriod = types.DurationProto(500 * time.Millisecond)
+		timeout := types.DurationProto(100 * time.Millisecond)
+		start := time.Now()
+		err := env.driver.RunUserCode(logs.NewMockLogger(), []string{}, &pps.ProcessStats{}, timeout)
+		require.YesError(t, err)
+		elapsed := time.Since(start)
+		require.True(t, elapsed >= 600*time.Millisecond, "process was killed before its grace period elapsed (%v)", elapsed)
+		require.True(t, elapsed < 5*time.Second, "process was not killed after its grace period elapsed (%v)", elapsed)
+	})
+	require.NoError(t, err)
+}
+
 func TestRunUserCodeEnv(t *testing.T) {
 	t.Parallel()
 	err := withTestEnv(func(env *testEnv) {
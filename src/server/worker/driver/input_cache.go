@@ -0,0 +1,207 @@
+package driver
+
+import (
+	"container/list"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// InputCacheOptions configures the cache that workers keep downloaded inputs
+// in, so that inputs shared by several datums (e.g. in a cross) are only
+// downloaded once
+type InputCacheOptions struct {
+	// MaxBytes bounds the size of the cache, which evicts the least recently
+	// used inputs to stay within it (0 disables the cache)
+	MaxBytes int64
+}
+
+// inputCache is an on-disk LRU cache of downloaded inputs, keyed by the hash
+// of their content. It's safe for concurrent use, and inputs that are being
+// copied out of the cache aren't evicted until the copy is done.
+type inputCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*inputCacheEntry
+	// lru orders the entries from most to least recently used
+	lru  *list.List
+	size int64
+}
+
+type inputCacheEntry struct {
+	key  string
+	size int64
+	refs int
+	elem *list.Element
+}
+
+// newInputCache constructs an inputCache that stores inputs in 'dir', which is
+// cleared of any inputs left over from a previous run.
+func newInputCache(dir string, maxBytes int64) (*inputCache, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &inputCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*inputCacheEntry),
+		lru:      list.New(),
+	}, nil
+}
+
+func (c *inputCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+// get copies the input cached under 'key' to 'dst', and returns false if
+// there is no such input.
+func (c *inputCache) get(key, dst string) (bool, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok {
+		e.refs++
+		c.lru.MoveToFront(e.elem)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		e.refs--
+		c.evict()
+	}()
+	return true, copyPath(c.path(key), dst)
+}
+
+// put copies the downloaded input at 'src' into the cache under 'key'. Inputs
+// that are larger than the whole cache aren't cached.
+func (c *inputCache) put(key, src string) (retErr error) {
+	size, err := pathSize(src)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	_, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok || size > c.maxBytes {
+		return nil
+	}
+	// Copy the input into a temporary directory first, so that a partial copy
+	// is never visible in the cache
+	tmpDir, err := ioutil.TempDir(c.dir, "tmp-")
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	tmpPath := filepath.Join(tmpDir, key)
+	if err := copyPath(src, tmpPath); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The same input may have been cached concurrently
+	if _, ok := c.entries[key]; ok {
+		return nil
+	}
+	if err := os.Rename(tmpPath, c.path(key)); err != nil {
+		return errors.EnsureStack(err)
+	}
+	e := &inputCacheEntry{key: key, size: size}
+	e.elem = c.lru.PushFront(e)
+	c.entries[key] = e
+	c.size += size
+	c.evict()
+	return nil
+}
+
+// evict removes the least recently used inputs that aren't being copied until
+// the cache is within its size limit. It must be called with c.mu held.
+func (c *inputCache) evict() {
+	for elem := c.lru.Back(); elem != nil && c.size > c.maxBytes; {
+		prev := elem.Prev()
+		e := elem.Value.(*inputCacheEntry)
+		if e.refs == 0 {
+			// Once the entry is removed, nothing reads its files, so a failure
+			// to remove them only leaks disk space until the next run
+			os.RemoveAll(c.path(e.key))
+			c.lru.Remove(elem)
+			delete(c.entries, e.key)
+			c.size -= e.size
+		}
+		elem = prev
+	}
+}
+
+// pathSize returns the total size of the regular files at or under 'path'
+func pathSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, errors.EnsureStack(err)
+}
+
+// copyPath recursively copies the file or directory at 'src' to 'dst'
+func copyPath(src, dst string) error {
+	return errors.EnsureStack(filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0777)
+		}
+		return copyFile(path, target)
+	}))
+}
+
+func copyFile(src, dst string) (retErr error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+		return err
+	}
+	r, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	w, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := w.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	_, err = io.Copy(w, r)
+	return err
+}
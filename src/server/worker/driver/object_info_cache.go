@@ -0,0 +1,54 @@
+package driver
+
+import (
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+)
+
+// defaultObjectInfoCacheSize is the number of InspectObject results that a
+// driver keeps
+const defaultObjectInfoCacheSize = 4096
+
+// objectInfoCache is a bounded LRU cache of InspectObject results, keyed by
+// object hash. An object's block ref never changes once the object has been
+// written, so results can be reused for as long as they're cached. It's safe
+// for concurrent use, and a nil cache doesn't cache anything.
+type objectInfoCache struct {
+	mu       sync.Mutex
+	lruCache simplelru.LRUCache
+}
+
+func newObjectInfoCache(size int) *objectInfoCache {
+	lruCache, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		// NewLRU only fails if 'size' isn't positive
+		panic(err)
+	}
+	return &objectInfoCache{lruCache: lruCache}
+}
+
+// inspectObject returns the cached result for 'hash', or calls 'inspect' and
+// caches its result if there isn't one. Errors aren't cached.
+func (c *objectInfoCache) inspectObject(hash string, inspect func(string) (*pfs.ObjectInfo, error)) (*pfs.ObjectInfo, error) {
+	if c == nil {
+		return inspect(hash)
+	}
+	c.mu.Lock()
+	value, ok := c.lruCache.Get(hash)
+	c.mu.Unlock()
+	if ok {
+		return value.(*pfs.ObjectInfo), nil
+	}
+	// Don't hold the lock during the call, concurrent misses for the same
+	// object will just get the same result
+	info, err := inspect(hash)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.lruCache.Add(hash, info)
+	c.mu.Unlock()
+	return info, nil
+}
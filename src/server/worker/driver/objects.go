@@ -0,0 +1,226 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"io"
+	"path"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+)
+
+// ObjectStorage is the object storage that workers upload hashtree chunks to,
+// and merge them from. By default, this is PFS's object API, but a driver can
+// be given an alternate backend (e.g. an obj.Client, for tests) with
+// WithObjectStorage.
+type ObjectStorage interface {
+	// PutObjectAsync returns a writer for a new object, which is tagged with
+	// 'tags' once it's written
+	PutObjectAsync(tags []*pfs.Tag) (ObjectWriter, error)
+	// GetObjectReader returns a reader for the object with the given hash
+	GetObjectReader(hash string) (io.ReadCloser, error)
+	// GetTagReader returns a reader for the object with the given tag
+	GetTagReader(tag string) (io.ReadCloser, error)
+	// InspectTag returns the object with the given tag
+	InspectTag(tag string) (*pfs.Object, error)
+	// IndexWriter returns a writer for the index of the hashtree stored in
+	// 'object', which is read alongside it by hashtree readers
+	IndexWriter(object *pfs.Object) (io.WriteCloser, error)
+}
+
+// ObjectWriter writes a new object. Its Object method returns the written
+// object once the writer has been closed.
+type ObjectWriter interface {
+	io.WriteCloser
+	Object() (*pfs.Object, error)
+}
+
+// pachObjectStorage is the ObjectStorage implemented by PFS's object API
+type pachObjectStorage struct {
+	pachClient  *client.APIClient
+	objectInfos *objectInfoCache
+}
+
+// NewPachObjectStorage returns an ObjectStorage that uses PFS's object API,
+// via 'pachClient'.
+func NewPachObjectStorage(pachClient *client.APIClient) ObjectStorage {
+	return &pachObjectStorage{pachClient: pachClient}
+}
+
+func (s *pachObjectStorage) PutObjectAsync(tags []*pfs.Tag) (ObjectWriter, error) {
+	w, err := s.pachClient.PutObjectAsync(tags)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return w, nil
+}
+
+func (s *pachObjectStorage) GetObjectReader(hash string) (io.ReadCloser, error) {
+	r, err := s.pachClient.GetObjectReader(hash)
+	return r, errors.EnsureStack(err)
+}
+
+func (s *pachObjectStorage) GetTagReader(tag string) (io.ReadCloser, error) {
+	r, err := s.pachClient.GetTagReader(tag)
+	return r, errors.EnsureStack(err)
+}
+
+func (s *pachObjectStorage) InspectTag(tag string) (*pfs.Object, error) {
+	info, err := s.pachClient.InspectTag(s.pachClient.Ctx(), client.NewTag(tag))
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return info.Object, nil
+}
+
+func (s *pachObjectStorage) IndexWriter(object *pfs.Object) (io.WriteCloser, error) {
+	info, err := s.objectInfos.inspectObject(object.Hash, s.pachClient.InspectObject)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	path, err := obj.BlockPathFromEnv(info.BlockRef.Block)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	w, err := s.pachClient.DirectObjWriter(path + hashtree.IndexPath)
+	return w, errors.EnsureStack(err)
+}
+
+// objClientStorage is an ObjectStorage that stores objects directly in an
+// obj.Client: objects under "object/<hash>", and tags under "tag/<tag>",
+// containing the hash of the tagged object.
+type objClientStorage struct {
+	ctx       context.Context
+	objClient obj.Client
+}
+
+// NewObjClientStorage returns an ObjectStorage that stores objects directly in
+// 'objClient', rather than going through PFS. Objects are buffered in memory
+// until they're written, as they're named by their hash.
+func NewObjClientStorage(ctx context.Context, objClient obj.Client) ObjectStorage {
+	return &objClientStorage{
+		ctx:       ctx,
+		objClient: objClient,
+	}
+}
+
+func (s *objClientStorage) objectPath(hash string) string {
+	return path.Join("object", hash)
+}
+
+func (s *objClientStorage) tagPath(tag string) string {
+	return path.Join("tag", tag)
+}
+
+func (s *objClientStorage) PutObjectAsync(tags []*pfs.Tag) (ObjectWriter, error) {
+	return &objClientWriter{
+		storage: s,
+		tags:    tags,
+		hash:    pfs.NewHash(),
+	}, nil
+}
+
+func (s *objClientStorage) GetObjectReader(hash string) (io.ReadCloser, error) {
+	r, err := s.objClient.Reader(s.ctx, s.objectPath(hash), 0, 0)
+	return r, errors.EnsureStack(err)
+}
+
+func (s *objClientStorage) GetTagReader(tag string) (io.ReadCloser, error) {
+	buf := &bytes.Buffer{}
+	if err := s.read(s.tagPath(tag), buf); err != nil {
+		return nil, err
+	}
+	return s.GetObjectReader(buf.String())
+}
+
+func (s *objClientStorage) InspectTag(tag string) (*pfs.Object, error) {
+	buf := &bytes.Buffer{}
+	if err := s.read(s.tagPath(tag), buf); err != nil {
+		return nil, err
+	}
+	return &pfs.Object{Hash: buf.String()}, nil
+}
+
+func (s *objClientStorage) IndexWriter(object *pfs.Object) (io.WriteCloser, error) {
+	w, err := s.objClient.Writer(s.ctx, s.objectPath(object.Hash)+hashtree.IndexPath)
+	return w, errors.EnsureStack(err)
+}
+
+func (s *objClientStorage) read(name string, w io.Writer) (retErr error) {
+	r, err := s.objClient.Reader(s.ctx, name, 0, 0)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := r.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	_, err = io.Copy(w, r)
+	return errors.EnsureStack(err)
+}
+
+func (s *objClientStorage) write(name string, data []byte) (retErr error) {
+	w, err := s.objClient.Writer(s.ctx, name)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := w.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	_, err = w.Write(data)
+	return errors.EnsureStack(err)
+}
+
+// objClientWriter is the ObjectWriter of an objClientStorage
+type objClientWriter struct {
+	storage *objClientStorage
+	tags    []*pfs.Tag
+	buf     bytes.Buffer
+	hash    hash.Hash
+	object  *pfs.Object
+}
+
+func (w *objClientWriter) Write(p []byte) (int, error) {
+	w.hash.Write(p)
+	return w.buf.Write(p)
+}
+
+func (w *objClientWriter) Close() error {
+	object := &pfs.Object{Hash: pfs.EncodeHash(w.hash.Sum(nil))}
+	// Objects are content-addressed, so an existing object has the same data
+	objectPath := w.storage.objectPath(object.Hash)
+	if !w.storage.objClient.Exists(w.storage.ctx, objectPath) {
+		if err := w.storage.write(objectPath, w.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	// Tags may be moved to a new object
+	for _, tag := range w.tags {
+		tagPath := w.storage.tagPath(tag.Name)
+		if w.storage.objClient.Exists(w.storage.ctx, tagPath) {
+			if err := w.storage.objClient.Delete(w.storage.ctx, tagPath); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		if err := w.storage.write(tagPath, []byte(object.Hash)); err != nil {
+			return err
+		}
+	}
+	w.object = object
+	return nil
+}
+
+func (w *objClientWriter) Object() (*pfs.Object, error) {
+	if w.object == nil {
+		return nil, errors.New("object hasn't been written")
+	}
+	return w.object, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/gogo/protobuf/types"
 
 	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
@@ -27,6 +29,12 @@ type MockOptions struct {
 	EtcdPrefix   string
 	PipelineInfo *pps.PipelineInfo
 	HashtreePath string
+
+	ChunkCacheOptions ChunkCacheOptions
+
+	// ObjectStorage is the object storage that hashtree chunks are uploaded to
+	// and merged from, e.g. one created with NewObjClientStorage
+	ObjectStorage ObjectStorage
 }
 
 // MockDriver is an implementation of the Driver interface for use by tests.
@@ -64,8 +72,8 @@ func NewMockDriver(etcdClient *etcd.Client, userOptions *MockOptions) *MockDrive
 	}
 
 	if options.HashtreePath != "" {
-		md.chunkCaches = cache.NewWorkerCache(filepath.Join(options.HashtreePath, "chunk"))
-		md.chunkStatsCaches = cache.NewWorkerCache(filepath.Join(options.HashtreePath, "chunkStats"))
+		md.chunkCaches = cache.NewBoundedWorkerCache(filepath.Join(options.HashtreePath, "chunk"), options.ChunkCacheOptions.MaxEntries, options.ChunkCacheOptions.MaxBytes)
+		md.chunkStatsCaches = cache.NewBoundedWorkerCache(filepath.Join(options.HashtreePath, "chunkStats"), options.ChunkCacheOptions.MaxEntries, options.ChunkCacheOptions.MaxBytes)
 	}
 
 	return md
@@ -125,6 +133,12 @@ func (md *MockDriver) ChunkStatsCaches() cache.WorkerCache {
 	return md.chunkStatsCaches
 }
 
+// SpillChunksToDisk returns whether fetched hashtree chunks should be spilled
+// to disk before they're cached, as set in the MockDriver options.
+func (md *MockDriver) SpillChunksToDisk() bool {
+	return md.options.ChunkCacheOptions.SpillToDisk
+}
+
 // WithDatumCache calls the given callback with two hashtree merge caches, one
 // for datums and one for datum stats. The lifetime of these caches will be
 // bound to the callback, and any resources will be cleaned up upon return.
@@ -152,6 +166,29 @@ func (md *MockDriver) PachClient() *client.APIClient {
 	return nil
 }
 
+// InspectObject always returns an error for a MockDriver, as it has no
+// pachClient to inspect objects with.
+func (md *MockDriver) InspectObject(hash string) (*pfs.ObjectInfo, error) {
+	return nil, errors.Errorf("cannot inspect object %s without a pachClient", hash)
+}
+
+// ObjectStorage returns the object storage set in the MockDriver options.
+func (md *MockDriver) ObjectStorage() ObjectStorage {
+	return md.options.ObjectStorage
+}
+
+// WithObjectStorage clones the current MockDriver and replaces its object
+// storage with the given backend.
+func (md *MockDriver) WithObjectStorage(objectStorage ObjectStorage) Driver {
+	result := &MockDriver{}
+	*result = *md
+	options := &MockOptions{}
+	*options = *md.options
+	options.ObjectStorage = objectStorage
+	result.options = options
+	return result
+}
+
 // ExpectedNumWorkers returns the configured number of workers
 func (md *MockDriver) ExpectedNumWorkers() (int64, error) {
 	return int64(md.options.NumWorkers), nil
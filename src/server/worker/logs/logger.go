@@ -20,12 +20,77 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/grpcutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/stats"
 )
 
 const (
 	logBuffer = 25
 )
 
+// Level is the severity of a log statement. Statements below a logger's
+// minimum level are dropped.
+type Level int
+
+const (
+	// DebugLevel is for verbose statements that are only useful when
+	// troubleshooting
+	DebugLevel Level = iota
+	// InfoLevel is for the statements logged during normal operation
+	InfoLevel
+	// WarnLevel is for statements about unexpected, but recoverable, conditions
+	WarnLevel
+	// ErrorLevel is for statements about failures
+	ErrorLevel
+)
+
+// defaultLevel is the minimum level of newly constructed loggers
+var defaultLevel = InfoLevel
+
+// ParseLevel parses a level name ("debug", "info", "warn" or "error").
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	default:
+		return InfoLevel, errors.Errorf("unrecognized log level %q", name)
+	}
+}
+
+// defaultMaxObjectSize is the maximum size of the log objects written by newly
+// constructed loggers (0 means unbounded)
+var defaultMaxObjectSize int64
+
+// SetMaxObjectSize sets the maximum size of the log objects written by loggers
+// constructed after it's called. Once a logger's object reaches this size, a
+// message noting that the log was truncated is written, and later messages
+// aren't written to the object. 0, the default, means unbounded.
+func SetMaxObjectSize(size int64) {
+	defaultMaxObjectSize = size
+}
+
+// exportStepTimes is whether loggers observe the durations of the steps that
+// they log in stats.StepTime
+var exportStepTimes bool
+
+// SetExportStepTimes sets whether loggers export the durations of the steps
+// that they log with LogStep as prometheus metrics, in addition to logging
+// them. It's off by default.
+func SetExportStepTimes(export bool) {
+	exportStepTimes = export
+}
+
+// SetLevel sets the minimum level of loggers constructed after it's called.
+// Statements logged below this level are dropped. The default is InfoLevel.
+func SetLevel(level Level) {
+	defaultLevel = level
+}
+
 // TaggedLogger is an interface providing logging functionality for use by the
 // worker, worker master, and user code processes
 type TaggedLogger interface {
@@ -34,13 +99,20 @@ type TaggedLogger interface {
 	io.Writer
 
 	// Logf logs to stdout and object storage (if stats are enabled), including
-	// metadata about the current pipeline and job
+	// metadata about the current pipeline and job. It logs at InfoLevel.
 	Logf(formatString string, args ...interface{})
+	// These are like Logf, but log at the given level, so statements below the
+	// logger's minimum level are dropped
+	Debugf(formatString string, args ...interface{})
+	Infof(formatString string, args ...interface{})
+	Warnf(formatString string, args ...interface{})
+	Errorf(formatString string, args ...interface{})
 	// Errf logs only to stderr
 	Errf(formatString string, args ...interface{})
 
 	// LogStep will log before and after the given callback function runs, using
-	// the name provided
+	// the name provided. The message logged after it runs includes the step's
+	// name and duration (in seconds) in its fields.
 	LogStep(name string, cb func() error) error
 
 	// These helpers will clone the current logger and construct a new logger that
@@ -48,6 +120,14 @@ type TaggedLogger interface {
 	WithJob(jobID string) TaggedLogger
 	WithData(data []*common.Input) TaggedLogger
 	WithUserCode() TaggedLogger
+	// WithField and WithFields attach structured key/value pairs, which are
+	// included in the 'fields' of each log message. Values may be strings,
+	// bools, numbers, or nil; other values are formatted as strings.
+	WithField(key string, value interface{}) TaggedLogger
+	WithFields(fields map[string]interface{}) TaggedLogger
+	// WithSampler makes the logger thin the statements it logs below
+	// ErrorLevel using 'sampler', which may be shared with other loggers.
+	WithSampler(sampler *Sampler) TaggedLogger
 
 	JobID() string
 
@@ -58,12 +138,16 @@ type TaggedLogger interface {
 
 type taggedLogger struct {
 	template  pps.LogMessage
+	level     Level
+	sampler   *Sampler
+	stdout    io.Writer
 	stderrLog *log.Logger
 	marshaler *jsonpb.Marshaler
 
 	// Used for mirroring log statements to object storage
 	putObjClient pfs.ObjectAPI_PutObjectClient
 	objSize      int64
+	maxObjSize   int64
 	msgCh        chan string
 	buffer       bytes.Buffer
 	eg           errgroup.Group
@@ -80,6 +164,8 @@ func newLogger(pipelineInfo *pps.PipelineInfo) *taggedLogger {
 			PipelineName: name,
 			WorkerID:     os.Getenv(client.PPSPodNameEnv),
 		},
+		level:     defaultLevel,
+		stdout:    os.Stdout,
 		stderrLog: log.New(os.Stderr, "", log.LstdFlags|log.Llongfile),
 		marshaler: &jsonpb.Marshaler{},
 		msgCh:     make(chan string, logBuffer),
@@ -107,22 +193,61 @@ func NewLogger(pipelineInfo *pps.PipelineInfo, pachClient *client.APIClient) (Ta
 		if err != nil {
 			return nil, err
 		}
-		result.putObjClient = putObjClient
-		result.eg.Go(func() error {
-			for msg := range result.msgCh {
-				for _, chunk := range grpcutil.Chunk([]byte(msg), grpcutil.MaxMsgSize/2) {
-					if err := result.putObjClient.Send(&pfs.PutObjectRequest{
-						Value: chunk,
-					}); err != nil && !errors.Is(err, io.EOF) {
-						return err
-					}
+		result.startObject(putObjClient, defaultMaxObjectSize)
+	}
+	return result, nil
+}
+
+// startObject starts mirroring log statements to the object written by
+// 'putObjClient', which is capped at 'maxSize' bytes (if it's non-zero).
+func (logger *taggedLogger) startObject(putObjClient pfs.ObjectAPI_PutObjectClient, maxSize int64) {
+	logger.putObjClient = putObjClient
+	logger.maxObjSize = maxSize
+	logger.eg.Go(func() error {
+		var truncated bool
+		for msg := range logger.msgCh {
+			// Keep draining messages once the object is truncated, so that
+			// loggers don't block
+			if truncated {
+				continue
+			}
+			if maxSize > 0 && logger.objSize+int64(len(msg)) > maxSize {
+				msg = logger.truncatedMessage(msg)
+				truncated = true
+			}
+			for _, chunk := range grpcutil.Chunk([]byte(msg), grpcutil.MaxMsgSize/2) {
+				if err := logger.putObjClient.Send(&pfs.PutObjectRequest{
+					Value: chunk,
+				}); err != nil && !errors.Is(err, io.EOF) {
+					return err
 				}
-				result.objSize += int64(len(msg))
 			}
-			return nil
-		})
+			logger.objSize += int64(len(msg))
+		}
+		return nil
+	})
+}
+
+// truncatedMessage returns the log line noting that a log object was
+// truncated, in place of the line 'msg', which would have exceeded its
+// maximum size. It has the same metadata as 'msg', so that it's returned
+// with the rest of the datum's logs.
+func (logger *taggedLogger) truncatedMessage(msg string) string {
+	template := &pps.LogMessage{}
+	if err := jsonpb.UnmarshalString(msg, template); err != nil {
+		template = &pps.LogMessage{
+			PipelineName: logger.template.PipelineName,
+			WorkerID:     logger.template.WorkerID,
+			Ts:           types.TimestampNow(),
+		}
 	}
-	return result, nil
+	template.User = false
+	template.Message = fmt.Sprintf("log truncated: exceeded the maximum size of %d bytes", logger.maxObjSize)
+	truncated, err := logger.marshaler.MarshalToString(template)
+	if err != nil {
+		return ""
+	}
+	return truncated + "\n"
 }
 
 // NewStatlessLogger constructs a TaggedLogger for the given pipeline.  This is
@@ -175,19 +300,94 @@ func (logger *taggedLogger) WithUserCode() TaggedLogger {
 	return result
 }
 
+// WithField clones the current logger and returns a new one that will include
+// the given key/value pair in the fields of log messages.
+func (logger *taggedLogger) WithField(key string, value interface{}) TaggedLogger {
+	return logger.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields clones the current logger and returns a new one that will include
+// the given key/value pairs in the fields of log messages, in addition to any
+// fields that the current logger already includes.
+func (logger *taggedLogger) WithFields(fields map[string]interface{}) TaggedLogger {
+	result := logger.clone()
+	if result.template.Fields == nil {
+		result.template.Fields = &types.Struct{Fields: make(map[string]*types.Value)}
+	}
+	for key, value := range fields {
+		result.template.Fields.Fields[key] = fieldValue(value)
+	}
+	return result
+}
+
+// WithSampler clones the current logger and returns a new one that thins the
+// statements it logs below ErrorLevel using 'sampler'. Loggers that share
+// 'sampler' are thinned together.
+func (logger *taggedLogger) WithSampler(sampler *Sampler) TaggedLogger {
+	result := logger.clone()
+	result.sampler = sampler
+	return result
+}
+
+// fieldValue converts a log field's value to a protobuf value, so that it's
+// marshalled with its JSON type.
+func fieldValue(value interface{}) *types.Value {
+	switch v := value.(type) {
+	case nil:
+		return &types.Value{Kind: &types.Value_NullValue{}}
+	case string:
+		return &types.Value{Kind: &types.Value_StringValue{StringValue: v}}
+	case bool:
+		return &types.Value{Kind: &types.Value_BoolValue{BoolValue: v}}
+	case int:
+		return numberValue(float64(v))
+	case int32:
+		return numberValue(float64(v))
+	case int64:
+		return numberValue(float64(v))
+	case uint:
+		return numberValue(float64(v))
+	case uint32:
+		return numberValue(float64(v))
+	case uint64:
+		return numberValue(float64(v))
+	case float32:
+		return numberValue(float64(v))
+	case float64:
+		return numberValue(v)
+	default:
+		return &types.Value{Kind: &types.Value_StringValue{StringValue: fmt.Sprint(v)}}
+	}
+}
+
+func numberValue(v float64) *types.Value {
+	return &types.Value{Kind: &types.Value_NumberValue{NumberValue: v}}
+}
+
 // JobID returns the current job that the logger is configured with.
 func (logger *taggedLogger) JobID() string {
 	return logger.template.JobID
 }
 
 func (logger *taggedLogger) clone() *taggedLogger {
-	return &taggedLogger{
-		template:     logger.template,  // Copy struct
+	result := &taggedLogger{
+		template:     logger.template, // Copy struct
+		level:        logger.level,
+		sampler:      logger.sampler,
+		stdout:       logger.stdout,
 		stderrLog:    logger.stderrLog, // logger should be goroutine-safe
 		marshaler:    &jsonpb.Marshaler{},
 		putObjClient: logger.putObjClient,
 		msgCh:        logger.msgCh,
 	}
+	// Copy fields, so that fields added to the clone aren't added to 'logger'
+	if logger.template.Fields != nil {
+		result.template.Fields = &types.Struct{Fields: make(map[string]*types.Value)}
+		for key, value := range logger.template.Fields.Fields {
+			result.template.Fields.Fields[key] = value
+		}
+	}
+	return result
 }
 
 // Logf logs the line Sprintf(formatString, args...), but formatted as a json
@@ -195,6 +395,37 @@ func (logger *taggedLogger) clone() *taggedLogger {
 //
 // Note: this is not thread-safe, as it modifies fields of 'logger.template'
 func (logger *taggedLogger) Logf(formatString string, args ...interface{}) {
+	logger.logf(InfoLevel, formatString, args...)
+}
+
+// Debugf logs like Logf, at DebugLevel.
+func (logger *taggedLogger) Debugf(formatString string, args ...interface{}) {
+	logger.logf(DebugLevel, formatString, args...)
+}
+
+// Infof logs like Logf, at InfoLevel.
+func (logger *taggedLogger) Infof(formatString string, args ...interface{}) {
+	logger.logf(InfoLevel, formatString, args...)
+}
+
+// Warnf logs like Logf, at WarnLevel.
+func (logger *taggedLogger) Warnf(formatString string, args ...interface{}) {
+	logger.logf(WarnLevel, formatString, args...)
+}
+
+// Errorf logs like Logf, at ErrorLevel. Unlike Errf, the statement goes to the
+// persistent log.
+func (logger *taggedLogger) Errorf(formatString string, args ...interface{}) {
+	logger.logf(ErrorLevel, formatString, args...)
+}
+
+func (logger *taggedLogger) logf(level Level, formatString string, args ...interface{}) {
+	if level < logger.level {
+		return
+	}
+	if level < ErrorLevel && !logger.sampler.sample() {
+		return
+	}
 	logger.template.Message = fmt.Sprintf(formatString, args...)
 	if ts, err := types.TimestampProto(time.Now()); err == nil {
 		logger.template.Ts = ts
@@ -207,21 +438,36 @@ func (logger *taggedLogger) Logf(formatString string, args ...interface{}) {
 		logger.Errf("could not marshal %v for logging: %s\n", &logger.template, err)
 		return
 	}
-	fmt.Println(msg)
+	fmt.Fprintln(logger.stdout, msg)
 	if logger.putObjClient != nil {
 		logger.msgCh <- msg + "\n"
 	}
 }
 
 // LogStep will log before and after the given callback function runs, using
-// the name provided
+// the name provided. The message logged after it runs includes the 'step' and
+// its 'duration' in its fields, so that nested steps each report their own
+// duration.
 func (logger *taggedLogger) LogStep(name string, cb func() error) (retErr error) {
+	start := time.Now()
 	logger.Logf("started %v", name)
 	defer func() {
+		duration := time.Since(start)
+		stepLogger := logger.WithFields(map[string]interface{}{
+			"step":     name,
+			"duration": duration.Seconds(),
+		})
 		if retErr != nil {
-			logger.Logf("errored %v: %v", name, retErr)
+			stepLogger.Logf("errored %v after %v: %v", name, duration, retErr)
 		} else {
-			logger.Logf("finished %v", name)
+			stepLogger.Logf("finished %v after %v", name, duration)
+		}
+		if exportStepTimes {
+			if hist, err := stats.StepTime.GetMetricWithLabelValues(logger.template.PipelineName, logger.JobID(), name); err != nil {
+				logger.Errf("failed to get step time histogram for %q: %v", name, err)
+			} else {
+				hist.Observe(duration.Seconds())
+			}
 		}
 	}()
 	return cb()
@@ -0,0 +1,274 @@
+package logs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	"github.com/prometheus/client_golang/prometheus"
+	prometheus_proto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/stats"
+)
+
+func testLogger(level Level) (*taggedLogger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	logger := newLogger(nil)
+	logger.level = level
+	logger.stdout = buf
+	return logger, buf
+}
+
+func TestLevels(t *testing.T) {
+	logger, buf := testLogger(WarnLevel)
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Logf("log %d", 3)
+	require.Equal(t, "", buf.String())
+
+	logger.Warnf("warn %d", 4)
+	logger.Errorf("error %d", 5)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	require.True(t, strings.Contains(lines[0], `"message":"warn 4"`), lines[0])
+	require.True(t, strings.Contains(lines[1], `"message":"error 5"`), lines[1])
+
+	// Cloned loggers keep the minimum level
+	buf.Reset()
+	child := logger.WithJob("job")
+	child.Infof("info")
+	require.Equal(t, "", buf.String())
+	child.Warnf("warn")
+	require.True(t, strings.Contains(buf.String(), `"jobId":"job"`), buf.String())
+
+	// Everything is logged at DebugLevel
+	logger, buf = testLogger(DebugLevel)
+	logger.Debugf("debug")
+	logger.Logf("log")
+	require.Equal(t, 2, strings.Count(buf.String(), "\n"))
+}
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel(defaultLevel)
+	require.Equal(t, InfoLevel, newLogger(nil).level)
+	SetLevel(ErrorLevel)
+	require.Equal(t, ErrorLevel, newLogger(nil).level)
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, expected := range map[string]Level{
+		"debug":   DebugLevel,
+		"info":    InfoLevel,
+		"INFO":    InfoLevel,
+		"warn":    WarnLevel,
+		"warning": WarnLevel,
+		"error":   ErrorLevel,
+	} {
+		level, err := ParseLevel(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, level, name)
+	}
+	_, err := ParseLevel("verbose")
+	require.YesError(t, err)
+}
+
+func TestFields(t *testing.T) {
+	logger, buf := testLogger(InfoLevel)
+	parent := logger.WithField("datum", 3).WithFields(map[string]interface{}{
+		"hash":   "abc",
+		"cached": true,
+	})
+	parent.Logf("parent")
+	require.True(t, strings.Contains(buf.String(), `"fields":{`), buf.String())
+	msg := &pps.LogMessage{}
+	require.NoError(t, jsonpb.UnmarshalString(buf.String(), msg))
+	require.Equal(t, "parent", msg.Message)
+	require.Equal(t, 3, len(msg.Fields.Fields))
+	require.Equal(t, float64(3), msg.Fields.Fields["datum"].GetNumberValue())
+	require.Equal(t, "abc", msg.Fields.Fields["hash"].GetStringValue())
+	require.Equal(t, true, msg.Fields.Fields["cached"].GetBoolValue())
+
+	// Child loggers inherit their parent's fields, without adding to them
+	buf.Reset()
+	child := parent.WithJob("job").WithField("hash", "def").WithField("chunk", nil)
+	child.Logf("child")
+	msg = &pps.LogMessage{}
+	require.NoError(t, jsonpb.UnmarshalString(buf.String(), msg))
+	require.Equal(t, "job", msg.JobID)
+	require.Equal(t, 4, len(msg.Fields.Fields))
+	require.Equal(t, float64(3), msg.Fields.Fields["datum"].GetNumberValue())
+	require.Equal(t, "def", msg.Fields.Fields["hash"].GetStringValue())
+	require.Equal(t, types.NullValue_NULL_VALUE, msg.Fields.Fields["chunk"].GetNullValue())
+
+	buf.Reset()
+	parent.Logf("parent")
+	msg = &pps.LogMessage{}
+	require.NoError(t, jsonpb.UnmarshalString(buf.String(), msg))
+	require.Equal(t, 3, len(msg.Fields.Fields))
+	require.Equal(t, "abc", msg.Fields.Fields["hash"].GetStringValue())
+
+	// Loggers without fields don't include them
+	buf.Reset()
+	logger.Logf("none")
+	require.False(t, strings.Contains(buf.String(), "fields"), buf.String())
+}
+
+// testPutObjectClient collects the object written by a logger
+type testPutObjectClient struct {
+	grpc.ClientStream
+	buf bytes.Buffer
+}
+
+func (c *testPutObjectClient) Send(req *pfs.PutObjectRequest) error {
+	c.buf.Write(req.Value)
+	return nil
+}
+
+func (c *testPutObjectClient) CloseAndRecv() (*pfs.Object, error) {
+	return &pfs.Object{Hash: "object"}, nil
+}
+
+func TestMaxObjectSize(t *testing.T) {
+	maxSize := int64(10 * 1024)
+	logger, _ := testLogger(InfoLevel)
+	logger.stdout = ioutil.Discard
+	putObjClient := &testPutObjectClient{}
+	logger.startObject(putObjClient, maxSize)
+	datumLogger := logger.WithJob("job").WithUserCode()
+	line := strings.Repeat("x", 100) + "\n"
+	for i := 0; i < 1000; i++ {
+		_, err := datumLogger.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	object, size, err := logger.Close()
+	require.NoError(t, err)
+	require.Equal(t, "object", object.Hash)
+	require.Equal(t, int64(putObjClient.buf.Len()), size)
+
+	// The object is capped, with the last line marking it as truncated
+	lines := strings.Split(strings.TrimSuffix(putObjClient.buf.String(), "\n"), "\n")
+	require.True(t, size < maxSize+int64(len(lines[0])), "%d", size)
+	require.True(t, len(lines) > 1 && len(lines) < 1000, "%d", len(lines))
+	for _, l := range lines {
+		msg := &pps.LogMessage{}
+		require.NoError(t, jsonpb.UnmarshalString(l, msg))
+		require.Equal(t, "job", msg.JobID)
+	}
+	msg := &pps.LogMessage{}
+	require.NoError(t, jsonpb.UnmarshalString(lines[len(lines)-1], msg))
+	require.True(t, strings.HasPrefix(msg.Message, "log truncated"), msg.Message)
+	require.False(t, msg.User)
+
+	// Objects are unbounded by default
+	logger, _ = testLogger(InfoLevel)
+	logger.stdout = ioutil.Discard
+	putObjClient = &testPutObjectClient{}
+	logger.startObject(putObjClient, 0)
+	for i := 0; i < 1000; i++ {
+		logger.Logf("%s", line)
+	}
+	_, size, err = logger.Close()
+	require.NoError(t, err)
+	require.True(t, size > 100*1000, "%d", size)
+	require.False(t, strings.Contains(putObjClient.buf.String(), "log truncated"))
+}
+
+func TestSampling(t *testing.T) {
+	// A nil sampler logs everything
+	require.Nil(t, NewSampler(nil))
+	require.Nil(t, NewSampler(&pps.LogSampling{Every: 1}))
+
+	// Loggers sharing a sampler log one in every N statements between them
+	logger, buf := testLogger(DebugLevel)
+	sampler := NewSampler(&pps.LogSampling{Every: 10})
+	for i := 0; i < 1000; i++ {
+		datumLogger := logger.WithData(nil).WithSampler(sampler)
+		datumLogger.Logf("datum %d", i)
+	}
+	require.Equal(t, 100, strings.Count(buf.String(), "\n"))
+	require.True(t, strings.Contains(buf.String(), `"message":"datum 0"`))
+	require.True(t, strings.Contains(buf.String(), `"message":"datum 990"`))
+	require.False(t, strings.Contains(buf.String(), `"message":"datum 1"`))
+
+	// Error statements bypass sampling, and aren't counted by it
+	buf.Reset()
+	sampled := logger.WithSampler(NewSampler(&pps.LogSampling{Every: 10}))
+	for i := 0; i < 100; i++ {
+		sampled.Errorf("error %d", i)
+		sampled.Warnf("warn %d", i)
+	}
+	require.Equal(t, 100, strings.Count(buf.String(), `"message":"error`))
+	require.Equal(t, 10, strings.Count(buf.String(), `"message":"warn`))
+
+	// Statements are rate-limited per second
+	buf.Reset()
+	now := time.Unix(1000, 0)
+	sampler = NewSampler(&pps.LogSampling{PerSecond: 5})
+	sampler.now = func() time.Time { return now }
+	sampled = logger.WithSampler(sampler)
+	for i := 0; i < 100; i++ {
+		sampled.Infof("burst")
+	}
+	require.Equal(t, 5, strings.Count(buf.String(), "\n"))
+	now = now.Add(500 * time.Millisecond)
+	sampled.Infof("same second")
+	require.Equal(t, 5, strings.Count(buf.String(), "\n"))
+	now = now.Add(500 * time.Millisecond)
+	for i := 0; i < 100; i++ {
+		sampled.WithJob("job").Infof("next second")
+	}
+	require.Equal(t, 10, strings.Count(buf.String(), "\n"))
+}
+
+func TestLogStepDuration(t *testing.T) {
+	logger, buf := testLogger(InfoLevel)
+	logger = logger.WithJob("job").(*taggedLogger)
+	SetExportStepTimes(true)
+	defer SetExportStepTimes(false)
+
+	// Nested steps each report their own duration
+	require.YesError(t, logger.LogStep("outer", func() error {
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, logger.LogStep("inner", func() error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}))
+		return errors.New("outer failed")
+	}))
+	durations := make(map[string]float64)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		msg := &pps.LogMessage{}
+		require.NoError(t, jsonpb.UnmarshalString(line, msg))
+		if strings.HasPrefix(msg.Message, "started") {
+			require.Nil(t, msg.Fields)
+			continue
+		}
+		step := msg.Fields.Fields["step"].GetStringValue()
+		durations[step] = msg.Fields.Fields["duration"].GetNumberValue()
+		require.True(t, strings.HasPrefix(msg.Message, map[string]string{
+			"inner": "finished inner after",
+			"outer": "errored outer after",
+		}[step]), msg.Message)
+	}
+	require.Equal(t, 2, len(durations))
+	require.True(t, durations["inner"] >= 0.02, "inner: %v", durations["inner"])
+	require.True(t, durations["outer"] >= 0.07, "outer: %v", durations["outer"])
+	require.True(t, durations["outer"] > durations["inner"])
+
+	// The durations are also exported as metrics
+	hist, err := stats.StepTime.GetMetricWithLabelValues("", "job", "outer")
+	require.NoError(t, err)
+	metric := &prometheus_proto.Metric{}
+	require.NoError(t, hist.(prometheus.Histogram).Write(metric))
+	require.Equal(t, uint64(1), metric.Histogram.GetSampleCount())
+	require.True(t, metric.Histogram.GetSampleSum() >= 0.07)
+}
@@ -0,0 +1,60 @@
+package logs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// Sampler thins the statements logged by the loggers that share it, according
+// to a pipeline's LogSampling spec. It's safe for concurrent use, so that it
+// can be shared by the loggers of datums that are processed concurrently.
+type Sampler struct {
+	every     int64
+	perSecond int64
+	now       func() time.Time
+
+	mu       sync.Mutex
+	count    int64
+	second   time.Time
+	inSecond int64
+}
+
+// NewSampler constructs a Sampler for the given LogSampling spec. It returns
+// nil, which samples nothing out, if 'spec' doesn't thin any statements.
+func NewSampler(spec *pps.LogSampling) *Sampler {
+	if spec == nil || (spec.Every <= 1 && spec.PerSecond <= 0) {
+		return nil
+	}
+	return &Sampler{
+		every:     spec.Every,
+		perSecond: spec.PerSecond,
+		now:       time.Now,
+	}
+}
+
+// sample returns true if the next statement should be logged.
+func (s *Sampler) sample() bool {
+	if s == nil {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	if s.every > 1 && (s.count-1)%s.every != 0 {
+		return false
+	}
+	if s.perSecond > 0 {
+		second := s.now().Truncate(time.Second)
+		if !second.Equal(s.second) {
+			s.second = second
+			s.inSecond = 0
+		}
+		if s.inSecond >= s.perSecond {
+			return false
+		}
+		s.inSecond++
+	}
+	return true
+}
@@ -18,9 +18,12 @@ import (
 type MockLogger struct {
 	// These fields are exposed so that tests can fuck around with them or make assertions
 	Writer   io.Writer
+	Level    Level
 	Job      string
 	Data     []*common.Input
 	UserCode bool
+	Fields   map[string]interface{}
+	Sampler  *Sampler
 }
 
 // Not used - forces a compile-time error in this file if MockLogger does not
@@ -43,10 +46,37 @@ func (ml *MockLogger) Write(p []byte) (_ int, retErr error) {
 
 // Logf optionally logs a statement using string formatting
 func (ml *MockLogger) Logf(formatString string, args ...interface{}) {
-	if ml.Writer != nil {
-		params := []interface{}{time.Now().Format(time.StampMilli), ml.Job, ml.Data, ml.UserCode}
+	ml.logf(InfoLevel, "LOGF", formatString, args...)
+}
+
+// Debugf optionally logs a statement using string formatting, if ml.Level is
+// DebugLevel
+func (ml *MockLogger) Debugf(formatString string, args ...interface{}) {
+	ml.logf(DebugLevel, "DEBUGF", formatString, args...)
+}
+
+// Infof optionally logs a statement using string formatting, if ml.Level is
+// at most InfoLevel
+func (ml *MockLogger) Infof(formatString string, args ...interface{}) {
+	ml.logf(InfoLevel, "INFOF", formatString, args...)
+}
+
+// Warnf optionally logs a statement using string formatting, if ml.Level is
+// at most WarnLevel
+func (ml *MockLogger) Warnf(formatString string, args ...interface{}) {
+	ml.logf(WarnLevel, "WARNF", formatString, args...)
+}
+
+// Errorf optionally logs a statement using string formatting
+func (ml *MockLogger) Errorf(formatString string, args ...interface{}) {
+	ml.logf(ErrorLevel, "ERRORF", formatString, args...)
+}
+
+func (ml *MockLogger) logf(level Level, prefix string, formatString string, args ...interface{}) {
+	if ml.Writer != nil && level >= ml.Level && (level >= ErrorLevel || ml.Sampler.sample()) {
+		params := []interface{}{prefix, time.Now().Format(time.StampMilli), ml.Job, ml.Data, ml.UserCode, ml.Fields}
 		params = append(params, args...)
-		str := fmt.Sprintf("LOGF %s (%v, %v, %v): "+formatString+"\n", params...)
+		str := fmt.Sprintf("%s %s (%v, %v, %v, %v): "+formatString+"\n", params...)
 		ml.Writer.Write([]byte(str))
 	}
 }
@@ -64,13 +94,14 @@ func (ml *MockLogger) Errf(formatString string, args ...interface{}) {
 // LogStep will log before and after the given callback function runs, using
 // the name provided
 func (ml *MockLogger) LogStep(name string, cb func() error) (retErr error) {
+	start := time.Now()
 	ml.Logf("started %v", name)
 	defer func() {
 		if retErr != nil {
 			retErr = errors.EnsureStack(retErr)
-			ml.Logf("errored %v: %v", name, retErr)
+			ml.Logf("errored %v after %v: %v", name, time.Since(start), retErr)
 		} else {
-			ml.Logf("finished %v", name)
+			ml.Logf("finished %v after %v", name, time.Since(start))
 		}
 	}()
 	return cb()
@@ -80,6 +111,12 @@ func (ml *MockLogger) LogStep(name string, cb func() error) (retErr error) {
 func (ml *MockLogger) clone() *MockLogger {
 	result := &MockLogger{}
 	*result = *ml
+	if ml.Fields != nil {
+		result.Fields = make(map[string]interface{})
+		for key, value := range ml.Fields {
+			result.Fields[key] = value
+		}
+	}
 	return result
 }
 
@@ -107,6 +144,33 @@ func (ml *MockLogger) WithUserCode() TaggedLogger {
 	return result
 }
 
+// WithField duplicates the MockLogger and returns a new one tagged with the
+// given key/value pair.
+func (ml *MockLogger) WithField(key string, value interface{}) TaggedLogger {
+	return ml.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields duplicates the MockLogger and returns a new one tagged with the
+// given key/value pairs, in addition to its existing fields.
+func (ml *MockLogger) WithFields(fields map[string]interface{}) TaggedLogger {
+	result := ml.clone()
+	if result.Fields == nil {
+		result.Fields = make(map[string]interface{})
+	}
+	for key, value := range fields {
+		result.Fields[key] = value
+	}
+	return result
+}
+
+// WithSampler duplicates the MockLogger and returns a new one that thins its
+// log statements using 'sampler'.
+func (ml *MockLogger) WithSampler(sampler *Sampler) TaggedLogger {
+	result := ml.clone()
+	result.Sampler = sampler
+	return result
+}
+
 // JobID returns the currently tagged job ID for the logger.  This is redundant
 // for MockLogger, as you can access ml.Job directly, but it is needed for the
 // TaggedLogger interface.
@@ -60,6 +60,8 @@ func withTestEnv(pipelineInfo *pps.PipelineInfo, cb func(*testEnv) error) error
 			filepath.Join(workerDir, "hashtrees"),
 			workerDir,
 			"namespace",
+			driver.ChunkCacheOptions{},
+			driver.InputCacheOptions{},
 		)
 		if err != nil {
 			return err
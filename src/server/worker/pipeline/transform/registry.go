@@ -349,6 +349,7 @@ func writeJobInfo(pachClient *client.APIClient, jobInfo *pps.JobInfo) error {
 		DataTotal:     jobInfo.DataTotal,
 		DataFailed:    jobInfo.DataFailed,
 		DataRecovered: jobInfo.DataRecovered,
+		DataExcluded:  jobInfo.DataExcluded,
 		Stats:         jobInfo.Stats,
 	})
 	return err
@@ -501,7 +502,29 @@ func (reg *registry) sendDatumTasks(ctx context.Context, pj *pendingJob, numDatu
 	return nil
 }
 
+// taskVersion is the version of the task format written by this worker. It
+// must be incremented when DatumData or MergeData change in a way that older
+// workers can't handle.
+const taskVersion = 2
+
+// partialStatsTaskVersion is the first task version whose master merges the
+// partial stats chunks that a datum task returns in PartialStatsHashtrees
+const partialStatsTaskVersion = 2
+
+// checkTaskVersion returns an error if a task's version is newer than this
+// worker understands. Version 0 is the format from before tasks were
+// versioned, in which a merge task merges a single shard given by MergeData's
+// legacy fields, and is otherwise compatible with version 1. Version 1 datum
+// tasks return all of their datums' stats in StatsHashtree.
+func checkTaskVersion(version uint32) error {
+	if version > taskVersion {
+		return errors.Errorf("worker task version %d is not supported by this worker (which supports up to version %d)", version, taskVersion)
+	}
+	return nil
+}
+
 func serializeDatumData(data *DatumData) (*types.Any, error) {
+	data.Version = taskVersion
 	serialized, err := types.MarshalAny(data)
 	if err != nil {
 		return nil, err
@@ -514,10 +537,14 @@ func deserializeDatumData(any *types.Any) (*DatumData, error) {
 	if err := types.UnmarshalAny(any, data); err != nil {
 		return nil, err
 	}
+	if err := checkTaskVersion(data.Version); err != nil {
+		return nil, err
+	}
 	return data, nil
 }
 
 func serializeMergeData(data *MergeData) (*types.Any, error) {
+	data.Version = taskVersion
 	serialized, err := types.MarshalAny(data)
 	if err != nil {
 		return nil, err
@@ -530,9 +557,31 @@ func deserializeMergeData(any *types.Any) (*MergeData, error) {
 	if err := types.UnmarshalAny(any, data); err != nil {
 		return nil, err
 	}
+	if err := checkTaskVersion(data.Version); err != nil {
+		return nil, err
+	}
+	if data.Version == 0 {
+		data.Shards = []*MergeShard{{
+			Shard:    data.LegacyShard,
+			Parent:   data.LegacyParent,
+			Tree:     data.LegacyTree,
+			TreeSize: data.LegacyTreeSize,
+		}}
+	}
 	return data, nil
 }
 
+// serializeLegacyMergeData serializes the result of a version 0 merge task,
+// which the master that created it reads from MergeData's legacy fields
+func serializeLegacyMergeData(data *MergeData) (*types.Any, error) {
+	if len(data.Shards) != 1 {
+		return nil, errors.Errorf("version 0 merge task has %d shards, rather than 1", len(data.Shards))
+	}
+	data.LegacyTree, data.LegacyTreeSize = data.Shards[0].Tree, data.Shards[0].TreeSize
+	data.Shards = nil
+	return types.MarshalAny(data)
+}
+
 func (reg *registry) getDatumSet(datumsObj *pfs.Object) (_ chain.DatumSet, retErr error) {
 	pachClient := reg.driver.PachClient()
 	if datumsObj == nil {
@@ -746,7 +795,8 @@ func (reg *registry) startJob(commitInfo *pfs.CommitInfo, statsCommit *pfs.Commi
 		mutex.Lock()
 		defer mutex.Unlock()
 
-		// This runs the callback asynchronously, but we want to block the errgroup until it completes
+		// This runs the callback asynchronously, but we want to block the errgroup until it completes.
+		// Jobs' tasks all have the default priority, as there's no setting to tell them apart.
 		if err := reg.taskQueue.RunTask(pj.driver.PachClient().Ctx(), func(master *work.Master) {
 			defer mutex.Unlock()
 			pj.taskMaster = master
@@ -982,6 +1032,7 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 					if data.ChunkHashtree != nil {
 						chunkHashtrees = append(chunkHashtrees, data.ChunkHashtree)
 					}
+					statsHashtrees = append(statsHashtrees, data.PartialStatsHashtrees...)
 					if data.StatsHashtree != nil {
 						statsHashtrees = append(statsHashtrees, data.StatsHashtree)
 					}
@@ -1031,10 +1082,11 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 
 func (pj *pendingJob) saveJobStats(stats *DatumStats) {
 	// Any unaccounted-for datums were skipped in the job datum iterator
-	pj.ji.DataSkipped = int64(pj.jdit.MaxLen()) - stats.DatumsProcessed - stats.DatumsFailed - stats.DatumsRecovered
+	pj.ji.DataSkipped = int64(pj.jdit.MaxLen()) - stats.DatumsProcessed - stats.DatumsFailed - stats.DatumsRecovered - stats.DatumsExcluded
 	pj.ji.DataProcessed = stats.DatumsProcessed
 	pj.ji.DataFailed = stats.DatumsFailed
 	pj.ji.DataRecovered = stats.DatumsRecovered
+	pj.ji.DataExcluded = stats.DatumsExcluded
 	pj.ji.DataTotal = int64(pj.jdit.MaxLen())
 	pj.ji.Stats = stats.ProcessStats
 }
@@ -1188,12 +1240,22 @@ func (reg *registry) makeMergeSubtasks(pj *pendingJob, commitInfo *pfs.CommitInf
 		}
 	}
 
+	// Each subtask merges a contiguous range of shards, so that a worker that's
+	// responsible for several shards only needs one pass over its hashtrees
+	numShards := reg.driver.NumShards()
+	numSubtasks := numShards
+	if reg.concurrency > 0 && reg.concurrency < numSubtasks {
+		numSubtasks = reg.concurrency
+	}
 	mergeSubtasks := []*work.Task{}
-	for i := int64(0); i < reg.driver.NumShards(); i++ {
-		mergeData := &MergeData{Hashtrees: hashtrees, Shard: i, JobID: pj.ji.Job.ID, Stats: stats}
-
-		if parentHashtrees != nil {
-			mergeData.Parent = parentHashtrees[i]
+	for i := int64(0); i < numSubtasks; i++ {
+		mergeData := &MergeData{Hashtrees: hashtrees, JobID: pj.ji.Job.ID, Stats: stats}
+		for shard := i * numShards / numSubtasks; shard < (i+1)*numShards/numSubtasks; shard++ {
+			mergeShard := &MergeShard{Shard: shard}
+			if parentHashtrees != nil {
+				mergeShard.Parent = parentHashtrees[shard]
+			}
+			mergeData.Shards = append(mergeData.Shards, mergeShard)
 		}
 
 		data, err := serializeMergeData(mergeData)
@@ -1254,19 +1316,23 @@ func (reg *registry) processJobMerging(pj *pendingJob) error {
 				return err
 			}
 
-			if data.Tree == nil {
-				return errors.Errorf("merge task for shard %d failed, no tree returned", data.Shard)
+			for _, mergeShard := range data.Shards {
+				if mergeShard.Tree == nil {
+					return errors.Errorf("merge task for shard %d failed, no tree returned", mergeShard.Shard)
+				}
 			}
 
 			mutex.Lock()
 			defer mutex.Unlock()
 
-			if data.Stats {
-				statsTrees[data.Shard] = data.Tree
-				statsSize += data.TreeSize
-			} else {
-				trees[data.Shard] = data.Tree
-				size += data.TreeSize
+			for _, mergeShard := range data.Shards {
+				if data.Stats {
+					statsTrees[mergeShard.Shard] = mergeShard.Tree
+					statsSize += mergeShard.TreeSize
+				} else {
+					trees[mergeShard.Shard] = mergeShard.Tree
+					size += mergeShard.TreeSize
+				}
 			}
 			return nil
 		},
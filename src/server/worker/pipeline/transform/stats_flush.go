@@ -0,0 +1,104 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// statsFlushInterval is how often the datum stats accumulated by a subtask are
+// flushed to object storage while it's processing datums
+var statsFlushInterval = 10 * time.Minute
+
+func jobChunkStatsPartialTag(jobID string, subtaskID string, attemptID string, flush int) string {
+	return fmt.Sprintf("%s-partial-%s-%d", jobChunkStatsTag(jobID, subtaskID), attemptID, flush)
+}
+
+// statsFlusher flushes the datum stats hashtrees that a subtask has
+// accumulated to object storage, so that the stats of a long subtask are
+// durable before it finishes. Each flush uploads a chunk of only the datums
+// that were cached since the previous flush, under its own partial tag, which
+// is unique to the subtask's attempt so that a retried subtask doesn't
+// overwrite its previous attempts' chunks. The partial chunks are returned
+// with the subtask's final stats chunk, which then only has the datums that
+// weren't flushed, and are merged into the job's stats along with it, so each
+// datum's stats are merged once.
+type statsFlusher struct {
+	driver          driver.Driver
+	logger          logs.TaggedLogger
+	statsCache      *hashtree.MergeCache
+	chunkStatsCache *hashtree.MergeCache
+	subtaskID       string
+	attemptID       string
+
+	// flushed is the set of cached datums that have been flushed
+	flushed map[string]bool
+	// hashtrees are the partial chunks that have been uploaded
+	hashtrees []*HashtreeInfo
+}
+
+func newStatsFlusher(driver driver.Driver, logger logs.TaggedLogger, statsCache *hashtree.MergeCache, chunkStatsCache *hashtree.MergeCache, subtaskID string) *statsFlusher {
+	return &statsFlusher{
+		driver:          driver,
+		logger:          logger,
+		statsCache:      statsCache,
+		chunkStatsCache: chunkStatsCache,
+		subtaskID:       subtaskID,
+		attemptID:       uuid.NewWithoutDashes(),
+		flushed:         make(map[string]bool),
+	}
+}
+
+// run flushes the stats every statsFlushInterval until 'ctx' is done. Flushing
+// is best effort, so errors are logged rather than failing the subtask.
+func (f *statsFlusher) run(ctx context.Context) {
+	ticker := time.NewTicker(statsFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.flush(); err != nil {
+				f.logger.Errf("error flushing partial stats: %v", err)
+			}
+		}
+	}
+}
+
+// unflushed returns the cached datums that haven't been flushed
+func (f *statsFlusher) unflushed() []string {
+	var ids []string
+	for _, id := range f.statsCache.Keys() {
+		if !f.flushed[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// flush uploads a partial chunk of the datum stats cached since the previous
+// flush, if there are any.
+func (f *statsFlusher) flush() error {
+	ids := f.unflushed()
+	if len(ids) == 0 {
+		return nil
+	}
+	tag := jobChunkStatsPartialTag(f.logger.JobID(), f.subtaskID, f.attemptID, len(f.hashtrees))
+	if err := uploadChunkKeys(f.driver, f.logger, f.statsCache, f.chunkStatsCache, tag, ids); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		f.flushed[id] = true
+	}
+	f.hashtrees = append(f.hashtrees, &HashtreeInfo{Address: os.Getenv(client.PPSWorkerIPEnv), Tag: tag})
+	f.logger.Logf("flushed stats of %d datums to %s", len(ids), tag)
+	return nil
+}
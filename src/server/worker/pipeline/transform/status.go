@@ -11,6 +11,21 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
 )
 
+// datumEventBufferSize is the number of datum events that are buffered for a
+// datum callback that's behind, after which further events are dropped
+const datumEventBufferSize = 1000
+
+// DatumEvent describes a datum that the transform worker has finished
+// processing
+type DatumEvent struct {
+	JobID   string
+	DatumID string
+	// State is the outcome of processing the datum: SUCCESS, SKIPPED, FAILED or
+	// RECOVERED
+	State    pps.DatumState
+	Duration time.Duration
+}
+
 // Status is a struct representing the current status of the transform worker,
 // its public interface only allows getting the status of a task and canceling
 // the currently-processing datum.
@@ -22,6 +37,9 @@ type Status struct {
 	datum     []*pps.InputFile
 	cancel    func()
 	started   time.Time
+
+	waitingForS3Gateway bool
+	datumEvents         chan *DatumEvent
 }
 
 func convertInputs(inputs []*common.Input) []*pps.InputFile {
@@ -67,6 +85,18 @@ func (s *Status) withStats(stats *pps.ProcessStats, queueSize *int64, cb func()
 	return cb()
 }
 
+func (s *Status) withS3GatewayWait(cb func() error) error {
+	s.withLock(func() {
+		s.waitingForS3Gateway = true
+	})
+
+	defer s.withLock(func() {
+		s.waitingForS3Gateway = false
+	})
+
+	return cb()
+}
+
 func (s *Status) withDatum(inputs []*common.Input, cancel func(), cb func() error) error {
 	s.withLock(func() {
 		s.datum = convertInputs(inputs)
@@ -83,6 +113,46 @@ func (s *Status) withDatum(inputs []*common.Input, cancel func(), cb func() erro
 	return cb()
 }
 
+// SetDatumCallback sets a function that's called with an event for each datum
+// that the worker finishes processing, or unsets it if 'cb' is nil. It's
+// called from its own goroutine, in the order that the datums finish, so that
+// a slow callback can't stall processing; if it falls more than
+// datumEventBufferSize events behind, events are dropped until it catches up.
+func (s *Status) SetDatumCallback(cb func(*DatumEvent)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.datumEvents != nil {
+		close(s.datumEvents)
+		s.datumEvents = nil
+	}
+	if cb == nil {
+		return
+	}
+	events := make(chan *DatumEvent, datumEventBufferSize)
+	s.datumEvents = events
+	go func() {
+		for event := range events {
+			cb(event)
+		}
+	}()
+}
+
+// datumFinished sends 'event' to the datum callback, if there is one. It
+// returns false if the event was dropped because the callback is behind.
+func (s *Status) datumFinished(event *DatumEvent) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.datumEvents == nil {
+		return true
+	}
+	select {
+	case s.datumEvents <- event:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetStatus returns the current WorkerStatus for the transform worker
 func (s *Status) GetStatus() (*pps.WorkerStatus, error) {
 	s.mutex.Lock()
@@ -103,6 +173,8 @@ func (s *Status) GetStatus() (*pps.WorkerStatus, error) {
 		Data:      s.datum,
 		Started:   started,
 		QueueSize: queueSize,
+
+		WaitingForS3Gateway: s.waitingForS3Gateway,
 	}, nil
 }
 
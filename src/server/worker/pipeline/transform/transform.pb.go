@@ -7,6 +7,7 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
 	pfs "github.com/pachyderm/pachyderm/src/client/pfs"
 	pps "github.com/pachyderm/pachyderm/src/client/pps"
 	common "github.com/pachyderm/pachyderm/src/server/worker/common"
@@ -351,15 +352,20 @@ func (m *HashtreeInfo) GetObject() *pfs.Object {
 }
 
 type DatumStats struct {
-	ProcessStats         *pps.ProcessStats `protobuf:"bytes,1,opt,name=process_stats,json=processStats,proto3" json:"process_stats,omitempty"`
-	DatumsProcessed      int64             `protobuf:"varint,2,opt,name=datums_processed,json=datumsProcessed,proto3" json:"datums_processed,omitempty"`
-	DatumsSkipped        int64             `protobuf:"varint,3,opt,name=datums_skipped,json=datumsSkipped,proto3" json:"datums_skipped,omitempty"`
-	DatumsFailed         int64             `protobuf:"varint,5,opt,name=datums_failed,json=datumsFailed,proto3" json:"datums_failed,omitempty"`
-	DatumsRecovered      int64             `protobuf:"varint,6,opt,name=datums_recovered,json=datumsRecovered,proto3" json:"datums_recovered,omitempty"`
-	FailedDatumID        string            `protobuf:"bytes,8,opt,name=failed_datum_id,json=failedDatumId,proto3" json:"failed_datum_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	ProcessStats    *pps.ProcessStats `protobuf:"bytes,1,opt,name=process_stats,json=processStats,proto3" json:"process_stats,omitempty"`
+	DatumsProcessed int64             `protobuf:"varint,2,opt,name=datums_processed,json=datumsProcessed,proto3" json:"datums_processed,omitempty"`
+	DatumsSkipped   int64             `protobuf:"varint,3,opt,name=datums_skipped,json=datumsSkipped,proto3" json:"datums_skipped,omitempty"`
+	DatumsFailed    int64             `protobuf:"varint,5,opt,name=datums_failed,json=datumsFailed,proto3" json:"datums_failed,omitempty"`
+	DatumsRecovered int64             `protobuf:"varint,6,opt,name=datums_recovered,json=datumsRecovered,proto3" json:"datums_recovered,omitempty"`
+	DatumsExcluded  int64             `protobuf:"varint,11,opt,name=datums_excluded,json=datumsExcluded,proto3" json:"datums_excluded,omitempty"`
+	FailedDatumID   string            `protobuf:"bytes,8,opt,name=failed_datum_id,json=failedDatumId,proto3" json:"failed_datum_id,omitempty"`
+	// The total and maximum time that datums spent waiting for a slot in the
+	// worker's processing queue before they began processing.
+	QueueWaitTime        *types.Duration `protobuf:"bytes,9,opt,name=queue_wait_time,json=queueWaitTime,proto3" json:"queue_wait_time,omitempty"`
+	MaxQueueWaitTime     *types.Duration `protobuf:"bytes,10,opt,name=max_queue_wait_time,json=maxQueueWaitTime,proto3" json:"max_queue_wait_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *DatumStats) Reset()         { *m = DatumStats{} }
@@ -430,6 +436,13 @@ func (m *DatumStats) GetDatumsRecovered() int64 {
 	return 0
 }
 
+func (m *DatumStats) GetDatumsExcluded() int64 {
+	if m != nil {
+		return m.DatumsExcluded
+	}
+	return 0
+}
+
 func (m *DatumStats) GetFailedDatumID() string {
 	if m != nil {
 		return m.FailedDatumID
@@ -437,19 +450,39 @@ func (m *DatumStats) GetFailedDatumID() string {
 	return ""
 }
 
+func (m *DatumStats) GetQueueWaitTime() *types.Duration {
+	if m != nil {
+		return m.QueueWaitTime
+	}
+	return nil
+}
+
+func (m *DatumStats) GetMaxQueueWaitTime() *types.Duration {
+	if m != nil {
+		return m.MaxQueueWaitTime
+	}
+	return nil
+}
+
 type DatumData struct {
+	// The version of the task format, which workers check before handling the
+	// task. Tasks from before the version was added have version 0.
+	Version uint32 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
 	// Inputs
 	JobID        string      `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	Datums       *pfs.Object `protobuf:"bytes,2,opt,name=datums,proto3" json:"datums,omitempty"`
 	OutputCommit *pfs.Commit `protobuf:"bytes,3,opt,name=output_commit,json=outputCommit,proto3" json:"output_commit,omitempty"`
 	// Outputs
-	Stats                *DatumStats   `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
-	ChunkHashtree        *HashtreeInfo `protobuf:"bytes,5,opt,name=chunk_hashtree,json=chunkHashtree,proto3" json:"chunk_hashtree,omitempty"`
-	StatsHashtree        *HashtreeInfo `protobuf:"bytes,6,opt,name=stats_hashtree,json=statsHashtree,proto3" json:"stats_hashtree,omitempty"`
-	RecoveredDatumsTag   string        `protobuf:"bytes,7,opt,name=recovered_datums_tag,json=recoveredDatumsTag,proto3" json:"recovered_datums_tag,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Stats              *DatumStats   `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	ChunkHashtree      *HashtreeInfo `protobuf:"bytes,5,opt,name=chunk_hashtree,json=chunkHashtree,proto3" json:"chunk_hashtree,omitempty"`
+	StatsHashtree      *HashtreeInfo `protobuf:"bytes,6,opt,name=stats_hashtree,json=statsHashtree,proto3" json:"stats_hashtree,omitempty"`
+	RecoveredDatumsTag string        `protobuf:"bytes,7,opt,name=recovered_datums_tag,json=recoveredDatumsTag,proto3" json:"recovered_datums_tag,omitempty"`
+	// The chunks of datum stats that were flushed while the datums were being
+	// processed, whose datums aren't in stats_hashtree
+	PartialStatsHashtrees []*HashtreeInfo `protobuf:"bytes,9,rep,name=partial_stats_hashtrees,json=partialStatsHashtrees,proto3" json:"partial_stats_hashtrees,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}        `json:"-"`
+	XXX_unrecognized      []byte          `json:"-"`
+	XXX_sizecache         int32           `json:"-"`
 }
 
 func (m *DatumData) Reset()         { *m = DatumData{} }
@@ -485,6 +518,13 @@ func (m *DatumData) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DatumData proto.InternalMessageInfo
 
+func (m *DatumData) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 func (m *DatumData) GetJobID() string {
 	if m != nil {
 		return m.JobID
@@ -534,16 +574,102 @@ func (m *DatumData) GetRecoveredDatumsTag() string {
 	return ""
 }
 
+func (m *DatumData) GetPartialStatsHashtrees() []*HashtreeInfo {
+	if m != nil {
+		return m.PartialStatsHashtrees
+	}
+	return nil
+}
+
+// MergeShard is one of the hashtree shards that a merge task produces
+type MergeShard struct {
+	// Inputs
+	Shard  int64       `protobuf:"varint,1,opt,name=shard,proto3" json:"shard,omitempty"`
+	Parent *pfs.Object `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Outputs
+	Tree                 *pfs.Object `protobuf:"bytes,3,opt,name=tree,proto3" json:"tree,omitempty"`
+	TreeSize             uint64      `protobuf:"varint,4,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MergeShard) Reset()         { *m = MergeShard{} }
+func (m *MergeShard) String() string { return proto.CompactTextString(m) }
+func (*MergeShard) ProtoMessage()    {}
+func (*MergeShard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_21583a759eb7fa97, []int{8}
+}
+func (m *MergeShard) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MergeShard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MergeShard.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MergeShard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeShard.Merge(m, src)
+}
+func (m *MergeShard) XXX_Size() int {
+	return m.Size()
+}
+func (m *MergeShard) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeShard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeShard proto.InternalMessageInfo
+
+func (m *MergeShard) GetShard() int64 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *MergeShard) GetParent() *pfs.Object {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *MergeShard) GetTree() *pfs.Object {
+	if m != nil {
+		return m.Tree
+	}
+	return nil
+}
+
+func (m *MergeShard) GetTreeSize() uint64 {
+	if m != nil {
+		return m.TreeSize
+	}
+	return 0
+}
+
 type MergeData struct {
+	// The version of the task format, as in DatumData
+	Version uint32 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	// Inputs
 	JobID     string          `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	Hashtrees []*HashtreeInfo `protobuf:"bytes,2,rep,name=hashtrees,proto3" json:"hashtrees,omitempty"`
-	Parent    *pfs.Object     `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
-	Shard     int64           `protobuf:"varint,4,opt,name=shard,proto3" json:"shard,omitempty"`
 	Stats     bool            `protobuf:"varint,5,opt,name=stats,proto3" json:"stats,omitempty"`
-	// Outputs
-	Tree                 *pfs.Object `protobuf:"bytes,6,opt,name=tree,proto3" json:"tree,omitempty"`
-	TreeSize             uint64      `protobuf:"varint,7,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	// The shards to merge, which are all produced in one pass over the hashtrees
+	Shards []*MergeShard `protobuf:"bytes,8,rep,name=shards,proto3" json:"shards,omitempty"`
+	// Version 0 tasks merge a single shard, which is given by these fields
+	// rather than 'shards'
+	LegacyParent         *pfs.Object `protobuf:"bytes,3,opt,name=legacy_parent,json=legacyParent,proto3" json:"legacy_parent,omitempty"`
+	LegacyShard          int64       `protobuf:"varint,4,opt,name=legacy_shard,json=legacyShard,proto3" json:"legacy_shard,omitempty"`
+	LegacyTree           *pfs.Object `protobuf:"bytes,6,opt,name=legacy_tree,json=legacyTree,proto3" json:"legacy_tree,omitempty"`
+	LegacyTreeSize       uint64      `protobuf:"varint,7,opt,name=legacy_tree_size,json=legacyTreeSize,proto3" json:"legacy_tree_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -553,7 +679,7 @@ func (m *MergeData) Reset()         { *m = MergeData{} }
 func (m *MergeData) String() string { return proto.CompactTextString(m) }
 func (*MergeData) ProtoMessage()    {}
 func (*MergeData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_21583a759eb7fa97, []int{8}
+	return fileDescriptor_21583a759eb7fa97, []int{9}
 }
 func (m *MergeData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -582,6 +708,13 @@ func (m *MergeData) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MergeData proto.InternalMessageInfo
 
+func (m *MergeData) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 func (m *MergeData) GetJobID() string {
 	if m != nil {
 		return m.JobID
@@ -596,37 +729,44 @@ func (m *MergeData) GetHashtrees() []*HashtreeInfo {
 	return nil
 }
 
-func (m *MergeData) GetParent() *pfs.Object {
+func (m *MergeData) GetStats() bool {
 	if m != nil {
-		return m.Parent
+		return m.Stats
+	}
+	return false
+}
+
+func (m *MergeData) GetShards() []*MergeShard {
+	if m != nil {
+		return m.Shards
 	}
 	return nil
 }
 
-func (m *MergeData) GetShard() int64 {
+func (m *MergeData) GetLegacyParent() *pfs.Object {
 	if m != nil {
-		return m.Shard
+		return m.LegacyParent
 	}
-	return 0
+	return nil
 }
 
-func (m *MergeData) GetStats() bool {
+func (m *MergeData) GetLegacyShard() int64 {
 	if m != nil {
-		return m.Stats
+		return m.LegacyShard
 	}
-	return false
+	return 0
 }
 
-func (m *MergeData) GetTree() *pfs.Object {
+func (m *MergeData) GetLegacyTree() *pfs.Object {
 	if m != nil {
-		return m.Tree
+		return m.LegacyTree
 	}
 	return nil
 }
 
-func (m *MergeData) GetTreeSize() uint64 {
+func (m *MergeData) GetLegacyTreeSize() uint64 {
 	if m != nil {
-		return m.TreeSize
+		return m.LegacyTreeSize
 	}
 	return 0
 }
@@ -640,6 +780,7 @@ func init() {
 	proto.RegisterType((*HashtreeInfo)(nil), "pachyderm.worker.pipeline.transform.HashtreeInfo")
 	proto.RegisterType((*DatumStats)(nil), "pachyderm.worker.pipeline.transform.DatumStats")
 	proto.RegisterType((*DatumData)(nil), "pachyderm.worker.pipeline.transform.DatumData")
+	proto.RegisterType((*MergeShard)(nil), "pachyderm.worker.pipeline.transform.MergeShard")
 	proto.RegisterType((*MergeData)(nil), "pachyderm.worker.pipeline.transform.MergeData")
 }
 
@@ -648,55 +789,68 @@ func init() {
 }
 
 var fileDescriptor_21583a759eb7fa97 = []byte{
-	// 753 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5d, 0x6e, 0xe3, 0x36,
-	0x10, 0x86, 0xff, 0xb4, 0xd1, 0xd8, 0x5e, 0xef, 0x12, 0x46, 0x61, 0x6c, 0xd1, 0xc4, 0x55, 0xb0,
-	0x80, 0xf7, 0x45, 0x72, 0x5d, 0xa0, 0x40, 0x5f, 0xb3, 0x6e, 0xb1, 0x5e, 0x6c, 0xb1, 0x29, 0x93,
-	0x87, 0xa2, 0x7d, 0x10, 0x68, 0x89, 0x96, 0x94, 0xc4, 0xa2, 0x40, 0xd2, 0x69, 0x9b, 0x3b, 0xf4,
-	0x10, 0x3d, 0x42, 0x6f, 0xd1, 0xc7, 0x9e, 0x20, 0x28, 0x7c, 0x92, 0x82, 0x43, 0xc9, 0x91, 0x8b,
-	0x00, 0x6b, 0xe4, 0xc1, 0xd0, 0xcc, 0x37, 0xc3, 0x8f, 0xc3, 0xf9, 0x86, 0x34, 0x4c, 0x15, 0x97,
-	0xb7, 0x5c, 0x06, 0xbf, 0x0a, 0x79, 0xcd, 0x65, 0x50, 0x64, 0x05, 0xbf, 0xc9, 0x72, 0x1e, 0x68,
-	0xc9, 0x72, 0xb5, 0x12, 0x72, 0xfd, 0x60, 0xf9, 0x85, 0x14, 0x5a, 0x90, 0xd3, 0x82, 0x45, 0xe9,
-	0xef, 0x31, 0x97, 0x6b, 0xdf, 0x2e, 0xf2, 0xab, 0x45, 0xfe, 0x2e, 0xf5, 0xd5, 0x30, 0x11, 0x89,
-	0xc0, 0xfc, 0xc0, 0x58, 0x76, 0xe9, 0xab, 0x61, 0x74, 0x93, 0xf1, 0x5c, 0x07, 0xc5, 0x4a, 0x99,
-	0xdf, 0xff, 0xd1, 0x42, 0x99, 0x5f, 0x89, 0x7e, 0xb9, 0x5f, 0x58, 0x24, 0xd6, 0x6b, 0x91, 0x97,
-	0x1f, 0x9b, 0xe2, 0xbd, 0x87, 0xee, 0x9c, 0xe9, 0xcd, 0x7a, 0x91, 0x17, 0x1b, 0xad, 0xc8, 0x6b,
-	0x70, 0x32, 0xb4, 0x46, 0x8d, 0x71, 0x6b, 0xd2, 0x9d, 0xf5, 0xfd, 0x32, 0x1b, 0xe3, 0xb4, 0x0c,
-	0x92, 0x21, 0x74, 0xb2, 0x3c, 0xe6, 0xbf, 0x8d, 0x9a, 0xe3, 0xc6, 0xa4, 0x45, 0xad, 0xe3, 0xfd,
-	0x02, 0x83, 0x1a, 0xd7, 0x87, 0x4c, 0x69, 0xf2, 0x0e, 0x9c, 0xd8, 0x40, 0x15, 0xdf, 0xd4, 0x3f,
-	0xe0, 0xe4, 0x7e, 0x8d, 0x85, 0x96, 0xeb, 0xbd, 0x0f, 0xd0, 0x7b, 0xc7, 0x54, 0xaa, 0x25, 0xe7,
-	0x97, 0x2c, 0x51, 0xe4, 0x0b, 0x80, 0x28, 0xdd, 0xe4, 0xd7, 0xa1, 0x66, 0x89, 0x65, 0x77, 0xa9,
-	0x8b, 0x48, 0x15, 0x56, 0x9a, 0x69, 0x65, 0xc3, 0x4d, 0x1b, 0x46, 0xc4, 0x84, 0xbd, 0x37, 0x30,
-	0xa0, 0x3c, 0x12, 0xb7, 0x5c, 0xf2, 0x18, 0x77, 0x53, 0xe4, 0x33, 0x70, 0x52, 0xa6, 0x52, 0x5e,
-	0x91, 0x95, 0x9e, 0x37, 0x01, 0xb2, 0x9f, 0x8a, 0xfc, 0x04, 0xda, 0xb5, 0x8d, 0xd1, 0xf6, 0xc2,
-	0x87, 0x12, 0x17, 0xf9, 0x4a, 0x90, 0x11, 0x3c, 0x63, 0x71, 0x2c, 0xb9, 0x32, 0x69, 0x8d, 0x89,
-	0x4b, 0x2b, 0x97, 0xbc, 0x80, 0x96, 0x66, 0x09, 0x76, 0xcf, 0xa5, 0xc6, 0x24, 0xa7, 0xe0, 0x88,
-	0xe5, 0x15, 0x8f, 0xf4, 0xa8, 0x35, 0x6e, 0x4c, 0xba, 0xb3, 0xae, 0x6f, 0xc4, 0xfd, 0x88, 0x10,
-	0x2d, 0x43, 0xde, 0x9f, 0x4d, 0x00, 0x2c, 0xe1, 0xc2, 0x1c, 0x84, 0x7c, 0x03, 0xfd, 0x42, 0x8a,
-	0x88, 0x2b, 0x15, 0xe2, 0xc9, 0x70, 0x97, 0xee, 0xec, 0xa5, 0x6f, 0x26, 0xe0, 0xdc, 0x46, 0x30,
-	0x93, 0xf6, 0x8a, 0x9a, 0x47, 0xde, 0xc0, 0x0b, 0xdb, 0xd4, 0xb0, 0x84, 0x79, 0x5c, 0x0a, 0x39,
-	0xb0, 0xf8, 0x79, 0x05, 0x93, 0xd7, 0xf0, 0xbc, 0x4c, 0x55, 0xd7, 0x59, 0x51, 0xf0, 0x18, 0xcb,
-	0x6b, 0xd1, 0xbe, 0x45, 0x2f, 0x2c, 0x48, 0x4e, 0xa1, 0x04, 0xc2, 0x15, 0xcb, 0x6e, 0x78, 0x3c,
-	0xea, 0x60, 0x56, 0xcf, 0x82, 0xdf, 0x23, 0x56, 0xdb, 0x56, 0x56, 0xfd, 0x1c, 0x39, 0xf5, 0x6d,
-	0x77, 0x6d, 0x26, 0xdf, 0xc2, 0xc0, 0x12, 0x85, 0x18, 0x09, 0xb3, 0x78, 0x74, 0x64, 0x7a, 0x75,
-	0xf6, 0x72, 0x7b, 0x7f, 0xd2, 0xb7, 0x7c, 0x76, 0x48, 0xe6, 0xb4, 0xbf, 0xaa, 0xb9, 0xb1, 0xf7,
-	0x57, 0x0b, 0x5c, 0xb4, 0xe7, 0x4c, 0x33, 0x32, 0x06, 0xe7, 0x4a, 0x2c, 0xcd, 0x7a, 0x54, 0xe0,
-	0xcc, 0xdd, 0xde, 0x9f, 0x74, 0xde, 0x8b, 0xe5, 0x62, 0x4e, 0x3b, 0x57, 0x62, 0xb9, 0x30, 0xa5,
-	0x57, 0x13, 0xda, 0x7c, 0xa4, 0xf1, 0x36, 0x44, 0xa6, 0xd0, 0x17, 0x1b, 0x5d, 0x6c, 0x74, 0x68,
-	0xae, 0x43, 0xb6, 0x2f, 0xd2, 0x5b, 0x84, 0x68, 0xcf, 0x66, 0x58, 0x8f, 0x7c, 0x07, 0x1d, 0xab,
-	0x49, 0x1b, 0x33, 0x83, 0xc3, 0xe7, 0xde, 0x2a, 0x66, 0x57, 0x93, 0x9f, 0xe0, 0xb9, 0x9d, 0xf2,
-	0xb4, 0x1c, 0x2c, 0xec, 0x6c, 0x77, 0xf6, 0xd5, 0x41, 0x7c, 0xf5, 0x69, 0xa4, 0x7d, 0x24, 0xaa,
-	0x20, 0xc3, 0x6c, 0x2f, 0xc8, 0x8e, 0xd9, 0x79, 0x32, 0x33, 0x12, 0xed, 0x98, 0xa7, 0x30, 0xdc,
-	0x09, 0x1c, 0x96, 0x8a, 0x9b, 0x69, 0x7f, 0x86, 0xd3, 0x4e, 0xe4, 0xfe, 0xbd, 0xbb, 0x64, 0x89,
-	0xf7, 0x47, 0x13, 0xdc, 0x1f, 0xb8, 0x4c, 0xf8, 0x81, 0x9a, 0x7d, 0x04, 0xb7, 0xaa, 0xda, 0xde,
-	0xed, 0x27, 0x95, 0xfd, 0xc0, 0x61, 0x86, 0xa0, 0x60, 0x92, 0xe7, 0x8f, 0xdf, 0x3e, 0x1b, 0x32,
-	0x8f, 0x9e, 0x4a, 0x99, 0x8c, 0x51, 0xd2, 0x16, 0xb5, 0x0e, 0xa2, 0x28, 0xb4, 0x11, 0xe6, 0xa8,
-	0xd2, 0xed, 0x04, 0xda, 0xb5, 0x9e, 0xee, 0xd1, 0x61, 0x80, 0x7c, 0x0e, 0xae, 0xf9, 0x86, 0x2a,
-	0xbb, 0xe3, 0xd8, 0x99, 0x36, 0x3d, 0x32, 0xc0, 0x45, 0x76, 0xc7, 0xcf, 0x7e, 0xfc, 0x7b, 0x7b,
-	0xdc, 0xf8, 0x67, 0x7b, 0xdc, 0xf8, 0x77, 0x7b, 0xdc, 0xf8, 0xf9, 0x6d, 0x92, 0xe9, 0x74, 0xb3,
-	0x34, 0x2f, 0x71, 0xb0, 0x3b, 0x64, 0xcd, 0x52, 0x32, 0x0a, 0x3e, 0xf5, 0x0f, 0xb4, 0x74, 0xf0,
-	0xb9, 0xff, 0xfa, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x06, 0x53, 0x61, 0xb2, 0xac, 0x06, 0x00,
-	0x00,
+	// 973 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x51, 0x4f, 0x1b, 0x47,
+	0x10, 0x96, 0xb1, 0x31, 0xdc, 0x18, 0x03, 0xd9, 0xd2, 0xf6, 0x9a, 0xaa, 0xe0, 0x18, 0x45, 0x75,
+	0xa4, 0xea, 0x8e, 0x52, 0xa9, 0x52, 0x1f, 0x4b, 0x48, 0x0b, 0x51, 0xaa, 0x90, 0x03, 0xa9, 0x55,
+	0xfb, 0x70, 0x5a, 0xdf, 0xad, 0xcf, 0x0b, 0xf6, 0xed, 0x75, 0x77, 0x8f, 0x90, 0xbc, 0xf7, 0x9f,
+	0xf4, 0x37, 0xf4, 0x37, 0xf4, 0xb1, 0x6f, 0x7d, 0x8b, 0x2a, 0x7e, 0x49, 0xb5, 0xb3, 0x7b, 0xf6,
+	0x19, 0x21, 0x85, 0xf0, 0x60, 0xdd, 0xce, 0x37, 0xb3, 0xdf, 0xce, 0xce, 0x7c, 0xb3, 0x00, 0x7b,
+	0x8a, 0xc9, 0x4b, 0x26, 0xc3, 0xd7, 0x42, 0x5e, 0x30, 0x19, 0x16, 0xbc, 0x60, 0x13, 0x9e, 0xb3,
+	0x50, 0x4b, 0x9a, 0xab, 0x91, 0x90, 0xd3, 0xf9, 0x2a, 0x28, 0xa4, 0xd0, 0x82, 0xec, 0x16, 0x34,
+	0x19, 0xbf, 0x49, 0x99, 0x9c, 0x06, 0x76, 0x53, 0x50, 0x6d, 0x0a, 0x66, 0xa1, 0x0f, 0xb7, 0x33,
+	0x21, 0xb2, 0x09, 0x0b, 0x71, 0xcb, 0xb0, 0x1c, 0x85, 0x69, 0x29, 0xa9, 0xe6, 0x22, 0xb7, 0x24,
+	0x0f, 0xb7, 0x32, 0x91, 0x09, 0x5c, 0x86, 0x66, 0x55, 0xa1, 0xc9, 0x84, 0xb3, 0x5c, 0x87, 0xc5,
+	0x48, 0x99, 0xdf, 0x4d, 0xb4, 0x50, 0xe6, 0xe7, 0xd0, 0x47, 0x8b, 0x89, 0x27, 0x62, 0x3a, 0x15,
+	0xb9, 0xfb, 0xd8, 0x90, 0xfe, 0x73, 0xe8, 0x1c, 0x52, 0x5d, 0x4e, 0x8f, 0xf3, 0xa2, 0xd4, 0x8a,
+	0x3c, 0x86, 0x36, 0xc7, 0x95, 0xdf, 0xe8, 0x35, 0x07, 0x9d, 0xfd, 0x6e, 0xe0, 0xa2, 0xd1, 0x1f,
+	0x39, 0x27, 0xd9, 0x82, 0x65, 0x9e, 0xa7, 0xec, 0xca, 0x5f, 0xea, 0x35, 0x06, 0xcd, 0xc8, 0x1a,
+	0xfd, 0xdf, 0x60, 0xa3, 0xc6, 0xf5, 0x82, 0x2b, 0x4d, 0x8e, 0xa0, 0x9d, 0x1a, 0xa8, 0xe2, 0xdb,
+	0x0b, 0xee, 0x50, 0x99, 0xa0, 0xc6, 0x12, 0xb9, 0xfd, 0xfd, 0x17, 0xb0, 0x76, 0x44, 0xd5, 0x58,
+	0x4b, 0xc6, 0xce, 0x68, 0xa6, 0xc8, 0x17, 0x00, 0xc9, 0xb8, 0xcc, 0x2f, 0x62, 0x4d, 0x33, 0xcb,
+	0xee, 0x45, 0x1e, 0x22, 0x95, 0x5b, 0x69, 0xaa, 0x95, 0x75, 0x2f, 0x59, 0x37, 0x22, 0xc6, 0xdd,
+	0x7f, 0x02, 0x1b, 0x11, 0x4b, 0xc4, 0x25, 0x93, 0x2c, 0xc5, 0xd3, 0x14, 0xf9, 0x04, 0xda, 0x63,
+	0xaa, 0xc6, 0xac, 0x22, 0x73, 0x56, 0x7f, 0x00, 0x64, 0x31, 0x14, 0xf9, 0x09, 0xb4, 0x6a, 0x07,
+	0xe3, 0xba, 0x1f, 0xcf, 0x53, 0x3c, 0xce, 0x47, 0x82, 0xf8, 0xb0, 0x42, 0xd3, 0x54, 0x32, 0x65,
+	0xc2, 0x1a, 0x03, 0x2f, 0xaa, 0x4c, 0xb2, 0x09, 0x4d, 0x4d, 0x33, 0xac, 0x9e, 0x17, 0x99, 0x25,
+	0xd9, 0x85, 0xb6, 0x18, 0x9e, 0xb3, 0x44, 0xfb, 0xcd, 0x5e, 0x63, 0xd0, 0xd9, 0xef, 0x04, 0xa6,
+	0xb9, 0x2f, 0x11, 0x8a, 0x9c, 0xab, 0xff, 0x6f, 0x13, 0x00, 0x53, 0x38, 0x35, 0x17, 0x21, 0xdf,
+	0x42, 0xb7, 0x90, 0x22, 0x61, 0x4a, 0xc5, 0x78, 0x33, 0x3c, 0xa5, 0xb3, 0xff, 0x20, 0x30, 0x0a,
+	0x38, 0xb1, 0x1e, 0x8c, 0x8c, 0xd6, 0x8a, 0x9a, 0x45, 0x9e, 0xc0, 0xa6, 0x2d, 0x6a, 0xec, 0x60,
+	0x96, 0xba, 0x46, 0x6e, 0x58, 0xfc, 0xa4, 0x82, 0xc9, 0x63, 0x58, 0x77, 0xa1, 0xea, 0x82, 0x17,
+	0x05, 0x4b, 0x31, 0xbd, 0x66, 0xd4, 0xb5, 0xe8, 0xa9, 0x05, 0xc9, 0x2e, 0x38, 0x20, 0x1e, 0x51,
+	0x3e, 0x61, 0xa9, 0xbf, 0x8c, 0x51, 0x6b, 0x16, 0xfc, 0x01, 0xb1, 0xda, 0xb1, 0xb2, 0xaa, 0xa7,
+	0xdf, 0xae, 0x1f, 0x3b, 0x2b, 0x33, 0xf9, 0x12, 0x1c, 0x14, 0xb3, 0xab, 0x64, 0x52, 0xa6, 0x2c,
+	0xf5, 0x3b, 0x18, 0xe9, 0xb2, 0x79, 0xe6, 0x50, 0xf2, 0x1d, 0x6c, 0xd8, 0x13, 0x63, 0x74, 0xc4,
+	0x3c, 0xf5, 0x57, 0x4d, 0x51, 0x0f, 0x1e, 0x5c, 0xbf, 0xdb, 0xe9, 0xda, 0x83, 0xad, 0x9a, 0x0e,
+	0xa3, 0xee, 0xa8, 0x66, 0xa6, 0xe4, 0x7b, 0xd8, 0xf8, 0xbd, 0x64, 0x25, 0x8b, 0x5f, 0x53, 0xae,
+	0x63, 0xcd, 0xa7, 0xcc, 0xf7, 0xb0, 0x7e, 0x9f, 0x05, 0x76, 0x30, 0x83, 0x6a, 0x30, 0x83, 0x43,
+	0x37, 0x98, 0x51, 0x17, 0x77, 0xfc, 0x4c, 0xb9, 0x3e, 0xe3, 0x53, 0x46, 0x8e, 0xe0, 0xa3, 0x29,
+	0xbd, 0x8a, 0x6f, 0xd2, 0xc0, 0xfb, 0x68, 0x36, 0xa7, 0xf4, 0xea, 0x55, 0x9d, 0xa9, 0xff, 0x57,
+	0x0b, 0x3c, 0x4c, 0xec, 0x90, 0x6a, 0x6a, 0x84, 0x73, 0xc9, 0xa4, 0xe2, 0x22, 0xc7, 0xdb, 0x74,
+	0xa3, 0xca, 0x24, 0x3d, 0x68, 0x9f, 0x8b, 0xa1, 0xb9, 0x26, 0x2a, 0xea, 0xc0, 0xbb, 0x7e, 0xb7,
+	0xb3, 0xfc, 0x5c, 0x0c, 0x8f, 0x0f, 0xa3, 0xe5, 0x73, 0x31, 0x3c, 0x36, 0xad, 0xa8, 0x26, 0x6e,
+	0xe9, 0x16, 0x21, 0x59, 0x17, 0xd9, 0x83, 0xae, 0x28, 0x75, 0x51, 0xea, 0xd8, 0x8c, 0x37, 0x5f,
+	0x14, 0xdd, 0x53, 0x84, 0xa2, 0x35, 0x1b, 0x61, 0x2d, 0xf2, 0x0c, 0x96, 0xad, 0xc6, 0x5a, 0x18,
+	0x19, 0xde, 0x7d, 0x8e, 0xad, 0x02, 0xed, 0x6e, 0xf2, 0x0b, 0xac, 0xdb, 0xa9, 0x1d, 0xbb, 0x41,
+	0x41, 0xa5, 0x74, 0xf6, 0xbf, 0xbe, 0x13, 0x5f, 0x7d, 0xba, 0xa2, 0x2e, 0x12, 0x55, 0x90, 0x61,
+	0xb6, 0x03, 0x3f, 0x63, 0x6e, 0xdf, 0x9b, 0x19, 0x89, 0x66, 0xcc, 0x7b, 0xb0, 0x35, 0x13, 0x6c,
+	0xec, 0x64, 0x69, 0xa6, 0x77, 0x05, 0xa7, 0x97, 0xc8, 0xc5, 0x77, 0xe4, 0x8c, 0x66, 0x84, 0xc3,
+	0xa7, 0x05, 0x95, 0x9a, 0xd3, 0x49, 0xbc, 0x98, 0x93, 0xf2, 0xbd, 0x5e, 0xf3, 0x7e, 0x49, 0x7d,
+	0xec, 0x18, 0x4f, 0xeb, 0xb9, 0xa9, 0xfe, 0x1f, 0x0d, 0x80, 0x9f, 0x98, 0xcc, 0xd8, 0xe9, 0x98,
+	0xca, 0xd4, 0x3c, 0xcc, 0xca, 0x2c, 0x50, 0x1e, 0xcd, 0xc8, 0x1a, 0x46, 0x13, 0x05, 0x95, 0x2c,
+	0xd7, 0xb7, 0x6a, 0xc2, 0xba, 0xc8, 0x0e, 0xb4, 0xb0, 0x6c, 0xb7, 0xbc, 0x3f, 0xe8, 0x20, 0x9f,
+	0x83, 0x67, 0xbe, 0xb1, 0xe2, 0x6f, 0x19, 0xca, 0xa0, 0x15, 0xad, 0x1a, 0xe0, 0x94, 0xbf, 0x65,
+	0xfd, 0x3f, 0x9b, 0xe0, 0x61, 0x1e, 0x37, 0x05, 0xec, 0x7d, 0xa8, 0x80, 0x5f, 0x82, 0x37, 0x2f,
+	0xd7, 0xd2, 0x7d, 0xcb, 0x35, 0xe7, 0xc0, 0x9a, 0xa0, 0x74, 0x8d, 0xd4, 0x56, 0x2b, 0x25, 0xfe,
+	0x08, 0x6d, 0x2c, 0x8e, 0xf2, 0x57, 0x7b, 0xcd, 0x3b, 0x2b, 0x7a, 0x5e, 0xea, 0xc8, 0x6d, 0x37,
+	0xb3, 0x34, 0x61, 0x19, 0x4d, 0xde, 0xc4, 0xae, 0xc6, 0xb7, 0x14, 0x70, 0xcd, 0x46, 0x9c, 0xd8,
+	0x4a, 0x3f, 0x02, 0x67, 0xc7, 0xb6, 0x57, 0x2d, 0xec, 0x55, 0xc7, 0x62, 0xb6, 0x8f, 0x5f, 0x81,
+	0x33, 0xe3, 0x9a, 0x94, 0x17, 0x28, 0xc1, 0xfa, 0xcf, 0x4c, 0x67, 0x06, 0xb0, 0x59, 0x8b, 0xb6,
+	0x0d, 0x5a, 0xc1, 0x06, 0xad, 0xcf, 0xa3, 0x4c, 0x9b, 0x0e, 0x5e, 0xfd, 0x7d, 0xbd, 0xdd, 0xf8,
+	0xe7, 0x7a, 0xbb, 0xf1, 0xdf, 0xf5, 0x76, 0xe3, 0xd7, 0xa7, 0x19, 0xd7, 0xe3, 0x72, 0x68, 0xfe,
+	0xc6, 0x87, 0xb3, 0xdb, 0xd7, 0x56, 0x4a, 0x26, 0xe1, 0xfb, 0xfe, 0xf7, 0x19, 0xb6, 0xf1, 0x7d,
+	0xfb, 0xe6, 0xff, 0x01, 0x00, 0x7a, 0x4e, 0xff, 0x95, 0x26, 0x09, 0x00, 0x00,
 }
 
 func (m *DatumInputs) Marshal() (dAtA []byte, err error) {
@@ -980,6 +1134,35 @@ func (m *DatumStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DatumsExcluded != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.DatumsExcluded))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.MaxQueueWaitTime != nil {
+		{
+			size, err := m.MaxQueueWaitTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransform(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.QueueWaitTime != nil {
+		{
+			size, err := m.QueueWaitTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransform(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.FailedDatumID) > 0 {
 		i -= len(m.FailedDatumID)
 		copy(dAtA[i:], m.FailedDatumID)
@@ -1046,6 +1229,25 @@ func (m *DatumData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.PartialStatsHashtrees) > 0 {
+		for iNdEx := len(m.PartialStatsHashtrees) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PartialStatsHashtrees[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransform(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if m.Version != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x40
+	}
 	if len(m.RecoveredDatumsTag) > 0 {
 		i -= len(m.RecoveredDatumsTag)
 		copy(dAtA[i:], m.RecoveredDatumsTag)
@@ -1123,7 +1325,7 @@ func (m *DatumData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *MergeData) Marshal() (dAtA []byte, err error) {
+func (m *MergeShard) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1133,12 +1335,12 @@ func (m *MergeData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MergeData) MarshalTo(dAtA []byte) (int, error) {
+func (m *MergeShard) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MergeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MergeShard) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1150,7 +1352,7 @@ func (m *MergeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	if m.TreeSize != 0 {
 		i = encodeVarintTransform(dAtA, i, uint64(m.TreeSize))
 		i--
-		dAtA[i] = 0x38
+		dAtA[i] = 0x20
 	}
 	if m.Tree != nil {
 		{
@@ -1162,6 +1364,86 @@ func (m *MergeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintTransform(dAtA, i, uint64(size))
 		}
 		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Parent != nil {
+		{
+			size, err := m.Parent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransform(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Shard != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.Shard))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MergeData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MergeData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MergeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Shards) > 0 {
+		for iNdEx := len(m.Shards) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Shards[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransform(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.LegacyTreeSize != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.LegacyTreeSize))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.LegacyTree != nil {
+		{
+			size, err := m.LegacyTree.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransform(dAtA, i, uint64(size))
+		}
+		i--
 		dAtA[i] = 0x32
 	}
 	if m.Stats {
@@ -1174,14 +1456,14 @@ func (m *MergeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	if m.Shard != 0 {
-		i = encodeVarintTransform(dAtA, i, uint64(m.Shard))
+	if m.LegacyShard != 0 {
+		i = encodeVarintTransform(dAtA, i, uint64(m.LegacyShard))
 		i--
 		dAtA[i] = 0x20
 	}
-	if m.Parent != nil {
+	if m.LegacyParent != nil {
 		{
-			size, err := m.Parent.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.LegacyParent.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1375,6 +1657,17 @@ func (m *DatumStats) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTransform(uint64(l))
 	}
+	if m.QueueWaitTime != nil {
+		l = m.QueueWaitTime.Size()
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if m.MaxQueueWaitTime != nil {
+		l = m.MaxQueueWaitTime.Size()
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if m.DatumsExcluded != 0 {
+		n += 1 + sovTransform(uint64(m.DatumsExcluded))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1415,6 +1708,41 @@ func (m *DatumData) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTransform(uint64(l))
 	}
+	if m.Version != 0 {
+		n += 1 + sovTransform(uint64(m.Version))
+	}
+	if len(m.PartialStatsHashtrees) > 0 {
+		for _, e := range m.PartialStatsHashtrees {
+			l = e.Size()
+			n += 1 + l + sovTransform(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MergeShard) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Shard != 0 {
+		n += 1 + sovTransform(uint64(m.Shard))
+	}
+	if m.Parent != nil {
+		l = m.Parent.Size()
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if m.Tree != nil {
+		l = m.Tree.Size()
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if m.TreeSize != 0 {
+		n += 1 + sovTransform(uint64(m.TreeSize))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1437,24 +1765,33 @@ func (m *MergeData) Size() (n int) {
 			n += 1 + l + sovTransform(uint64(l))
 		}
 	}
-	if m.Parent != nil {
-		l = m.Parent.Size()
+	if m.LegacyParent != nil {
+		l = m.LegacyParent.Size()
 		n += 1 + l + sovTransform(uint64(l))
 	}
-	if m.Shard != 0 {
-		n += 1 + sovTransform(uint64(m.Shard))
+	if m.LegacyShard != 0 {
+		n += 1 + sovTransform(uint64(m.LegacyShard))
 	}
 	if m.Stats {
 		n += 2
 	}
-	if m.Tree != nil {
-		l = m.Tree.Size()
+	if m.LegacyTree != nil {
+		l = m.LegacyTree.Size()
 		n += 1 + l + sovTransform(uint64(l))
 	}
-	if m.TreeSize != 0 {
-		n += 1 + sovTransform(uint64(m.TreeSize))
+	if m.LegacyTreeSize != 0 {
+		n += 1 + sovTransform(uint64(m.LegacyTreeSize))
 	}
-	if m.XXX_unrecognized != nil {
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
+			l = e.Size()
+			n += 1 + l + sovTransform(uint64(l))
+		}
+	}
+	if m.Version != 0 {
+		n += 1 + sovTransform(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
@@ -2278,6 +2615,97 @@ func (m *DatumStats) Unmarshal(dAtA []byte) error {
 			}
 			m.FailedDatumID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueWaitTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.QueueWaitTime == nil {
+				m.QueueWaitTime = &types.Duration{}
+			}
+			if err := m.QueueWaitTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueueWaitTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxQueueWaitTime == nil {
+				m.MaxQueueWaitTime = &types.Duration{}
+			}
+			if err := m.MaxQueueWaitTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumsExcluded", wireType)
+			}
+			m.DatumsExcluded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DatumsExcluded |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTransform(dAtA[iNdEx:])
@@ -2576,6 +3004,223 @@ func (m *DatumData) Unmarshal(dAtA []byte) error {
 			}
 			m.RecoveredDatumsTag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialStatsHashtrees", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PartialStatsHashtrees = append(m.PartialStatsHashtrees, &HashtreeInfo{})
+			if err := m.PartialStatsHashtrees[len(m.PartialStatsHashtrees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransform(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MergeShard) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransform
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MergeShard: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MergeShard: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Parent == nil {
+				m.Parent = &pfs.Object{}
+			}
+			if err := m.Parent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tree == nil {
+				m.Tree = &pfs.Object{}
+			}
+			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeSize", wireType)
+			}
+			m.TreeSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TreeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTransform(dAtA[iNdEx:])
@@ -2698,7 +3343,7 @@ func (m *MergeData) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LegacyParent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2725,18 +3370,18 @@ func (m *MergeData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Parent == nil {
-				m.Parent = &pfs.Object{}
+			if m.LegacyParent == nil {
+				m.LegacyParent = &pfs.Object{}
 			}
-			if err := m.Parent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LegacyParent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LegacyShard", wireType)
 			}
-			m.Shard = 0
+			m.LegacyShard = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTransform
@@ -2746,7 +3391,7 @@ func (m *MergeData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Shard |= int64(b&0x7F) << shift
+				m.LegacyShard |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2773,7 +3418,7 @@ func (m *MergeData) Unmarshal(dAtA []byte) error {
 			m.Stats = bool(v != 0)
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LegacyTree", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2800,18 +3445,18 @@ func (m *MergeData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Tree == nil {
-				m.Tree = &pfs.Object{}
+			if m.LegacyTree == nil {
+				m.LegacyTree = &pfs.Object{}
 			}
-			if err := m.Tree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LegacyTree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TreeSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LegacyTreeSize", wireType)
 			}
-			m.TreeSize = 0
+			m.LegacyTreeSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTransform
@@ -2821,7 +3466,60 @@ func (m *MergeData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TreeSize |= uint64(b&0x7F) << shift
+				m.LegacyTreeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shards = append(m.Shards, &MergeShard{})
+			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -176,6 +176,7 @@ func mockBasicJob(t *testing.T, env *testEnv, pi *pps.PipelineInfo) (context.Con
 		etcdJobInfo.DataTotal = request.DataTotal
 		etcdJobInfo.DataFailed = request.DataFailed
 		etcdJobInfo.DataRecovered = request.DataRecovered
+		etcdJobInfo.DataExcluded = request.DataExcluded
 		etcdJobInfo.StatsCommit = request.StatsCommit
 		etcdJobInfo.Started = request.Started
 		etcdJobInfo.Finished = request.Finished
@@ -197,6 +198,7 @@ func mockBasicJob(t *testing.T, env *testEnv, pi *pps.PipelineInfo) (context.Con
 			DataTotal:        etcdJobInfo.DataTotal,
 			DataFailed:       etcdJobInfo.DataFailed,
 			DataRecovered:    etcdJobInfo.DataRecovered,
+			DataExcluded:     etcdJobInfo.DataExcluded,
 			Stats:            etcdJobInfo.Stats,
 			StatsCommit:      etcdJobInfo.StatsCommit,
 			State:            etcdJobInfo.State,
@@ -1,21 +1,26 @@
 package transform
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
+	globlib "github.com/pachyderm/ohmyglob"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/pachyderm/pachyderm/src/client"
@@ -26,8 +31,8 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/pbutil"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
-	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/server/pkg/work"
@@ -39,6 +44,7 @@ import (
 
 var (
 	errDatumRecovered = errors.New("the datum errored, and the error was handled successfully")
+	errDatumFailed    = errors.New("the datum failed, canceling the other datums")
 	statsTagSuffix    = "_stats"
 )
 
@@ -76,6 +82,35 @@ func plusDuration(x *types.Duration, y *types.Duration) (*types.Duration, error)
 	return types.DurationProto(xd + yd), nil
 }
 
+func maxDuration(x *types.Duration, y *types.Duration) (*types.Duration, error) {
+	if x == nil {
+		return y, nil
+	}
+	if y == nil {
+		return x, nil
+	}
+	xd, err := types.DurationFromProto(x)
+	if err != nil {
+		return nil, err
+	}
+	yd, err := types.DurationFromProto(y)
+	if err != nil {
+		return nil, err
+	}
+	if yd > xd {
+		return y, nil
+	}
+	return x, nil
+}
+
+// acquireDatum blocks until the limiter admits another datum, and returns how
+// long the datum spent waiting in the queue.
+func acquireDatum(limiter limit.ConcurrencyLimiter) time.Duration {
+	start := time.Now()
+	limiter.Acquire()
+	return time.Since(start)
+}
+
 // mergeStats merges y into x
 func mergeStats(x, y *DatumStats) error {
 	if yps := y.ProcessStats; yps != nil {
@@ -94,10 +129,19 @@ func mergeStats(x, y *DatumStats) error {
 		xps.UploadBytes += yps.UploadBytes
 	}
 
+	var err error
+	if x.QueueWaitTime, err = plusDuration(x.QueueWaitTime, y.QueueWaitTime); err != nil {
+		return err
+	}
+	if x.MaxQueueWaitTime, err = maxDuration(x.MaxQueueWaitTime, y.MaxQueueWaitTime); err != nil {
+		return err
+	}
+
 	x.DatumsProcessed += y.DatumsProcessed
 	x.DatumsSkipped += y.DatumsSkipped
 	x.DatumsFailed += y.DatumsFailed
 	x.DatumsRecovered += y.DatumsRecovered
+	x.DatumsExcluded += y.DatumsExcluded
 	if x.FailedDatumID == "" {
 		x.FailedDatumID = y.FailedDatumID
 	}
@@ -116,9 +160,19 @@ func Worker(driver driver.Driver, logger logs.TaggedLogger, subtask *work.Task,
 			err = errors.Unwrap(err)
 		}
 	}()
+	// Dispatch on the task data's type, rather than trying to deserialize it
+	// as each type in turn, which could succeed for the wrong type
+	name, err := types.AnyMessageName(subtask.Data)
+	if err != nil {
+		return errors.Wrap(err, "worker task format unrecognized")
+	}
+	switch name {
 	// Handle 'process datum' tasks
-	datumData, err := deserializeDatumData(subtask.Data)
-	if err == nil {
+	case proto.MessageName(&DatumData{}):
+		datumData, err := deserializeDatumData(subtask.Data)
+		if err != nil {
+			return err
+		}
 		return status.withJob(datumData.JobID, func() error {
 			logger = logger.WithJob(datumData.JobID)
 			if err := logger.LogStep("datum task", func() error {
@@ -130,11 +184,13 @@ func Worker(driver driver.Driver, logger logs.TaggedLogger, subtask *work.Task,
 			subtask.Data, err = serializeDatumData(datumData)
 			return err
 		})
-	}
 
 	// Handle 'merge hashtrees' tasks
-	mergeData, err := deserializeMergeData(subtask.Data)
-	if err == nil {
+	case proto.MessageName(&MergeData{}):
+		mergeData, err := deserializeMergeData(subtask.Data)
+		if err != nil {
+			return err
+		}
 		return status.withJob(mergeData.JobID, func() error {
 			logger = logger.WithJob(mergeData.JobID)
 			if err := logger.LogStep("merge task", func() error {
@@ -143,12 +199,16 @@ func Worker(driver driver.Driver, logger logs.TaggedLogger, subtask *work.Task,
 				return err
 			}
 
-			subtask.Data, err = serializeMergeData(mergeData)
+			if mergeData.Version == 0 {
+				subtask.Data, err = serializeLegacyMergeData(mergeData)
+			} else {
+				subtask.Data, err = serializeMergeData(mergeData)
+			}
 			return err
 		})
 	}
 
-	return errors.New("worker task format unrecognized")
+	return errors.Errorf("worker task format unrecognized: %q", name)
 }
 
 func forEachDatum(driver driver.Driver, object *pfs.Object, cb func(int64, []*common.Input) error) (retErr error) {
@@ -197,22 +257,38 @@ func uploadChunk(
 	subtaskCache *hashtree.MergeCache,
 	chunkCache *hashtree.MergeCache,
 	tag string,
+) error {
+	return uploadChunkKeys(driver, logger, subtaskCache, chunkCache, tag, subtaskCache.Keys())
+}
+
+// uploadChunkKeys is like uploadChunk, but only merges the hashtrees with the
+// given ids into the chunk
+func uploadChunkKeys(
+	driver driver.Driver,
+	logger logs.TaggedLogger,
+	subtaskCache *hashtree.MergeCache,
+	chunkCache *hashtree.MergeCache,
+	tag string,
+	ids []string,
 ) error {
 	return logger.LogStep("uploading hashtree chunk", func() error {
-		// Merge the datums for this job into a chunk
+		// Merge the datums for this job into a chunk, skipping any that are
+		// evicted before they're merged
 		buf := &bytes.Buffer{}
-		if err := subtaskCache.Merge(hashtree.NewWriter(buf), nil, nil); err != nil {
+		if err := subtaskCache.MergeKeys(hashtree.NewWriter(buf), nil, nil, ids, func(string) (io.ReadCloser, error) {
+			return nil, nil
+		}); err != nil {
 			return err
 		}
 
-		logger.Logf("merged hashtree cache into buffer, len: %d, tag: %s", buf.Len(), tag)
+		logger.Debugf("merged hashtree cache into buffer, len: %d, tag: %s", buf.Len(), tag)
 
 		if err := chunkCache.Put(tag, bytes.NewBuffer(buf.Bytes())); err != nil {
 			return err
 		}
 
 		// Upload the hashtree for this subtask to the given tag
-		putObjectWriter, err := driver.PachClient().PutObjectAsync([]*pfs.Tag{client.NewTag(tag)})
+		putObjectWriter, err := driver.ObjectStorage().PutObjectAsync([]*pfs.Tag{client.NewTag(tag)})
 		if err != nil {
 			return err
 		}
@@ -225,19 +301,95 @@ func uploadChunk(
 	})
 }
 
-func checkS3Gateway(driver driver.Driver, logger logs.TaggedLogger) error {
-	return backoff.RetryNotify(func() error {
-		endpoint := fmt.Sprintf("http://%s:%s/",
-			ppsutil.SidecarS3GatewayService(logger.JobID()),
-			os.Getenv("S3GATEWAY_PORT"),
-		)
+// Environment variables that may be set (e.g. via the pipeline's
+// 'transform.env') to tune how workers probe the s3 gateway sidecar before
+// processing datums.
+const (
+	s3GatewayProbeTimeoutEnv = "S3GATEWAY_PROBE_TIMEOUT"
+	s3GatewayProbePathEnv    = "S3GATEWAY_PROBE_PATH"
+	s3GatewayProbeMethodEnv  = "S3GATEWAY_PROBE_METHOD"
+
+	defaultS3GatewayProbeTimeout = 5 * time.Second
+	defaultS3GatewayProbePath    = "/"
+	defaultS3GatewayProbeMethod  = http.MethodGet
+)
 
-		_, err := (&http.Client{Timeout: 5 * time.Second}).Get(endpoint)
-		logger.Logf("checking s3 gateway service for job %q: %v", logger.JobID(), err)
+// s3GatewayProbe describes the request used to check that the s3 gateway
+// sidecar for a job is reachable.
+type s3GatewayProbe struct {
+	Timeout time.Duration
+	Path    string
+	Method  string
+}
+
+// s3GatewayProbeFromEnv constructs an s3GatewayProbe using the defaults,
+// overridden by any of the s3GatewayProbe*Env variables that are set.
+func s3GatewayProbeFromEnv() (*s3GatewayProbe, error) {
+	probe := &s3GatewayProbe{
+		Timeout: defaultS3GatewayProbeTimeout,
+		Path:    defaultS3GatewayProbePath,
+		Method:  defaultS3GatewayProbeMethod,
+	}
+	if timeout, ok := os.LookupEnv(s3GatewayProbeTimeoutEnv); ok && timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse %s", s3GatewayProbeTimeoutEnv)
+		}
+		if d <= 0 {
+			return nil, errors.Errorf("%s must be positive, got %v", s3GatewayProbeTimeoutEnv, d)
+		}
+		probe.Timeout = d
+	}
+	if probePath, ok := os.LookupEnv(s3GatewayProbePathEnv); ok && probePath != "" {
+		probe.Path = "/" + strings.TrimPrefix(probePath, "/")
+	}
+	if method, ok := os.LookupEnv(s3GatewayProbeMethodEnv); ok && method != "" {
+		probe.Method = strings.ToUpper(method)
+	}
+	return probe, nil
+}
+
+// do makes a single probe request against the s3 gateway at the given host
+// (of the form 'host:port').
+func (p *s3GatewayProbe) do(host string) error {
+	req, err := http.NewRequest(p.Method, fmt.Sprintf("http://%s%s", host, p.Path), nil)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	resp, err := (&http.Client{Timeout: p.Timeout}).Do(req)
+	if err != nil {
 		return err
-	}, backoff.New60sBackOff(), func(err error, d time.Duration) error {
-		logger.Logf("worker could not connect to s3 gateway for %q: %v", logger.JobID(), err)
-		return nil
+	}
+	return resp.Body.Close()
+}
+
+func checkS3Gateway(driver driver.Driver, logger logs.TaggedLogger, status *Status) error {
+	probe, err := s3GatewayProbeFromEnv()
+	if err != nil {
+		return err
+	}
+	host := fmt.Sprintf("%s:%s",
+		ppsutil.SidecarS3GatewayService(logger.JobID()),
+		os.Getenv("S3GATEWAY_PORT"),
+	)
+	return waitForS3Gateway(logger, status, probe, host)
+}
+
+// waitForS3Gateway probes the s3 gateway at 'host' until it's reachable. The
+// worker's status shows that it's waiting for the gateway in the meantime, as
+// it hasn't started processing datums.
+func waitForS3Gateway(logger logs.TaggedLogger, status *Status, probe *s3GatewayProbe, host string) error {
+	return status.withS3GatewayWait(func() error {
+		// Every worker in the pipeline checks the s3 gateway at once, so their
+		// retries are jittered
+		return backoff.RetryNotify(func() error {
+			err := probe.do(host)
+			logger.Logf("checking s3 gateway service for job %q: %v", logger.JobID(), err)
+			return err
+		}, backoff.NewDecorrelatedJitterBackOff(100*time.Millisecond, 2*time.Second).For(60*time.Second), func(err error, d time.Duration) error {
+			logger.Logf("worker could not connect to s3 gateway for %q: %v", logger.JobID(), err)
+			return nil
+		})
 	})
 	// TODO: `master` implementation fails the job here, we may need to do the same
 	// We would need to load the jobInfo first for this:
@@ -252,7 +404,7 @@ func checkS3Gateway(driver driver.Driver, logger logs.TaggedLogger) error {
 
 func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *DatumData, subtaskID string, status *Status) error {
 	if ppsutil.ContainsS3Inputs(driver.PipelineInfo().Input) || driver.PipelineInfo().S3Out {
-		if err := checkS3Gateway(driver, logger); err != nil {
+		if err := checkS3Gateway(driver, logger, status); err != nil {
 			return err
 		}
 	}
@@ -260,64 +412,60 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 	// TODO: check for existing tagged output files - continue with processing if any are missing
 	return driver.WithDatumCache(func(datumCache *hashtree.MergeCache, statsCache *hashtree.MergeCache) error {
 		logger.Logf("transform worker datum task: %v", data)
-		limiter := limit.New(int(driver.PipelineInfo().MaxQueueSize))
+		// The datums' loggers share a sampler, so that their logs are thinned
+		// together
+		sampler := logs.NewSampler(driver.PipelineInfo().LogSampling)
 
-		// statsMutex controls access to stats so that they can be safely merged
-		statsMutex := &sync.Mutex{}
-		recoveredDatums := []string{}
 		data.Stats = &DatumStats{
 			ProcessStats: &pps.ProcessStats{},
 		}
 
-		queueSize := int64(0)
-		// TODO: the status.GetStatus call may read the process stats without having a lock, it this ~ok?
-		if err := logger.LogStep("processing datums", func() error {
-			return status.withStats(data.Stats.ProcessStats, &queueSize, func() error {
-				ctx, cancel := context.WithCancel(driver.PachClient().Ctx())
-				defer cancel()
+		// Periodically flush the stats of the processed datums, until the final
+		// stats chunk is uploaded below. Masters of older task versions only
+		// merge the final chunk, so it has all of the datums for them.
+		var flusher *statsFlusher
+		stopFlushing := func() {}
+		if driver.PipelineInfo().EnableStats && data.Version >= partialStatsTaskVersion {
+			chunkStatsCache, err := driver.ChunkStatsCaches().GetOrCreateCache(logger.JobID())
+			if err != nil {
+				return err
+			}
+			flusher = newStatsFlusher(driver, logger, statsCache, chunkStatsCache, subtaskID)
+			ctx, cancel := context.WithCancel(driver.PachClient().Ctx())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				flusher.run(ctx)
+			}()
+			stopFlushing = func() {
+				cancel()
+				<-done
+			}
+		}
+		defer stopFlushing()
 
-				eg, ctx := errgroup.WithContext(ctx)
+		var recoveredDatums []string
+		if err := logger.LogStep("processing datums", func() error {
+			var err error
+			recoveredDatums, err = processDatums(driver, logger, data.Stats, status, func(cb func(int64, []*common.Input) error) error {
+				return forEachDatum(driver, data.Datums, cb)
+			}, func(ctx context.Context, index int64, inputs []*common.Input) (*DatumStats, []string, error) {
 				driver := driver.WithContext(ctx)
-				if err := forEachDatum(driver, data.Datums, func(index int64, inputs []*common.Input) error {
-					limiter.Acquire()
-					atomic.AddInt64(&queueSize, 1)
-					eg.Go(func() error {
-						defer limiter.Release()
-						defer atomic.AddInt64(&queueSize, -1)
-
-						// Construct a new logger here which will capture datum-specific
-						// logs for object storage if stats are enabled.
-						jobID := logger.JobID()
-						logger, err := logs.NewLogger(driver.PipelineInfo(), driver.PachClient())
-						if err != nil {
-							return err
-						}
-						logger = logger.WithJob(jobID).WithData(inputs)
-
-						// subStats is still valid even on an error, merge those in before proceeding
-						subStats, subRecovered, err := processDatum(driver, logger, index, inputs, data.OutputCommit, datumCache, statsCache, status)
-
-						statsMutex.Lock()
-						defer statsMutex.Unlock()
-						statsErr := mergeStats(data.Stats, subStats)
-						if err != nil {
-							return err
-						}
-						recoveredDatums = append(recoveredDatums, subRecovered...)
-						return statsErr
-					})
-					return nil
-				}); err != nil {
-					cancel()
-					eg.Wait()
-					return err
+				// Construct a new logger here which will capture datum-specific
+				// logs for object storage if stats are enabled.
+				jobID := logger.JobID()
+				logger, err := logs.NewLogger(driver.PipelineInfo(), driver.PachClient())
+				if err != nil {
+					return &DatumStats{}, nil, err
 				}
-
-				return eg.Wait()
+				logger = logger.WithJob(jobID).WithData(inputs).WithSampler(sampler)
+				return processDatum(driver, logger, index, inputs, data.OutputCommit, datumCache, statsCache, status)
 			})
+			return err
 		}); err != nil {
 			return err
 		}
+		stopFlushing()
 
 		if data.Stats.DatumsFailed == 0 && !driver.PipelineInfo().S3Out {
 			if len(recoveredDatums) > 0 {
@@ -348,7 +496,12 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 			}
 
 			chunkStatsTag := jobChunkStatsTag(logger.JobID(), subtaskID)
-			if err := uploadChunk(driver, logger, statsCache, chunkStatsCache, chunkStatsTag); err != nil {
+			ids := statsCache.Keys()
+			if flusher != nil {
+				ids = flusher.unflushed()
+				data.PartialStatsHashtrees = flusher.hashtrees
+			}
+			if err := uploadChunkKeys(driver, logger, statsCache, chunkStatsCache, chunkStatsTag, ids); err != nil {
 				return err
 			}
 			data.StatsHashtree = &HashtreeInfo{Address: os.Getenv(client.PPSWorkerIPEnv), Tag: chunkStatsTag}
@@ -358,6 +511,158 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 	})
 }
 
+// processDatums processes the datums iterated by 'forEach' concurrently (up
+// to the pipeline's max queue size at a time) with 'process', which is called
+// with a context that's canceled if the datums are. It merges the datums'
+// stats into 'stats', and returns the tags of the datums that were recovered.
+// A datum that fails is recorded in 'stats' as a failed datum, and the other
+// datums keep being processed, unless the pipeline's datum failure policy is
+// CANCEL_ON_FAILURE. Any other error processing a datum cancels the other
+// datums and is returned, so that the subtask is retried, unless the policy is
+// COLLECT_ALL, in which case it's recorded as a failed datum instead.
+// Datums matching the pipeline's datum exclude glob aren't processed at all,
+// and are only counted in 'stats'. An event is sent to the datum callback of
+// 'status' as each datum finishes (excluded datums are reported as skipped).
+func processDatums(
+	driver driver.Driver,
+	logger logs.TaggedLogger,
+	stats *DatumStats,
+	status *Status,
+	forEach func(func(int64, []*common.Input) error) error,
+	process func(context.Context, int64, []*common.Input) (*DatumStats, []string, error),
+) ([]string, error) {
+	excluded, err := datumExcluder(driver.PipelineInfo().DatumExclude)
+	if err != nil {
+		return nil, err
+	}
+	limiter := limit.New(int(driver.PipelineInfo().MaxQueueSize))
+	policy := driver.PipelineInfo().DatumFailurePolicy
+	// statsMutex controls access to stats so that they can be safely merged
+	statsMutex := &sync.Mutex{}
+	recoveredDatums := []string{}
+	queueSize := int64(0)
+	canceled := int32(0)
+	// TODO: the status.GetStatus call may read the process stats without having a lock, it this ~ok?
+	err = status.withStats(stats.ProcessStats, &queueSize, func() error {
+		ctx, cancel := context.WithCancel(driver.PachClient().Ctx())
+		defer cancel()
+
+		eg, ctx := errgroup.WithContext(ctx)
+		if err := forEach(func(index int64, inputs []*common.Input) error {
+			if excluded(inputs) {
+				logger.Logf("excluding datum %s, as it matches %q", common.DatumID(inputs), driver.PipelineInfo().DatumExclude)
+				status.datumFinished(&DatumEvent{
+					JobID:   logger.JobID(),
+					DatumID: common.DatumID(inputs),
+					State:   pps.DatumState_SKIPPED,
+				})
+				statsMutex.Lock()
+				defer statsMutex.Unlock()
+				stats.DatumsExcluded++
+				return nil
+			}
+			queueWait := acquireDatum(limiter)
+			// Don't start any more datums once one has failed and canceled them
+			if atomic.LoadInt32(&canceled) != 0 {
+				limiter.Release()
+				return errDatumFailed
+			}
+			atomic.AddInt64(&queueSize, 1)
+			eg.Go(func() error {
+				defer limiter.Release()
+				defer atomic.AddInt64(&queueSize, -1)
+
+				// subStats is still valid even on an error, merge those in before proceeding
+				start := time.Now()
+				subStats, subRecovered, err := process(ctx, index, inputs)
+				subStats.QueueWaitTime = types.DurationProto(queueWait)
+				subStats.MaxQueueWaitTime = subStats.QueueWaitTime
+				// Errors caused by the datums being canceled aren't the datum's fault
+				if err != nil && policy == pps.DatumFailurePolicy_COLLECT_ALL && ctx.Err() == nil {
+					logger.Errf("failed to process datum %s, continuing with the other datums: %v", common.DatumID(inputs), err)
+					subStats.DatumsFailed++
+					if subStats.FailedDatumID == "" {
+						subStats.FailedDatumID = common.DatumID(inputs)
+					}
+					err = nil
+				}
+				// Datums that errored will be retried along with the rest of the
+				// subtask, so they haven't finished
+				if err == nil && !status.datumFinished(&DatumEvent{
+					JobID:    logger.JobID(),
+					DatumID:  common.DatumID(inputs),
+					State:    datumState(subStats),
+					Duration: time.Since(start),
+				}) {
+					logger.Logf("dropped the event for datum %s, as the datum callback is behind", common.DatumID(inputs))
+				}
+
+				statsMutex.Lock()
+				defer statsMutex.Unlock()
+				statsErr := mergeStats(stats, subStats)
+				if err != nil {
+					return err
+				}
+				recoveredDatums = append(recoveredDatums, subRecovered...)
+				if statsErr == nil && subStats.DatumsFailed > 0 && policy == pps.DatumFailurePolicy_CANCEL_ON_FAILURE {
+					logger.Logf("datum %s failed, canceling the other datums", common.DatumID(inputs))
+					atomic.StoreInt32(&canceled, 1)
+					return errDatumFailed
+				}
+				return statsErr
+			})
+			return nil
+		}); err != nil {
+			cancel()
+			if egErr := eg.Wait(); err == errDatumFailed {
+				err = egErr
+			}
+			return err
+		}
+
+		return eg.Wait()
+	})
+	// The failed datum has already been recorded in 'stats'
+	if err == errDatumFailed {
+		err = nil
+	}
+	return recoveredDatums, err
+}
+
+// datumExcluder returns a function that reports whether a datum has an input
+// file matching the glob 'pattern'. No datums are excluded if it's empty.
+func datumExcluder(pattern string) (func([]*common.Input) bool, error) {
+	if pattern == "" {
+		return func([]*common.Input) bool { return false }, nil
+	}
+	g, err := globlib.Compile(pattern, '/')
+	if err != nil {
+		return nil, errors.Wrapf(err, "malformed datum exclude glob %q", pattern)
+	}
+	return func(inputs []*common.Input) bool {
+		for _, input := range inputs {
+			if input.FileInfo != nil && g.Match(input.FileInfo.File.Path) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// datumState returns the outcome of processing a datum, given its stats
+func datumState(stats *DatumStats) pps.DatumState {
+	switch {
+	case stats.DatumsFailed > 0:
+		return pps.DatumState_FAILED
+	case stats.DatumsRecovered > 0:
+		return pps.DatumState_RECOVERED
+	case stats.DatumsSkipped > 0:
+		return pps.DatumState_SKIPPED
+	default:
+		return pps.DatumState_SUCCESS
+	}
+}
+
 func processDatum(
 	driver driver.Driver,
 	logger logs.TaggedLogger,
@@ -410,7 +715,7 @@ func processDatum(
 		if err != nil {
 			return stats, recoveredDatumTags, err
 		}
-		objectInfo, err := driver.PachClient().InspectObject(object.Hash)
+		objectInfo, err := driver.InspectObject(object.Hash)
 		if err != nil {
 			return stats, recoveredDatumTags, err
 		}
@@ -427,12 +732,14 @@ func processDatum(
 		}()
 	}
 
+	// Datum retries are jittered, so that datums failing on a shared dependency
+	// don't all retry at once
 	var failures int64
 	if err := backoff.RetryUntilCancel(driver.PachClient().Ctx(), func() error {
 		var err error
 
-		// WithData will download the inputs for this datum
-		stats.ProcessStats, err = driver.WithData(inputs, inputTree, logger, func(dir string, processStats *pps.ProcessStats) error {
+		// withData will download the inputs for this datum
+		stats.ProcessStats, err = withData(driver, inputs, inputTree, logger, func(dir string, processStats *pps.ProcessStats) error {
 
 			// WithActiveData acquires a mutex so that we don't run this section concurrently
 			if err := driver.WithActiveData(inputs, dir, func() error {
@@ -462,7 +769,7 @@ func processDatum(
 				return nil // S3Out pipelines do not store data in worker hashtrees
 			}
 
-			hashtreeBytes, err := driver.UploadOutput(dir, tag, logger, inputs, processStats, outputTree)
+			hashtreeBytes, err := uploadOutput(driver, dir, tag, logger, inputs, processStats, outputTree)
 			if err != nil {
 				return err
 			}
@@ -471,7 +778,7 @@ func processDatum(
 			return datumCache.Put(uuid.NewWithoutDashes(), bytes.NewReader(hashtreeBytes))
 		})
 		return err
-	}, &backoff.ZeroBackOff{}, func(err error, d time.Duration) error {
+	}, backoff.NewDecorrelatedJitterBackOff(100*time.Millisecond, 2*time.Second), func(err error, d time.Duration) error {
 		failures++
 		if failures >= driver.PipelineInfo().DatumTries {
 			logger.Logf("failed to process datum with error: %+v", err)
@@ -480,7 +787,7 @@ func processDatum(
 				if err != nil {
 					logger.Errf("could not put error object: %s\n", err)
 				} else {
-					objectInfo, err := driver.PachClient().InspectObject(object.Hash)
+					objectInfo, err := driver.InspectObject(object.Hash)
 					if err != nil {
 						return err
 					}
@@ -513,38 +820,184 @@ func processDatum(
 	return stats, recoveredDatumTags, nil
 }
 
+// phaseTimeout cancels the context of a phase of processing a datum (such as
+// downloading its inputs) if the phase takes longer than a timeout, so that a
+// stuck phase fails the datum instead of hanging it.
+type phaseTimeout struct {
+	phase   string
+	timeout time.Duration
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	expired int32
+}
+
+// startPhaseTimeout returns a driver whose context is canceled if 'phase'
+// isn't stopped within 'rawTimeout'. A nil timeout never expires.
+func startPhaseTimeout(d driver.Driver, phase string, rawTimeout *types.Duration) (driver.Driver, *phaseTimeout, error) {
+	ctx, cancel := context.WithCancel(d.PachClient().Ctx())
+	t := &phaseTimeout{phase: phase, cancel: cancel}
+	if rawTimeout != nil {
+		timeout, err := types.DurationFromProto(rawTimeout)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		t.timeout = timeout
+		t.timer = time.AfterFunc(timeout, func() {
+			atomic.StoreInt32(&t.expired, 1)
+			cancel()
+		})
+	}
+	return d.WithContext(ctx), t, nil
+}
+
+// stop disarms the timeout, without canceling the phase's context.
+func (t *phaseTimeout) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// done stops the timeout and cancels the phase's context. It returns 'err',
+// wrapped to report which phase timed out if the timeout expired.
+func (t *phaseTimeout) done(err error) error {
+	t.stop()
+	t.cancel()
+	if err != nil && atomic.LoadInt32(&t.expired) == 1 {
+		return errors.Wrapf(err, "timed out %s after %v", t.phase, t.timeout)
+	}
+	return err
+}
+
+// withData downloads the inputs of a datum and calls 'cb' with them, like
+// driver.WithData, but fails if the download takes longer than the pipeline's
+// download timeout. The timeout doesn't apply to 'cb'.
+func withData(
+	d driver.Driver,
+	inputs []*common.Input,
+	inputTree *hashtree.Ordered,
+	logger logs.TaggedLogger,
+	cb func(string, *pps.ProcessStats) error,
+) (*pps.ProcessStats, error) {
+	// The download's context isn't canceled until WithData returns, as lazy
+	// inputs are still being downloaded while 'cb' runs
+	downloadDriver, download, err := startPhaseTimeout(d, "downloading inputs", d.PipelineInfo().DownloadTimeout)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := downloadDriver.WithData(inputs, inputTree, logger, func(dir string, processStats *pps.ProcessStats) error {
+		download.stop()
+		return cb(dir, processStats)
+	})
+	return stats, download.done(err)
+}
+
+// uploadOutput uploads the outputs of a datum, like driver.UploadOutput, but
+// fails if the upload takes longer than the pipeline's upload timeout.
+func uploadOutput(
+	d driver.Driver,
+	dir string,
+	tag string,
+	logger logs.TaggedLogger,
+	inputs []*common.Input,
+	stats *pps.ProcessStats,
+	outputTree *hashtree.Ordered,
+) ([]byte, error) {
+	uploadDriver, upload, err := startPhaseTimeout(d, "uploading outputs", d.PipelineInfo().UploadTimeout)
+	if err != nil {
+		return nil, err
+	}
+	hashtreeBytes, err := uploadDriver.UploadOutput(dir, tag, logger, inputs, stats, outputTree)
+	return hashtreeBytes, upload.done(err)
+}
+
 func userCodeEnv(
 	driver driver.Driver,
 	jobID string,
 	outputCommit *pfs.Commit,
 	inputs []*common.Input,
 ) []string {
-	result := os.Environ()
+	// The env vars set by Pachyderm take precedence over the ones in the
+	// pipeline's transform, which in turn take precedence over the ones
+	// inherited from the worker
+	var pachEnv []string
 	for _, input := range inputs {
-		result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(driver.InputDir(), input.Name, input.FileInfo.File.Path)))
-		result = append(result, fmt.Sprintf("%s_COMMIT=%s", input.Name, input.FileInfo.File.Commit.ID))
+		pachEnv = append(pachEnv, fmt.Sprintf("%s=%s", input.Name, filepath.Join(driver.InputDir(), input.Name, input.FileInfo.File.Path)))
+		pachEnv = append(pachEnv, fmt.Sprintf("%s_COMMIT=%s", input.Name, input.FileInfo.File.Commit.ID))
 	}
-	result = append(result, fmt.Sprintf("%s=%s", client.JobIDEnv, jobID))
-	result = append(result, fmt.Sprintf("%s=%s", client.OutputCommitIDEnv, outputCommit.ID))
+	pachEnv = append(pachEnv, fmt.Sprintf("%s=%s", client.JobIDEnv, jobID))
+	pachEnv = append(pachEnv, fmt.Sprintf("%s=%s", client.OutputCommitIDEnv, outputCommit.ID))
 	if ppsutil.ContainsS3Inputs(driver.PipelineInfo().Input) || driver.PipelineInfo().S3Out {
-		// TODO(msteffen) Instead of reading S3GATEWAY_PORT directly, worker/main.go
-		// should pass its ServiceEnv to worker.NewAPIServer, which should store it
-		// in 'a'. However, requiring worker.APIServer to have a ServiceEnv would
-		// break the worker.APIServer initialization in newTestAPIServer (in
-		// worker/worker_test.go), which uses mock clients but has no good way to
-		// mock a ServiceEnv. Once we can create mock ServiceEnvs, we should store
-		// a ServiceEnv in worker.APIServer, rewrite newTestAPIServer and
-		// NewAPIServer, and then change this code.
-		result = append(
-			result,
-			fmt.Sprintf("S3_ENDPOINT=http://%s.%s:%s",
-				ppsutil.SidecarS3GatewayService(jobID),
-				driver.Namespace(),
-				os.Getenv("S3GATEWAY_PORT"),
-			),
-		)
-	}
-	return result
+		pachEnv = append(pachEnv, fmt.Sprintf("%s=%s", client.S3EndpointEnv, s3Endpoint(driver, jobID)))
+	}
+	pachNames := make(map[string]bool)
+	for _, kv := range pachEnv {
+		pachNames[envName(kv)] = true
+	}
+	// Pipelines created before transform.env was validated may still set
+	// reserved vars, which are ignored
+	reserved := ppsutil.ReservedUserCodeEnv(driver.PipelineInfo().Input, driver.PipelineInfo().S3Out)
+	for name := range pachNames {
+		reserved[name] = true
+	}
+
+	var specEnv map[string]string
+	if driver.PipelineInfo().Transform != nil {
+		specEnv = driver.PipelineInfo().Transform.Env
+	}
+	var result []string
+	for _, kv := range os.Environ() {
+		if _, ok := specEnv[envName(kv)]; !ok && !pachNames[envName(kv)] {
+			result = append(result, kv)
+		}
+	}
+	var names []string
+	for name := range specEnv {
+		if !reserved[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result = append(result, fmt.Sprintf("%s=%s", name, specEnv[name]))
+	}
+	return append(result, pachEnv...)
+}
+
+// s3Endpoint returns the endpoint of the S3 gateway that serves the S3 inputs
+// and outputs of the job 'jobID' to its user code. By default this is the
+// job's S3 gateway sidecar service over http, but the scheme, host and port
+// can each be overridden by the pipeline's S3Endpoint.
+func s3Endpoint(driver driver.Driver, jobID string) string {
+	scheme := "http"
+	host := fmt.Sprintf("%s.%s", ppsutil.SidecarS3GatewayService(jobID), driver.Namespace())
+	// TODO(msteffen) Instead of reading S3GATEWAY_PORT directly, worker/main.go
+	// should pass its ServiceEnv to worker.NewAPIServer, which should store it
+	// in 'a'. However, requiring worker.APIServer to have a ServiceEnv would
+	// break the worker.APIServer initialization in newTestAPIServer (in
+	// worker/worker_test.go), which uses mock clients but has no good way to
+	// mock a ServiceEnv. Once we can create mock ServiceEnvs, we should store
+	// a ServiceEnv in worker.APIServer, rewrite newTestAPIServer and
+	// NewAPIServer, and then change this code.
+	port := os.Getenv("S3GATEWAY_PORT")
+	if endpoint := driver.PipelineInfo().S3Endpoint; endpoint != nil {
+		if endpoint.Scheme != "" {
+			scheme = endpoint.Scheme
+		}
+		if endpoint.Host != "" {
+			host = endpoint.Host
+		}
+		if endpoint.Port != 0 {
+			port = fmt.Sprint(endpoint.Port)
+		}
+	}
+	return fmt.Sprintf("%s://%s:%s", scheme, host, port)
+}
+
+// envName returns the name of the env var 'kv', which is of the form
+// NAME=VALUE
+func envName(kv string) string {
+	return strings.SplitN(kv, "=", 2)[0]
 }
 
 func writeStats(
@@ -569,7 +1022,7 @@ func writeStats(
 		logger.Errf("could not put stats object: %s\n", err)
 		return err
 	}
-	objectInfo, err := driver.PachClient().InspectObject(object.Hash)
+	objectInfo, err := driver.InspectObject(object.Hash)
 	if err != nil {
 		return err
 	}
@@ -584,7 +1037,7 @@ func writeStats(
 		return err
 	}
 	if object != nil {
-		objectInfo, err := driver.PachClient().InspectObject(object.Hash)
+		objectInfo, err := driver.InspectObject(object.Hash)
 		if err != nil {
 			return err
 		}
@@ -627,15 +1080,26 @@ func writeStats(
 	return datumStatsCache.Put(tag, bytes.NewReader(buf.Bytes()))
 }
 
-func fetchChunkFromWorker(driver driver.Driver, logger logs.TaggedLogger, address string, tag string, shard int64, stats bool) (io.ReadCloser, error) {
-	// TODO: cache cross-worker clients at the driver level
+// fetchChunkFromWorker fetches the hashtree chunk 'tag', filtered to 'shards',
+// from the worker at 'address'. If 'offset' or 'length' are set, only that
+// range of the filtered chunk is fetched (a 'length' of 0 means to the end).
+func fetchChunkFromWorker(driver driver.Driver, logger logs.TaggedLogger, address string, tag string, shards []int64, stats bool, offset, length int64) (io.ReadCloser, error) {
+	// Connections to other workers are pooled by NewClient, so this doesn't
+	// dial the worker for each chunk
 	client, err := server.NewClient(address)
 	if err != nil {
 		return nil, err
 	}
 
 	ctx, cancel := context.WithCancel(driver.PachClient().Ctx())
-	getChunkClient, err := client.GetChunk(ctx, &server.GetChunkRequest{JobID: logger.JobID(), Tag: tag, Shard: shard, Stats: stats})
+	getChunkClient, err := client.GetChunk(ctx, &server.GetChunkRequest{
+		JobID:  logger.JobID(),
+		Tag:    tag,
+		Shards: shards,
+		Stats:  stats,
+		Offset: offset,
+		Length: length,
+	})
 	if err != nil {
 		cancel()
 		return nil, grpcutil.ScrubGRPC(err)
@@ -644,22 +1108,62 @@ func fetchChunkFromWorker(driver driver.Driver, logger logs.TaggedLogger, addres
 	return grpcutil.NewStreamingBytesReader(getChunkClient, cancel), nil
 }
 
-func fetchChunk(driver driver.Driver, logger logs.TaggedLogger, info *HashtreeInfo, shard int64, stats bool) (io.ReadCloser, error) {
+func fetchChunk(driver driver.Driver, logger logs.TaggedLogger, info *HashtreeInfo, shards []int64, stats bool) (io.ReadCloser, error) {
 	if info.Address != "" {
-		reader, err := fetchChunkFromWorker(driver, logger, info.Address, info.Tag, shard, stats)
+		reader, err := fetchChunkFromWorker(driver, logger, info.Address, info.Tag, shards, stats, 0, 0)
 		if err == nil {
 			return reader, nil
 		}
 		logger.Logf("error when fetching cached chunk (%s) from worker (%s) - fetching from object store instead: %v", info.Tag, info.Address, err)
 	}
 
-	reader, err := driver.PachClient().GetTagReader(info.Tag)
-	if err != nil {
+	return getTagReader(driver, logger, info.Tag)
+}
+
+// getTagReader returns a reader for the object with the given tag, retrying
+// transient object storage errors for a bounded time. Tags that don't exist
+// aren't retried.
+func getTagReader(driver driver.Driver, logger logs.TaggedLogger, tag string) (io.ReadCloser, error) {
+	var reader io.ReadCloser
+	if err := backoff.RetryNotify(func() error {
+		r, err := driver.ObjectStorage().GetTagReader(tag)
+		if err != nil {
+			return err
+		}
+		// The object may be streamed, in which case errors getting it are only
+		// returned once it's read, so it's read from before it's returned
+		br := bufio.NewReader(r)
+		if _, err := br.Peek(1); err != nil && !errors.Is(err, io.EOF) {
+			r.Close()
+			return err
+		}
+		reader = &bufferedReadCloser{Reader: br, Closer: r}
+		return nil
+	}, backoff.New10sBackOff(), func(err error, d time.Duration) error {
+		if isNotFound(err) {
+			return err
+		}
+		logger.Logf("error getting tag %s from object storage, retrying in %v: %v", tag, d, err)
+		return nil
+	}); err != nil {
 		return nil, errors.EnsureStack(err)
 	}
 	return reader, nil
 }
 
+// isNotFound returns true if 'err' is due to an object or tag not existing in
+// object storage, rather than object storage being unavailable.
+func isNotFound(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errutil.IsNotFoundError(err)
+}
+
+// bufferedReadCloser reads from a buffered reader of the stream closed by
+// Closer.
+type bufferedReadCloser struct {
+	*bufio.Reader
+	io.Closer
+}
+
 func handleMergeTask(driver driver.Driver, logger logs.TaggedLogger, data *MergeData) (retErr error) {
 	var cache *hashtree.MergeCache
 	var err error
@@ -672,17 +1176,27 @@ func handleMergeTask(driver driver.Driver, logger logs.TaggedLogger, data *Merge
 		return err
 	}
 
-	var parentReader io.ReadCloser
+	var shards []int64
+	for _, mergeShard := range data.Shards {
+		shards = append(shards, mergeShard.Shard)
+	}
+	if len(shards) == 0 {
+		return errors.New("merge task has no shards")
+	}
+
+	parentReaders := make([]io.ReadCloser, len(data.Shards))
 	defer func() {
-		if parentReader != nil {
-			if err := parentReader.Close(); retErr == nil {
-				retErr = err
+		for _, parentReader := range parentReaders {
+			if parentReader != nil {
+				if err := parentReader.Close(); retErr == nil {
+					retErr = err
+				}
 			}
 		}
 	}()
 
 	if err := logger.LogStep("downloading hashtree chunks", func() error {
-		eg, _ := errgroup.WithContext(driver.PachClient().Ctx())
+		var eg errgroup.Group
 		limiter := limit.New(20) // TODO: base this off of configuration
 
 		cachedIDs := cache.Keys()
@@ -696,7 +1210,7 @@ func handleMergeTask(driver driver.Driver, logger logs.TaggedLogger, data *Merge
 				hashtreeInfo := hashtreeInfo
 				eg.Go(func() (retErr error) {
 					defer limiter.Release()
-					reader, err := fetchChunk(driver, logger, hashtreeInfo, data.Shard, data.Stats)
+					reader, err := fetchChunk(driver, logger, hashtreeInfo, shards, data.Stats)
 					if err != nil {
 						return err
 					}
@@ -707,7 +1221,11 @@ func handleMergeTask(driver driver.Driver, logger logs.TaggedLogger, data *Merge
 						}
 					}()
 
-					// TODO: this only works if it is read into a buffer first?
+					// The chunk is read before it's put in the cache, so that the cache
+					// isn't locked while the chunk is fetched
+					if driver.SpillChunksToDisk() {
+						return errors.EnsureStack(cache.PutFromStream(hashtreeInfo.Tag, reader))
+					}
 					buf := &bytes.Buffer{}
 					if _, err := io.Copy(buf, reader); err != nil {
 						return errors.EnsureStack(err)
@@ -725,12 +1243,15 @@ func handleMergeTask(driver driver.Driver, logger logs.TaggedLogger, data *Merge
 			}
 		}
 
-		if data.Parent != nil {
-			eg.Go(func() error {
-				var err error
-				parentReader, err = driver.PachClient().GetObjectReader(data.Parent.Hash)
-				return errors.EnsureStack(err)
-			})
+		for i, mergeShard := range data.Shards {
+			if mergeShard.Parent != nil {
+				i, mergeShard := i, mergeShard
+				eg.Go(func() error {
+					var err error
+					parentReaders[i], err = driver.ObjectStorage().GetObjectReader(mergeShard.Parent.Hash)
+					return errors.EnsureStack(err)
+				})
+			}
 		}
 
 		return errors.EnsureStack(eg.Wait())
@@ -739,64 +1260,187 @@ func handleMergeTask(driver driver.Driver, logger logs.TaggedLogger, data *Merge
 	}
 
 	return logger.LogStep("merging hashtree chunks", func() error {
-		tree, size, err := merge(driver, parentReader, cache, data.Shard)
+		// If the cache is bounded, some of the chunks may have been evicted since
+		// they were downloaded, in which case they're fetched again
+		infos := make(map[string]*HashtreeInfo)
+		var tags []string
+		for _, hashtreeInfo := range data.Hashtrees {
+			if _, ok := infos[hashtreeInfo.Tag]; !ok {
+				tags = append(tags, hashtreeInfo.Tag)
+			}
+			infos[hashtreeInfo.Tag] = hashtreeInfo
+		}
+		fetch := func(tag string) (io.ReadCloser, error) {
+			return fetchChunk(driver, logger, infos[tag], shards, data.Stats)
+		}
+		var parents []io.Reader
+		for _, parentReader := range parentReaders {
+			if parentReader != nil {
+				parents = append(parents, parentReader)
+			}
+		}
+		return merge(driver, logger, parents, cache, tags, fetch, data.Shards)
+	})
+}
+
+// mergedTreeTag returns the tag of the hashtree that's merged for 'shard' from
+// the hashtrees with the given tags. It's deterministic given the merge's
+// inputs, so that a retried merge can find the trees of a previous attempt.
+func mergedTreeTag(jobID string, shard *MergeShard, tags []string) string {
+	h := pfs.NewHash()
+	if shard.Parent != nil {
+		h.Write([]byte(shard.Parent.Hash))
+	}
+	tags = append([]string(nil), tags...)
+	sort.Strings(tags)
+	for _, tag := range tags {
+		h.Write([]byte("\x00" + tag))
+	}
+	return fmt.Sprintf("%s-merged-%d-%s", jobTagPrefix(jobID), shard.Shard, pfs.EncodeHash(h.Sum(nil))[:32])
+}
+
+// reuseMergedTree checks for a hashtree that a previous attempt at the merge
+// wrote for 'shard' (but may not have indexed), under the tag 'tag'. If there
+// is one, and it can be read back in full, its index is rewritten and it's set
+// as the shard's output. Otherwise, the shard must be merged again.
+func reuseMergedTree(d driver.Driver, logger logs.TaggedLogger, tag string, shard *MergeShard) (bool, error) {
+	tree, err := d.ObjectStorage().InspectTag(tag)
+	if err != nil {
+		if !isNotFound(err) {
+			logger.Logf("could not check for a previously merged tree of shard %d, merging it again: %v", shard.Shard, err)
+		}
+		return false, nil
+	}
+	indexF, err := ioutil.TempFile("", "hashtree-index-")
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	defer func() {
+		indexF.Close()
+		os.Remove(indexF.Name())
+	}()
+	// Reading the tree rebuilds its index, and checks that it isn't corrupt
+	size, err := func() (_ uint64, retErr error) {
+		r, err := d.ObjectStorage().GetObjectReader(tree.Hash)
 		if err != nil {
-			return err
+			return 0, err
+		}
+		defer func() {
+			if err := r.Close(); err != nil && retErr == nil {
+				retErr = errors.EnsureStack(err)
+			}
+		}()
+		w := hashtree.NewIndexedWriter(ioutil.Discard, indexF)
+		if err := w.Copy(hashtree.NewReader(r, nil)); err != nil {
+			return 0, err
 		}
+		return w.Size(), nil
+	}()
+	if err != nil {
+		logger.Logf("discarding the previously merged tree of shard %d, as it couldn't be read: %v", shard.Shard, err)
+		return false, nil
+	}
+	if _, err := indexF.Seek(0, io.SeekStart); err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	if err := writeIndex(d, tree, indexF); err != nil {
+		return false, err
+	}
+	logger.Logf("reusing the previously merged tree of shard %d", shard.Shard)
+	shard.Tree = tree
+	shard.TreeSize = size
+	return true, nil
+}
 
-		data.Tree = tree
-		data.TreeSize = size
+// merge merges the hashtrees in 'cache' (and the shards' parent hashtrees)
+// into a hashtree for each of 'shards', in one pass, and sets their outputs.
+// Each shard's tree is tagged before it's indexed, so that if the merge fails
+// after a tree has been written, a retry reuses it rather than merging it
+// again.
+func merge(d driver.Driver, logger logs.TaggedLogger, parents []io.Reader, cache *hashtree.MergeCache, tags []string, fetch func(string) (io.ReadCloser, error), shards []*MergeShard) (retErr error) {
+	treeTags := make(map[int64]string)
+	var unmerged []*MergeShard
+	for _, mergeShard := range shards {
+		treeTags[mergeShard.Shard] = mergedTreeTag(logger.JobID(), mergeShard, tags)
+		reused, err := reuseMergedTree(d, logger, treeTags[mergeShard.Shard], mergeShard)
+		if err != nil {
+			return err
+		}
+		if !reused {
+			unmerged = append(unmerged, mergeShard)
+		}
+	}
+	if len(unmerged) == 0 {
 		return nil
-	})
-}
+	}
+	shards = unmerged
 
-func merge(driver driver.Driver, parent io.Reader, cache *hashtree.MergeCache, shard int64) (*pfs.Object, uint64, error) {
-	var tree *pfs.Object
-	var size uint64
-	if err := func() (retErr error) {
-		objW, err := driver.PachClient().PutObjectAsync(nil)
+	objWs := make(map[int64]driver.ObjectWriter)
+	indexFs := make(map[int64]*os.File)
+	ws := make(map[int64]*hashtree.Writer)
+	defer func() {
+		// Only close the object writers if the merge failed, as otherwise they're
+		// closed below
+		if retErr != nil {
+			for _, objW := range objWs {
+				objW.Close()
+			}
+		}
+		for _, indexF := range indexFs {
+			indexF.Close()
+			os.Remove(indexF.Name())
+		}
+	}()
+	for _, mergeShard := range shards {
+		objW, err := d.ObjectStorage().PutObjectAsync([]*pfs.Tag{client.NewTag(treeTags[mergeShard.Shard])})
 		if err != nil {
 			return errors.EnsureStack(err)
 		}
-
-		w := hashtree.NewWriter(objW)
-		filter := hashtree.NewFilter(driver.NumShards(), shard)
-		err = cache.Merge(w, parent, filter)
-		size = w.Size()
+		objWs[mergeShard.Shard] = objW
+		// The index is streamed to a local file while the hashtree is written, as
+		// its location in object storage isn't known until the hashtree is closed
+		indexF, err := ioutil.TempFile("", "hashtree-index-")
 		if err != nil {
-			objW.Close()
 			return errors.EnsureStack(err)
 		}
+		indexFs[mergeShard.Shard] = indexF
+		shard := mergeShard.Shard
+		ws[shard] = hashtree.NewIndexedWriter(objW, indexF).WithProgress(func(p hashtree.Progress) {
+			logger.Logf("merged %d hashtree nodes (%d bytes) of shard %d", p.Nodes, p.Bytes, shard)
+		})
+	}
+
+	if err := cache.MergeShards(ws, d.NumShards(), parents, tags, fetch); err != nil {
+		return errors.EnsureStack(err)
+	}
+
+	for _, mergeShard := range shards {
+		objW, w := objWs[mergeShard.Shard], ws[mergeShard.Shard]
+		delete(objWs, mergeShard.Shard)
 		// Get object hash for hashtree
 		if err := objW.Close(); err != nil {
 			return errors.EnsureStack(err)
 		}
-		tree, err = objW.Object()
+		tree, err := objW.Object()
 		if err != nil {
 			return errors.EnsureStack(err)
 		}
-		// Get index and write it out
-		indexData, err := w.Index()
-		if err != nil {
+		// Write out the index streamed while merging
+		indexF := indexFs[mergeShard.Shard]
+		if _, err := indexF.Seek(0, io.SeekStart); err != nil {
 			return errors.EnsureStack(err)
 		}
-		return writeIndex(driver, tree, indexData)
-	}(); err != nil {
-		return nil, 0, err
+		if err := writeIndex(d, tree, indexF); err != nil {
+			return err
+		}
+		mergeShard.Tree = tree
+		mergeShard.TreeSize = w.Size()
 	}
-	return tree, size, nil
+	return nil
 }
 
-func writeIndex(driver driver.Driver, tree *pfs.Object, indexData []byte) (retErr error) {
-	info, err := driver.PachClient().InspectObject(tree.Hash)
-	if err != nil {
-		return errors.EnsureStack(err)
-	}
-	path, err := obj.BlockPathFromEnv(info.BlockRef.Block)
-	if err != nil {
-		return errors.EnsureStack(err)
-	}
-	indexWriter, err := driver.PachClient().DirectObjWriter(path + hashtree.IndexPath)
+func writeIndex(driver driver.Driver, tree *pfs.Object, index io.Reader) (retErr error) {
+	indexWriter, err := driver.ObjectStorage().IndexWriter(tree)
 	if err != nil {
 		return errors.EnsureStack(err)
 	}
@@ -805,6 +1449,6 @@ func writeIndex(driver driver.Driver, tree *pfs.Object, indexData []byte) (retEr
 			retErr = errors.EnsureStack(err)
 		}
 	}()
-	_, err = indexWriter.Write(indexData)
+	_, err = io.Copy(indexWriter, index)
 	return errors.EnsureStack(err)
 }
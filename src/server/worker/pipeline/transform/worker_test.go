@@ -0,0 +1,1195 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/limit"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/work"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+func TestS3GatewayProbeFromEnv(t *testing.T) {
+	probe, err := s3GatewayProbeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, defaultS3GatewayProbeTimeout, probe.Timeout)
+	require.Equal(t, defaultS3GatewayProbePath, probe.Path)
+	require.Equal(t, defaultS3GatewayProbeMethod, probe.Method)
+
+	defer os.Unsetenv(s3GatewayProbeTimeoutEnv)
+	defer os.Unsetenv(s3GatewayProbePathEnv)
+	defer os.Unsetenv(s3GatewayProbeMethodEnv)
+	require.NoError(t, os.Setenv(s3GatewayProbeTimeoutEnv, "250ms"))
+	require.NoError(t, os.Setenv(s3GatewayProbePathEnv, "minio/health/live"))
+	require.NoError(t, os.Setenv(s3GatewayProbeMethodEnv, "head"))
+	probe, err = s3GatewayProbeFromEnv()
+	require.NoError(t, err)
+	require.Equal(t, 250*time.Millisecond, probe.Timeout)
+	require.Equal(t, "/minio/health/live", probe.Path)
+	require.Equal(t, http.MethodHead, probe.Method)
+
+	require.NoError(t, os.Setenv(s3GatewayProbeTimeoutEnv, "soon"))
+	_, err = s3GatewayProbeFromEnv()
+	require.YesError(t, err)
+}
+
+func TestS3GatewayProbeTimeout(t *testing.T) {
+	// The handler of the probe that times out is still running when the next
+	// probe is made, so the requests are recorded under a lock
+	var mu sync.Mutex
+	var gotMethod, gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		gotMethod, gotPath = r.Method, r.URL.Path
+		mu.Unlock()
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	// A timeout shorter than the server's response time should fail
+	probe := &s3GatewayProbe{Timeout: 50 * time.Millisecond, Path: "/", Method: http.MethodGet}
+	require.YesError(t, probe.do(host))
+
+	// A timeout longer than the server's response time should succeed, and the
+	// configured path and method should be used
+	probe = &s3GatewayProbe{Timeout: 5 * time.Second, Path: "/health", Method: http.MethodHead}
+	require.NoError(t, probe.do(host))
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, http.MethodHead, gotMethod)
+	require.Equal(t, "/health", gotPath)
+}
+
+func TestQueueWaitStats(t *testing.T) {
+	// Saturate the limiter so that each datum must wait for the previous one to
+	// release its slot.
+	limiter := limit.New(1)
+	stats := &DatumStats{ProcessStats: &pps.ProcessStats{}}
+	var prevTotal time.Duration
+	for i := 0; i < 5; i++ {
+		if i > 0 {
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				limiter.Release()
+			}()
+		}
+		wait := acquireDatum(limiter)
+		if i > 0 {
+			require.True(t, wait > 0)
+		}
+		subStats := &DatumStats{
+			QueueWaitTime:    types.DurationProto(wait),
+			MaxQueueWaitTime: types.DurationProto(wait),
+		}
+		require.NoError(t, mergeStats(stats, subStats))
+
+		total, err := types.DurationFromProto(stats.QueueWaitTime)
+		require.NoError(t, err)
+		require.True(t, total >= prevTotal)
+		prevTotal = total
+
+		max, err := types.DurationFromProto(stats.MaxQueueWaitTime)
+		require.NoError(t, err)
+		require.True(t, max >= wait)
+		require.True(t, max <= total)
+	}
+	require.True(t, prevTotal >= 4*20*time.Millisecond)
+	limiter.Release()
+}
+
+// memObjClient is an in-memory obj.Client
+type memObjClient struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemObjClient() *memObjClient {
+	return &memObjClient{objects: make(map[string][]byte)}
+}
+
+type memObjWriter struct {
+	bytes.Buffer
+	c    *memObjClient
+	name string
+}
+
+func (w *memObjWriter) Close() error {
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	w.c.objects[w.name] = w.Bytes()
+	return nil
+}
+
+func (c *memObjClient) Writer(_ context.Context, name string) (io.WriteCloser, error) {
+	return &memObjWriter{c: c, name: name}, nil
+}
+
+func (c *memObjClient) Reader(_ context.Context, name string, offset uint64, size uint64) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.objects[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	data = data[offset:]
+	if size > 0 {
+		data = data[:size]
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (c *memObjClient) Delete(_ context.Context, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[name]; !ok {
+		return os.ErrNotExist
+	}
+	delete(c.objects, name)
+	return nil
+}
+
+func (c *memObjClient) Walk(_ context.Context, prefix string, fn func(name string) error) error {
+	c.mu.Lock()
+	var names []string
+	for name := range c.objects {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	c.mu.Unlock()
+	sort.Strings(names)
+	for _, name := range names {
+		if err := fn(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *memObjClient) Exists(_ context.Context, name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.objects[name]
+	return ok
+}
+
+func (c *memObjClient) IsRetryable(err error) bool { return false }
+func (c *memObjClient) IsNotExist(err error) bool  { return errors.Is(err, os.ErrNotExist) }
+func (c *memObjClient) IsIgnorable(err error) bool { return false }
+
+func TestMergeWithObjectStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "merge-object-storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	objClient := newMemObjClient()
+	numShards := int64(2)
+	md := driver.NewMockDriver(nil, &driver.MockOptions{
+		NumShards:     int(numShards),
+		PipelineInfo:  defaultPipelineInfo(),
+		HashtreePath:  dir,
+		ObjectStorage: driver.NewObjClientStorage(context.Background(), objClient),
+	})
+	logger := logs.NewMockLogger().WithJob("job")
+
+	// Upload a chunk of datum hashtrees for each of three subtasks, keeping
+	// each chunk to compute the expected merge
+	expectedCache, err := hashtree.NewMergeCache(filepath.Join(dir, "expected"))
+	require.NoError(t, err)
+	defer expectedCache.Close()
+	var tags []string
+	for i := 0; i < 3; i++ {
+		subtaskCache, err := hashtree.NewMergeCache(filepath.Join(dir, fmt.Sprintf("subtask-%d", i)))
+		require.NoError(t, err)
+		for j := 0; j < 10; j++ {
+			u := hashtree.NewUnordered("")
+			u.PutFile(fmt.Sprintf("/dir-%d/file-%d-%d", j%3, i, j), []byte(fmt.Sprintf("%d-%d", i, j)), 1)
+			buf := &bytes.Buffer{}
+			require.NoError(t, u.Ordered().Serialize(buf))
+			require.NoError(t, subtaskCache.Put(fmt.Sprint(j), bytes.NewReader(buf.Bytes())))
+		}
+		tag := fmt.Sprintf("chunk-%d", i)
+		chunkBuf := &bytes.Buffer{}
+		require.NoError(t, subtaskCache.Merge(hashtree.NewWriter(chunkBuf), nil, nil))
+		require.NoError(t, expectedCache.Put(tag, chunkBuf))
+		// Chunks are cached by another job, so that merging fetches them from
+		// object storage
+		uploadCache, err := md.ChunkCaches().GetOrCreateCache("upload")
+		require.NoError(t, err)
+		require.NoError(t, uploadChunk(md, logger, subtaskCache, uploadCache, tag))
+		require.NoError(t, subtaskCache.Close())
+		tags = append(tags, tag)
+	}
+	require.True(t, objClient.Exists(context.Background(), "tag/chunk-0"))
+
+	// checkShards checks that the merged shards are stored with their indexes,
+	// and are the same as merging the chunks with the given tags
+	checkShards := func(shards []*MergeShard, tags []string) {
+		for _, mergeShard := range shards {
+			require.NotNil(t, mergeShard.Tree)
+			expectedBuf := &bytes.Buffer{}
+			w := hashtree.NewWriter(expectedBuf)
+			require.NoError(t, expectedCache.MergeKeys(w, nil, hashtree.NewFilter(numShards, mergeShard.Shard), tags, nil))
+			require.Equal(t, w.Size(), mergeShard.TreeSize)
+
+			r, err := md.ObjectStorage().GetObjectReader(mergeShard.Tree.Hash)
+			require.NoError(t, err)
+			resultBuf := &bytes.Buffer{}
+			_, err = io.Copy(resultBuf, r)
+			require.NoError(t, err)
+			require.Equal(t, expectedBuf.Bytes(), resultBuf.Bytes())
+			require.True(t, objClient.Exists(context.Background(), "object/"+mergeShard.Tree.Hash+hashtree.IndexPath))
+		}
+	}
+
+	// Merge the first two chunks into each shard
+	data := &MergeData{
+		JobID:     "job",
+		Hashtrees: []*HashtreeInfo{{Tag: tags[0]}, {Tag: tags[1]}},
+		Shards:    []*MergeShard{{Shard: 0}, {Shard: 1}},
+	}
+	require.NoError(t, handleMergeTask(md, logger, data))
+	checkShards(data.Shards, tags[:2])
+
+	// Then merge the last chunk into them, as their parents
+	parentData := &MergeData{
+		JobID:     "job",
+		Hashtrees: []*HashtreeInfo{{Tag: tags[2]}},
+		Shards: []*MergeShard{
+			{Shard: 0, Parent: data.Shards[0].Tree},
+			{Shard: 1, Parent: data.Shards[1].Tree},
+		},
+	}
+	require.NoError(t, handleMergeTask(md, logger.WithJob("next-job"), parentData))
+	checkShards(parentData.Shards, tags)
+
+	// A version 0 task, from before tasks were versioned, merges the single
+	// shard given by its legacy fields, and returns the result in them too
+	legacyAny, err := types.MarshalAny(&MergeData{
+		JobID:        "job",
+		Hashtrees:    []*HashtreeInfo{{Tag: tags[2]}},
+		LegacyShard:  1,
+		LegacyParent: data.Shards[1].Tree,
+	})
+	require.NoError(t, err)
+	legacyData, err := deserializeMergeData(legacyAny)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(legacyData.Shards))
+	require.NoError(t, handleMergeTask(md, logger.WithJob("legacy-job"), legacyData))
+	resultAny, err := serializeLegacyMergeData(legacyData)
+	require.NoError(t, err)
+	result := &MergeData{}
+	require.NoError(t, types.UnmarshalAny(resultAny, result))
+	require.Equal(t, uint32(0), result.Version)
+	require.Equal(t, 0, len(result.Shards))
+	require.Equal(t, int64(1), result.LegacyShard)
+	// which is read back as its shard
+	resultData, err := deserializeMergeData(resultAny)
+	require.NoError(t, err)
+	checkShards(resultData.Shards, tags)
+	require.Equal(t, result.LegacyTree, resultData.Shards[0].Tree)
+}
+
+// failingIndexStorage is an ObjectStorage whose index writers fail a number of
+// times before they succeed, and which counts the objects that are written
+type failingIndexStorage struct {
+	driver.ObjectStorage
+	mu       sync.Mutex
+	failures int
+	puts     int
+}
+
+func (s *failingIndexStorage) PutObjectAsync(tags []*pfs.Tag) (driver.ObjectWriter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.puts++
+	return s.ObjectStorage.PutObjectAsync(tags)
+}
+
+func (s *failingIndexStorage) IndexWriter(object *pfs.Object) (io.WriteCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, errors.Errorf("connection reset by peer")
+	}
+	return s.ObjectStorage.IndexWriter(object)
+}
+
+func TestMergeResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "merge-resume")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	objClient := newMemObjClient()
+	storage := &failingIndexStorage{ObjectStorage: driver.NewObjClientStorage(context.Background(), objClient)}
+	numShards := int64(2)
+	md := driver.NewMockDriver(nil, &driver.MockOptions{
+		NumShards:     int(numShards),
+		PipelineInfo:  defaultPipelineInfo(),
+		HashtreePath:  dir,
+		ObjectStorage: storage,
+	})
+	logger := logs.NewMockLogger().WithJob("job")
+
+	// Upload a chunk of datum hashtrees for each of two subtasks
+	expectedCache, err := hashtree.NewMergeCache(filepath.Join(dir, "expected"))
+	require.NoError(t, err)
+	defer expectedCache.Close()
+	var tags []string
+	for i := 0; i < 2; i++ {
+		subtaskCache, err := hashtree.NewMergeCache(filepath.Join(dir, fmt.Sprintf("subtask-%d", i)))
+		require.NoError(t, err)
+		for j := 0; j < 10; j++ {
+			u := hashtree.NewUnordered("")
+			u.PutFile(fmt.Sprintf("/dir-%d/file-%d-%d", j%3, i, j), []byte(fmt.Sprintf("%d-%d", i, j)), 1)
+			buf := &bytes.Buffer{}
+			require.NoError(t, u.Ordered().Serialize(buf))
+			require.NoError(t, subtaskCache.Put(fmt.Sprint(j), bytes.NewReader(buf.Bytes())))
+		}
+		tag := fmt.Sprintf("chunk-%d", i)
+		chunkBuf := &bytes.Buffer{}
+		require.NoError(t, subtaskCache.Merge(hashtree.NewWriter(chunkBuf), nil, nil))
+		require.NoError(t, expectedCache.Put(tag, chunkBuf))
+		uploadCache, err := md.ChunkCaches().GetOrCreateCache("upload")
+		require.NoError(t, err)
+		require.NoError(t, uploadChunk(md, logger, subtaskCache, uploadCache, tag))
+		require.NoError(t, subtaskCache.Close())
+		tags = append(tags, tag)
+	}
+	newData := func() *MergeData {
+		return &MergeData{
+			JobID:     "job",
+			Hashtrees: []*HashtreeInfo{{Tag: tags[0]}, {Tag: tags[1]}},
+			Shards:    []*MergeShard{{Shard: 0}, {Shard: 1}},
+		}
+	}
+	checkShards := func(shards []*MergeShard) {
+		for _, mergeShard := range shards {
+			require.NotNil(t, mergeShard.Tree)
+			expectedBuf := &bytes.Buffer{}
+			w := hashtree.NewWriter(expectedBuf)
+			require.NoError(t, expectedCache.MergeKeys(w, nil, hashtree.NewFilter(numShards, mergeShard.Shard), tags, nil))
+			require.Equal(t, w.Size(), mergeShard.TreeSize)
+			r, err := storage.GetObjectReader(mergeShard.Tree.Hash)
+			require.NoError(t, err)
+			resultBuf := &bytes.Buffer{}
+			_, err = io.Copy(resultBuf, r)
+			require.NoError(t, err)
+			require.Equal(t, expectedBuf.Bytes(), resultBuf.Bytes())
+			require.True(t, objClient.Exists(context.Background(), "object/"+mergeShard.Tree.Hash+hashtree.IndexPath))
+		}
+	}
+
+	// The merge fails while writing an index, after the trees were written
+	storage.failures, storage.puts = 1, 0
+	require.YesError(t, handleMergeTask(md, logger, newData()))
+	require.Equal(t, 2, storage.puts)
+
+	// So the retry reuses the trees, and only writes their indexes
+	storage.puts = 0
+	data := newData()
+	require.NoError(t, handleMergeTask(md, logger, data))
+	require.Equal(t, 0, storage.puts)
+	checkShards(data.Shards)
+
+	// A truncated tree is merged again
+	corruptTag := mergedTreeTag("job", &MergeShard{Shard: 0}, tags)
+	r, err := storage.GetObjectReader(data.Shards[0].Tree.Hash)
+	require.NoError(t, err)
+	treeBuf := &bytes.Buffer{}
+	_, err = io.Copy(treeBuf, r)
+	require.NoError(t, err)
+	require.NoError(t, objClient.Delete(context.Background(), "tag/"+corruptTag))
+	w, err := objClient.Writer(context.Background(), "object/corrupt")
+	require.NoError(t, err)
+	_, err = w.Write(treeBuf.Bytes()[:treeBuf.Len()/2])
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	w, err = objClient.Writer(context.Background(), "tag/"+corruptTag)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("corrupt"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	storage.puts = 0
+	data = newData()
+	require.NoError(t, handleMergeTask(md, logger, data))
+	require.Equal(t, 1, storage.puts)
+	checkShards(data.Shards)
+	tree, err := storage.InspectTag(corruptTag)
+	require.NoError(t, err)
+	require.Equal(t, data.Shards[0].Tree.Hash, tree.Hash)
+}
+
+func TestStatsFlush(t *testing.T) {
+	defer func(interval time.Duration) { statsFlushInterval = interval }(statsFlushInterval)
+	statsFlushInterval = 10 * time.Millisecond
+	dir, err := ioutil.TempDir("", "stats-flush")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	objClient := newMemObjClient()
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.EnableStats = true
+	md := driver.NewMockDriver(nil, &driver.MockOptions{
+		NumShards:     1,
+		PipelineInfo:  pipelineInfo,
+		HashtreePath:  dir,
+		ObjectStorage: driver.NewObjClientStorage(context.Background(), objClient),
+	})
+	logger := logs.NewMockLogger().WithJob("job")
+
+	statsCache, err := hashtree.NewMergeCache(filepath.Join(dir, "stats"))
+	require.NoError(t, err)
+	// Keep the datums' stats hashtrees to compute the expected chunks
+	expectedCache, err := hashtree.NewMergeCache(filepath.Join(dir, "expected"))
+	require.NoError(t, err)
+	defer expectedCache.Close()
+	putDatums := func(start, end int) []string {
+		var ids []string
+		for i := start; i < end; i++ {
+			u := hashtree.NewUnordered(fmt.Sprintf("/datum-%d", i))
+			u.PutFile("stats", []byte(fmt.Sprint(i)), 1)
+			buf := &bytes.Buffer{}
+			require.NoError(t, u.Ordered().Serialize(buf))
+			id := fmt.Sprint(i)
+			require.NoError(t, statsCache.Put(id, bytes.NewReader(buf.Bytes())))
+			require.NoError(t, expectedCache.Put(id, bytes.NewReader(buf.Bytes())))
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	requireChunk := func(tag string, ids []string) {
+		expectedBuf := &bytes.Buffer{}
+		require.NoError(t, expectedCache.MergeKeys(hashtree.NewWriter(expectedBuf), nil, nil, ids, nil))
+		r, err := md.ObjectStorage().GetTagReader(tag)
+		require.NoError(t, err)
+		defer r.Close()
+		resultBuf := &bytes.Buffer{}
+		_, err = io.Copy(resultBuf, r)
+		require.NoError(t, err)
+		require.Equal(t, expectedBuf.Bytes(), resultBuf.Bytes())
+	}
+	chunkStatsCache, err := md.ChunkStatsCaches().GetOrCreateCache("job")
+	require.NoError(t, err)
+	waitForFlush := func(f *statsFlusher, flush int) {
+		tag := "tag/" + jobChunkStatsPartialTag("job", "subtask", f.attemptID, flush)
+		require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+			if !objClient.Exists(context.Background(), tag) {
+				return errors.Errorf("%s hasn't been flushed", tag)
+			}
+			return nil
+		})
+	}
+
+	// Each flush uploads only the datums processed since the previous one
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	crashed := newStatsFlusher(md, logger, statsCache, chunkStatsCache, "subtask")
+	go func() {
+		defer close(done)
+		crashed.run(ctx)
+	}()
+	firstIDs := putDatums(0, 5)
+	waitForFlush(crashed, 0)
+	secondIDs := putDatums(5, 10)
+	waitForFlush(crashed, 1)
+	putDatums(10, 15)
+
+	// Then the subtask crashes, losing its locally cached stats, but the
+	// flushed stats survive
+	cancel()
+	<-done
+	require.NoError(t, statsCache.Close())
+	requireChunk(jobChunkStatsPartialTag("job", "subtask", crashed.attemptID, 0), firstIDs)
+	requireChunk(jobChunkStatsPartialTag("job", "subtask", crashed.attemptID, 1), secondIDs)
+	require.False(t, objClient.Exists(context.Background(), "tag/"+jobChunkStatsPartialTag("job", "subtask", crashed.attemptID, 2)))
+
+	// The subtask is retried with the same ID. Its flushes are tagged with its
+	// own attempt, so they don't overwrite the crashed attempt's flushed stats.
+	statsCache, err = hashtree.NewMergeCache(filepath.Join(dir, "stats-retry"))
+	require.NoError(t, err)
+	defer statsCache.Close()
+	f := newStatsFlusher(md, logger, statsCache, chunkStatsCache, "subtask")
+	require.NotEqual(t, crashed.attemptID, f.attemptID)
+	ids := putDatums(0, 3)
+	require.NoError(t, f.flush())
+	requireChunk(jobChunkStatsPartialTag("job", "subtask", crashed.attemptID, 0), firstIDs)
+	requireChunk(jobChunkStatsPartialTag("job", "subtask", crashed.attemptID, 1), secondIDs)
+	requireChunk(jobChunkStatsPartialTag("job", "subtask", f.attemptID, 0), ids)
+	require.Equal(t, 1, len(f.hashtrees))
+
+	// When the subtask finishes, its final chunk only has the datums that
+	// weren't flushed
+	unflushedIDs := putDatums(3, 10)
+	ids = append(ids, unflushedIDs...)
+	require.Equal(t, len(unflushedIDs), len(f.unflushed()))
+	finalTag := jobChunkStatsTag("job", "subtask")
+	require.NoError(t, uploadChunkKeys(md, logger, statsCache, chunkStatsCache, finalTag, f.unflushed()))
+	requireChunk(finalTag, unflushedIDs)
+
+	// So merging it with the partial chunks, as the job's stats merge does,
+	// has every datum's stats once
+	mergeCache, err := hashtree.NewMergeCache(filepath.Join(dir, "merge"))
+	require.NoError(t, err)
+	defer mergeCache.Close()
+	for _, info := range append(f.hashtrees, &HashtreeInfo{Tag: finalTag}) {
+		r, err := fetchChunk(md, logger, info, nil, true)
+		require.NoError(t, err)
+		require.NoError(t, mergeCache.Put(info.Tag, r))
+		require.NoError(t, r.Close())
+	}
+	// Directory hashes depend on how the datums were chunked, so only the
+	// files are compared
+	files := func(merge func(*hashtree.Writer) error) map[string]string {
+		buf := &bytes.Buffer{}
+		require.NoError(t, merge(hashtree.NewWriter(buf)))
+		result := make(map[string]string)
+		require.NoError(t, hashtree.Walk([]io.ReadCloser{ioutil.NopCloser(buf)}, "/", func(path string, node *hashtree.NodeProto) error {
+			if node.FileNode != nil {
+				result[path] = node.FileNode.String()
+			}
+			return nil
+		}))
+		return result
+	}
+	expected := files(func(w *hashtree.Writer) error {
+		return expectedCache.MergeKeys(w, nil, nil, ids, nil)
+	})
+	require.Equal(t, len(ids), len(expected))
+	require.Equal(t, expected, files(func(w *hashtree.Writer) error {
+		return mergeCache.Merge(w, nil, nil)
+	}))
+}
+
+// stuckDriver is a MockDriver whose downloads or uploads hang until its
+// context is canceled
+type stuckDriver struct {
+	*driver.MockDriver
+	ctx           context.Context
+	stuckDownload bool
+	stuckUpload   bool
+}
+
+func (d *stuckDriver) WithContext(ctx context.Context) driver.Driver {
+	result := *d
+	result.ctx = ctx
+	return &result
+}
+
+func (d *stuckDriver) PachClient() *client.APIClient {
+	return (&client.APIClient{}).WithCtx(d.ctx)
+}
+
+func (d *stuckDriver) WithData(inputs []*common.Input, inputTree *hashtree.Ordered, logger logs.TaggedLogger, cb func(string, *pps.ProcessStats) error) (*pps.ProcessStats, error) {
+	stats := &pps.ProcessStats{}
+	if d.stuckDownload {
+		<-d.ctx.Done()
+		return stats, d.ctx.Err()
+	}
+	return stats, cb("", stats)
+}
+
+func (d *stuckDriver) UploadOutput(string, string, logs.TaggedLogger, []*common.Input, *pps.ProcessStats, *hashtree.Ordered) ([]byte, error) {
+	if d.stuckUpload {
+		<-d.ctx.Done()
+		return nil, d.ctx.Err()
+	}
+	return []byte{}, nil
+}
+
+func TestDatumPhaseTimeouts(t *testing.T) {
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.DownloadTimeout = types.DurationProto(100 * time.Millisecond)
+	pipelineInfo.UploadTimeout = types.DurationProto(100 * time.Millisecond)
+	logger := logs.NewMockLogger()
+	// process downloads a datum, runs 'userCode' and uploads its outputs, like
+	// processDatum
+	process := func(ctx context.Context, d *stuckDriver, userCode time.Duration) error {
+		d.MockDriver = driver.NewMockDriver(nil, &driver.MockOptions{PipelineInfo: pipelineInfo})
+		d.ctx = ctx
+		_, err := withData(d, nil, nil, logger, func(dir string, stats *pps.ProcessStats) error {
+			time.Sleep(userCode)
+			_, err := uploadOutput(d, dir, "tag", logger, nil, stats, nil)
+			return err
+		})
+		return err
+	}
+
+	// A stuck download times out, and the error reports that it was the
+	// download that timed out
+	err := process(context.Background(), &stuckDriver{stuckDownload: true}, 0)
+	require.YesError(t, err)
+	require.Matches(t, "timed out downloading inputs", err.Error())
+
+	// As does a stuck upload, even though the datum took longer than the
+	// download timeout in total
+	err = process(context.Background(), &stuckDriver{stuckUpload: true}, 200*time.Millisecond)
+	require.YesError(t, err)
+	require.Matches(t, "timed out uploading outputs", err.Error())
+	require.False(t, strings.Contains(err.Error(), "downloading"))
+
+	// Neither timeout applies to user code
+	require.NoError(t, process(context.Background(), &stuckDriver{}, 200*time.Millisecond))
+
+	// And without timeouts, a stuck download hangs until the datum is canceled
+	pipelineInfo.DownloadTimeout, pipelineInfo.UploadTimeout = nil, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = process(ctx, &stuckDriver{stuckDownload: true}, 0)
+	require.YesError(t, err)
+	require.False(t, strings.Contains(err.Error(), "timed out"))
+	require.True(t, time.Since(start) >= 300*time.Millisecond)
+}
+
+func TestDatumFailurePolicy(t *testing.T) {
+	pipelineInfo := defaultPipelineInfo()
+	// Process the datums one at a time, in order
+	pipelineInfo.MaxQueueSize = 1
+	// The user code fails on the 'bad' datum, and succeeds on the others
+	pipelineInfo.Transform.Cmd = []string{"bash", "-c", `case "$inputRepo" in */bad) exit 1;; esac`}
+	err := withTestEnv(pipelineInfo, func(env *testEnv) error {
+		require.NoError(t, env.PachClient.CreateRepo("inputRepo"))
+		commit, err := env.PachClient.StartCommit("inputRepo", "master")
+		require.NoError(t, err)
+		for _, name := range []string{"a", "b", "bad", "c", "d", "e"} {
+			_, err := env.PachClient.PutFile("inputRepo", commit.ID, name, strings.NewReader(name))
+			require.NoError(t, err)
+		}
+		require.NoError(t, env.PachClient.FinishCommit("inputRepo", commit.ID))
+		fileInfos, err := env.PachClient.ListFile("inputRepo", commit.ID, "/")
+		require.NoError(t, err)
+		require.Equal(t, 6, len(fileInfos))
+		datums := make([][]*common.Input, len(fileInfos))
+		for i, fileInfo := range fileInfos {
+			datums[i] = []*common.Input{{Name: "inputRepo", FileInfo: fileInfo}}
+		}
+		require.Equal(t, "/bad", datums[2][0].FileInfo.File.Path)
+		forEach := func(cb func(int64, []*common.Input) error) error {
+			for i, inputs := range datums {
+				if err := cb(int64(i), inputs); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		dir, err := ioutil.TempDir("", "datum-failure-policy")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		outputCommit := client.NewCommit(pipelineInfo.Pipeline.Name, "output")
+		logger := logs.NewMockLogger().WithJob("job")
+		// run processes the datums, failing to process 'broken' with an error
+		// that isn't caused by the user code
+		run := func(name string, broken string) (*DatumStats, error) {
+			datumCache, err := hashtree.NewMergeCache(filepath.Join(dir, name))
+			require.NoError(t, err)
+			defer datumCache.Close()
+			status := &Status{}
+			stats := &DatumStats{ProcessStats: &pps.ProcessStats{}}
+			_, err = processDatums(env.driver, logger, stats, status, forEach, func(ctx context.Context, index int64, inputs []*common.Input) (*DatumStats, []string, error) {
+				if inputs[0].FileInfo.File.Path == broken {
+					return &DatumStats{ProcessStats: &pps.ProcessStats{}}, nil, errors.New("failed to upload the datum's output")
+				}
+				return processDatum(env.driver.WithContext(ctx), logger, index, inputs, outputCommit, datumCache, nil, status)
+			})
+			return stats, err
+		}
+
+		// With CANCEL_ON_FAILURE, the failed datum stops the datums after it
+		// from being processed, and is recorded in the stats rather than
+		// failing the subtask
+		pipelineInfo.DatumFailurePolicy = pps.DatumFailurePolicy_CANCEL_ON_FAILURE
+		stats, err := run("cancel-on-failure", "")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), stats.DatumsProcessed)
+		require.Equal(t, int64(1), stats.DatumsFailed)
+		require.Equal(t, common.DatumID(datums[2]), stats.FailedDatumID)
+
+		// By default, every other datum is processed (the first two are
+		// skipped, as they were already processed)
+		pipelineInfo.DatumFailurePolicy = pps.DatumFailurePolicy_FAIL_FAST
+		stats, err = run("fail-fast", "")
+		require.NoError(t, err)
+		require.Equal(t, int64(3), stats.DatumsProcessed)
+		require.Equal(t, int64(2), stats.DatumsSkipped)
+		require.Equal(t, int64(1), stats.DatumsFailed)
+		require.Equal(t, common.DatumID(datums[2]), stats.FailedDatumID)
+
+		// But any other error fails the subtask, so that it's retried
+		_, err = run("fail-fast-broken", "/d")
+		require.YesError(t, err)
+		require.Matches(t, "failed to upload", err.Error())
+
+		// Whereas with COLLECT_ALL it's recorded as a failed datum too, and the
+		// other datums are still processed (or skipped)
+		pipelineInfo.DatumFailurePolicy = pps.DatumFailurePolicy_COLLECT_ALL
+		stats, err = run("collect-all-broken", "/d")
+		require.NoError(t, err)
+		require.Equal(t, int64(4), stats.DatumsSkipped)
+		require.Equal(t, int64(2), stats.DatumsFailed)
+		require.Equal(t, common.DatumID(datums[2]), stats.FailedDatumID)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDatumExclude(t *testing.T) {
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.MaxQueueSize = 2
+	pipelineInfo.DatumExclude = "/bad-*"
+	d := &stuckDriver{
+		MockDriver: driver.NewMockDriver(nil, &driver.MockOptions{PipelineInfo: pipelineInfo}),
+		ctx:        context.Background(),
+	}
+	// Every third datum has a file matching the glob
+	datumInputs := func(index int64) []*common.Input {
+		name := fmt.Sprintf("/file-%d", index)
+		if index%3 == 0 {
+			name = fmt.Sprintf("/bad-%d", index)
+		}
+		return []*common.Input{{
+			Name:     "inputRepo",
+			FileInfo: &pfs.FileInfo{File: client.NewFile("inputRepo", "master", name)},
+		}}
+	}
+	forEach := func(cb func(int64, []*common.Input) error) error {
+		for i := int64(0); i < 9; i++ {
+			if err := cb(i, datumInputs(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var mu sync.Mutex
+	processed := make(map[int64]bool)
+	process := func(ctx context.Context, index int64, inputs []*common.Input) (*DatumStats, []string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		processed[index] = true
+		return &DatumStats{DatumsProcessed: 1}, nil, nil
+	}
+
+	// The excluded datums are neither processed nor failed
+	stats := &DatumStats{ProcessStats: &pps.ProcessStats{}}
+	_, err := processDatums(d, logs.NewMockLogger(), stats, &Status{}, forEach, process)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), stats.DatumsProcessed)
+	require.Equal(t, int64(3), stats.DatumsExcluded)
+	require.Equal(t, int64(0), stats.DatumsFailed)
+	require.Equal(t, int64(0), stats.DatumsSkipped)
+	for i := int64(0); i < 9; i++ {
+		require.Equal(t, i%3 != 0, processed[i], i)
+	}
+
+	// A datum is excluded if any of its inputs match
+	excluded, err := datumExcluder(pipelineInfo.DatumExclude)
+	require.NoError(t, err)
+	require.True(t, excluded(append(datumInputs(1), datumInputs(3)...)))
+	require.False(t, excluded(append(datumInputs(1), datumInputs(2)...)))
+
+	// A malformed glob fails the subtask
+	pipelineInfo.DatumExclude = "/bad-["
+	_, err = processDatums(d, logs.NewMockLogger(), &DatumStats{ProcessStats: &pps.ProcessStats{}}, &Status{}, forEach, process)
+	require.YesError(t, err)
+	require.Matches(t, "malformed datum exclude glob", err.Error())
+}
+
+func TestS3GatewayWaitStatus(t *testing.T) {
+	// Reserve an address for the gateway, which refuses connections until the
+	// gateway is started on it
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	status := &Status{}
+	waiting := func() bool {
+		workerStatus, err := status.GetStatus()
+		require.NoError(t, err)
+		return workerStatus.WaitingForS3Gateway
+	}
+	require.False(t, waiting())
+
+	probe := &s3GatewayProbe{Timeout: time.Second, Path: "/", Method: http.MethodGet}
+	done := make(chan error)
+	go func() {
+		done <- waitForS3Gateway(logs.NewMockLogger(), status, probe, host)
+	}()
+
+	// The worker shows that it's waiting for the gateway while it's unreachable
+	require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+		if !waiting() {
+			return errors.Errorf("worker isn't waiting for the s3 gateway")
+		}
+		return nil
+	})
+	time.Sleep(300 * time.Millisecond)
+	require.True(t, waiting())
+	select {
+	case err := <-done:
+		t.Fatalf("stopped waiting for an unreachable s3 gateway: %v", err)
+	default:
+	}
+
+	// And stops waiting once the gateway is reachable
+	l, err = net.Listen("tcp", host)
+	require.NoError(t, err)
+	server := &httptest.Server{Listener: l, Config: &http.Server{Handler: http.NotFoundHandler()}}
+	server.Start()
+	defer server.Close()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("still waiting for a reachable s3 gateway")
+	}
+	require.False(t, waiting())
+
+	// Then it processes datums
+	queueSize := int64(3)
+	require.NoError(t, status.withStats(&pps.ProcessStats{}, &queueSize, func() error {
+		workerStatus, err := status.GetStatus()
+		require.NoError(t, err)
+		require.False(t, workerStatus.WaitingForS3Gateway)
+		require.Equal(t, int64(3), workerStatus.QueueSize)
+		return nil
+	}))
+}
+
+// flakyObjectStorage is an ObjectStorage whose tag readers fail with a
+// transient error a number of times before they succeed, either when they're
+// opened or when they're first read
+type flakyObjectStorage struct {
+	driver.ObjectStorage
+	mu        sync.Mutex
+	failures  int
+	failReads bool
+	attempts  int
+}
+
+func (s *flakyObjectStorage) GetTagReader(tag string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		err := errors.Errorf("connection reset by peer")
+		if s.failReads {
+			return ioutil.NopCloser(&errReader{err}), nil
+		}
+		return nil, err
+	}
+	return s.ObjectStorage.GetTagReader(tag)
+}
+
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestFetchChunkRetry(t *testing.T) {
+	objClient := newMemObjClient()
+	md := driver.NewMockDriver(nil, &driver.MockOptions{
+		PipelineInfo:  defaultPipelineInfo(),
+		ObjectStorage: driver.NewObjClientStorage(context.Background(), objClient),
+	})
+	logger := logs.NewMockLogger().WithJob("job")
+	objW, err := md.ObjectStorage().PutObjectAsync([]*pfs.Tag{client.NewTag("chunk")})
+	require.NoError(t, err)
+	_, err = objW.Write([]byte("chunk data"))
+	require.NoError(t, err)
+	require.NoError(t, objW.Close())
+
+	fetch := func(storage *flakyObjectStorage, tag string) ([]byte, error) {
+		storage.ObjectStorage = md.ObjectStorage()
+		r, err := fetchChunk(md.WithObjectStorage(storage), logger, &HashtreeInfo{Tag: tag}, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+
+	// A transient error getting the tag is retried, whether it's returned when
+	// the reader is opened or when it's read
+	for _, failReads := range []bool{false, true} {
+		storage := &flakyObjectStorage{failures: 1, failReads: failReads}
+		data, err := fetch(storage, "chunk")
+		require.NoError(t, err)
+		require.Equal(t, "chunk data", string(data))
+		require.Equal(t, 2, storage.attempts)
+	}
+
+	// But a tag that doesn't exist isn't
+	storage := &flakyObjectStorage{}
+	_, err = fetch(storage, "missing")
+	require.YesError(t, err)
+	require.Equal(t, 1, storage.attempts)
+}
+
+func TestDatumCallback(t *testing.T) {
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.MaxQueueSize = 3
+	pipelineInfo.DatumFailurePolicy = pps.DatumFailurePolicy_COLLECT_ALL
+	d := &stuckDriver{
+		MockDriver: driver.NewMockDriver(nil, &driver.MockOptions{PipelineInfo: pipelineInfo}),
+		ctx:        context.Background(),
+	}
+	datumInputs := func(index int64) []*common.Input {
+		return []*common.Input{{
+			Name:     "inputRepo",
+			FileInfo: &pfs.FileInfo{File: client.NewFile("inputRepo", "master", fmt.Sprintf("/file-%d", index))},
+		}}
+	}
+	forEach := func(cb func(int64, []*common.Input) error) error {
+		for i := int64(0); i < 12; i++ {
+			if err := cb(i, datumInputs(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// Datums are processed, fail, are skipped or are recovered in turn
+	expected := make(map[string]pps.DatumState)
+	states := []pps.DatumState{pps.DatumState_SUCCESS, pps.DatumState_FAILED, pps.DatumState_SKIPPED, pps.DatumState_RECOVERED}
+	for i := int64(0); i < 12; i++ {
+		expected[common.DatumID(datumInputs(i))] = states[i%4]
+	}
+	process := func(ctx context.Context, index int64, inputs []*common.Input) (*DatumStats, []string, error) {
+		time.Sleep(time.Millisecond)
+		stats := &DatumStats{}
+		switch states[index%4] {
+		case pps.DatumState_SUCCESS:
+			stats.DatumsProcessed++
+		case pps.DatumState_FAILED:
+			stats.DatumsFailed++
+		case pps.DatumState_SKIPPED:
+			stats.DatumsSkipped++
+		case pps.DatumState_RECOVERED:
+			stats.DatumsRecovered++
+		}
+		return stats, nil, nil
+	}
+
+	// The callback can block without stalling processing
+	status := &Status{}
+	var mu sync.Mutex
+	var events []*DatumEvent
+	unblock := make(chan struct{})
+	status.SetDatumCallback(func(event *DatumEvent) {
+		<-unblock
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	stats := &DatumStats{ProcessStats: &pps.ProcessStats{}}
+	_, err := processDatums(d, logs.NewMockLogger().WithJob("job"), stats, status, forEach, process)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stats.DatumsProcessed)
+	close(unblock)
+
+	// It's called once for each datum, with the datum's outcome
+	require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(events) < len(expected) {
+			return errors.Errorf("got %d of %d datum events", len(events), len(expected))
+		}
+		return nil
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, len(expected), len(events))
+	seen := make(map[string]bool)
+	for _, event := range events {
+		require.False(t, seen[event.DatumID], event.DatumID)
+		seen[event.DatumID] = true
+		require.Equal(t, expected[event.DatumID], event.State, event.DatumID)
+		require.Equal(t, "job", event.JobID)
+		require.True(t, event.Duration >= time.Millisecond)
+	}
+
+	// And without a callback, nothing is sent
+	status.SetDatumCallback(nil)
+	_, err = processDatums(d, logs.NewMockLogger(), &DatumStats{ProcessStats: &pps.ProcessStats{}}, status, forEach, process)
+	require.NoError(t, err)
+	require.Equal(t, len(expected), len(events))
+}
+
+func TestTaskVersion(t *testing.T) {
+	// Each type of task round-trips with the current version
+	datumAny, err := serializeDatumData(&DatumData{JobID: "job"})
+	require.NoError(t, err)
+	datumData, err := deserializeDatumData(datumAny)
+	require.NoError(t, err)
+	require.Equal(t, "job", datumData.JobID)
+	require.Equal(t, uint32(taskVersion), datumData.Version)
+	mergeAny, err := serializeMergeData(&MergeData{JobID: "job"})
+	require.NoError(t, err)
+	mergeData, err := deserializeMergeData(mergeAny)
+	require.NoError(t, err)
+	require.Equal(t, "job", mergeData.JobID)
+	require.Equal(t, uint32(taskVersion), mergeData.Version)
+
+	// A task can't be deserialized as the other type
+	_, err = deserializeMergeData(datumAny)
+	require.YesError(t, err)
+	_, err = deserializeDatumData(mergeAny)
+	require.YesError(t, err)
+
+	// Tasks from before tasks were versioned are still handled
+	legacyAny, err := types.MarshalAny(&DatumData{JobID: "job"})
+	require.NoError(t, err)
+	_, err = deserializeDatumData(legacyAny)
+	require.NoError(t, err)
+
+	// Tasks of an unknown type or a newer version are rejected
+	worker := func(data *types.Any) error {
+		return Worker(nil, logs.NewMockLogger(), &work.Task{ID: "task", Data: data}, &Status{})
+	}
+	unknownAny, err := types.MarshalAny(&pps.Job{ID: "job"})
+	require.NoError(t, err)
+	err = worker(unknownAny)
+	require.YesError(t, err)
+	require.Matches(t, "worker task format unrecognized", err.Error())
+	for _, data := range []proto.Message{
+		&DatumData{Version: taskVersion + 1, JobID: "job"},
+		&MergeData{Version: taskVersion + 1, JobID: "job"},
+	} {
+		newerAny, err := types.MarshalAny(data)
+		require.NoError(t, err)
+		err = worker(newerAny)
+		require.YesError(t, err)
+		require.Matches(t, "version 3 is not supported", err.Error())
+	}
+}
+
+func TestUserCodeEnv(t *testing.T) {
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.Transform.Env = map[string]string{
+		"CUSTOM":           "spec",
+		"SPEC_ONLY":        "spec",
+		client.JobIDEnv:    "spec",
+		"inputRepo":        "spec",
+		"inputRepo_COMMIT": "spec",
+	}
+	d := driver.NewMockDriver(nil, &driver.MockOptions{PipelineInfo: pipelineInfo})
+	for name, value := range map[string]string{
+		"CUSTOM":             "inherited",
+		"INHERITED":          "inherited",
+		client.JobIDEnv:      "inherited",
+		client.S3EndpointEnv: "inherited",
+	} {
+		require.NoError(t, os.Setenv(name, value))
+		defer os.Unsetenv(name)
+	}
+	inputs := []*common.Input{{
+		Name:     "inputRepo",
+		FileInfo: &pfs.FileInfo{File: client.NewFile("inputRepo", "commit", "/file")},
+	}}
+
+	env := make(map[string]string)
+	for _, kv := range userCodeEnv(d, "job", client.NewCommit("out", "outputCommit"), inputs) {
+		parts := strings.SplitN(kv, "=", 2)
+		_, ok := env[parts[0]]
+		require.False(t, ok, "%s is set more than once", parts[0])
+		env[parts[0]] = parts[1]
+	}
+	// The spec's env vars override the inherited ones
+	require.Equal(t, "spec", env["CUSTOM"])
+	require.Equal(t, "spec", env["SPEC_ONLY"])
+	require.Equal(t, "inherited", env["INHERITED"])
+	// But not the reserved ones
+	require.Equal(t, "job", env[client.JobIDEnv])
+	require.Equal(t, "outputCommit", env[client.OutputCommitIDEnv])
+	require.Equal(t, "/pfs/inputRepo/file", env["inputRepo"])
+	require.Equal(t, "commit", env["inputRepo_COMMIT"])
+	// The S3 endpoint is only set, and only replaces the inherited one, for
+	// pipelines with S3 inputs or outputs
+	require.Equal(t, "inherited", env[client.S3EndpointEnv])
+	pipelineInfo.S3Out = true
+	var s3Endpoints []string
+	for _, kv := range userCodeEnv(d, "job", client.NewCommit("out", "outputCommit"), inputs) {
+		if strings.HasPrefix(kv, client.S3EndpointEnv+"=") {
+			s3Endpoints = append(s3Endpoints, kv)
+		}
+	}
+	require.Equal(t, []string{client.S3EndpointEnv + "=http://s3-job.namespace:" + os.Getenv("S3GATEWAY_PORT")}, s3Endpoints)
+}
+
+func TestS3EndpointOverride(t *testing.T) {
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.S3Out = true
+	d := driver.NewMockDriver(nil, &driver.MockOptions{PipelineInfo: pipelineInfo})
+	require.NoError(t, os.Setenv("S3GATEWAY_PORT", "600"))
+	defer os.Unsetenv("S3GATEWAY_PORT")
+	s3EndpointEnv := func() string {
+		for _, kv := range userCodeEnv(d, "job", client.NewCommit("out", "outputCommit"), nil) {
+			if strings.HasPrefix(kv, client.S3EndpointEnv+"=") {
+				return strings.TrimPrefix(kv, client.S3EndpointEnv+"=")
+			}
+		}
+		return ""
+	}
+
+	// By default, the endpoint is the job's sidecar service over http
+	require.Equal(t, "http://s3-job.namespace:600", s3EndpointEnv())
+
+	// The scheme and host can be overridden, keeping the default port
+	pipelineInfo.S3Endpoint = &pps.S3Endpoint{Scheme: "https", Host: "s3.example.com"}
+	require.Equal(t, "https://s3.example.com:600", s3EndpointEnv())
+
+	// As can the port, independently of the host
+	pipelineInfo.S3Endpoint = &pps.S3Endpoint{Scheme: "https", Port: 443}
+	require.Equal(t, "https://s3-job.namespace:443", s3EndpointEnv())
+}
+
+func TestUserCodeEnvS3EndpointWithoutS3(t *testing.T) {
+	// A pipeline without S3 inputs or outputs may set S3_ENDPOINT itself, for
+	// example to use an external object store
+	pipelineInfo := defaultPipelineInfo()
+	pipelineInfo.Transform.Env = map[string]string{client.S3EndpointEnv: "http://minio:9000"}
+	require.False(t, ppsutil.ReservedUserCodeEnv(pipelineInfo.Input, pipelineInfo.S3Out)[client.S3EndpointEnv])
+	d := driver.NewMockDriver(nil, &driver.MockOptions{PipelineInfo: pipelineInfo})
+	require.NoError(t, os.Setenv(client.S3EndpointEnv, "inherited"))
+	defer os.Unsetenv(client.S3EndpointEnv)
+	inputs := []*common.Input{{
+		Name:     "inputRepo",
+		FileInfo: &pfs.FileInfo{File: client.NewFile("inputRepo", "commit", "/file")},
+	}}
+
+	var s3Endpoints []string
+	for _, kv := range userCodeEnv(d, "job", client.NewCommit("out", "outputCommit"), inputs) {
+		if strings.HasPrefix(kv, client.S3EndpointEnv+"=") {
+			s3Endpoints = append(s3Endpoints, kv)
+		}
+	}
+	require.Equal(t, []string{client.S3EndpointEnv + "=http://minio:9000"}, s3Endpoints)
+
+	// But not one with S3 outputs
+	pipelineInfo.S3Out = true
+	require.True(t, ppsutil.ReservedUserCodeEnv(pipelineInfo.Input, pipelineInfo.S3Out)[client.S3EndpointEnv])
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"io"
+
 	"github.com/gogo/protobuf/types"
 	"golang.org/x/net/context"
 
@@ -52,17 +54,74 @@ func (a *APIServer) Cancel(ctx context.Context, request *CancelRequest) (*Cancel
 
 // GetChunk returns the merged datum hashtrees of a particular chunk (if available)
 func (a *APIServer) GetChunk(request *GetChunkRequest, server Worker_GetChunkServer) error {
+	if request.Offset < 0 || request.Length < 0 {
+		return errors.Errorf("invalid range of hashtree chunk: offset %d, length %d", request.Offset, request.Length)
+	}
 	filter := hashtree.NewFilter(a.driver.NumShards(), request.Shard)
+	if len(request.Shards) > 0 {
+		filter = hashtree.NewShardsFilter(a.driver.NumShards(), request.Shards...)
+	}
+	cache := a.driver.ChunkCaches().GetCache(request.JobID)
 	if request.Stats {
-		cache := a.driver.ChunkStatsCaches().GetCache(request.JobID)
-		if cache != nil && cache.Has(request.Tag) {
-			return cache.Get(request.Tag, grpcutil.NewStreamingBytesWriter(server), filter)
+		cache = a.driver.ChunkStatsCaches().GetCache(request.JobID)
+	}
+	if cache == nil || !cache.Has(request.Tag) {
+		return errors.New("hashtree chunk not found")
+	}
+	w := &rangeWriter{
+		w:      grpcutil.NewStreamingBytesWriter(server),
+		skip:   request.Offset,
+		remain: request.Length,
+	}
+	if err := cache.Get(request.Tag, w, filter); err != nil && !errors.Is(err, errRangeComplete) {
+		return err
+	}
+	// The chunk ended before the requested range started
+	if w.skip > 0 {
+		return errors.Errorf("offset %d is past the end of the hashtree chunk (%d bytes)", request.Offset, w.size)
+	}
+	return nil
+}
+
+// errRangeComplete stops a chunk from being read once all of the requested
+// range has been written
+var errRangeComplete = errors.New("range complete")
+
+// rangeWriter writes a range of the bytes written to it to 'w': it skips the
+// first 'skip' bytes, then writes 'remain' bytes (or all of the rest, if
+// 'remain' is 0).
+type rangeWriter struct {
+	w      io.Writer
+	skip   int64
+	remain int64
+	// size is the number of bytes written to the rangeWriter
+	size int64
+}
+
+func (rw *rangeWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	rw.size += int64(n)
+	if rw.skip >= int64(n) {
+		rw.skip -= int64(n)
+		return n, nil
+	}
+	p = p[rw.skip:]
+	rw.skip = 0
+	if rw.remain == 0 {
+		if _, err := rw.w.Write(p); err != nil {
+			return 0, err
 		}
-	} else {
-		cache := a.driver.ChunkCaches().GetCache(request.JobID)
-		if cache != nil && cache.Has(request.Tag) {
-			return cache.Get(request.Tag, grpcutil.NewStreamingBytesWriter(server), filter)
+		return n, nil
+	}
+	if int64(len(p)) < rw.remain {
+		if _, err := rw.w.Write(p); err != nil {
+			return 0, err
 		}
+		rw.remain -= int64(len(p))
+		return n, nil
+	}
+	if _, err := rw.w.Write(p[:rw.remain]); err != nil {
+		return 0, err
 	}
-	return errors.New("hashtree chunk not found")
+	return n, errRangeComplete
 }
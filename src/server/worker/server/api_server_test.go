@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+)
+
+// testGetChunkServer collects the bytes sent by GetChunk
+type testGetChunkServer struct {
+	grpc.ServerStream
+	buf bytes.Buffer
+}
+
+func (s *testGetChunkServer) Send(value *types.BytesValue) error {
+	_, err := s.buf.Write(value.Value)
+	return err
+}
+
+func TestGetChunkRange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "get-chunk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	d := driver.NewMockDriver(nil, &driver.MockOptions{
+		NumShards:    2,
+		PipelineInfo: &pps.PipelineInfo{},
+		HashtreePath: dir,
+	})
+	cache, err := d.ChunkCaches().GetOrCreateCache("job")
+	require.NoError(t, err)
+	u := hashtree.NewUnordered("")
+	for i := 0; i < 100; i++ {
+		u.PutFile(fmt.Sprintf("/dir-%d/file-%d", i%5, i), []byte(fmt.Sprint(i)), 1)
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, u.Ordered().Serialize(buf))
+	require.NoError(t, cache.Put("tag", buf))
+
+	a := NewAPIServer(d, nil, "worker")
+	getChunk := func(shard, offset, length int64) ([]byte, error) {
+		server := &testGetChunkServer{}
+		err := a.GetChunk(&GetChunkRequest{
+			JobID:  "job",
+			Tag:    "tag",
+			Shard:  shard,
+			Offset: offset,
+			Length: length,
+		}, server)
+		return server.buf.Bytes(), err
+	}
+
+	for shard := int64(0); shard < 2; shard++ {
+		full, err := getChunk(shard, 0, 0)
+		require.NoError(t, err)
+		require.True(t, len(full) > 100)
+		size := int64(len(full))
+		for _, r := range [][2]int64{
+			{0, 1},
+			{0, size / 2},
+			{size / 3, size / 3},
+			{size / 2, 0},
+			{size - 1, 0},
+			{size - 10, 10},
+			// Ranges past the end of the chunk are truncated
+			{size - 10, 100},
+			{size, 0},
+		} {
+			offset, length := r[0], r[1]
+			expected := full[offset:]
+			if length > 0 && offset+length < size {
+				expected = full[offset : offset+length]
+			}
+			partial, err := getChunk(shard, offset, length)
+			require.NoError(t, err, "offset %d, length %d", offset, length)
+			require.True(t, bytes.Equal(expected, partial), "offset %d, length %d", offset, length)
+		}
+
+		// Ranges that start past the end of the chunk are rejected
+		_, err = getChunk(shard, size+1, 0)
+		require.YesError(t, err)
+		require.Matches(t, "past the end", err.Error())
+	}
+
+	// As are negative ranges
+	_, err = getChunk(0, -1, 0)
+	require.YesError(t, err)
+	_, err = getChunk(0, 0, -1)
+	require.YesError(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -17,6 +18,7 @@ import (
 	etcd "github.com/coreos/etcd/clientv3"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
 const (
@@ -130,15 +132,75 @@ func Clients(ctx context.Context, pipelineRcName string, etcdClient *etcd.Client
 }
 
 // NewClient returns a worker client for the worker at the IP address passed in.
+// Clients for the same worker share a pooled connection, which is replaced if
+// it breaks.
 func NewClient(address string) (Client, error) {
 	port, err := strconv.Atoi(os.Getenv(client.PPSWorkerPortEnv))
 	if err != nil {
 		return Client{}, err
 	}
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", address, port),
-		append(client.DefaultDialOptions(), grpc.WithInsecure())...)
+	conn, err := workerConns.get(fmt.Sprintf("%s:%d", address, port))
 	if err != nil {
 		return Client{}, err
 	}
 	return newClient(conn), nil
 }
+
+// workerConns is the pool of connections used by NewClient
+var workerConns = &connPool{conns: make(map[string]*grpc.ClientConn)}
+
+// connPool is a pool of gRPC connections, keyed by target address. It's safe
+// for concurrent use.
+type connPool struct {
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// get returns the pooled connection to 'target', dialing a new one if there
+// isn't one or it's broken (i.e. closed, or failing to connect).
+func (p *connPool) get(target string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	conn := p.healthy(target)
+	p.mu.Unlock()
+	if conn != nil {
+		return conn, nil
+	}
+	// Dialing blocks until the connection is established, so don't hold the
+	// lock, which would block callers connecting to other workers
+	conn, err := grpc.Dial(target, append(client.DefaultDialOptions(), grpc.WithInsecure())...)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Keep the connection that was pooled by a concurrent caller, if any
+	if pooled := p.healthy(target); pooled != nil {
+		if err := conn.Close(); err != nil {
+			log.Warnf("error closing extra connection to worker %s: %v", target, err)
+		}
+		return pooled, nil
+	}
+	p.conns[target] = conn
+	return conn, nil
+}
+
+// healthy returns the pooled connection to 'target', or nil if there isn't
+// one. Broken connections are removed from the pool. It must be called with
+// p.mu held.
+func (p *connPool) healthy(target string) *grpc.ClientConn {
+	conn, ok := p.conns[target]
+	if !ok {
+		return nil
+	}
+	switch conn.GetState() {
+	case connectivity.Shutdown:
+	case connectivity.TransientFailure:
+		if err := conn.Close(); err != nil {
+			log.Warnf("error closing broken connection to worker %s: %v", target, err)
+		}
+	default:
+		return conn
+	}
+	delete(p.conns, target)
+	return nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestNewClientPool(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	defer server.Stop()
+	port := listener.Addr().(*net.TCPAddr).Port
+	defer os.Setenv(client.PPSWorkerPortEnv, os.Getenv(client.PPSWorkerPortEnv))
+	require.NoError(t, os.Setenv(client.PPSWorkerPortEnv, strconv.Itoa(port)))
+	target := "127.0.0.1:" + strconv.Itoa(port)
+
+	pooledConn := func() *grpc.ClientConn {
+		workerConns.mu.Lock()
+		defer workerConns.mu.Unlock()
+		return workerConns.conns[target]
+	}
+
+	// Concurrent and repeated clients for the same worker share a connection
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := NewClient("127.0.0.1")
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	conn := pooledConn()
+	require.NotNil(t, conn)
+	for i := 0; i < 3; i++ {
+		_, err := NewClient("127.0.0.1")
+		require.NoError(t, err)
+		require.True(t, conn == pooledConn())
+	}
+
+	// A broken connection is replaced
+	require.NoError(t, conn.Close())
+	_, err = NewClient("127.0.0.1")
+	require.NoError(t, err)
+	newConn := pooledConn()
+	require.NotNil(t, newConn)
+	require.True(t, conn != newConn)
+	_, err = NewClient("127.0.0.1")
+	require.NoError(t, err)
+	require.True(t, newConn == pooledConn())
+}
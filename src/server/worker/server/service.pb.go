@@ -132,10 +132,16 @@ func (m *CancelResponse) GetSuccess() bool {
 }
 
 type GetChunkRequest struct {
-	JobID                string   `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Tag                  string   `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
-	Shard                int64    `protobuf:"varint,3,opt,name=shard,proto3" json:"shard,omitempty"`
-	Stats                bool     `protobuf:"varint,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	JobID string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Tag   string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Shard int64  `protobuf:"varint,3,opt,name=shard,proto3" json:"shard,omitempty"`
+	Stats bool   `protobuf:"varint,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	// If set, the chunk is filtered to these shards, rather than just 'shard'
+	Shards []int64 `protobuf:"varint,5,rep,packed,name=shards,proto3" json:"shards,omitempty"`
+	// If set, only the bytes of the (filtered) chunk from 'offset' on are
+	// returned, and at most 'length' of them (0 means to the end of the chunk)
+	Offset               int64    `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length               int64    `protobuf:"varint,7,opt,name=length,proto3" json:"length,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -202,6 +208,27 @@ func (m *GetChunkRequest) GetStats() bool {
 	return false
 }
 
+func (m *GetChunkRequest) GetShards() []int64 {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+func (m *GetChunkRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetChunkRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*CancelRequest)(nil), "server.CancelRequest")
 	proto.RegisterType((*CancelResponse)(nil), "server.CancelResponse")
@@ -213,33 +240,35 @@ func init() {
 }
 
 var fileDescriptor_c4407c0c45dc0204 = []byte{
-	// 409 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd4, 0x30,
-	0x10, 0x5d, 0xb3, 0x6c, 0xe8, 0x9a, 0x6f, 0x6b, 0x29, 0x51, 0x2a, 0x2d, 0x4b, 0x4e, 0x11, 0x07,
-	0x1b, 0x81, 0x10, 0xe2, 0xba, 0x6d, 0x41, 0xe5, 0x18, 0x10, 0x48, 0x5c, 0x2a, 0xc7, 0x99, 0x26,
-	0x69, 0xd3, 0xd8, 0xb5, 0x1d, 0xaa, 0xfd, 0x77, 0x1c, 0x39, 0xf2, 0x0b, 0x10, 0xca, 0x2f, 0x41,
-	0x8e, 0x13, 0x09, 0x76, 0xe1, 0x10, 0x65, 0xde, 0x9b, 0xa7, 0xc9, 0x9b, 0x37, 0xc1, 0xb1, 0x01,
-	0xfd, 0x15, 0x34, 0xbb, 0x96, 0xfa, 0x02, 0x34, 0x1b, 0x90, 0x7b, 0x55, 0x02, 0xa8, 0xd2, 0xd2,
-	0x4a, 0x12, 0x78, 0x36, 0x5a, 0x88, 0xba, 0x82, 0xc6, 0x32, 0xa5, 0x8c, 0x7b, 0x7c, 0x37, 0x5a,
-	0x14, 0xb2, 0x90, 0x7d, 0xc9, 0x5c, 0x35, 0xb0, 0x07, 0x85, 0x94, 0x45, 0x0d, 0xac, 0x47, 0x59,
-	0x7b, 0xc6, 0xe0, 0x52, 0xd9, 0xcd, 0xd0, 0x5c, 0x6e, 0x37, 0xaf, 0x35, 0x57, 0x0a, 0xf4, 0x30,
-	0x32, 0xfe, 0x88, 0xef, 0x1e, 0xf2, 0x46, 0x40, 0x9d, 0xc2, 0x55, 0x0b, 0xc6, 0x92, 0x15, 0x0e,
-	0xce, 0x65, 0x76, 0x5a, 0xe5, 0xe1, 0x8d, 0x15, 0x4a, 0xe6, 0xeb, 0x79, 0xf7, 0xf3, 0xc9, 0xec,
-	0xbd, 0xcc, 0x4e, 0x8e, 0xd2, 0xd9, 0xb9, 0xcc, 0x4e, 0x72, 0xf2, 0x14, 0xdf, 0xc9, 0xb9, 0xe5,
-	0xa7, 0x67, 0x55, 0x6d, 0x41, 0x9b, 0x10, 0xad, 0xa6, 0xc9, 0x3c, 0xbd, 0xed, 0xb8, 0xb7, 0x9e,
-	0x8a, 0x9f, 0xe1, 0x7b, 0xe3, 0x54, 0xa3, 0x64, 0x63, 0x80, 0x84, 0xf8, 0x96, 0x69, 0x85, 0x00,
-	0xe3, 0xf4, 0x28, 0xd9, 0x4b, 0x47, 0x18, 0x5f, 0xe1, 0xfb, 0xef, 0xc0, 0x1e, 0x96, 0x6d, 0x73,
-	0xb1, 0xeb, 0x01, 0xfd, 0xc7, 0xc3, 0x03, 0x3c, 0xb5, 0xbc, 0xf0, 0x16, 0x53, 0x57, 0x92, 0x05,
-	0x9e, 0x99, 0x92, 0xeb, 0x3c, 0x9c, 0xae, 0x50, 0x32, 0x4d, 0x3d, 0xe8, 0x59, 0xcb, 0xad, 0x09,
-	0x6f, 0xf6, 0x1f, 0xf5, 0xe0, 0xc5, 0x37, 0x84, 0x83, 0xcf, 0xfd, 0x15, 0xc8, 0x2b, 0x1c, 0x7c,
-	0xb0, 0xdc, 0xb6, 0x86, 0xec, 0x53, 0x1f, 0x15, 0x1d, 0xa3, 0xa2, 0xc7, 0x2e, 0xc7, 0xe8, 0x21,
-	0x75, 0x07, 0xf0, 0x72, 0x2f, 0x8d, 0x27, 0xe4, 0x0d, 0x0e, 0xfc, 0x82, 0xe4, 0x11, 0xf5, 0x27,
-	0xa3, 0x7f, 0xc5, 0x18, 0xed, 0x6f, 0xd3, 0x3e, 0x87, 0x78, 0x42, 0x8e, 0xf0, 0xde, 0xb8, 0x2f,
-	0x79, 0x3c, 0xaa, 0xb6, 0x12, 0x88, 0x0e, 0x76, 0xcc, 0xac, 0x37, 0x16, 0xcc, 0x27, 0x5e, 0xb7,
-	0x10, 0x4f, 0x9e, 0xa3, 0xf5, 0xf1, 0xf7, 0x6e, 0x89, 0x7e, 0x74, 0x4b, 0xf4, 0xab, 0x5b, 0xa2,
-	0x2f, 0xaf, 0x8b, 0xca, 0x96, 0x6d, 0x46, 0x85, 0xbc, 0x64, 0x8a, 0x8b, 0x72, 0x93, 0x83, 0xfe,
-	0xb3, 0x32, 0x5a, 0xb0, 0x7f, 0xfd, 0x7d, 0x59, 0xd0, 0xcf, 0x7f, 0xf9, 0x3b, 0x00, 0x00, 0xff,
-	0xff, 0xd0, 0xb3, 0x4c, 0x0e, 0x9c, 0x02, 0x00, 0x00,
+	// 441 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x8e, 0x94, 0x40,
+	0x10, 0xc6, 0xa7, 0xc5, 0x61, 0x77, 0xda, 0xff, 0x9d, 0x71, 0x24, 0x6c, 0x32, 0x22, 0x27, 0xe2,
+	0x01, 0x8c, 0xc6, 0x18, 0xaf, 0xb3, 0xbb, 0x9a, 0xf5, 0x88, 0x46, 0x13, 0x2f, 0x9b, 0x06, 0x6a,
+	0x80, 0x5d, 0x96, 0xc6, 0xee, 0xc2, 0xcd, 0x3c, 0x9a, 0x37, 0x8f, 0x1e, 0x7d, 0x02, 0x63, 0xe6,
+	0x49, 0x4c, 0x77, 0x43, 0xa2, 0xb3, 0xee, 0x81, 0x50, 0xdf, 0xaf, 0x2a, 0x45, 0xe5, 0xfb, 0xa0,
+	0xa1, 0x02, 0xf9, 0x15, 0x64, 0x72, 0x29, 0xe4, 0x39, 0xc8, 0x64, 0x50, 0xfa, 0x55, 0xe7, 0x10,
+	0x77, 0x52, 0xa0, 0x60, 0xae, 0xa5, 0xfe, 0x3c, 0x6f, 0x6a, 0x68, 0x31, 0xe9, 0x3a, 0xa5, 0x1f,
+	0xdb, 0xf5, 0xe7, 0xa5, 0x28, 0x85, 0x29, 0x13, 0x5d, 0x0d, 0xf4, 0xa0, 0x14, 0xa2, 0x6c, 0x20,
+	0x31, 0x2a, 0xeb, 0xd7, 0x09, 0x5c, 0x74, 0xb8, 0x19, 0x9a, 0xcb, 0xdd, 0xe6, 0xa5, 0xe4, 0x5d,
+	0x07, 0x72, 0x58, 0x19, 0x7e, 0xa0, 0x77, 0x0e, 0x79, 0x9b, 0x43, 0x93, 0xc2, 0x97, 0x1e, 0x14,
+	0xb2, 0x80, 0xba, 0x67, 0x22, 0x3b, 0xad, 0x0b, 0xef, 0x46, 0x40, 0xa2, 0xd9, 0x6a, 0xb6, 0xfd,
+	0xf5, 0x78, 0xfa, 0x4e, 0x64, 0x27, 0x47, 0xe9, 0xf4, 0x4c, 0x64, 0x27, 0x05, 0x7b, 0x42, 0x6f,
+	0x17, 0x1c, 0xf9, 0xe9, 0xba, 0x6e, 0x10, 0xa4, 0xf2, 0x48, 0xe0, 0x44, 0xb3, 0xf4, 0x96, 0x66,
+	0x6f, 0x2c, 0x0a, 0x9f, 0xd2, 0xbb, 0xe3, 0x56, 0xd5, 0x89, 0x56, 0x01, 0xf3, 0xe8, 0x9e, 0xea,
+	0xf3, 0x1c, 0x94, 0x9e, 0x27, 0xd1, 0x7e, 0x3a, 0xca, 0xf0, 0x1b, 0xa1, 0xf7, 0xde, 0x02, 0x1e,
+	0x56, 0x7d, 0x7b, 0x7e, 0xf5, 0x08, 0x72, 0xcd, 0x11, 0xf7, 0xa9, 0x83, 0xbc, 0xb4, 0x37, 0xa6,
+	0xba, 0x64, 0x73, 0x3a, 0x55, 0x15, 0x97, 0x85, 0xe7, 0x04, 0x24, 0x72, 0x52, 0x2b, 0x0c, 0x45,
+	0x8e, 0xca, 0xbb, 0x69, 0xbe, 0x6a, 0x05, 0x5b, 0x50, 0xd7, 0xb4, 0x95, 0x37, 0x0d, 0x9c, 0xc8,
+	0x49, 0x07, 0xa5, 0xb9, 0x58, 0xaf, 0x15, 0xa0, 0xe7, 0x9a, 0x25, 0x83, 0xd2, 0xbc, 0x81, 0xb6,
+	0xc4, 0xca, 0xdb, 0xb3, 0xdc, 0xaa, 0xe7, 0xdf, 0x09, 0x75, 0x3f, 0x99, 0x38, 0xd9, 0x4b, 0xea,
+	0xbe, 0x47, 0x8e, 0xbd, 0x62, 0x8b, 0xd8, 0x7a, 0x1e, 0x8f, 0x9e, 0xc7, 0xc7, 0x3a, 0x10, 0xff,
+	0x41, 0xac, 0x93, 0xb4, 0xe3, 0x76, 0x34, 0x9c, 0xb0, 0xd7, 0xd4, 0xb5, 0x4e, 0xb1, 0x87, 0xb1,
+	0xcd, 0x3e, 0xfe, 0x27, 0x0f, 0x7f, 0xb1, 0x8b, 0xad, 0xa1, 0xe1, 0x84, 0x1d, 0xd1, 0xfd, 0xd1,
+	0x37, 0xf6, 0x68, 0x9c, 0xda, 0x71, 0xd2, 0x3f, 0xb8, 0x72, 0xcc, 0x6a, 0x83, 0xa0, 0x3e, 0xf2,
+	0xa6, 0x87, 0x70, 0xf2, 0x8c, 0xac, 0x8e, 0x7f, 0x6c, 0x97, 0xe4, 0xe7, 0x76, 0x49, 0x7e, 0x6f,
+	0x97, 0xe4, 0xf3, 0xab, 0xb2, 0xc6, 0xaa, 0xcf, 0xe2, 0x5c, 0x5c, 0x24, 0x1d, 0xcf, 0xab, 0x4d,
+	0x01, 0xf2, 0xef, 0x4a, 0xc9, 0x3c, 0xf9, 0xdf, 0x6f, 0x9c, 0xb9, 0x66, 0xff, 0x8b, 0x3f, 0x03,
+	0x00, 0x43, 0x6f, 0xc4, 0x22, 0xe5, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -526,6 +555,35 @@ func (m *GetChunkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Length != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Length))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Offset != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Shards) > 0 {
+		dAtA2 := make([]byte, len(m.Shards)*10)
+		var j1 int
+		for _, num1 := range m.Shards {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintService(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Stats {
 		i--
 		if m.Stats {
@@ -626,6 +684,19 @@ func (m *GetChunkRequest) Size() (n int) {
 	if m.Stats {
 		n += 2
 	}
+	if len(m.Shards) > 0 {
+		l = 0
+		for _, e := range m.Shards {
+			l += sovService(uint64(e))
+		}
+		n += 1 + sovService(uint64(l)) + l
+	}
+	if m.Offset != 0 {
+		n += 1 + sovService(uint64(m.Offset))
+	}
+	if m.Length != 0 {
+		n += 1 + sovService(uint64(m.Length))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -962,6 +1033,120 @@ func (m *GetChunkRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Stats = bool(v != 0)
+		case 5:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowService
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Shards = append(m.Shards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowService
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthService
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthService
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Shards) == 0 {
+					m.Shards = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowService
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Shards = append(m.Shards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
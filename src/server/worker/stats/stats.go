@@ -178,6 +178,23 @@ var (
 			"job",
 		},
 	)
+
+	// StepTime is a histogram tracking the time spent in each step that a
+	// pipeline's workers log (e.g. "processing datums")
+	StepTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "pachyderm",
+			Subsystem: "worker",
+			Name:      "step_time",
+			Help:      "Time spent in each step of a job",
+			Buckets:   prometheus.ExponentialBuckets(1.0, bucketFactor, bucketCount),
+		},
+		[]string{
+			"pipeline",
+			"job",
+			"step",
+		},
+	)
 )
 
 // InitPrometheus sets up the default datum stats collectors for use by worker
@@ -195,6 +212,7 @@ func InitPrometheus() {
 		DatumDownloadBytesCount,
 		DatumUploadSize,
 		DatumUploadBytesCount,
+		StepTime,
 	}
 	for _, metric := range metrics {
 		if err := prometheus.Register(metric); err != nil {
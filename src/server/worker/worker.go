@@ -54,6 +54,8 @@ func NewWorker(
 	namespace string,
 	hashtreePath string,
 	rootPath string,
+	chunkCacheOptions driver.ChunkCacheOptions,
+	inputCacheOptions driver.InputCacheOptions,
 ) (*Worker, error) {
 	stats.InitPrometheus()
 
@@ -70,6 +72,8 @@ func NewWorker(
 		hashtreePath,
 		rootPath,
 		namespace,
+		chunkCacheOptions,
+		inputCacheOptions,
 	)
 	if err != nil {
 		return nil, err
@@ -112,6 +116,12 @@ func NewWorker(
 	return worker, nil
 }
 
+// SetDatumCallback sets a function that's called with an event for each datum
+// that the worker finishes processing. See transform.Status.SetDatumCallback.
+func (w *Worker) SetDatumCallback(cb func(*transform.DatumEvent)) {
+	w.status.SetDatumCallback(cb)
+}
+
 func (w *Worker) worker() {
 	ctx := w.driver.PachClient().Ctx()
 	logger := logs.NewStatlessLogger(w.driver.PipelineInfo())
@@ -120,19 +130,9 @@ func (w *Worker) worker() {
 		eg, ctx := errgroup.WithContext(ctx)
 		driver := w.driver.WithContext(ctx)
 
-		// Clean the driver hashtree cache for any jobs that are deleted
+		// Clean the driver hashtree cache for any jobs that finish or are deleted
 		eg.Go(func() error {
-			return driver.Jobs().ReadOnly(ctx).WatchF(func(e *watch.Event) error {
-				var key string
-				if err := e.Unmarshal(&key, &pps.EtcdJobInfo{}); err != nil {
-					return err
-				}
-				if e.Type == watch.EventDelete {
-					driver.ChunkCaches().RemoveCache(key)
-					driver.ChunkStatsCaches().RemoveCache(key)
-				}
-				return nil
-			})
+			return removeJobCaches(ctx, driver, logger)
 		})
 
 		// Run any worker tasks that the master creates
@@ -157,6 +157,29 @@ func (w *Worker) worker() {
 	})
 }
 
+// removeJobCaches watches the pipeline's jobs, and removes the driver's chunk
+// caches for each job once it finishes (or is deleted), as the chunks are only
+// fetched by other workers while the job is running. It returns when 'ctx' is
+// canceled.
+func removeJobCaches(ctx context.Context, driver driver.Driver, logger logs.TaggedLogger) error {
+	return driver.Jobs().ReadOnly(ctx).WatchF(func(e *watch.Event) error {
+		var key string
+		jobPtr := &pps.EtcdJobInfo{}
+		if err := e.Unmarshal(&key, jobPtr); err != nil {
+			return err
+		}
+		if e.Type == watch.EventDelete || ppsutil.IsTerminal(jobPtr.State) {
+			if err := driver.ChunkCaches().RemoveCache(key); err != nil {
+				logger.Errf("failed to remove chunk cache for job %s: %v", key, err)
+			}
+			if err := driver.ChunkStatsCaches().RemoveCache(key); err != nil {
+				logger.Errf("failed to remove chunk stats cache for job %s: %v", key, err)
+			}
+		}
+		return nil
+	})
+}
+
 func (w *Worker) master(etcdClient *etcd.Client, etcdPrefix string) {
 	pipelineInfo := w.driver.PipelineInfo()
 	logger := logs.NewMasterLogger(pipelineInfo)
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testetcd"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+func TestRemoveJobCaches(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		pipelineInfo := &pps.PipelineInfo{Pipeline: client.NewPipeline("pipeline")}
+		hashtreePath := filepath.Join(env.Directory, "hashtrees")
+		d := driver.NewMockDriver(env.EtcdClient, &driver.MockOptions{
+			EtcdPrefix:   tu.UniqueString("worker-test"),
+			PipelineInfo: pipelineInfo,
+			HashtreePath: hashtreePath,
+		})
+
+		putJob := func(jobID string, state pps.JobState) {
+			_, err := d.NewSTM(func(stm col.STM) error {
+				return d.Jobs().ReadWrite(stm).Put(jobID, &pps.EtcdJobInfo{
+					Job:          client.NewJob(jobID),
+					Pipeline:     pipelineInfo.Pipeline,
+					OutputCommit: client.NewCommit("pipeline", jobID),
+					State:        state,
+				})
+			})
+			require.NoError(t, err)
+		}
+
+		// Run several jobs on the worker, each with chunk and stats caches
+		jobIDs := []string{"job-1", "job-2", "job-3", "job-4"}
+		for _, jobID := range jobIDs {
+			putJob(jobID, pps.JobState_JOB_RUNNING)
+			_, err := d.ChunkCaches().GetOrCreateCache(jobID)
+			require.NoError(t, err)
+			_, err = d.ChunkStatsCaches().GetOrCreateCache(jobID)
+			require.NoError(t, err)
+		}
+
+		ctx, cancel := context.WithCancel(env.Context)
+		defer cancel()
+		go removeJobCaches(ctx, d.WithContext(ctx), logs.NewMockLogger())
+
+		requireCaches := func(expected map[string]bool) {
+			require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+				for jobID, exists := range expected {
+					for _, dir := range []string{"chunk", "chunkStats"} {
+						_, err := os.Stat(filepath.Join(hashtreePath, dir, jobID))
+						if exists != (err == nil) {
+							return errors.Errorf("expected %s cache for %s to exist: %t", dir, jobID, exists)
+						}
+					}
+					if exists != (d.ChunkCaches().GetCache(jobID) != nil) {
+						return errors.Errorf("expected chunk cache for %s to exist: %t", jobID, exists)
+					}
+					if exists != (d.ChunkStatsCaches().GetCache(jobID) != nil) {
+						return errors.Errorf("expected chunk stats cache for %s to exist: %t", jobID, exists)
+					}
+				}
+				return nil
+			})
+		}
+
+		// Caches of jobs that are still running are kept
+		putJob("job-1", pps.JobState_JOB_MERGING)
+		requireCaches(map[string]bool{"job-1": true, "job-2": true, "job-3": true, "job-4": true})
+
+		// Caches are released once a job finishes, whether or not it succeeded
+		putJob("job-1", pps.JobState_JOB_SUCCESS)
+		putJob("job-2", pps.JobState_JOB_FAILURE)
+		putJob("job-3", pps.JobState_JOB_KILLED)
+		requireCaches(map[string]bool{"job-1": false, "job-2": false, "job-3": false, "job-4": true})
+
+		// And when a job is deleted
+		_, err := d.NewSTM(func(stm col.STM) error {
+			return d.Jobs().ReadWrite(stm).Delete("job-4")
+		})
+		require.NoError(t, err)
+		requireCaches(map[string]bool{"job-4": false})
+		return nil
+	}))
+}
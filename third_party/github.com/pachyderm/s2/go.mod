@@ -0,0 +1,12 @@
+module github.com/pachyderm/s2
+
+go 1.12
+
+require (
+	github.com/gofrs/uuid v3.2.0+incompatible
+	github.com/gorilla/mux v1.7.3
+	github.com/jinzhu/gorm v1.9.12 // indirect
+	github.com/minio/minio-go/v6 v6.0.55 // indirect
+	github.com/pachyderm/s2/examples/sql v0.0.0-20200528231500-590b33e3c716 // indirect
+	github.com/sirupsen/logrus v1.5.0
+)